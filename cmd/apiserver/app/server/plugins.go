@@ -25,6 +25,10 @@ import (
 	// Admission controllers
 	"github.com/poy/service-catalog/plugin/pkg/admission/broker/authsarcheck"
 	siclifecycle "github.com/poy/service-catalog/plugin/pkg/admission/servicebindings/lifecycle"
+	"github.com/poy/service-catalog/plugin/pkg/admission/serviceinstances/dependencies"
+	"github.com/poy/service-catalog/plugin/pkg/admission/serviceinstances/quiescedbroker"
+	"github.com/poy/service-catalog/plugin/pkg/admission/serviceinstances/removedclass"
+	"github.com/poy/service-catalog/plugin/pkg/admission/serviceinstances/requiredlabels"
 	"github.com/poy/service-catalog/plugin/pkg/admission/serviceplan/changevalidator"
 	"github.com/poy/service-catalog/plugin/pkg/admission/serviceplan/defaultserviceplan"
 )
@@ -35,4 +39,8 @@ func registerAllAdmissionPlugins(plugins *admission.Plugins) {
 	siclifecycle.Register(plugins)
 	changevalidator.Register(plugins)
 	authsarcheck.Register(plugins)
+	requiredlabels.Register(plugins)
+	removedclass.Register(plugins)
+	quiescedbroker.Register(plugins)
+	dependencies.Register(plugins)
 }
@@ -25,6 +25,7 @@ import (
 	"os"
 	goruntime "runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"k8s.io/client-go/kubernetes"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 
 	"github.com/poy/service-catalog/pkg/client/clientset_generated/clientset"
 	"github.com/poy/service-catalog/pkg/kubernetes/pkg/util/configz"
@@ -46,6 +48,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/server/healthz"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 
@@ -54,6 +57,7 @@ import (
 	settingsv1alpha1 "github.com/poy/service-catalog/pkg/apis/settings/v1alpha1"
 	servicecataloginformers "github.com/poy/service-catalog/pkg/client/informers_generated/externalversions"
 	"github.com/poy/service-catalog/pkg/controller"
+	scfeatures "github.com/poy/service-catalog/pkg/features"
 
 	"context"
 
@@ -93,10 +97,18 @@ func Run(controllerManagerOptions *options.ControllerManagerServer) error {
 	// 	klog.Errorf("unable to register configz: %s", err)
 	// }
 
+	if err := controllerManagerOptions.Validate(); err != nil {
+		return err
+	}
+
 	if controllerManagerOptions.Port > 0 {
 		klog.Warning("program option --port is obsolete and ignored, specify --secure-port instead")
 	}
 
+	// Expose the metrics of the controller workqueues. This has to happen
+	// before the controller creates its workqueues.
+	workqueue.SetProvider(metrics.NewWorkqueueMetricsProvider())
+
 	// Build the K8s kubeconfig / client / clientBuilder
 	klog.V(4).Info("Building k8s kubeconfig")
 
@@ -145,6 +157,8 @@ func Run(controllerManagerOptions *options.ControllerManagerServer) error {
 		return fmt.Errorf("failed to get Service Catalog client configuration: %v", err)
 	}
 	serviceCatalogKubeconfig.Insecure = controllerManagerOptions.ServiceCatalogInsecureSkipVerify
+	serviceCatalogKubeconfig.QPS = controllerManagerOptions.KubeAPIQPS
+	serviceCatalogKubeconfig.Burst = int(controllerManagerOptions.KubeAPIBurst)
 
 	// Initialize SSL/TLS configuration.  Ensures we have a certificate and key to use.
 	// This is the same code as what is done in the API Server.  By default, Helm created
@@ -169,6 +183,21 @@ func Run(controllerManagerOptions *options.ControllerManagerServer) error {
 		// readiness registered at /healthz/ready indicates if traffic should be routed to this container
 		healthz.InstallPathHandler(mux, "/healthz/ready", apiAvailableChecker)
 
+		// /healthz/catalog indicates if every broker has retrieved its catalog at least once
+		healthz.InstallPathHandler(mux, "/healthz/catalog", checkBrokerCatalogsRetrieved{
+			controller.SimpleClientBuilder{
+				ClientConfig: serviceCatalogKubeconfig,
+			},
+		})
+
+		// /healthz/brokers summarizes which brokers are ready; failing brokers
+		// degrade it without failing it, unlike the liveness check
+		mux.Handle("/healthz/brokers", brokerHealthHandler{
+			controller.SimpleClientBuilder{
+				ClientConfig: serviceCatalogKubeconfig,
+			},
+		})
+
 		configz.InstallHandler(mux)
 		metrics.RegisterMetricsAndInstallHandler(mux)
 
@@ -360,16 +389,23 @@ func StartControllers(s *options.ControllerManagerServer,
 	if err != nil {
 		klog.Fatal(err)
 	}
-	klog.V(5).Infof("Creating shared informers; resync interval: %v", s.ResyncInterval)
+	klog.V(5).Infof("Creating shared informers; resync interval: %v, broker resync interval: %v, instance resync interval: %v, binding resync interval: %v",
+		s.ResyncInterval, s.BrokerResyncInterval, s.InstanceResyncInterval, s.BindingResyncInterval)
 
 	// Build the informer factory for service-catalog resources
-	informerFactory := servicecataloginformers.NewSharedInformerFactory(
+	informerFactory := servicecataloginformers.NewSharedInformerFactoryWithOptions(
 		serviceCatalogClientBuilder.ClientOrDie("shared-informers"),
 		s.ResyncInterval,
+		servicecataloginformers.WithCustomResyncConfig(s.CustomResyncIntervals()),
 	)
 	// All shared informers are v1beta1 API level
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
 
+	requestContextPolicy, err := s.RequestContextPolicy()
+	if err != nil {
+		return err
+	}
+
 	klog.V(5).Infof("Creating controller; broker relist interval: %v", s.ServiceBrokerRelistInterval)
 	serviceCatalogController, err := controller.NewController(
 		coreClient,
@@ -383,13 +419,36 @@ func StartControllers(s *options.ControllerManagerServer,
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
 		osbclientproxy.NewClient,
+		s.BrokerDefaultQPS,
+		s.BrokerDefaultBurst,
 		s.ServiceBrokerRelistInterval,
 		s.OSBAPIPreferredVersion,
+		s.BrokerUserAgent,
 		recorder,
 		s.ReconciliationRetryDuration,
 		s.OperationPollingMaximumBackoffDuration,
 		s.ClusterIDConfigMapName,
 		s.ClusterIDConfigMapNamespace,
+		controller.SecretKeyConvention{
+			Case:     s.SecretKeyCase,
+			Prefix:   s.SecretKeyPrefix,
+			Sanitize: s.SanitizeSecretKeys,
+		},
+		controller.ForceDeletionPolicy{
+			FailedDeprovisionAttempts: s.ForceDeleteFailedDeprovisionAttempts,
+			Window:                    s.ForceDeleteWindow,
+		},
+		controller.QuotaExceededPolicy{
+			BrokerErrors: s.QuotaExceededBrokerErrors,
+			RetryDelay:   s.QuotaExceededRetryDelay,
+		},
+		s.BrokerAuthSecretNamespaces,
+		requestContextPolicy,
+		controller.OrphanMitigationPolicy{
+			Mode:  controller.OrphanMitigationMode(s.OrphanMitigationPolicy),
+			Delay: s.OrphanMitigationDelay,
+		},
+		controller.SecretUpdateStrategy(s.BindingSecretUpdateStrategy),
 	)
 	if err != nil {
 		return err
@@ -428,3 +487,134 @@ func (c checkAPIAvailableResources) Check(_ *http.Request) error {
 	}
 	return nil
 }
+
+// checkBrokerCatalogsRetrieved is a HealthzChecker that makes sure every
+// broker has retrieved its catalog at least once, so that the classes and
+// plans it offers are available to the instances referencing them.
+type checkBrokerCatalogsRetrieved struct {
+	serviceCatalogClientBuilder controller.ClientBuilder
+}
+
+func (c checkBrokerCatalogsRetrieved) Name() string {
+	return "checkBrokerCatalogsRetrieved"
+}
+
+func (c checkBrokerCatalogsRetrieved) Check(_ *http.Request) error {
+	client, err := c.serviceCatalogClientBuilder.Client(controllerDiscoveryAgentName)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	clusterBrokers, err := client.ServicecatalogV1beta1().ClusterServiceBrokers().List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, broker := range clusterBrokers.Items {
+		if broker.Status.LastCatalogRetrievalTime == nil {
+			pending = append(pending, broker.Name)
+		}
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		brokers, err := client.ServicecatalogV1beta1().ServiceBrokers(metav1.NamespaceAll).List(metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		for _, broker := range brokers.Items {
+			if broker.Status.LastCatalogRetrievalTime == nil {
+				pending = append(pending, broker.Namespace+"/"+broker.Name)
+			}
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("brokers have not retrieved their catalog yet: %s", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// brokerHealthHandler serves a summary of how many brokers are ready and which
+// ones are failing. Failing brokers report the brokers as degraded without
+// failing the request, so that broker outages are not mistaken for problems
+// of the controller manager itself. The request only fails when the brokers
+// cannot be listed.
+type brokerHealthHandler struct {
+	serviceCatalogClientBuilder controller.ClientBuilder
+}
+
+// brokerHealth is the readiness of a single broker.
+type brokerHealth struct {
+	name   string
+	ready  bool
+	reason string
+}
+
+func (h brokerHealthHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	brokers, err := h.listBrokerHealth()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to list brokers: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	fmt.Fprint(w, summarizeBrokerHealth(brokers))
+}
+
+func (h brokerHealthHandler) listBrokerHealth() ([]brokerHealth, error) {
+	client, err := h.serviceCatalogClientBuilder.Client(controllerDiscoveryAgentName)
+	if err != nil {
+		return nil, err
+	}
+
+	var brokers []brokerHealth
+	clusterBrokers, err := client.ServicecatalogV1beta1().ClusterServiceBrokers().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, broker := range clusterBrokers.Items {
+		brokers = append(brokers, getBrokerHealth(broker.Name, broker.Status.CommonServiceBrokerStatus))
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		namespacedBrokers, err := client.ServicecatalogV1beta1().ServiceBrokers(metav1.NamespaceAll).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, broker := range namespacedBrokers.Items {
+			brokers = append(brokers, getBrokerHealth(broker.Namespace+"/"+broker.Name, broker.Status.CommonServiceBrokerStatus))
+		}
+	}
+	return brokers, nil
+}
+
+// getBrokerHealth returns whether the Ready condition of a broker is true,
+// and the reason of the condition when it is not.
+func getBrokerHealth(name string, status servicecatalogv1beta1.CommonServiceBrokerStatus) brokerHealth {
+	for _, condition := range status.Conditions {
+		if condition.Type == servicecatalogv1beta1.ServiceBrokerConditionReady {
+			return brokerHealth{
+				name:   name,
+				ready:  condition.Status == servicecatalogv1beta1.ConditionTrue,
+				reason: condition.Reason,
+			}
+		}
+	}
+	return brokerHealth{name: name, reason: "NotReady"}
+}
+
+// summarizeBrokerHealth returns "ok" followed by the number of brokers when
+// all of them are ready, or "degraded" followed by the number of ready brokers
+// and the failing ones with the reason of their Ready condition.
+func summarizeBrokerHealth(brokers []brokerHealth) string {
+	var failing []string
+	for _, broker := range brokers {
+		if !broker.ready {
+			failing = append(failing, fmt.Sprintf("%s (%s)", broker.name, broker.reason))
+		}
+	}
+	if len(failing) == 0 {
+		return fmt.Sprintf("ok: %d of %d brokers ready", len(brokers), len(brokers))
+	}
+	return fmt.Sprintf("degraded: %d of %d brokers ready, failing: %s", len(brokers)-len(failing), len(brokers), strings.Join(failing, ", "))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"testing"
+
+	servicecatalogv1beta1 "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestGetBrokerHealth(t *testing.T) {
+	ready := servicecatalogv1beta1.CommonServiceBrokerStatus{
+		Conditions: []servicecatalogv1beta1.ServiceBrokerCondition{{
+			Type:   servicecatalogv1beta1.ServiceBrokerConditionReady,
+			Status: servicecatalogv1beta1.ConditionTrue,
+			Reason: "FetchedCatalog",
+		}},
+	}
+	if health := getBrokerHealth("ready-broker", ready); !health.ready {
+		t.Errorf("expected the broker to be ready, got %+v", health)
+	}
+
+	failing := servicecatalogv1beta1.CommonServiceBrokerStatus{
+		Conditions: []servicecatalogv1beta1.ServiceBrokerCondition{{
+			Type:   servicecatalogv1beta1.ServiceBrokerConditionReady,
+			Status: servicecatalogv1beta1.ConditionFalse,
+			Reason: "ErrorFetchingCatalog",
+		}},
+	}
+	if health := getBrokerHealth("failing-broker", failing); health.ready || health.reason != "ErrorFetchingCatalog" {
+		t.Errorf("expected the broker to be failing with the reason of its condition, got %+v", health)
+	}
+
+	if health := getBrokerHealth("new-broker", servicecatalogv1beta1.CommonServiceBrokerStatus{}); health.ready || health.reason != "NotReady" {
+		t.Errorf("expected a broker without conditions to be failing, got %+v", health)
+	}
+}
+
+func TestSummarizeBrokerHealth(t *testing.T) {
+	cases := []struct {
+		name     string
+		brokers  []brokerHealth
+		expected string
+	}{
+		{
+			name:     "no brokers",
+			expected: "ok: 0 of 0 brokers ready",
+		},
+		{
+			name:     "all ready",
+			brokers:  []brokerHealth{{name: "a", ready: true}, {name: "b", ready: true}},
+			expected: "ok: 2 of 2 brokers ready",
+		},
+		{
+			name: "failing",
+			brokers: []brokerHealth{
+				{name: "a", ready: true},
+				{name: "b", reason: "ErrorFetchingCatalog"},
+				{name: "ns/c", reason: "NotReady"},
+			},
+			expected: "degraded: 1 of 3 brokers ready, failing: b (ErrorFetchingCatalog), ns/c (NotReady)",
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.expected, summarizeBrokerHealth(tc.brokers); e != a {
+			t.Errorf("%s: expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
@@ -21,17 +21,24 @@ limitations under the License.
 package options
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/pflag"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	"github.com/poy/service-catalog/pkg/apis/componentconfig"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/poy/service-catalog/pkg/controller"
 	k8scomponentconfig "github.com/poy/service-catalog/pkg/kubernetes/pkg/apis/componentconfig"
 	"github.com/poy/service-catalog/pkg/kubernetes/pkg/client/leaderelectionconfig"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/client-go/tools/leaderelection"
 )
 
 const (
@@ -50,6 +57,10 @@ type ControllerManagerServer struct {
 const (
 	defaultResyncInterval                         = 5 * time.Minute
 	defaultServiceBrokerRelistInterval            = 24 * time.Hour
+	defaultBrokerDefaultQPS                       = 20
+	defaultBrokerDefaultBurst                     = 40
+	defaultKubeAPIQPS                             = 5
+	defaultKubeAPIBurst                           = 10
 	defaultContentType                            = "application/json"
 	defaultBindAddress                            = "0.0.0.0"
 	defaultPort                                   = 8444
@@ -60,6 +71,9 @@ const (
 	defaultLeaderElectionNamespace                = "kube-system"
 	defaultReconciliationRetryDuration            = 7 * 24 * time.Hour
 	defaultOperationPollingMaximumBackoffDuration = 20 * time.Minute
+	defaultForceDeleteWindow                      = 24 * time.Hour
+	defaultQuotaExceededRetryDelay                = 10 * time.Minute
+	defaultOrphanMitigationDelay                  = time.Hour
 )
 
 var defaultOSBAPIPreferredVersion = osb.LatestAPIVersion().HeaderValue()
@@ -72,10 +86,14 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			Address:                                defaultBindAddress,
 			Port:                                   0,
 			ContentType:                            defaultContentType,
+			KubeAPIQPS:                             defaultKubeAPIQPS,
+			KubeAPIBurst:                           defaultKubeAPIBurst,
 			K8sKubeconfigPath:                      defaultK8sKubeconfigPath,
 			ServiceCatalogKubeconfigPath:           defaultServiceCatalogKubeconfigPath,
 			ResyncInterval:                         defaultResyncInterval,
 			ServiceBrokerRelistInterval:            defaultServiceBrokerRelistInterval,
+			BrokerDefaultQPS:                       defaultBrokerDefaultQPS,
+			BrokerDefaultBurst:                     defaultBrokerDefaultBurst,
 			OSBAPIContextProfile:                   defaultOSBAPIContextProfile,
 			OSBAPIPreferredVersion:                 defaultOSBAPIPreferredVersion,
 			ConcurrentSyncs:                        defaultConcurrentSyncs,
@@ -85,6 +103,11 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			EnableContentionProfiling:              false,
 			ReconciliationRetryDuration:            defaultReconciliationRetryDuration,
 			OperationPollingMaximumBackoffDuration: defaultOperationPollingMaximumBackoffDuration,
+			ForceDeleteWindow:                      defaultForceDeleteWindow,
+			QuotaExceededRetryDelay:                defaultQuotaExceededRetryDelay,
+			OrphanMitigationPolicy:                 string(controller.OrphanMitigationAutomatic),
+			OrphanMitigationDelay:                  defaultOrphanMitigationDelay,
+			BindingSecretUpdateStrategy:            string(controller.SecretUpdateStrategyInPlace),
 			SecureServingOptions:                   genericoptions.NewSecureServingOptions(),
 		},
 	}
@@ -103,16 +126,24 @@ func (s *ControllerManagerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&s.ConcurrentSyncs, "concurrent-syncs", defaultConcurrentSyncs, "Number of concurrent syncs")
 	fs.MarkDeprecated("port", "see --secure-port instead")
 	fs.StringVar(&s.ContentType, "api-content-type", s.ContentType, "Content type of requests sent to API servers")
+	fs.Float32Var(&s.KubeAPIQPS, "kube-api-qps", s.KubeAPIQPS, "The maximum number of requests per second the controller sends to each API server")
+	fs.Int32Var(&s.KubeAPIBurst, "kube-api-burst", s.KubeAPIBurst, "The maximum burst of requests the controller sends to each API server")
 	fs.StringVar(&s.K8sAPIServerURL, "k8s-api-server-url", "", "The URL for the k8s API server")
 	fs.StringVar(&s.K8sKubeconfigPath, "k8s-kubeconfig", "", "Path to k8s core kubeconfig")
 	fs.StringVar(&s.ServiceCatalogAPIServerURL, "service-catalog-api-server-url", "", "The URL for the service-catalog API server")
 	fs.StringVar(&s.ServiceCatalogKubeconfigPath, "service-catalog-kubeconfig", "", "Path to service-catalog kubeconfig")
 	fs.BoolVar(&s.ServiceCatalogInsecureSkipVerify, "service-catalog-insecure-skip-verify", s.ServiceCatalogInsecureSkipVerify, "Skip verification of the TLS certificate for the service-catalog API server")
 	fs.DurationVar(&s.ResyncInterval, "resync-interval", s.ResyncInterval, "The interval on which the controller will resync its informers")
+	fs.DurationVar(&s.BrokerResyncInterval, "broker-resync-period", s.BrokerResyncInterval, "The interval on which the controller resyncs its informers of brokers. If not present, --resync-interval is used")
+	fs.DurationVar(&s.InstanceResyncInterval, "instance-resync-period", s.InstanceResyncInterval, "The interval on which the controller resyncs its informer of instances. If not present, --resync-interval is used")
+	fs.DurationVar(&s.BindingResyncInterval, "binding-resync-period", s.BindingResyncInterval, "The interval on which the controller resyncs its informer of bindings. If not present, --resync-interval is used")
 	fs.DurationVar(&s.ServiceBrokerRelistInterval, "broker-relist-interval", s.ServiceBrokerRelistInterval, "The interval on which a broker's catalog is relisted after the broker becomes ready")
+	fs.Float32Var(&s.BrokerDefaultQPS, "broker-default-qps", s.BrokerDefaultQPS, "The maximum number of calls per second made to each broker, 0 disables rate limiting")
+	fs.IntVar(&s.BrokerDefaultBurst, "broker-default-burst", s.BrokerDefaultBurst, "The maximum burst of calls made to each broker")
 	fs.BoolVar(&s.OSBAPIContextProfile, "enable-osb-api-context-profile", s.OSBAPIContextProfile, "This does nothing.")
 	fs.MarkHidden("enable-osb-api-context-profile")
-	fs.StringVar(&s.OSBAPIPreferredVersion, "osb-api-preferred-version", s.OSBAPIPreferredVersion, "The string to send as the version header.")
+	fs.StringVar(&s.OSBAPIPreferredVersion, "osb-api-preferred-version", s.OSBAPIPreferredVersion, "The newest version of the Open Service Broker API to negotiate with brokers.")
+	fs.StringVar(&s.BrokerUserAgent, "broker-user-agent", s.BrokerUserAgent, "The User-Agent sent with requests to brokers. If not present, service-catalog/<version> (cluster <cluster ID>) is sent, where the cluster ID is the one stored in the cluster ID configmap")
 	fs.BoolVar(&s.EnableProfiling, "profiling", s.EnableProfiling, "Enable profiling via web interface host:port/debug/pprof/")
 	fs.BoolVar(&s.EnableContentionProfiling, "contention-profiling", s.EnableContentionProfiling, "Enable lock contention profiling, if profiling is enabled")
 	leaderelectionconfig.BindFlags(&s.LeaderElection, fs)
@@ -123,4 +154,127 @@ func (s *ControllerManagerServer) AddFlags(fs *pflag.FlagSet) {
 	utilfeature.DefaultFeatureGate.AddFlag(fs)
 	fs.StringVar(&s.ClusterIDConfigMapName, "cluster-id-configmap-name", controller.DefaultClusterIDConfigMapName, "k8s name for clusterid configmap")
 	fs.StringVar(&s.ClusterIDConfigMapNamespace, "cluster-id-configmap-namespace", controller.DefaultClusterIDConfigMapNamespace, "k8s namespace for clusterid configmap")
+	fs.StringVar(&s.SecretKeyCase, "secret-key-case", s.SecretKeyCase, "The case that the keys of binding credentials are converted to before they are written to secrets, after any secret transforms. Valid options are upper-snake-case. If not present, keys are left unchanged")
+	fs.StringVar(&s.SecretKeyPrefix, "secret-key-prefix", s.SecretKeyPrefix, "A prefix added to the keys of binding credentials before they are written to secrets, after any secret transforms and case conversion")
+	fs.IntVar(&s.ForceDeleteFailedDeprovisionAttempts, "force-delete-failed-deprovision-attempts", s.ForceDeleteFailedDeprovisionAttempts, "The number of failed deprovision attempts after which the finalizer of a deleted instance is removed without deprovisioning it, once --force-delete-window has elapsed since its deletion. The instance may then still exist at the broker. 0 disables force deletion")
+	fs.DurationVar(&s.ForceDeleteWindow, "force-delete-window", s.ForceDeleteWindow, "The minimum amount of time since the deletion of an instance was requested before its finalizer is removed by --force-delete-failed-deprovision-attempts")
+	fs.StringSliceVar(&s.QuotaExceededBrokerErrors, "quota-exceeded-broker-errors", s.QuotaExceededBrokerErrors, "The error codes, or fragments of the error descriptions, that brokers return for a provision when a quota was exceeded. Such provisions are reported with the ErrorQuotaExceeded reason and retried after --quota-exceeded-retry-delay. Codes must match exactly, descriptions are matched ignoring case")
+	fs.DurationVar(&s.QuotaExceededRetryDelay, "quota-exceeded-retry-delay", s.QuotaExceededRetryDelay, "The minimum amount of time to wait before retrying a provision that failed because a quota was exceeded")
+	fs.StringSliceVar(&s.BrokerAuthSecretNamespaces, "broker-auth-secret-namespaces", s.BrokerAuthSecretNamespaces, "The namespaces, other than their own, in which namespaced brokers may reference their auth secrets, for example a namespace where secrets are kept centrally. By default, the auth secret of a namespaced broker must be in the namespace of the broker")
+	fs.StringSliceVar(&s.RequestContextNamespaceLabels, "request-context-namespace-labels", s.RequestContextNamespaceLabels, "The keys of the labels of the namespace of an instance or binding that are sent to brokers in the namespace_labels object of the context of provision, update and bind requests. Other labels are not sent")
+	fs.StringSliceVar(&s.RequestContextValues, "request-context-values", s.RequestContextValues, "Custom values, format: KEY=VALUE, added to the context of the provision, update and bind requests sent to every broker, for example to identify the cluster for billing. Do not include sensitive data")
+	fs.StringVar(&s.OrphanMitigationPolicy, "orphan-mitigation-policy", s.OrphanMitigationPolicy, "What to do with an instance whose provision failed in a way that may have left an orphaned resource on the broker: Automatic deprovisions it right away, Delayed deprovisions it after --orphan-mitigation-delay, and Manual leaves it until it is deleted. Instances can override it with the servicecatalog.k8s.io/orphan-mitigation-policy annotation")
+	fs.DurationVar(&s.OrphanMitigationDelay, "orphan-mitigation-delay", s.OrphanMitigationDelay, "The amount of time to wait after a failed provision before deprovisioning an instance whose orphan mitigation policy is Delayed")
+	fs.StringVar(&s.BindingSecretUpdateStrategy, "binding-secret-update-strategy", s.BindingSecretUpdateStrategy, "How new credentials are written to the existing secret of a binding: InPlace updates the secret, and Recreate deletes the secret and creates it again. Applications watching the secret see a single modification with InPlace, and a deletion followed by an addition with Recreate")
+	fs.BoolVar(&s.SanitizeSecretKeys, "sanitize-secret-keys", s.SanitizeSecretKeys, "Whether to replace the characters of binding credential keys that are not valid in secret keys with underscores. The original keys are recorded in the "+controller.OriginalSecretKeysAnnotation+" annotation of the secret. If false, bindings whose credentials have such keys fail")
+}
+
+// Validate checks that the flags of the controller manager have not been set
+// in a conflictory manner.
+func (s *ControllerManagerServer) Validate() error {
+	errors := []error{}
+	errors = append(errors, validateLeaderElection(&s.LeaderElection)...)
+	if s.ForceDeleteFailedDeprovisionAttempts < 0 {
+		errors = append(errors, fmt.Errorf("--force-delete-failed-deprovision-attempts must not be negative"))
+	}
+	if s.ForceDeleteWindow < 0 {
+		errors = append(errors, fmt.Errorf("--force-delete-window must not be negative"))
+	}
+	for _, brokerError := range s.QuotaExceededBrokerErrors {
+		if brokerError == "" {
+			errors = append(errors, fmt.Errorf("--quota-exceeded-broker-errors must not contain empty errors"))
+			break
+		}
+	}
+	if s.QuotaExceededRetryDelay < 0 {
+		errors = append(errors, fmt.Errorf("--quota-exceeded-retry-delay must not be negative"))
+	}
+	for _, namespace := range s.BrokerAuthSecretNamespaces {
+		if msgs := apivalidation.ValidateNamespaceName(namespace, false); len(msgs) != 0 {
+			errors = append(errors, fmt.Errorf("--broker-auth-secret-namespaces contains an invalid namespace %q: %s", namespace, strings.Join(msgs, ", ")))
+		}
+	}
+	if _, err := s.RequestContextPolicy(); err != nil {
+		errors = append(errors, err)
+	}
+	if _, err := controller.ParseOrphanMitigationMode(s.OrphanMitigationPolicy); err != nil {
+		errors = append(errors, fmt.Errorf("invalid --orphan-mitigation-policy: %v", err))
+	}
+	if s.OrphanMitigationDelay < 0 {
+		errors = append(errors, fmt.Errorf("--orphan-mitigation-delay must not be negative"))
+	}
+	if _, err := controller.ParseSecretUpdateStrategy(s.BindingSecretUpdateStrategy); err != nil {
+		errors = append(errors, fmt.Errorf("invalid --binding-secret-update-strategy: %v", err))
+	}
+	if s.BrokerResyncInterval < 0 {
+		errors = append(errors, fmt.Errorf("--broker-resync-period must not be negative"))
+	}
+	if s.InstanceResyncInterval < 0 {
+		errors = append(errors, fmt.Errorf("--instance-resync-period must not be negative"))
+	}
+	if s.BindingResyncInterval < 0 {
+		errors = append(errors, fmt.Errorf("--binding-resync-period must not be negative"))
+	}
+	return utilerrors.NewAggregate(errors)
+}
+
+// CustomResyncIntervals returns the resync intervals of the informers that
+// don't use --resync-interval, as set by the --*-resync-period flags, keyed
+// by an object of the type of resource each informer lists.
+func (s *ControllerManagerServer) CustomResyncIntervals() map[metav1.Object]time.Duration {
+	intervals := make(map[metav1.Object]time.Duration)
+	if s.BrokerResyncInterval > 0 {
+		intervals[&v1beta1.ClusterServiceBroker{}] = s.BrokerResyncInterval
+		intervals[&v1beta1.ServiceBroker{}] = s.BrokerResyncInterval
+	}
+	if s.InstanceResyncInterval > 0 {
+		intervals[&v1beta1.ServiceInstance{}] = s.InstanceResyncInterval
+	}
+	if s.BindingResyncInterval > 0 {
+		intervals[&v1beta1.ServiceBinding{}] = s.BindingResyncInterval
+	}
+	return intervals
+}
+
+// RequestContextPolicy returns the policy for the context of the requests
+// sent to brokers, as set by the --request-context-* flags.
+func (s *ControllerManagerServer) RequestContextPolicy() (controller.RequestContextPolicy, error) {
+	values, err := controller.ParseRequestContextValues(s.RequestContextValues)
+	if err != nil {
+		return controller.RequestContextPolicy{}, fmt.Errorf("invalid --request-context-values: %v", err)
+	}
+	policy := controller.RequestContextPolicy{
+		NamespaceLabels: s.RequestContextNamespaceLabels,
+		Values:          values,
+	}
+	if err := policy.Validate(); err != nil {
+		return controller.RequestContextPolicy{}, fmt.Errorf("invalid --request-context-namespace-labels or --request-context-values: %v", err)
+	}
+	return policy, nil
+}
+
+// validateLeaderElection checks the timings of leader election, which the
+// leader election client would otherwise reject with a panic once the
+// controller manager is started.
+func validateLeaderElection(l *k8scomponentconfig.LeaderElectionConfiguration) []error {
+	if !l.LeaderElect {
+		return nil
+	}
+	errors := []error{}
+	if l.LeaseDuration.Duration <= 0 {
+		errors = append(errors, fmt.Errorf("--leader-elect-lease-duration must be greater than zero"))
+	}
+	if l.RenewDeadline.Duration <= 0 {
+		errors = append(errors, fmt.Errorf("--leader-elect-renew-deadline must be greater than zero"))
+	}
+	if l.RetryPeriod.Duration <= 0 {
+		errors = append(errors, fmt.Errorf("--leader-elect-retry-period must be greater than zero"))
+	}
+	if l.LeaseDuration.Duration <= l.RenewDeadline.Duration {
+		errors = append(errors, fmt.Errorf("--leader-elect-lease-duration (%v) must be greater than --leader-elect-renew-deadline (%v)", l.LeaseDuration.Duration, l.RenewDeadline.Duration))
+	}
+	if l.RenewDeadline.Duration <= time.Duration(leaderelection.JitterFactor*float64(l.RetryPeriod.Duration)) {
+		errors = append(errors, fmt.Errorf("--leader-elect-renew-deadline (%v) must be greater than %v times --leader-elect-retry-period (%v)", l.RenewDeadline.Duration, leaderelection.JitterFactor, l.RetryPeriod.Duration))
+	}
+	return errors
 }
@@ -0,0 +1,345 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func TestValidateLeaderElection(t *testing.T) {
+	cases := []struct {
+		name  string
+		args  []string
+		error string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name: "custom timings",
+			args: []string{"--leader-elect-lease-duration=60s", "--leader-elect-renew-deadline=40s", "--leader-elect-retry-period=10s"},
+		},
+		{
+			name: "invalid timings with leader election disabled",
+			args: []string{"--leader-elect=false", "--leader-elect-lease-duration=5s"},
+		},
+		{
+			name:  "lease duration not greater than renew deadline",
+			args:  []string{"--leader-elect-lease-duration=10s"},
+			error: "--leader-elect-lease-duration (10s) must be greater than --leader-elect-renew-deadline (10s)",
+		},
+		{
+			name:  "renew deadline too close to retry period",
+			args:  []string{"--leader-elect-retry-period=9s"},
+			error: "--leader-elect-renew-deadline (10s) must be greater than 1.2 times --leader-elect-retry-period (9s)",
+		},
+		{
+			name:  "zero retry period",
+			args:  []string{"--leader-elect-retry-period=0"},
+			error: "--leader-elect-retry-period must be greater than zero",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewControllerManagerServer()
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			s.AddFlags(fs)
+			if err := fs.Parse(tc.args); err != nil {
+				t.Fatalf("unexpected error parsing flags: %v", err)
+			}
+
+			err := s.Validate()
+			if tc.error == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.error) {
+				t.Fatalf("expected error containing %q, got %v", tc.error, err)
+			}
+		})
+	}
+}
+
+func TestValidateForceDelete(t *testing.T) {
+	cases := []struct {
+		args  []string
+		error string
+	}{
+		{args: []string{"--force-delete-failed-deprovision-attempts=3", "--force-delete-window=1h"}},
+		{args: []string{"--force-delete-failed-deprovision-attempts=-1"}, error: "--force-delete-failed-deprovision-attempts must not be negative"},
+		{args: []string{"--force-delete-window=-1h"}, error: "--force-delete-window must not be negative"},
+	}
+
+	for _, tc := range cases {
+		s := NewControllerManagerServer()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		s.AddFlags(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatalf("unexpected error parsing flags: %v", err)
+		}
+
+		err := s.Validate()
+		if tc.error == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.error) {
+			t.Errorf("expected error containing %q for %v, got %v", tc.error, tc.args, err)
+		}
+	}
+}
+
+func TestValidateQuotaExceeded(t *testing.T) {
+	cases := []struct {
+		args  []string
+		error string
+	}{
+		{args: []string{"--quota-exceeded-broker-errors=QuotaExceeded,quota reached", "--quota-exceeded-retry-delay=1h"}},
+		{args: []string{"--quota-exceeded-broker-errors=QuotaExceeded,"}, error: "--quota-exceeded-broker-errors must not contain empty errors"},
+		{args: []string{"--quota-exceeded-retry-delay=-1h"}, error: "--quota-exceeded-retry-delay must not be negative"},
+	}
+
+	for _, tc := range cases {
+		s := NewControllerManagerServer()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		s.AddFlags(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatalf("unexpected error parsing flags: %v", err)
+		}
+
+		err := s.Validate()
+		if tc.error == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.error) {
+			t.Errorf("expected error containing %q for %v, got %v", tc.error, tc.args, err)
+		}
+	}
+}
+
+func TestValidateBrokerAuthSecretNamespaces(t *testing.T) {
+	cases := []struct {
+		args  []string
+		error string
+	}{
+		{args: []string{"--broker-auth-secret-namespaces=secrets,shared-secrets"}},
+		{args: []string{"--broker-auth-secret-namespaces=Secrets"}, error: "--broker-auth-secret-namespaces contains an invalid namespace \"Secrets\""},
+	}
+
+	for _, tc := range cases {
+		s := NewControllerManagerServer()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		s.AddFlags(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatalf("unexpected error parsing flags: %v", err)
+		}
+
+		err := s.Validate()
+		if tc.error == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.error) {
+			t.Errorf("expected error containing %q for %v, got %v", tc.error, tc.args, err)
+		}
+	}
+}
+
+func TestValidateRequestContext(t *testing.T) {
+	cases := []struct {
+		args  []string
+		error string
+	}{
+		{args: []string{"--request-context-namespace-labels=team,example.com/cost-center", "--request-context-values=region=eu-west"}},
+		{args: []string{"--request-context-values=region"}, error: "invalid --request-context-values"},
+		{args: []string{"--request-context-values=platform=other"}, error: "the key is set by the controller"},
+		{args: []string{"--request-context-namespace-labels=not a key"}, error: "invalid namespace label key"},
+	}
+
+	for _, tc := range cases {
+		s := NewControllerManagerServer()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		s.AddFlags(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatalf("unexpected error parsing flags: %v", err)
+		}
+
+		err := s.Validate()
+		if tc.error == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.error) {
+			t.Errorf("expected error containing %q for %v, got %v", tc.error, tc.args, err)
+		}
+	}
+}
+
+func TestValidateOrphanMitigation(t *testing.T) {
+	cases := []struct {
+		args  []string
+		error string
+	}{
+		{args: []string{"--orphan-mitigation-policy=Delayed", "--orphan-mitigation-delay=2h"}},
+		{args: []string{"--orphan-mitigation-policy=Manual"}},
+		{args: []string{"--orphan-mitigation-policy=Never"}, error: "invalid --orphan-mitigation-policy"},
+		{args: []string{"--orphan-mitigation-delay=-1h"}, error: "--orphan-mitigation-delay must not be negative"},
+	}
+
+	for _, tc := range cases {
+		s := NewControllerManagerServer()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		s.AddFlags(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatalf("unexpected error parsing flags: %v", err)
+		}
+
+		err := s.Validate()
+		if tc.error == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.error) {
+			t.Errorf("expected error containing %q for %v, got %v", tc.error, tc.args, err)
+		}
+	}
+}
+
+func TestValidateBindingSecretUpdateStrategy(t *testing.T) {
+	cases := []struct {
+		args  []string
+		error string
+	}{
+		{args: []string{"--binding-secret-update-strategy=InPlace"}},
+		{args: []string{"--binding-secret-update-strategy=Recreate"}},
+		{args: []string{"--binding-secret-update-strategy=Patch"}, error: "invalid --binding-secret-update-strategy"},
+	}
+
+	for _, tc := range cases {
+		s := NewControllerManagerServer()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		s.AddFlags(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatalf("unexpected error parsing flags: %v", err)
+		}
+
+		err := s.Validate()
+		if tc.error == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.error) {
+			t.Errorf("expected error containing %q for %v, got %v", tc.error, tc.args, err)
+		}
+	}
+}
+
+func TestValidateResyncPeriods(t *testing.T) {
+	cases := []struct {
+		args  []string
+		error string
+	}{
+		{args: []string{}},
+		{args: []string{"--broker-resync-period=1h", "--instance-resync-period=10m", "--binding-resync-period=30m"}},
+		{args: []string{"--broker-resync-period=-1m"}, error: "--broker-resync-period must not be negative"},
+		{args: []string{"--instance-resync-period=-1m"}, error: "--instance-resync-period must not be negative"},
+		{args: []string{"--binding-resync-period=-1m"}, error: "--binding-resync-period must not be negative"},
+	}
+
+	for _, tc := range cases {
+		s := NewControllerManagerServer()
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		s.AddFlags(fs)
+		if err := fs.Parse(tc.args); err != nil {
+			t.Fatalf("unexpected error parsing flags: %v", err)
+		}
+
+		err := s.Validate()
+		if tc.error == "" {
+			if err != nil {
+				t.Errorf("unexpected error for %v: %v", tc.args, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.error) {
+			t.Errorf("expected error containing %q for %v, got %v", tc.error, tc.args, err)
+		}
+	}
+}
+
+func TestCustomResyncIntervals(t *testing.T) {
+	s := NewControllerManagerServer()
+	if intervals := s.CustomResyncIntervals(); len(intervals) != 0 {
+		t.Errorf("expected no custom resync intervals by default, got %v", intervals)
+	}
+
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	s.AddFlags(fs)
+	if err := fs.Parse([]string{"--broker-resync-period=1h", "--binding-resync-period=30m"}); err != nil {
+		t.Fatalf("unexpected error parsing flags: %v", err)
+	}
+
+	expected := map[string]time.Duration{
+		"*v1beta1.ClusterServiceBroker": time.Hour,
+		"*v1beta1.ServiceBroker":        time.Hour,
+		"*v1beta1.ServiceBinding":       30 * time.Minute,
+	}
+	intervals := s.CustomResyncIntervals()
+	if e, a := len(expected), len(intervals); e != a {
+		t.Fatalf("unexpected number of custom resync intervals: expected %v, got %v", e, a)
+	}
+	for obj, interval := range intervals {
+		typ := fmt.Sprintf("%T", obj)
+		if e, a := expected[typ], interval; e != a {
+			t.Errorf("unexpected resync interval for %v: expected %v, got %v", typ, e, a)
+		}
+	}
+}
+
+func TestLeaderElectionDefaults(t *testing.T) {
+	s := NewControllerManagerServer()
+	if e, a := 15*time.Second, s.LeaderElection.LeaseDuration.Duration; e != a {
+		t.Errorf("unexpected lease duration: expected %v, got %v", e, a)
+	}
+	if e, a := 10*time.Second, s.LeaderElection.RenewDeadline.Duration; e != a {
+		t.Errorf("unexpected renew deadline: expected %v, got %v", e, a)
+	}
+	if e, a := 2*time.Second, s.LeaderElection.RetryPeriod.Duration; e != a {
+		t.Errorf("unexpected retry period: expected %v, got %v", e, a)
+	}
+}
@@ -18,16 +18,20 @@ package binding
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/poy/service-catalog/cmd/svcat/output"
 	"github.com/poy/service-catalog/cmd/svcat/parameters"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type bindCmd struct {
 	*command.Namespaced
 	*command.Waitable
+	*command.Formatted
 
 	instanceName string
 	bindingName  string
@@ -38,6 +42,9 @@ type bindCmd struct {
 	params       interface{}
 	rawSecrets   []string
 	secrets      map[string]string
+
+	paramsFromSecret string
+	paramsFromKey    string
 }
 
 // NewBindCmd builds a "svcat bind" command
@@ -45,6 +52,7 @@ func NewBindCmd(cxt *command.Context) *cobra.Command {
 	bindCmd := &bindCmd{
 		Namespaced: command.NewNamespaced(cxt),
 		Waitable:   command.NewWaitable(),
+		Formatted:  command.NewFormatted(),
 	}
 	cmd := &cobra.Command{
 		Use:   "bind INSTANCE_NAME",
@@ -62,6 +70,8 @@ func NewBindCmd(cxt *command.Context) *cobra.Command {
 		"sports"
 	]
   }'
+  svcat bind wordpress-instance --params-from-secret wordpress-params --params-from-key parameters
+  svcat bind wordpress-instance -o yaml
 `),
 		PreRunE: command.PreRunE(bindCmd),
 		RunE:    command.RunE(bindCmd),
@@ -90,7 +100,12 @@ func NewBindCmd(cxt *command.Context) *cobra.Command {
 		"Additional parameter, whose value is stored in a secret, to use when binding the instance, format: SECRET[KEY]")
 	cmd.Flags().StringVar(&bindCmd.jsonParams, "params-json", "",
 		"Additional parameters to use when binding the instance, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().StringVar(&bindCmd.paramsFromSecret, "params-from-secret", "",
+		"The name of an existing secret holding parameters to use when binding the instance. Requires --params-from-key")
+	cmd.Flags().StringVar(&bindCmd.paramsFromKey, "params-from-key", "",
+		"The key of the --params-from-secret secret holding the parameters, provided as a JSON object. Requires --params-from-secret")
 	bindCmd.AddWaitFlags(cmd)
+	bindCmd.AddOutputFlags(cmd.Flags())
 	return cmd
 }
 
@@ -100,6 +115,12 @@ func (c *bindCmd) Validate(args []string) error {
 	}
 	c.instanceName = args[0]
 
+	if c.bindingName != "" {
+		if errs := validation.IsDNS1123Subdomain(c.bindingName); len(errs) > 0 {
+			return fmt.Errorf("invalid --name value %q (%s)", c.bindingName, strings.Join(errs, ", "))
+		}
+	}
+
 	var err error
 
 	if c.jsonParams != "" && len(c.rawParams) > 0 {
@@ -123,6 +144,16 @@ func (c *bindCmd) Validate(args []string) error {
 		return fmt.Errorf("invalid --secret value (%s)", err)
 	}
 
+	if (c.paramsFromSecret == "") != (c.paramsFromKey == "") {
+		return fmt.Errorf("--params-from-secret and --params-from-key must be provided together")
+	}
+	if c.paramsFromSecret != "" {
+		if _, found := c.secrets[c.paramsFromSecret]; found {
+			return fmt.Errorf("secret %q cannot be used with both --params-from-secret and --secret", c.paramsFromSecret)
+		}
+		c.secrets[c.paramsFromSecret] = c.paramsFromKey
+	}
+
 	return nil
 }
 
@@ -137,7 +168,9 @@ func (c *bindCmd) bind() error {
 	}
 
 	if c.Wait {
-		fmt.Fprintln(c.Output, "Waiting for binding to be injected...")
+		if c.OutputFormat == output.FormatTable {
+			fmt.Fprintln(c.Output, "Waiting for binding to be injected...")
+		}
 		finalBinding, err := c.App.WaitForBinding(binding.Namespace, binding.Name, c.Interval, c.Timeout)
 		if err == nil {
 			binding = finalBinding
@@ -145,10 +178,21 @@ func (c *bindCmd) bind() error {
 
 		// Always print the binding because the bind did succeed,
 		// and just print any errors that occurred while polling
-		output.WriteBindingDetails(c.Output, binding)
+		c.writeBinding(binding)
 		return err
 	}
 
-	output.WriteBindingDetails(c.Output, binding)
+	c.writeBinding(binding)
 	return nil
 }
+
+// writeBinding prints the created binding: its details, including the name
+// of the secret holding its credentials, by default, or the binding itself in
+// the requested output format.
+func (c *bindCmd) writeBinding(binding *v1beta1.ServiceBinding) {
+	if c.OutputFormat == output.FormatTable {
+		output.WriteBindingDetails(c.Output, binding)
+		return
+	}
+	output.WriteBinding(c.Output, c.OutputFormat, c.TableOptions(), *binding)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	_ "github.com/poy/service-catalog/internal/test"
+)
+
+func TestBindCommandParamsFromSecret(t *testing.T) {
+	testcases := []struct {
+		name        string
+		rawSecrets  []string
+		secret      string
+		key         string
+		wantSecrets map[string]string
+		wantError   string
+	}{
+		{
+			name:        "no secrets",
+			wantSecrets: map[string]string{},
+		},
+		{
+			name:        "params from secret",
+			secret:      "mysecret",
+			key:         "params",
+			wantSecrets: map[string]string{"mysecret": "params"},
+		},
+		{
+			name:        "params from secret combined with --secret",
+			rawSecrets:  []string{"othersecret[key]"},
+			secret:      "mysecret",
+			key:         "params",
+			wantSecrets: map[string]string{"mysecret": "params", "othersecret": "key"},
+		},
+		{
+			name:      "secret without key",
+			secret:    "mysecret",
+			wantError: "--params-from-secret and --params-from-key must be provided together",
+		},
+		{
+			name:      "key without secret",
+			key:       "params",
+			wantError: "--params-from-secret and --params-from-key must be provided together",
+		},
+		{
+			name:       "secret also given with --secret",
+			rawSecrets: []string{"mysecret[key]"},
+			secret:     "mysecret",
+			key:        "params",
+			wantError:  `secret "mysecret" cannot be used with both --params-from-secret and --secret`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := &bindCmd{
+				rawSecrets:       tc.rawSecrets,
+				paramsFromSecret: tc.secret,
+				paramsFromKey:    tc.key,
+			}
+			err := cmd.Validate([]string{"myinstance"})
+			if tc.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.wantSecrets, cmd.secrets) {
+				t.Fatalf("expected secrets %v, got %v", tc.wantSecrets, cmd.secrets)
+			}
+		})
+	}
+}
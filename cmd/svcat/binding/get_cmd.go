@@ -17,22 +17,38 @@ limitations under the License.
 package binding
 
 import (
+	"fmt"
+
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/poy/service-catalog/cmd/svcat/output"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
 	*command.Namespaced
 	*command.Formatted
-	name string
+	*command.Selected
+	*command.Paged
+	*command.Watched
+	*command.AgeFiltered
+	name           string
+	instanceFilter string
 }
 
 // NewGetCmd builds a "svcat get bindings" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
 	getCmd := &getCmd{
-		Namespaced: command.NewNamespaced(cxt),
-		Formatted:  command.NewFormatted(),
+		Namespaced:  command.NewNamespaced(cxt),
+		Formatted:   command.NewWideFormatted().WithStatusOnly(),
+		Selected:    command.NewSelected(),
+		Paged:       command.NewPaged(),
+		Watched:     command.NewWatched(),
+		AgeFiltered: command.NewAgeFiltered(),
 	}
 	cmd := &cobra.Command{
 		Use:     "bindings [NAME]",
@@ -41,6 +57,15 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat get bindings
   svcat get bindings --all-namespaces
+  svcat get bindings -A
+  svcat get bindings --selector app=wordpress
+  svcat get bindings --field-selector spec.externalID=0e3a2d14-b2e0-4a4c-9bf7-ab0a5e5ab2c5
+  svcat get bindings --instance wordpress-mysql-instance
+  svcat get bindings -o wide
+  svcat get bindings -o status-only
+  svcat get bindings --limit 50
+  svcat get bindings --older-than 30d
+  svcat get bindings --watch
   svcat get binding wordpress-mysql-binding
   svcat get binding -n ci concourse-postgres-binding
 `),
@@ -50,12 +75,38 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	getCmd.AddOutputFlags(cmd.Flags())
+	getCmd.AddSelectorFlag(cmd)
+	getCmd.AddPagingFlags(cmd)
+	getCmd.AddWatchFlag(cmd)
+	getCmd.AddAgeFlags(cmd)
+	cmd.Flags().StringVar(
+		&getCmd.instanceFilter,
+		"instance",
+		"",
+		"If present, only list the bindings of the specified instance",
+	)
 	return cmd
 }
 
 func (c *getCmd) Validate(args []string) error {
 	if len(args) > 0 {
 		c.name = args[0]
+
+		if c.instanceFilter != "" {
+			return fmt.Errorf("instance filter is not supported when specifying binding name")
+		}
+
+		if c.FiltersByAge() {
+			return fmt.Errorf("--older-than and --newer-than are not supported when specifying binding name")
+		}
+	}
+
+	if c.Watch && (c.Limit > 0 || c.Continue != "") {
+		return fmt.Errorf("--limit and --continue are not supported with --watch")
+	}
+
+	if c.Watch && c.FiltersByAge() {
+		return fmt.Errorf("--older-than and --newer-than are not supported with --watch")
 	}
 
 	return nil
@@ -70,12 +121,27 @@ func (c *getCmd) Run() error {
 }
 
 func (c *getCmd) getAll() error {
-	bindings, err := c.App.RetrieveBindings(c.Namespace)
+	bindings, err := c.App.RetrieveBindingsPage(servicecatalog.ScopeOptions{
+		Namespace:      c.Namespace,
+		InstanceFilter: c.instanceFilter,
+		LabelSelector:  c.LabelSelector,
+		FieldSelector:  c.FieldSelector,
+		Limit:          c.Limit,
+		Continue:       c.Continue,
+		ChunkSize:      c.ChunkSize,
+		OlderThan:      c.OlderThan,
+		NewerThan:      c.NewerThan,
+	})
 	if err != nil {
 		return err
 	}
 
-	output.WriteBindingList(c.Output, c.OutputFormat, bindings)
+	output.WriteBindingList(c.Output, c.OutputFormat, c.TableOptions(), bindings)
+	c.WriteContinueHint(c.Output, c.OutputFormat, bindings.Continue)
+
+	if c.Watch {
+		return c.watch(bindings.ResourceVersion, c.FieldSelector)
+	}
 	return nil
 }
 
@@ -85,6 +151,31 @@ func (c *getCmd) get() error {
 		return err
 	}
 
-	output.WriteBinding(c.Output, c.OutputFormat, *binding)
+	output.WriteBinding(c.Output, c.OutputFormat, c.TableOptions(), *binding)
+
+	if c.Watch {
+		return c.watch(binding.ResourceVersion, fields.OneTermEqualSelector("metadata.name", c.name).String())
+	}
 	return nil
 }
+
+// watch prints the bindings that change after the given resource version,
+// without the header row of tables. The watched bindings are filtered by
+// instance like the listed ones are by the SDK.
+func (c *getCmd) watch(resourceVersion, fieldSelector string) error {
+	opts := c.TableOptions()
+	opts.NoHeaders = true
+	start := func(resourceVersion string) (watch.Interface, error) {
+		return c.App.WatchBindings(servicecatalog.ScopeOptions{
+			Namespace:       c.Namespace,
+			LabelSelector:   c.LabelSelector,
+			FieldSelector:   fieldSelector,
+			ResourceVersion: resourceVersion,
+		})
+	}
+	return c.RunWatch(resourceVersion, start, func(obj runtime.Object) {
+		if binding, ok := obj.(*v1beta1.ServiceBinding); ok && (c.instanceFilter == "" || binding.Spec.InstanceRef.Name == c.instanceFilter) {
+			output.WriteBinding(c.Output, c.OutputFormat, opts, *binding)
+		}
+	})
+}
@@ -28,7 +28,9 @@ import (
 	"github.com/poy/service-catalog/pkg/svcat"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"github.com/poy/service-catalog/cmd/svcat/output"
 	_ "github.com/poy/service-catalog/internal/test"
@@ -132,8 +134,12 @@ func TestGetCommand(t *testing.T) {
 
 			// Initialize the command arguments
 			cmd := &getCmd{
-				Namespaced: command.NewNamespaced(cxt),
-				Formatted:  command.NewFormatted(),
+				Namespaced:  command.NewNamespaced(cxt),
+				Formatted:   command.NewFormatted(),
+				Selected:    command.NewSelected(),
+				Paged:       command.NewPaged(),
+				Watched:     command.NewWatched(),
+				AgeFiltered: command.NewAgeFiltered(),
 			}
 			cmd.Namespace = namespace
 			cmd.name = tc.bindingName
@@ -157,3 +163,117 @@ func TestGetCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCommandInstanceFilter(t *testing.T) {
+	const namespace = "default"
+	newBinding := func(name, instanceName string) *v1beta1.ServiceBinding {
+		return &v1beta1.ServiceBinding{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: v1beta1.ServiceBindingSpec{
+				InstanceRef: v1beta1.LocalObjectReference{Name: instanceName},
+			},
+		}
+	}
+
+	svcatClient := svcatfake.NewSimpleClientset(
+		newBinding("wordpress-binding", "wordpress-instance"),
+		newBinding("other-binding", "other-instance"),
+	)
+	fakeApp, _ := svcat.NewApp(k8sfake.NewSimpleClientset(), svcatClient, namespace)
+	output := &bytes.Buffer{}
+	cxt := svcattest.NewContext(output, fakeApp)
+
+	cmd := &getCmd{
+		Namespaced:  command.NewNamespaced(cxt),
+		Formatted:   command.NewFormatted(),
+		Selected:    command.NewSelected(),
+		Paged:       command.NewPaged(),
+		Watched:     command.NewWatched(),
+		AgeFiltered: command.NewAgeFiltered(),
+	}
+	cmd.Namespace = namespace
+	cmd.instanceFilter = "wordpress-instance"
+	cmd.OutputFormat = "table"
+
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("expected the command to succeed but it failed with %q", err)
+	}
+
+	got := output.String()
+	if !strings.Contains(got, "wordpress-binding") {
+		t.Errorf("expected the binding of the instance to be listed:\n%s", got)
+	}
+	if strings.Contains(got, "other-binding") {
+		t.Errorf("expected the bindings of other instances to be filtered out:\n%s", got)
+	}
+}
+
+func TestGetCommandWatch(t *testing.T) {
+	const namespace = "default"
+	newBinding := func(name, resourceVersion string) *v1beta1.ServiceBinding {
+		return &v1beta1.ServiceBinding{
+			ObjectMeta: v1.ObjectMeta{
+				Namespace:       namespace,
+				Name:            name,
+				ResourceVersion: resourceVersion,
+			},
+		}
+	}
+
+	svcatClient := svcatfake.NewSimpleClientset(newBinding("first-binding", ""))
+	watchers := []*watch.FakeWatcher{watch.NewFake(), watch.NewFake(), watch.NewFake()}
+	var resourceVersions []string
+	svcatClient.PrependWatchReactor("servicebindings", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		resourceVersions = append(resourceVersions, action.(k8stesting.WatchActionImpl).WatchRestrictions.ResourceVersion)
+		return true, watchers[len(resourceVersions)-1], nil
+	})
+	fakeApp, _ := svcat.NewApp(k8sfake.NewSimpleClientset(), svcatClient, namespace)
+	output := &bytes.Buffer{}
+	cxt := svcattest.NewContext(output, fakeApp)
+
+	stop := make(chan struct{})
+	cmd := &getCmd{
+		Namespaced:  command.NewNamespaced(cxt),
+		Formatted:   command.NewFormatted(),
+		Selected:    command.NewSelected(),
+		Paged:       command.NewPaged(),
+		Watched:     &command.Watched{Watch: true, Stop: stop},
+		AgeFiltered: command.NewAgeFiltered(),
+	}
+	cmd.Namespace = namespace
+	cmd.OutputFormat = "table"
+
+	done := make(chan error)
+	go func() {
+		done <- cmd.Run()
+	}()
+
+	// The first watch is closed by the server, and is started again from
+	// the last resource version received. The second one expires, and is
+	// started again from the current state.
+	watchers[0].Modify(newBinding("first-binding", "5"))
+	watchers[0].Stop()
+	watchers[1].Error(&v1.Status{Status: v1.StatusFailure, Code: 410, Reason: v1.StatusReasonExpired})
+	watchers[2].Add(newBinding("second-binding", "7"))
+	close(stop)
+	if err := <-done; err != nil {
+		t.Fatalf("expected the command to succeed but it failed with %q", err)
+	}
+
+	if e, a := []string{"", "5", ""}, resourceVersions; strings.Join(e, ",") != strings.Join(a, ",") {
+		t.Errorf("expected watches from resource versions %q, got %q", e, a)
+	}
+	got := output.String()
+	if e, a := 2, strings.Count(got, "first-binding"); e != a {
+		t.Errorf("expected the first binding to be printed %d times, got %d:\n%s", e, a, got)
+	}
+	if !strings.Contains(got, "second-binding") {
+		t.Errorf("expected the added binding to be printed:\n%s", got)
+	}
+	if e, a := 1, strings.Count(got, "INSTANCE"); e != a {
+		t.Errorf("expected the header to be printed once, got %d times:\n%s", a, got)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"fmt"
+
+	"github.com/poy/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type touchBindingCmd struct {
+	*command.Namespaced
+	name string
+}
+
+// NewTouchCommand builds a "svcat touch binding" command.
+func NewTouchCommand(cxt *command.Context) *cobra.Command {
+	touchBindingCmd := &touchBindingCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "binding",
+		Short: "Touch a binding to make service-catalog try to bind it again",
+		Long: `Touch binding will update an annotation on the binding.
+Then, service catalog will process the binding again, retrying a bind that
+failed with an error that is retried. A binding that failed with a terminal
+error is not retried; delete and recreate it instead.`,
+		Example: command.NormalizeExamples(`svcat touch binding wordpress-mysql-binding --namespace mynamespace`),
+		PreRunE: command.PreRunE(touchBindingCmd),
+		RunE:    command.RunE(touchBindingCmd),
+	}
+	touchBindingCmd.AddNamespaceFlags(cmd.Flags(), false)
+
+	return cmd
+}
+
+func (c *touchBindingCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a binding name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *touchBindingCmd) Run() error {
+	const retries = 3
+	binding, err := c.App.TouchBinding(c.Namespace, c.name, retries)
+	if err != nil {
+		return err
+	}
+	if c.App.IsBindingFailed(binding) {
+		fmt.Fprintf(c.Output, "Warning: binding %s/%s has a terminal failure and will not be retried, delete and recreate it instead\n",
+			binding.Namespace, binding.Name)
+	}
+	return nil
+}
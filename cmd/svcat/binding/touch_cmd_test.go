@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/poy/service-catalog/cmd/svcat/command"
+	"github.com/poy/service-catalog/cmd/svcat/test"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	svcatfake "github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	"github.com/poy/service-catalog/pkg/svcat"
+	servicecatalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTouchCommand(t *testing.T) {
+	const ns = "default"
+	testcases := []struct {
+		name        string
+		bindingName string
+		failed      bool
+		wantOutput  string
+		wantError   bool
+	}{
+		{
+			name:        "touch binding",
+			bindingName: "mybinding",
+		},
+		{
+			name:        "touch binding with a terminal failure",
+			bindingName: "mybinding",
+			failed:      true,
+			wantOutput:  "Warning: binding default/mybinding has a terminal failure and will not be retried",
+		},
+		{
+			name:        "touch missing binding",
+			bindingName: "missing",
+			wantOutput:  "not found",
+			wantError:   true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			binding := &v1beta1.ServiceBinding{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: ns,
+					Name:      "mybinding",
+				},
+			}
+			if tc.failed {
+				binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+					{Type: v1beta1.ServiceBindingConditionFailed, Status: v1beta1.ConditionTrue},
+				}
+			}
+			svcatClient := svcatfake.NewSimpleClientset(binding)
+			output := &bytes.Buffer{}
+			fakeApp, _ := svcat.NewApp(k8sfake.NewSimpleClientset(), svcatClient, ns)
+			cxt := svcattest.NewContext(output, fakeApp)
+
+			cmd := &touchBindingCmd{
+				Namespaced: command.NewNamespaced(cxt),
+				name:       tc.bindingName,
+			}
+			cmd.Namespace = ns
+
+			err := cmd.Run()
+			if tc.wantError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				output.WriteString(err.Error())
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			gotOutput := output.String()
+			if tc.wantOutput == "" && gotOutput != "" {
+				t.Fatalf("expected no output, got:\n%s", gotOutput)
+			}
+			if !strings.Contains(gotOutput, tc.wantOutput) {
+				t.Fatalf("unexpected output:\n\nExpected:\n%q\n\nActual:\n%q\n", tc.wantOutput, gotOutput)
+			}
+
+			if !tc.wantError {
+				updated, err := svcatClient.ServicecatalogV1beta1().ServiceBindings(ns).Get(tc.bindingName, v1.GetOptions{})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if _, ok := updated.Annotations[servicecatalog.TouchBindingAnnotation]; !ok {
+					t.Fatalf("expected the %s annotation to be set", servicecatalog.TouchBindingAnnotation)
+				}
+			}
+		})
+	}
+}
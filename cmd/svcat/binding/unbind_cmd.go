@@ -24,9 +24,10 @@ import (
 
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/poy/service-catalog/cmd/svcat/output"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/spf13/cobra"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type unbindCmd struct {
@@ -117,8 +118,9 @@ func (c *unbindCmd) getBindingsToDelete() []types.NamespacedName {
 	return bindings
 }
 
-// waitForBindingDeletes waits for the bindings to be deleted and prints either
-// and error message or the name of the deleted binding.
+// waitForBindingDeletes waits for the bindings to be deleted, including across
+// asynchronous unbind operations and retried unbind failures, and prints either
+// an error message or the name of the deleted binding.
 func (c *unbindCmd) waitForBindingDeletes(waitMessage string, bindings ...types.NamespacedName) bool {
 	if len(bindings) == 0 {
 		return false
@@ -138,18 +140,40 @@ func (c *unbindCmd) waitForBindingDeletes(waitMessage string, bindings ...types.
 		go func(ns, name string) {
 			defer g.Done()
 
-			binding, err := c.App.WaitForBinding(ns, name, c.Interval, c.Timeout)
+			// Report progress only when the status of the binding changes
+			var lastStatus *v1beta1.ServiceBindingCondition
+			reportProgress := func(binding *v1beta1.ServiceBinding) {
+				var status v1beta1.ServiceBindingCondition
+				if n := len(binding.Status.Conditions); n > 0 {
+					status = binding.Status.Conditions[n-1]
+				}
+				if lastStatus != nil && lastStatus.Reason == status.Reason && lastStatus.Status == status.Status {
+					return
+				}
+				lastStatus = &status
+
+				mutex.Lock()
+				defer mutex.Unlock()
+				output.WriteBindingDeletionProgress(c.Output, binding)
+			}
+
+			binding, err := c.App.WaitForBindingToNotExist(ns, name, c.Interval, c.Timeout, reportProgress)
 
 			mutex.Lock()
 			defer mutex.Unlock()
 
-			if err != nil && !apierrors.IsNotFound(errors.Cause(err)) {
+			switch {
+			case err == wait.ErrWaitTimeout:
+				hasErrors = true
+				fmt.Fprintf(c.Output, "timed out waiting for binding %s/%s to be deleted\n", ns, name)
+			case err != nil:
 				hasErrors = true
 				fmt.Fprintln(c.Output, err)
-			} else if c.App.IsBindingFailed(binding) {
+			case binding != nil:
+				// The unbind failed and will not be retried
 				hasErrors = true
-				fmt.Fprintf(c.Output, "could not delete binding %s/%s\n", ns, name)
-			} else {
+				output.WriteBindingUnbindFailure(c.Output, binding)
+			default:
 				output.WriteDeletedResourceName(c.Output, name)
 			}
 		}(binding.Namespace, binding.Name)
@@ -75,6 +75,14 @@ func TestUnbindCommand(t *testing.T) {
 			wantOutput:   "remove binding default/badbinding failed",
 			wantError:    true,
 		},
+		{
+			name:         "delete binding and wait - unbind failed",
+			fakeBindings: []string{"failedbinding"},
+			bindingNames: []string{"failedbinding"},
+			wait:         true,
+			wantOutput:   "waiting for the binding(s) to be deleted...\nWaiting for binding failedbinding to be unbound (Failed)...\ncould not delete binding default/failedbinding: Failed - The unbind call failed",
+			wantError:    true,
+		},
 		{
 			name:           "delete multiple bindings",
 			fakeBindings:   []string{"binding1", "binding2"},
@@ -171,13 +179,21 @@ func TestUnbindCommand(t *testing.T) {
 				})
 			}
 			for _, name := range tc.fakeBindings {
-				fakes = append(fakes, &v1beta1.ServiceBinding{
+				binding := &v1beta1.ServiceBinding{
 					ObjectMeta: v1.ObjectMeta{
 						Namespace: ns,
 						Name:      name,
 					},
 					Spec: v1beta1.ServiceBindingSpec{InstanceRef: v1beta1.LocalObjectReference{Name: tc.fakeInstance}},
-				})
+				}
+				// Fail the unbind of any binding with "failed" in the name
+				if strings.Contains(name, "failed") {
+					binding.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusFailed
+					binding.Status.Conditions = []v1beta1.ServiceBindingCondition{
+						{Type: v1beta1.ServiceBindingConditionFailed, Status: v1beta1.ConditionTrue, Reason: "UnbindCallFailed", Message: "The unbind call failed"},
+					}
+				}
+				fakes = append(fakes, binding)
 			}
 			svcatClient := svcatfake.NewSimpleClientset(fakes...)
 			output := &bytes.Buffer{}
@@ -191,6 +207,10 @@ func TestUnbindCommand(t *testing.T) {
 					if strings.Contains(a.GetName(), "bad") {
 						return true, nil, errors.New("sabotaged")
 					}
+					// Keep the bindings whose unbind failed
+					if strings.Contains(a.GetName(), "failed") {
+						return true, nil, nil
+					}
 					return false, nil, nil
 				})
 
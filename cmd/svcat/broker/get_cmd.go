@@ -28,13 +28,17 @@ type getCmd struct {
 	*command.Formatted
 	*command.Scoped
 	name string
+
+	// scopeSpecified is set when --scope was given. Otherwise, a broker
+	// retrieved by name is only looked for at the cluster scope.
+	scopeSpecified bool
 }
 
 // NewGetCmd builds a "svcat get brokers" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
 	getCmd := &getCmd{
 		Namespaced: command.NewNamespaced(cxt),
-		Formatted:  command.NewFormatted(),
+		Formatted:  command.NewWideFormatted(),
 		Scoped:     command.NewScoped(),
 	}
 	cmd := &cobra.Command{
@@ -45,9 +49,14 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get brokers
   svcat get brokers --scope=cluster
   svcat get brokers --scope=all
+  svcat get brokers -o wide
   svcat get broker minibroker
+  svcat get broker minibroker --scope=namespace --namespace=dev
 `),
-		PreRunE: command.PreRunE(getCmd),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			getCmd.scopeSpecified = cmd.Flags().Changed("scope")
+			return command.PreRunE(getCmd)(cmd, args)
+		},
 		RunE:    command.RunE(getCmd),
 	}
 	getCmd.AddOutputFlags(cmd.Flags())
@@ -82,16 +91,23 @@ func (c *getCmd) getAll() error {
 		return err
 	}
 
-	output.WriteBrokerList(c.Output, c.OutputFormat, brokers...)
+	output.WriteBrokerList(c.Output, c.OutputFormat, c.TableOptions(), brokers...)
 	return nil
 }
 
 func (c *getCmd) get() error {
-	broker, err := c.App.RetrieveBroker(c.name)
+	opts := servicecatalog.ScopeOptions{
+		Namespace: c.Namespace,
+		Scope:     servicecatalog.ClusterScope,
+	}
+	if c.scopeSpecified {
+		opts.Scope = c.Scope
+	}
+	broker, err := c.App.RetrieveBrokerByName(c.name, opts)
 	if err != nil {
 		return err
 	}
 
-	output.WriteBroker(c.Output, c.OutputFormat, *broker)
+	output.WriteBroker(c.Output, c.OutputFormat, c.TableOptions(), broker)
 	return nil
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/poy/service-catalog/cmd/svcat/output"
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/filter"
 	servicecatalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,16 +37,22 @@ type RegisterCmd struct {
 	*command.Scoped
 	*command.Waitable
 
+	AllowInsecure     bool
 	BasicSecret       string
 	BearerSecret      string
 	BrokerName        string
 	CAFile            string
+	ClassRestriction  []string
 	ClassRestrictions []string
+	Password          string
+	PlanRestriction   []string
 	PlanRestrictions  []string
 	SkipTLS           bool
 	RelistBehavior    string
 	RelistDuration    time.Duration
 	URL               string
+	Username          string
+	Verify            bool
 }
 
 // NewRegisterCmd builds a "svcat register" command
@@ -60,6 +67,12 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 		Short: "Registers a new broker with service catalog",
 		Example: command.NormalizeExamples(`
 		svcat register mysqlbroker --url http://mysqlbroker.com
+		svcat register mysqlbroker --url https://mysqlbroker.com --basic-secret mysqlbroker-auth
+		svcat register mysqlbroker --url https://mysqlbroker.com --username admin --password s3cr3t
+		svcat register mysqlbroker --url https://mysqlbroker.com --relist-duration 1h
+		svcat register mysqlbroker --url https://mysqlbroker.com --relist-behavior manual
+		svcat register mysqlbroker --url https://mysqlbroker.com --basic-secret mysqlbroker-auth --verify
+		svcat register mysqlbroker --url https://mysqlbroker.com --class-restriction "spec.externalName in (mysql,mariadb)" --plan-restriction "spec.free=true"
 		`),
 		PreRunE: command.PreRunE(registerCmd),
 		RunE:    command.RunE(registerCmd),
@@ -71,18 +84,30 @@ func NewRegisterCmd(cxt *command.Context) *cobra.Command {
 		"A secret containing basic auth (username/password) information to connect to the broker")
 	cmd.Flags().StringVar(&registerCmd.BearerSecret, "bearer-secret", "",
 		"A secret containing a bearer token to connect to the broker")
+	cmd.Flags().StringVar(&registerCmd.Username, "username", "",
+		"The username used to connect to the broker. Creates the secret named by --basic-secret, or NAME-auth by default")
+	cmd.Flags().StringVar(&registerCmd.Password, "password", "",
+		"The password used to connect to the broker, requires --username")
 	cmd.Flags().StringVar(&registerCmd.CAFile, "ca", "",
 		"A file containing the CA certificate to connect to the broker")
 	cmd.Flags().StringSliceVar(&registerCmd.ClassRestrictions, "class-restrictions", []string{},
 		"A list of restrictions to apply to the classes allowed from the broker")
 	cmd.Flags().StringSliceVar(&registerCmd.PlanRestrictions, "plan-restrictions", []string{},
 		"A list of restrictions to apply to the plans allowed from the broker")
+	cmd.Flags().StringArrayVar(&registerCmd.ClassRestriction, "class-restriction", []string{},
+		"A restriction to apply to the classes allowed from the broker, such as 'spec.externalName in (a,b)'. May be repeated; unlike --class-restrictions, commas are not treated as separators")
+	cmd.Flags().StringArrayVar(&registerCmd.PlanRestriction, "plan-restriction", []string{},
+		"A restriction to apply to the plans allowed from the broker, such as 'spec.free=true'. May be repeated; unlike --plan-restrictions, commas are not treated as separators")
 	cmd.Flags().StringVar(&registerCmd.RelistBehavior, "relist-behavior", "",
 		"Behavior for relisting the broker's catalog. Valid options are manual or duration. Defaults to duration with an interval of 15m.")
 	cmd.Flags().DurationVar(&registerCmd.RelistDuration, "relist-duration", 0*time.Second,
-		"Interval to refetch broker catalog when relist-behavior is set to duration, specified in human readable format: 30s, 1m, 1h")
+		"Interval to refetch broker catalog when relist-behavior is set to duration, specified in human readable format: 30s, 1m, 1h. Implies --relist-behavior duration")
+	cmd.Flags().BoolVar(&registerCmd.AllowInsecure, "allow-insecure", false,
+		"Allows sending credentials to a broker URL that does not use https. Only use this for local or development brokers.")
 	cmd.Flags().BoolVar(&registerCmd.SkipTLS, "skip-tls", false,
 		"Disables TLS certificate verification when communicating with this broker. This is strongly discouraged. You should use --ca instead.")
+	cmd.Flags().BoolVar(&registerCmd.Verify, "verify", false,
+		"Before registering the broker, check that it answers a request for its catalog at the broker URL, with the given credentials and CA. The broker is not registered when the check fails.")
 	registerCmd.AddNamespaceFlags(cmd.Flags(), false)
 	registerCmd.AddScopedFlags(cmd.Flags(), false)
 	registerCmd.AddWaitFlags(cmd)
@@ -101,6 +126,18 @@ func (c *RegisterCmd) Validate(args []string) error {
 		return fmt.Errorf("cannot use both basic auth and bearer auth")
 	}
 
+	if c.Username != "" || c.Password != "" {
+		if c.Username == "" || c.Password == "" {
+			return fmt.Errorf("--username and --password must be provided together")
+		}
+		if c.BearerSecret != "" {
+			return fmt.Errorf("cannot use both basic auth and bearer auth")
+		}
+		if c.BasicSecret == "" {
+			c.BasicSecret = c.BrokerName + "-auth"
+		}
+	}
+
 	if c.CAFile != "" {
 		_, err := os.Stat(c.CAFile)
 		if err != nil {
@@ -110,7 +147,53 @@ func (c *RegisterCmd) Validate(args []string) error {
 	if c.RelistBehavior != "" {
 		c.RelistBehavior = strings.ToLower(c.RelistBehavior)
 		if c.RelistBehavior != "duration" && c.RelistBehavior != "manual" {
-			return fmt.Errorf("invalid --relist-duration value, allowed values are: duration, manual")
+			return fmt.Errorf("invalid --relist-behavior value %q, allowed values are: duration, manual", c.RelistBehavior)
+		}
+	}
+	if c.RelistDuration < 0 {
+		return fmt.Errorf("invalid --relist-duration value %v, must be greater than zero", c.RelistDuration)
+	}
+	if c.RelistDuration > 0 {
+		if c.RelistBehavior == "manual" {
+			return fmt.Errorf("--relist-duration cannot be used with --relist-behavior manual")
+		}
+		// A relist interval only makes sense when relisting on a duration
+		c.RelistBehavior = "duration"
+	}
+
+	c.ClassRestrictions = append(c.ClassRestrictions, c.ClassRestriction...)
+	c.PlanRestrictions = append(c.PlanRestrictions, c.PlanRestriction...)
+	if len(c.ClassRestrictions) > 0 || len(c.PlanRestrictions) > 0 {
+		return c.validateCatalogRestrictions()
+	}
+	return nil
+}
+
+// validateCatalogRestrictions checks the class and plan restrictions against
+// the properties supported for the scope of the broker.
+func (c *RegisterCmd) validateCatalogRestrictions() error {
+	isValidClassProperty := v1beta1.IsValidServiceClassProperty
+	isValidPlanProperty := v1beta1.IsValidServicePlanProperty
+	if c.Scope.Matches(servicecatalog.ClusterScope) {
+		isValidClassProperty = v1beta1.IsValidClusterServiceClassProperty
+		isValidPlanProperty = v1beta1.IsValidClusterServicePlanProperty
+	}
+	if err := validateRestrictions("class", c.ClassRestrictions, isValidClassProperty); err != nil {
+		return err
+	}
+	return validateRestrictions("plan", c.PlanRestrictions, isValidPlanProperty)
+}
+
+// validateRestrictions checks that each of the given class or plan
+// restrictions is a valid selector on a property the broker's catalog can be
+// restricted by, so that mistakes are reported before creating the broker.
+func validateRestrictions(kind string, restrictions []string, isValidProperty func(string) bool) error {
+	for _, restriction := range restrictions {
+		if _, err := filter.CreatePredicate([]string{restriction}); err != nil {
+			return fmt.Errorf("invalid %s restriction %q: %v", kind, restriction, err)
+		}
+		if property := strings.TrimSpace(filter.ExtractProperty(restriction)); !isValidProperty(property) {
+			return fmt.Errorf("invalid %s restriction %q: unsupported property %q", kind, restriction, property)
 		}
 	}
 	return nil
@@ -119,13 +202,16 @@ func (c *RegisterCmd) Validate(args []string) error {
 // Run creates the broker and then displays the broker details
 func (c *RegisterCmd) Run() error {
 	opts := &servicecatalog.RegisterOptions{
+		AllowInsecure:     c.AllowInsecure,
 		BasicSecret:       c.BasicSecret,
 		BearerSecret:      c.BearerSecret,
 		CAFile:            c.CAFile,
 		ClassRestrictions: c.ClassRestrictions,
 		Namespace:         c.Namespace,
+		Password:          c.Password,
 		PlanRestrictions:  c.PlanRestrictions,
 		SkipTLS:           c.SkipTLS,
+		Username:          c.Username,
 	}
 	scopeOpts := &servicecatalog.ScopeOptions{
 		Namespace: c.Namespace,
@@ -133,11 +219,20 @@ func (c *RegisterCmd) Run() error {
 	}
 	if c.RelistBehavior == "duration" {
 		opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorDuration
-		opts.RelistDuration = &metav1.Duration{Duration: c.RelistDuration}
+		// Leave the duration unset to get the default interval
+		if c.RelistDuration > 0 {
+			opts.RelistDuration = &metav1.Duration{Duration: c.RelistDuration}
+		}
 	} else if c.RelistBehavior == "manual" {
 		opts.RelistBehavior = v1beta1.ServiceBrokerRelistBehaviorManual
 	}
 
+	if c.Verify {
+		if err := c.Context.App.VerifyBroker(c.URL, opts); err != nil {
+			return fmt.Errorf("the broker was not registered because it could not be verified: %v", err)
+		}
+	}
+
 	broker, err := c.Context.App.Register(c.BrokerName, c.URL, opts, scopeOpts)
 	if err != nil {
 		return err
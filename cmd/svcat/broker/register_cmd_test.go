@@ -18,6 +18,7 @@ package broker_test
 
 import (
 	"bytes"
+	"errors"
 	"time"
 
 	. "github.com/poy/service-catalog/cmd/svcat/broker"
@@ -57,6 +58,14 @@ var _ = Describe("Register Command", func() {
 			Expect(bearerSecretFlag).NotTo(BeNil())
 			Expect(bearerSecretFlag.Usage).To(ContainSubstring("A secret containing a bearer token to connect to the broker"))
 
+			usernameFlag := cmd.Flags().Lookup("username")
+			Expect(usernameFlag).NotTo(BeNil())
+			Expect(usernameFlag.Usage).To(ContainSubstring("The username used to connect to the broker"))
+
+			passwordFlag := cmd.Flags().Lookup("password")
+			Expect(passwordFlag).NotTo(BeNil())
+			Expect(passwordFlag.Usage).To(ContainSubstring("The password used to connect to the broker"))
+
 			caFlag := cmd.Flags().Lookup("ca")
 			Expect(caFlag).NotTo(BeNil())
 			Expect(caFlag.Usage).To(ContainSubstring("A file containing the CA certificate to connect to the broker"))
@@ -69,6 +78,14 @@ var _ = Describe("Register Command", func() {
 			Expect(planRestrictionFlag).NotTo(BeNil())
 			Expect(planRestrictionFlag.Usage).To(ContainSubstring("A list of restrictions to apply to the plans allowed from the broker"))
 
+			classRestrictionArrayFlag := cmd.Flags().Lookup("class-restriction")
+			Expect(classRestrictionArrayFlag).NotTo(BeNil())
+			Expect(classRestrictionArrayFlag.Usage).To(ContainSubstring("A restriction to apply to the classes allowed from the broker"))
+
+			planRestrictionArrayFlag := cmd.Flags().Lookup("plan-restriction")
+			Expect(planRestrictionArrayFlag).NotTo(BeNil())
+			Expect(planRestrictionArrayFlag.Usage).To(ContainSubstring("A restriction to apply to the plans allowed from the broker"))
+
 			relistBehaviorFlag := cmd.Flags().Lookup("relist-behavior")
 			Expect(relistBehaviorFlag).NotTo(BeNil())
 			Expect(relistBehaviorFlag.Usage).To(ContainSubstring("Behavior for relisting the broker's catalog. Valid options are manual or duration. Defaults to duration with an interval of 15m."))
@@ -77,10 +94,18 @@ var _ = Describe("Register Command", func() {
 			Expect(relistDurationFlag).NotTo(BeNil())
 			Expect(relistDurationFlag.Usage).To(ContainSubstring("Interval to refetch broker catalog when relist-behavior is set to duration, specified in human readable format: 30s, 1m, 1h"))
 
+			allowInsecureFlag := cmd.Flags().Lookup("allow-insecure")
+			Expect(allowInsecureFlag).NotTo(BeNil())
+			Expect(allowInsecureFlag.Usage).To(ContainSubstring("Allows sending credentials to a broker URL that does not use https."))
+
 			skipTLSFlag := cmd.Flags().Lookup("skip-tls")
 			Expect(skipTLSFlag).NotTo(BeNil())
 			Expect(skipTLSFlag.Usage).To(ContainSubstring("Disables TLS certificate verification when communicating with this broker. This is strongly discouraged. You should use --ca instead."))
 
+			verifyFlag := cmd.Flags().Lookup("verify")
+			Expect(verifyFlag).NotTo(BeNil())
+			Expect(verifyFlag.DefValue).To(Equal("false"))
+
 			waitFlag := cmd.Flags().Lookup("wait")
 			Expect(waitFlag).NotTo(BeNil())
 			timeoutFlag := cmd.Flags().Lookup("timeout")
@@ -113,6 +138,42 @@ var _ = Describe("Register Command", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("cannot use both basic auth and bearer auth"))
 		})
+		It("errors if only one of username and password is provided", func() {
+			cmd := RegisterCmd{
+				Username: "admin",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--username and --password must be provided together"))
+		})
+		It("errors if username and password are provided with bearer-secret", func() {
+			cmd := RegisterCmd{
+				BearerSecret: "bearersecret",
+				Password:     "s3cr3t",
+				Username:     "admin",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot use both basic auth and bearer auth"))
+		})
+		It("defaults the basic secret name when username and password are provided", func() {
+			cmd := RegisterCmd{
+				Password: "s3cr3t",
+				Username: "admin",
+			}
+			err := cmd.Validate([]string{"bananabroker"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.BasicSecret).To(Equal("bananabroker-auth"))
+
+			cmd = RegisterCmd{
+				BasicSecret: "bananasecret",
+				Password:    "s3cr3t",
+				Username:    "admin",
+			}
+			err = cmd.Validate([]string{"bananabroker"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.BasicSecret).To(Equal("bananasecret"))
+		})
 		It("errors if a provided CA file does not exist", func() {
 			cmd := RegisterCmd{
 				CAFile: "/not/a/real/file",
@@ -127,7 +188,7 @@ var _ = Describe("Register Command", func() {
 			}
 			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("invalid --relist-duration value, allowed values are: duration, manual"))
+			Expect(err.Error()).To(ContainSubstring("invalid --relist-behavior value \"foobar\", allowed values are: duration, manual"))
 
 			cmd = RegisterCmd{
 				RelistBehavior: "Duration",
@@ -141,6 +202,64 @@ var _ = Describe("Register Command", func() {
 			err = cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
 			Expect(err).NotTo(HaveOccurred())
 		})
+		It("only allows a positive relist duration", func() {
+			cmd := RegisterCmd{
+				RelistDuration: -1 * time.Minute,
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid --relist-duration value -1m0s, must be greater than zero"))
+		})
+		It("does not allow a relist duration with the manual relist behavior", func() {
+			cmd := RegisterCmd{
+				RelistBehavior: "manual",
+				RelistDuration: 10 * time.Minute,
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("--relist-duration cannot be used with --relist-behavior manual"))
+		})
+		It("defaults the relist behavior to duration when a relist duration is given", func() {
+			cmd := RegisterCmd{
+				RelistDuration: 10 * time.Minute,
+			}
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.RelistBehavior).To(Equal("duration"))
+		})
+		It("adds the restrictions given one at a time to the restriction lists", func() {
+			cmd := RegisterCmd{
+				Scoped:            command.NewScoped(),
+				ClassRestriction:  []string{"spec.externalName in (mysql,mariadb)"},
+				ClassRestrictions: []string{"name!=abc"},
+				PlanRestriction:   []string{"spec.free=true"},
+			}
+			cmd.Scope = servicecatalog.ClusterScope
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.ClassRestrictions).To(Equal([]string{"name!=abc", "spec.externalName in (mysql,mariadb)"}))
+			Expect(cmd.PlanRestrictions).To(Equal([]string{"spec.free=true"}))
+		})
+		It("errors if a restriction is not a valid selector", func() {
+			cmd := RegisterCmd{
+				Scoped:           command.NewScoped(),
+				ClassRestriction: []string{"spec.externalName in (mysql"},
+			}
+			cmd.Scope = servicecatalog.ClusterScope
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid class restriction "spec.externalName in (mysql"`))
+		})
+		It("errors if a restriction uses an unsupported property", func() {
+			cmd := RegisterCmd{
+				Scoped:          command.NewScoped(),
+				PlanRestriction: []string{"spec.clusterServiceClass.name=abc"},
+			}
+			cmd.Scope = servicecatalog.NamespaceScope
+			err := cmd.Validate([]string{"bananabroker", "http://bananabroker.com"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`invalid plan restriction "spec.clusterServiceClass.name=abc": unsupported property "spec.clusterServiceClass.name"`))
+		})
 	})
 	Describe("Run", func() {
 		var (
@@ -293,6 +412,58 @@ var _ = Describe("Register Command", func() {
 			Expect(output).To(ContainSubstring(brokerName))
 			Expect(output).To(ContainSubstring(brokerURL))
 		})
+		It("Verifies the broker before registering it when Verify==true", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RegisterReturns(brokerToReturn, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := RegisterCmd{
+				BasicSecret: basicSecret,
+				BrokerName:  brokerName,
+				Namespaced:  command.NewNamespaced(cxt),
+				Scoped:      command.NewScoped(),
+				Waitable:    command.NewWaitable(),
+				URL:         brokerURL,
+				Verify:      true,
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSDK.VerifyBrokerCallCount()).To(Equal(1))
+			returnedURL, returnedOpts := fakeSDK.VerifyBrokerArgsForCall(0)
+			Expect(returnedURL).To(Equal(brokerURL))
+			Expect(returnedOpts.BasicSecret).To(Equal(basicSecret))
+			Expect(fakeSDK.RegisterCallCount()).To(Equal(1))
+		})
+		It("Does not register the broker when it cannot be verified", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, namespace)
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.VerifyBrokerReturns(errors.New("connection refused"))
+			fakeApp.SvcatClient = fakeSDK
+			cxt := svcattest.NewContext(outputBuffer, fakeApp)
+			cmd := RegisterCmd{
+				BrokerName: brokerName,
+				Namespaced: command.NewNamespaced(cxt),
+				Scoped:     command.NewScoped(),
+				Waitable:   command.NewWaitable(),
+				URL:        brokerURL,
+				Verify:     true,
+			}
+			cmd.Namespaced.ApplyNamespaceFlags(&pflag.FlagSet{})
+			cmd.Waitable.ApplyWaitFlags()
+			err := cmd.Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the broker was not registered because it could not be verified: connection refused"))
+			Expect(fakeSDK.RegisterCallCount()).To(Equal(0))
+		})
 		It("Calls the SDK's WaitForBroker method with the passed in interval and timeout when Wait==true", func() {
 			interval := 1 * time.Second
 			timeout := 1 * time.Minute
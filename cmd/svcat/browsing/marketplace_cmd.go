@@ -82,6 +82,6 @@ func (c *MarketplaceCmd) Run() error {
 			}
 		}
 	}
-	output.WriteClassAndPlanDetails(c.Output, classes, plans)
+	output.WriteClassAndPlanDetails(c.Output, c.TableOptions(), classes, plans)
 	return nil
 }
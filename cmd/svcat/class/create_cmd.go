@@ -84,6 +84,6 @@ func (c *CreateCmd) Run() error {
 		return err
 	}
 
-	output.WriteClassList(c.Output, output.FormatTable, createdClass)
+	output.WriteClassList(c.Output, output.FormatTable, output.TableOptions{}, createdClass)
 	return nil
 }
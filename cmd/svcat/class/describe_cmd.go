@@ -30,6 +30,7 @@ type describeCmd struct {
 	lookupByKubeName bool
 	kubeName         string
 	name             string
+	showPlans        bool
 }
 
 // NewDescribeCmd builds a "svcat describe class" command
@@ -42,6 +43,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		Example: command.NormalizeExamples(`
   svcat describe class mysqldb
   svcat describe class --kube-name 997b8372-8dac-40ac-ae65-758b4a5075a5
+  svcat describe class mysqldb --show-plans=false
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
@@ -53,6 +55,12 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		false,
 		"Whether or not to get the class by its Kubernetes Name (the default is by external name)",
 	)
+	cmd.Flags().BoolVar(
+		&describeCmd.showPlans,
+		"show-plans",
+		true,
+		"Whether or not to list the plans of the class",
+	)
 	return cmd
 }
 
@@ -89,13 +97,16 @@ func (c *describeCmd) describe() error {
 	}
 
 	output.WriteClassDetails(c.Output, class)
+	if !c.showPlans {
+		return nil
+	}
 
 	opts := servicecatalog.ScopeOptions{Scope: servicecatalog.AllScope}
 	plans, err := c.App.RetrievePlans(class.GetName(), opts)
 	if err != nil {
 		return err
 	}
-	output.WriteAssociatedPlans(c.Output, plans)
+	output.WriteAssociatedPlans(c.Output, class, plans)
 
 	return nil
 }
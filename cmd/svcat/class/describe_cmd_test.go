@@ -37,11 +37,18 @@ func TestDescribeCommand(t *testing.T) {
 		cmd             string // Command to run
 		golden          string // Relative path to a golden file, compared to the command output
 		continueOnError bool   // Should the test stop immediately if the command fails or continue and capture the console output
+		showPlans       bool   // Whether the plans of the class are listed
 	}{
 		{
-			name:   "describe class by name",
-			cmd:    "describe class user-provided-service",
-			golden: "describe-class.txt",
+			name:      "describe class by name",
+			cmd:       "describe class user-provided-service",
+			golden:    "describe-class.txt",
+			showPlans: true,
+		},
+		{
+			name:   "describe class without plans",
+			cmd:    "describe class user-provided-service --show-plans=false",
+			golden: "describe-class-no-plans.txt",
 		},
 	}
 	for _, tc := range testcases {
@@ -129,7 +136,8 @@ func TestDescribeCommand(t *testing.T) {
 
 			// Initialize the command arguments
 			cmd := &describeCmd{
-				Context: cxt,
+				Context:   cxt,
+				showPlans: tc.showPlans,
 			}
 			// Capture all output: stderr and stdout
 			cmd.Context.Output = output
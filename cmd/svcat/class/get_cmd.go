@@ -17,19 +17,30 @@ limitations under the License.
 package class
 
 import (
+	"fmt"
+
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/poy/service-catalog/cmd/svcat/output"
 	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
 type getCmd struct {
 	*command.Namespaced
 	*command.Scoped
 	*command.Formatted
+	*command.Selected
+	*command.Paged
 	lookupByKubeName bool
 	kubeName         string
 	name             string
+	tags             []string
+	broker           string
+
+	// showRemoved is set when classes that were removed from their broker's
+	// catalog should be listed too.
+	showRemoved bool
 }
 
 // NewGetCmd builds a "svcat get classes" command
@@ -38,6 +49,8 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Namespaced: command.NewNamespaced(cxt),
 		Scoped:     command.NewScoped(),
 		Formatted:  command.NewFormatted(),
+		Selected:   command.NewSelected(),
+		Paged:      command.NewPaged(),
 	}
 	cmd := &cobra.Command{
 		Use:     "classes [NAME]",
@@ -47,7 +60,13 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get classes
   svcat get classes --scope cluster
   svcat get classes --scope namespace --namespace dev
+  svcat get classes --selector tier=gold
+  svcat get classes --tag database --tag mysql
+  svcat get classes --broker ups-broker
+  svcat get classes --show-removed
+  svcat get classes --limit 50
   svcat get class mysqldb
+  svcat get class mysqldb --broker ups-broker
   svcat get class --kube-name 997b8372-8dac-40ac-ae65-758b4a5075a5
 `),
 		PreRunE: command.PreRunE(getCmd),
@@ -60,14 +79,40 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		false,
 		"Whether or not to get the class by its Kubernetes name (the default is by external name)",
 	)
+	cmd.Flags().StringSliceVar(
+		&getCmd.tags,
+		"tag",
+		nil,
+		"Only list the classes with this tag, ignoring case. May be repeated or comma separated to list the classes with all of the tags",
+	)
+	cmd.Flags().StringVar(
+		&getCmd.broker,
+		"broker",
+		"",
+		"If present, only get the classes offered by this broker. Required to get a class by name when more than one broker offers a class with that name",
+	)
+	cmd.Flags().BoolVar(
+		&getCmd.showRemoved,
+		"show-removed",
+		false,
+		"If present, also list the classes removed from their broker's catalog, with a column showing whether each class was removed",
+	)
 	getCmd.AddOutputFlags(cmd.Flags())
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	getCmd.AddScopedFlags(cmd.Flags(), true)
+	getCmd.AddSelectorFlag(cmd)
+	getCmd.AddPagingFlags(cmd)
 	return cmd
 }
 
 func (c *getCmd) Validate(args []string) error {
 	if len(args) > 0 {
+		if len(c.tags) > 0 {
+			return fmt.Errorf("--tag cannot be used when getting a class by name")
+		}
+		if c.showRemoved {
+			return fmt.Errorf("--show-removed cannot be used when getting a class by name")
+		}
 		if c.lookupByKubeName {
 			c.kubeName = args[0]
 		} else {
@@ -88,15 +133,28 @@ func (c *getCmd) Run() error {
 
 func (c *getCmd) getAll() error {
 	opts := servicecatalog.ScopeOptions{
-		Namespace: c.Namespace,
-		Scope:     c.Scope,
+		Namespace:      c.Namespace,
+		Scope:          c.Scope,
+		LabelSelector:  c.LabelSelector,
+		FieldSelector:  c.FieldSelector,
+		Limit:          c.Limit,
+		Continue:       c.Continue,
+		ChunkSize:      c.ChunkSize,
+		Tags:           c.tags,
+		Broker:         c.broker,
+		ExcludeRemoved: !c.showRemoved,
 	}
-	classes, err := c.App.RetrieveClasses(opts)
+	classes, next, err := c.App.RetrieveClassesPage(opts)
 	if err != nil {
 		return err
 	}
 
-	output.WriteClassList(c.Output, c.OutputFormat, classes...)
+	if c.showRemoved {
+		output.WriteClassListWithRemoved(c.Output, c.OutputFormat, c.TableOptions(), classes...)
+	} else {
+		output.WriteClassList(c.Output, c.OutputFormat, c.TableOptions(), classes...)
+	}
+	c.WriteContinueHint(c.Output, c.OutputFormat, next)
 	return nil
 }
 
@@ -107,12 +165,29 @@ func (c *getCmd) get() error {
 	if c.lookupByKubeName {
 		class, err = c.App.RetrieveClassByID(c.kubeName)
 	} else if c.name != "" {
-		class, err = c.App.RetrieveClassByName(c.name, servicecatalog.ScopeOptions{Scope: c.Scope, Namespace: c.Namespace})
+		opts := servicecatalog.ScopeOptions{Scope: c.Scope, Namespace: c.Namespace, Broker: c.broker}
+		class, err = c.App.RetrieveClassByName(c.name, opts)
+		if servicecatalog.IsAmbiguous(err) {
+			return c.writeAmbiguousClasses(opts)
+		}
 	}
 	if err != nil {
 		return err
 	}
 
-	output.WriteClass(c.Output, c.OutputFormat, class)
+	output.WriteClass(c.Output, c.OutputFormat, c.TableOptions(), class)
 	return nil
 }
+
+// writeAmbiguousClasses prints the classes offered under the requested name
+// by more than one broker, and fails so that the user picks one with --broker.
+func (c *getCmd) writeAmbiguousClasses(opts servicecatalog.ScopeOptions) error {
+	opts.FieldSelector = fields.OneTermEqualSelector(servicecatalog.FieldExternalClassName, c.name).String()
+	classes, err := c.App.RetrieveClasses(opts)
+	if err != nil {
+		return err
+	}
+
+	output.WriteAmbiguousClasses(c.Output, classes...)
+	return fmt.Errorf("class '%s' is offered by more than one broker, use --broker to select one", c.name)
+}
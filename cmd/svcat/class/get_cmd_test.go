@@ -156,6 +156,8 @@ func TestListClasses(t *testing.T) {
 				Namespaced: command.NewNamespaced(cxt),
 				Scoped:     command.NewScoped(),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
 			}
 			cmd.Namespace = ns
 			cmd.Scope = tc.scope
@@ -208,7 +210,7 @@ var _ = Describe("Get Classes Command", func() {
 		})
 	})
 	Describe("Run", func() {
-		It("Calls the pkg/svcat libs RetrieveClasses with namespace scope and current namespace", func() {
+		It("Calls the pkg/svcat libs RetrieveClassesPage with namespace scope and current namespace", func() {
 			className := "mysqldb"
 			classNamespace := "default"
 
@@ -227,28 +229,92 @@ var _ = Describe("Get Classes Command", func() {
 
 			fakeApp, _ := svcat.NewApp(nil, nil, "default")
 			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
-			fakeSDK.RetrieveClassesReturns([]servicecatalog.Class{classToReturn}, nil)
+			fakeSDK.RetrieveClassesPageReturns([]servicecatalog.Class{classToReturn}, "", nil)
 			fakeApp.SvcatClient = fakeSDK
 			cmd := getCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
 				Scoped:     command.NewScoped(),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
 			}
 			cmd.Scope = servicecatalog.NamespaceScope
 			cmd.Namespace = classNamespace
 			err := cmd.Run()
 
 			Expect(err).NotTo(HaveOccurred())
-			scopeArg := fakeSDK.RetrieveClassesArgsForCall(0)
+			scopeArg := fakeSDK.RetrieveClassesPageArgsForCall(0)
 			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
-				Namespace: classNamespace,
-				Scope:     servicecatalog.NamespaceScope,
+				Namespace:      classNamespace,
+				Scope:          servicecatalog.NamespaceScope,
+				ExcludeRemoved: true,
 			}))
 
 			output := outputBuffer.String()
 			Expect(output).To(ContainSubstring("mysqldb"))
 		})
-		It("Calls the pkg/svcat libs RetrieveClasses with namespace scope and all namespaces", func() {
+		It("Passes the label and field selectors to the pkg/svcat libs RetrieveClassesPage", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, "default")
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveClassesPageReturns([]servicecatalog.Class{}, "", nil)
+			fakeApp.SvcatClient = fakeSDK
+			cmd := getCmd{
+				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Scoped:     command.NewScoped(),
+				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
+			}
+			cmd.Scope = servicecatalog.AllScope
+			cmd.Namespace = "default"
+			cmd.LabelSelector = "tier=gold"
+			cmd.FieldSelector = "spec.externalName=mysqldb"
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			scopeArg := fakeSDK.RetrieveClassesPageArgsForCall(0)
+			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
+				Namespace:      "default",
+				Scope:          servicecatalog.AllScope,
+				LabelSelector:  "tier=gold",
+				FieldSelector:  "spec.externalName=mysqldb",
+				ExcludeRemoved: true,
+			}))
+		})
+		It("Passes the limit and continue token to the pkg/svcat libs RetrieveClassesPage and prints the next token", func() {
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, "default")
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveClassesPageReturns([]servicecatalog.Class{}, "namespace:abc", nil)
+			fakeApp.SvcatClient = fakeSDK
+			cmd := getCmd{
+				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Scoped:     command.NewScoped(),
+				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
+			}
+			cmd.Scope = servicecatalog.AllScope
+			cmd.Namespace = "default"
+			cmd.Limit = 10
+			cmd.Continue = "cluster:xyz"
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			scopeArg := fakeSDK.RetrieveClassesPageArgsForCall(0)
+			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
+				Namespace:      "default",
+				Scope:          servicecatalog.AllScope,
+				Limit:          10,
+				Continue:       "cluster:xyz",
+				ExcludeRemoved: true,
+			}))
+			Expect(outputBuffer.String()).To(ContainSubstring("--continue namespace:abc"))
+		})
+		It("Calls the pkg/svcat libs RetrieveClassesPage with namespace scope and all namespaces", func() {
 			classOneName := "mysqldb"
 			classOneNamespace := "default"
 
@@ -281,29 +347,32 @@ var _ = Describe("Get Classes Command", func() {
 
 			fakeApp, _ := svcat.NewApp(nil, nil, "default")
 			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
-			fakeSDK.RetrieveClassesReturns([]servicecatalog.Class{classOneToReturn, classTwoToReturn}, nil)
+			fakeSDK.RetrieveClassesPageReturns([]servicecatalog.Class{classOneToReturn, classTwoToReturn}, "", nil)
 			fakeApp.SvcatClient = fakeSDK
 			cmd := getCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
 				Scoped:     command.NewScoped(),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
 			}
 			cmd.Scope = servicecatalog.NamespaceScope
 			cmd.Namespace = ""
 			err := cmd.Run()
 
 			Expect(err).NotTo(HaveOccurred())
-			scopeArg := fakeSDK.RetrieveClassesArgsForCall(0)
+			scopeArg := fakeSDK.RetrieveClassesPageArgsForCall(0)
 			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
-				Namespace: "",
-				Scope:     servicecatalog.NamespaceScope,
+				Namespace:      "",
+				Scope:          servicecatalog.NamespaceScope,
+				ExcludeRemoved: true,
 			}))
 
 			output := outputBuffer.String()
 			Expect(output).To(ContainSubstring("mysqldb"))
 			Expect(output).To(ContainSubstring("postgresdb"))
 		})
-		It("Calls the pkg/svcat libs RetrieveClasses with all scope and current namespaces", func() {
+		It("Calls the pkg/svcat libs RetrieveClassesPage with all scope and current namespaces", func() {
 			classOneName := "mysqldb"
 
 			classTwoName := "postgresdb"
@@ -332,27 +401,113 @@ var _ = Describe("Get Classes Command", func() {
 
 			fakeApp, _ := svcat.NewApp(nil, nil, "default")
 			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
-			fakeSDK.RetrieveClassesReturns([]servicecatalog.Class{classOneToReturn, classTwoToReturn}, nil)
+			fakeSDK.RetrieveClassesPageReturns([]servicecatalog.Class{classOneToReturn, classTwoToReturn}, "", nil)
 			fakeApp.SvcatClient = fakeSDK
 			cmd := getCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
 				Scoped:     command.NewScoped(),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
 			}
 			cmd.Scope = servicecatalog.AllScope
 			cmd.Namespace = classTwoNamespace
 			err := cmd.Run()
 
 			Expect(err).NotTo(HaveOccurred())
-			scopeArg := fakeSDK.RetrieveClassesArgsForCall(0)
+			scopeArg := fakeSDK.RetrieveClassesPageArgsForCall(0)
 			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
-				Namespace: classTwoNamespace,
-				Scope:     servicecatalog.AllScope,
+				Namespace:      classTwoNamespace,
+				Scope:          servicecatalog.AllScope,
+				ExcludeRemoved: true,
 			}))
 
 			output := outputBuffer.String()
 			Expect(output).To(ContainSubstring("mysqldb"))
 			Expect(output).To(ContainSubstring("postgresdb"))
 		})
+		It("Passes the broker to the pkg/svcat libs RetrieveClassByName", func() {
+			classToReturn := &v1beta1.ClusterServiceClass{
+				Spec: v1beta1.ClusterServiceClassSpec{
+					CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+						ExternalName: "mysqldb",
+					},
+					ClusterServiceBrokerName: "sql-broker",
+				},
+			}
+
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, "default")
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveClassByNameReturns(classToReturn, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cmd := getCmd{
+				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Scoped:     command.NewScoped(),
+				Formatted:  command.NewFormatted(),
+				name:       "mysqldb",
+				broker:     "sql-broker",
+			}
+			cmd.Scope = servicecatalog.AllScope
+			cmd.Namespace = "default"
+			err := cmd.Run()
+
+			Expect(err).NotTo(HaveOccurred())
+			name, scopeArg := fakeSDK.RetrieveClassByNameArgsForCall(0)
+			Expect(name).To(Equal("mysqldb"))
+			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
+				Namespace: "default",
+				Scope:     servicecatalog.AllScope,
+				Broker:    "sql-broker",
+			}))
+			Expect(outputBuffer.String()).To(ContainSubstring("mysqldb"))
+		})
+		It("Prints the brokers offering an ambiguous class name and fails", func() {
+			classesToReturn := []servicecatalog.Class{
+				&v1beta1.ClusterServiceClass{
+					Spec: v1beta1.ClusterServiceClassSpec{
+						CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+							ExternalName: "mysqldb",
+						},
+						ClusterServiceBrokerName: "sql-broker",
+					},
+				},
+				&v1beta1.ClusterServiceClass{
+					Spec: v1beta1.ClusterServiceClassSpec{
+						CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+							ExternalName: "mysqldb",
+						},
+						ClusterServiceBrokerName: "other-broker",
+					},
+				},
+			}
+
+			outputBuffer := &bytes.Buffer{}
+
+			fakeApp, _ := svcat.NewApp(nil, nil, "default")
+			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
+			fakeSDK.RetrieveClassByNameReturns(nil, &servicecatalog.Error{Reason: servicecatalog.ReasonAmbiguous})
+			fakeSDK.RetrieveClassesReturns(classesToReturn, nil)
+			fakeApp.SvcatClient = fakeSDK
+			cmd := getCmd{
+				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
+				Scoped:     command.NewScoped(),
+				Formatted:  command.NewFormatted(),
+				name:       "mysqldb",
+			}
+			cmd.Scope = servicecatalog.ClusterScope
+			err := cmd.Run()
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("class 'mysqldb' is offered by more than one broker, use --broker to select one"))
+			scopeArg := fakeSDK.RetrieveClassesArgsForCall(0)
+			Expect(scopeArg.FieldSelector).To(Equal("spec.externalName=mysqldb"))
+
+			output := outputBuffer.String()
+			Expect(output).To(ContainSubstring("BROKER"))
+			Expect(output).To(ContainSubstring("sql-broker"))
+			Expect(output).To(ContainSubstring("other-broker"))
+		})
 	})
 })
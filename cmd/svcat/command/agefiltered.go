@@ -0,0 +1,99 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+)
+
+// HasAgeFlags represents a command that supports --older-than and
+// --newer-than.
+type HasAgeFlags interface {
+	// ApplyAgeFlags validates and persists the age related flags.
+	//   --older-than
+	//   --newer-than
+	ApplyAgeFlags(*cobra.Command) error
+}
+
+// AgeFiltered adds support to a command for the --older-than and
+// --newer-than flags, which filter resources by their creation time.
+type AgeFiltered struct {
+	OlderThan time.Duration
+	NewerThan time.Duration
+}
+
+// NewAgeFiltered initializes a new command that supports filtering by age.
+func NewAgeFiltered() *AgeFiltered {
+	return &AgeFiltered{}
+}
+
+// AddAgeFlags adds the age related flags.
+//
+//	--older-than
+//	--newer-than
+func (c *AgeFiltered) AddAgeFlags(cmd *cobra.Command) {
+	cmd.Flags().String(
+		"older-than",
+		"",
+		"If present, only list the resources created longer ago than this, in days such as 30d or as a duration such as 12h",
+	)
+	cmd.Flags().String(
+		"newer-than",
+		"",
+		"If present, only list the resources created more recently than this, in days such as 7d or as a duration such as 12h",
+	)
+}
+
+// ApplyAgeFlags validates and persists the age related flags.
+//
+//	--older-than
+//	--newer-than
+func (c *AgeFiltered) ApplyAgeFlags(cmd *cobra.Command) error {
+	olderThan, err := cmd.Flags().GetString("older-than")
+	if err != nil {
+		return err
+	}
+	if olderThan != "" {
+		if c.OlderThan, err = servicecatalog.ParseAge(olderThan); err != nil {
+			return fmt.Errorf("invalid --older-than (%s)", err)
+		}
+	}
+
+	newerThan, err := cmd.Flags().GetString("newer-than")
+	if err != nil {
+		return err
+	}
+	if newerThan != "" {
+		if c.NewerThan, err = servicecatalog.ParseAge(newerThan); err != nil {
+			return fmt.Errorf("invalid --newer-than (%s)", err)
+		}
+	}
+
+	if c.OlderThan > 0 && c.NewerThan > 0 && c.NewerThan <= c.OlderThan {
+		return fmt.Errorf("--newer-than must be longer than --older-than, otherwise no resources can match")
+	}
+	return nil
+}
+
+// FiltersByAge returns whether the age related flags were set.
+func (c *AgeFiltered) FiltersByAge() bool {
+	return c.OlderThan > 0 || c.NewerThan > 0
+}
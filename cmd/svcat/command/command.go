@@ -64,6 +64,24 @@ func PreRunE(cmd Command) func(*cobra.Command, []string) error {
 				return err
 			}
 		}
+		if selectedCmd, ok := cmd.(HasSelectorFlag); ok {
+			err := selectedCmd.ApplySelectorFlag(c)
+			if err != nil {
+				return err
+			}
+		}
+		if pagedCmd, ok := cmd.(HasPagingFlags); ok {
+			err := pagedCmd.ApplyPagingFlags(c)
+			if err != nil {
+				return err
+			}
+		}
+		if ageFilteredCmd, ok := cmd.(HasAgeFlags); ok {
+			err := ageFilteredCmd.ApplyAgeFlags(c)
+			if err != nil {
+				return err
+			}
+		}
 		if waitCmd, ok := cmd.(HasWaitFlags); ok {
 			err := waitCmd.ApplyWaitFlags()
 			if err != nil {
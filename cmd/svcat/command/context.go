@@ -31,6 +31,14 @@ type Context struct {
 	// svcat application, the library behind the cli
 	App *svcat.App
 
+	// KubeConfig is the path to the kubeconfig file selected with --kubeconfig,
+	// or empty when the default kubeconfig loading rules are used.
+	KubeConfig string
+
+	// KubeContext is the name of the kubeconfig context the App is connected to,
+	// selected with --context or defaulting to the current context.
+	KubeContext string
+
 	// Viper configuration
 	Viper *viper.Viper
 }
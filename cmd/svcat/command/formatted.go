@@ -28,12 +28,27 @@ import (
 type HasFormatFlags interface {
 	// ApplyFormatFlags persists the format-related flags:
 	// * --output
+	// * --no-headers
+	// * --label-columns
 	ApplyFormatFlags(lags *pflag.FlagSet) error
 }
 
 // Formatted is the base command of all svcat commands that support customizable output formats.
 type Formatted struct {
 	OutputFormat string
+
+	// NoHeaders omits the header row of tables.
+	NoHeaders bool
+
+	// LabelColumns are the label keys shown as extra columns of tables.
+	LabelColumns []string
+
+	// wide indicates if the command supports the wide output format.
+	wide bool
+
+	// statusOnly indicates if the command supports the status-only output
+	// format.
+	statusOnly bool
 }
 
 // NewFormatted command.
@@ -43,22 +58,78 @@ func NewFormatted() *Formatted {
 	}
 }
 
+// NewWideFormatted command that also supports the wide output format.
+func NewWideFormatted() *Formatted {
+	return &Formatted{
+		OutputFormat: output.FormatTable,
+		wide:         true,
+	}
+}
+
+// WithStatusOnly makes the command also support the status-only output
+// format.
+func (c *Formatted) WithStatusOnly() *Formatted {
+	c.statusOnly = true
+	return c
+}
+
+// formats returns the output formats supported by the command, besides
+// custom-columns.
+func (c *Formatted) formats() []string {
+	formats := []string{output.FormatTable}
+	if c.wide {
+		formats = append(formats, output.FormatWide)
+	}
+	formats = append(formats, output.FormatJSON, output.FormatYAML, output.FormatName)
+	if c.statusOnly {
+		formats = append(formats, output.FormatStatusOnly)
+	}
+	return formats
+}
+
 // AddOutputFlags adds common output flags to a command that can have variable output formats.
 func (c *Formatted) AddOutputFlags(flags *pflag.FlagSet) {
-	flags.StringVarP(&c.OutputFormat, "output", "o", output.FormatTable,
-		"The output format to use. Valid options are table, json or yaml. If not present, defaults to table",
-	)
+	usage := fmt.Sprintf("The output format to use. Valid options are %s or custom-columns=<header>:<json-path-expr>,... If not present, defaults to table", strings.Join(c.formats(), ", "))
+	flags.StringVarP(&c.OutputFormat, "output", "o", output.FormatTable, usage)
+	flags.BoolVar(&c.NoHeaders, "no-headers", false,
+		"When using the table or custom-columns output format, don't print headers")
+	flags.StringSliceVarP(&c.LabelColumns, "label-columns", "L", nil,
+		"When using the table or custom-columns output format, show the values of these comma-separated label keys as extra columns")
+}
+
+// TableOptions returns the options of the tables printed by the command, set
+// by --no-headers and --label-columns.
+func (c *Formatted) TableOptions() output.TableOptions {
+	return output.TableOptions{
+		NoHeaders:    c.NoHeaders,
+		LabelColumns: c.LabelColumns,
+	}
 }
 
 // ApplyFormatFlags persists the format-related flags:
 // * --output
+// * --no-headers
+// * --label-columns
 func (c *Formatted) ApplyFormatFlags(flags *pflag.FlagSet) error {
+	if format := strings.SplitN(c.OutputFormat, "=", 2); len(format) == 2 &&
+		strings.ToLower(format[0]) == output.FormatCustomColumns {
+		// Only the format name is case insensitive, the headers and
+		// JSONPath expressions of the columns are kept as is.
+		c.OutputFormat = output.FormatCustomColumns + "=" + format[1]
+		if _, err := output.ParseCustomColumns(c.OutputFormat); err != nil {
+			return fmt.Errorf("invalid --output format: %v", err)
+		}
+		return nil
+	}
+
 	c.OutputFormat = strings.ToLower(c.OutputFormat)
 
-	switch c.OutputFormat {
-	case output.FormatTable, output.FormatJSON, output.FormatYAML:
-		return nil
-	default:
-		return fmt.Errorf("invalid --output format %q, allowed values are: table, json and yaml", c.OutputFormat)
+	formats := c.formats()
+	for _, format := range formats {
+		if c.OutputFormat == format {
+			return nil
+		}
 	}
+
+	return fmt.Errorf("invalid --output format %q, allowed values are: %s and custom-columns", c.OutputFormat, strings.Join(formats, ", "))
 }
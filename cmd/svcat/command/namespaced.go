@@ -16,7 +16,10 @@ limitations under the License.
 
 package command
 
-import "github.com/spf13/pflag"
+import (
+	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/pflag"
+)
 
 // HasNamespaceFlags represents a command that can be scoped to a namespace.
 type HasNamespaceFlags interface {
@@ -51,8 +54,9 @@ func (c *Namespaced) AddNamespaceFlags(flags *pflag.FlagSet, allowAll bool) {
 	)
 
 	if allowAll {
-		flags.Bool(
+		flags.BoolP(
 			"all-namespaces",
+			"A",
 			false,
 			"If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace",
 		)
@@ -74,7 +78,7 @@ func (c *Namespaced) determineNamespace(flags *pflag.FlagSet) string {
 	allNamespaces, _ := flags.GetBool("all-namespaces")
 
 	if allNamespaces {
-		return ""
+		return servicecatalog.AllNamespaces
 	}
 
 	if namespace != "" {
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/poy/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+// HasPagingFlags represents a command that supports --limit.
+type HasPagingFlags interface {
+	// ApplyPagingFlags validates and persists the paging related flags.
+	//   --limit
+	//   --continue
+	//   --chunk-size
+	ApplyPagingFlags(*cobra.Command) error
+}
+
+// Paged adds support to a command for the --limit, --continue and
+// --chunk-size flags.
+type Paged struct {
+	Limit     int64
+	Continue  string
+	ChunkSize int64
+}
+
+// NewPaged initializes a new command that supports listing results in pages.
+func NewPaged() *Paged {
+	return &Paged{}
+}
+
+// AddPagingFlags adds the paging related flags.
+//
+//	--limit
+//	--continue
+//	--chunk-size
+func (c *Paged) AddPagingFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64(
+		"limit",
+		0,
+		"Maximum number of results to list at once. When more results are available, a token to pass to --continue is printed after them. The default is to list all results.",
+	)
+	cmd.Flags().String(
+		"continue",
+		"",
+		"Token printed with a previous page of results, to list the next page",
+	)
+	cmd.Flags().Int64(
+		"chunk-size",
+		0,
+		"Maximum number of results to request from the server at once. All results, or --limit of them, are still listed, in as many requests as needed. The default is to request them all at once.",
+	)
+}
+
+// ApplyPagingFlags validates and persists the paging related flags.
+//
+//	--limit
+//	--continue
+//	--chunk-size
+func (c *Paged) ApplyPagingFlags(cmd *cobra.Command) error {
+	limit, err := cmd.Flags().GetInt64("limit")
+	if err != nil {
+		return err
+	}
+	if limit < 0 {
+		return fmt.Errorf("invalid --limit %d, must not be negative", limit)
+	}
+	c.Limit = limit
+
+	c.Continue, err = cmd.Flags().GetString("continue")
+	if err != nil {
+		return err
+	}
+
+	chunkSize, err := cmd.Flags().GetInt64("chunk-size")
+	if err != nil {
+		return err
+	}
+	if chunkSize < 0 {
+		return fmt.Errorf("invalid --chunk-size %d, must not be negative", chunkSize)
+	}
+	c.ChunkSize = chunkSize
+	return nil
+}
+
+// WriteContinueHint tells how to list the next page of results when the
+// results were truncated. The hint is only written after tables so that it
+// does not break the other output formats.
+func (c *Paged) WriteContinueHint(w io.Writer, outputFormat, next string) {
+	if next == "" || (outputFormat != output.FormatTable && outputFormat != output.FormatWide) {
+		return
+	}
+	fmt.Fprintf(w, "\nMore results are available, to list them run the same command with --continue %s\n", next)
+}
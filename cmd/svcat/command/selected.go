@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// HasSelectorFlag represents a command that supports --selector.
+type HasSelectorFlag interface {
+	// ApplySelectorFlag validates and persists the selector related flags.
+	//   --selector
+	//   --field-selector
+	ApplySelectorFlag(*cobra.Command) error
+}
+
+// Selected adds support to a command for the --selector and --field-selector
+// flags.
+type Selected struct {
+	LabelSelector string
+	FieldSelector string
+}
+
+// NewSelected initializes a new command that supports filtering by labels
+// and fields.
+func NewSelected() *Selected {
+	return &Selected{}
+}
+
+// AddSelectorFlag adds the selector related flags.
+//   --selector
+//   --field-selector
+func (c *Selected) AddSelectorFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(
+		"selector",
+		"l",
+		"",
+		"Selector (label query) to filter on, supports '=', '==', and '!=' (e.g. -l key1=value1,key2=value2)",
+	)
+	cmd.Flags().String(
+		"field-selector",
+		"",
+		"Selector (field query) to filter on, supports '=', '==', and '!=' (e.g. --field-selector spec.externalID=abc123). The server only supports a limited number of field queries per type.",
+	)
+}
+
+// ApplySelectorFlag validates and persists the selector related flags.
+//   --selector
+//   --field-selector
+func (c *Selected) ApplySelectorFlag(cmd *cobra.Command) error {
+	selector, err := cmd.Flags().GetString("selector")
+	if err != nil {
+		return err
+	}
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("invalid --selector (%s)", err)
+	}
+	c.LabelSelector = selector
+
+	fieldSelector, err := cmd.Flags().GetString("field-selector")
+	if err != nil {
+		return err
+	}
+	if _, err := fields.ParseSelector(fieldSelector); err != nil {
+		return fmt.Errorf("invalid --field-selector (%s)", err)
+	}
+	c.FieldSelector = fieldSelector
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// Watched adds support to a command for the --watch flag.
+type Watched struct {
+	Watch bool
+
+	// Stop ends watching when it is closed. When nil, the command watches
+	// until it is interrupted.
+	Stop <-chan struct{}
+}
+
+// NewWatched initializes a new command that can watch for changes.
+func NewWatched() *Watched {
+	return &Watched{}
+}
+
+// AddWatchFlag adds the --watch flag.
+func (c *Watched) AddWatchFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&c.Watch, "watch", "w", false,
+		"After listing the results, watch for changes and print the results again as they change")
+}
+
+// RunWatch prints the objects received from the watches started by start
+// with print, until Stop is closed. The first watch starts from the given
+// resource version, usually the one of the list that was just printed.
+// Watches closed by the server, for example because they timed out, are
+// started again from the last resource version received. When that version
+// has expired, the watch starts over from the current state, and every
+// object is printed again.
+func (c *Watched) RunWatch(resourceVersion string, start func(resourceVersion string) (watch.Interface, error), print func(runtime.Object)) error {
+	for {
+		w, err := start(resourceVersion)
+		if err != nil {
+			if resourceVersion != "" && isExpired(errors.Cause(err)) {
+				resourceVersion = ""
+				continue
+			}
+			return err
+		}
+
+		var stopped bool
+		resourceVersion, stopped, err = c.receive(w, resourceVersion, print)
+		if err != nil || stopped {
+			return err
+		}
+	}
+}
+
+// receive prints the objects received from a watch until it is closed, and
+// returns the last resource version received. The returned version is empty
+// when the watch ended because the version it started from has expired.
+func (c *Watched) receive(w watch.Interface, resourceVersion string, print func(runtime.Object)) (string, bool, error) {
+	defer w.Stop()
+	for {
+		select {
+		case <-c.Stop:
+			return resourceVersion, true, nil
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return resourceVersion, false, nil
+			}
+			if event.Type == watch.Error {
+				err := apierrors.FromObject(event.Object)
+				if isExpired(err) {
+					return "", false, nil
+				}
+				return resourceVersion, false, err
+			}
+			if accessor, err := meta.Accessor(event.Object); err == nil {
+				resourceVersion = accessor.GetResourceVersion()
+			}
+			print(event.Object)
+		}
+	}
+}
+
+// isExpired returns whether a watch failed because the resource version it
+// was started from is too old.
+func isExpired(err error) bool {
+	return apierrors.IsResourceExpired(err) || apierrors.IsGone(err)
+}
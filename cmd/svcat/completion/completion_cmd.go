@@ -26,7 +26,7 @@ import (
 
 var (
 	completionLong = `
-Output shell completion code for the specified shell (bash or zsh).
+Output shell completion code for the specified shell (bash, zsh or fish).
 The shell code must be evaluated to provide interactive
 completion of svcat commands. This can be done by sourcing it from
 the .bash_profile.
@@ -42,6 +42,9 @@ following line to the .bash_profile
 	$ source $(brew --prefix)/etc/bash_completion
 
 Note for zsh users: zsh completions are only supported in versions of zsh >= 5.2
+
+Note for fish users: the fish completion code can be saved to a file in
+~/.config/fish/completions to be loaded automatically.
 `
 
 	completionExample = command.NormalizeExamples(`
@@ -53,6 +56,9 @@ source $HOME/.bash_profile
 # Load the svcat completion code for the specified shell (bash or zsh)
 source <(svcat completion bash)
 
+# Install the svcat completion code for fish
+svcat completion fish > ~/.config/fish/completions/svcat.fish
+
 # Write bash completion code to a file and source if from .bash_profile
 svcat completion bash > ~/.svcat/svcat_completion.bash.inc
 printf "\n# Svcat shell completion\nsource '$HOME/.svcat/svcat_completion.bash.inc'\n" >> $HOME/.bash_profile
@@ -64,6 +70,7 @@ var (
 	completionShells = map[string]func(w io.Writer, cmd *cobra.Command) error{
 		"bash": runCompletionBash,
 		"zsh":  runCompletionZsh,
+		"fish": runCompletionFish,
 	}
 )
 
@@ -84,7 +91,7 @@ func NewCompletionCmd(cxt *command.Context) *cobra.Command {
 
 	cmd := &cobra.Command{
 		Use:       "completion SHELL",
-		Short:     "Output shell completion code for the specified shell (bash or zsh).",
+		Short:     "Output shell completion code for the specified shell (bash, zsh or fish).",
 		Long:      completionLong,
 		Example:   completionExample,
 		PreRunE:   command.PreRunE(completionCmd),
@@ -0,0 +1,204 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package completion
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// fishHeader defines the helper used to decide which command is being
+// completed. __svcat_using_command succeeds when the words typed so far,
+// ignoring flags and the values of flags that take one, match its
+// arguments. Each argument lists the accepted names of a command separated
+// by "|", so that aliases are matched too.
+const fishHeader = `# fish completion for svcat
+
+function __svcat_using_command
+	set -l cmd (commandline -opc)
+	set -e cmd[1]
+	set -l words
+	set -l skip 0
+	for w in $cmd
+		if test $skip -eq 1
+			set skip 0
+			continue
+		end
+		switch $w
+			case '--*=*'
+			case $__svcat_value_flags
+				set skip 1
+			case '-*'
+			case '*'
+				set words $words $w
+		end
+	end
+	test (count $words) -eq (count $argv); or return 1
+	for i in (seq (count $argv))
+		contains -- $words[$i] (string split '|' -- $argv[$i]); or return 1
+	end
+end
+
+complete -c svcat -e
+`
+
+// runCompletionFish writes a fish completion script for the root command.
+// The vendored cobra has no fish generator, so the script is built from the
+// command tree here.
+func runCompletionFish(w io.Writer, cmd *cobra.Command) error {
+	root := cmd.Root()
+
+	if _, err := io.WriteString(w, fishHeader); err != nil {
+		return err
+	}
+
+	var valueFlags []string
+	collectFishValueFlags(root, &valueFlags)
+	if _, err := fmt.Fprintf(w, "set -g __svcat_value_flags %s\n\n", strings.Join(valueFlags, " ")); err != nil {
+		return err
+	}
+
+	// Persistent flags of the root command apply to every command.
+	var err error
+	root.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if err == nil {
+			err = writeFishFlag(w, "", f)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeFishCommand(w, root, nil)
+}
+
+// writeFishCommand writes the completions of the subcommands and flags of
+// cmd, then recurses into its subcommands. path holds the accepted names of
+// each command leading to cmd, excluding the root.
+func writeFishCommand(w io.Writer, cmd *cobra.Command, path []string) error {
+	condition := strings.TrimSpace("__svcat_using_command " + strings.Join(path, " "))
+
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "complete -c svcat -f -n \"%s\" -a %s -d %s\n",
+			condition, fishQuote(sub.Name()), fishQuote(sub.Short)); err != nil {
+			return err
+		}
+	}
+
+	if len(cmd.ValidArgs) > 0 {
+		args := append([]string{}, cmd.ValidArgs...)
+		sort.Strings(args)
+		if _, err := fmt.Fprintf(w, "complete -c svcat -f -n \"%s\" -a %s\n",
+			condition, fishQuote(strings.Join(args, " "))); err != nil {
+			return err
+		}
+	}
+
+	if cmd.HasParent() {
+		rootFlags := cmd.Root().PersistentFlags()
+		var err error
+		visit := func(f *pflag.Flag) {
+			if err == nil && rootFlags.Lookup(f.Name) == nil {
+				err = writeFishFlag(w, condition, f)
+			}
+		}
+		cmd.LocalFlags().VisitAll(visit)
+		cmd.InheritedFlags().VisitAll(visit)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, sub := range cmd.Commands() {
+		if !sub.IsAvailableCommand() {
+			continue
+		}
+		names := append([]string{sub.Name()}, sub.Aliases...)
+		subPath := append(append([]string{}, path...), fishQuote(strings.Join(names, "|")))
+		if err := writeFishCommand(w, sub, subPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFishFlag writes the completion of a single flag, offered when
+// condition holds, or always when condition is empty.
+func writeFishFlag(w io.Writer, condition string, f *pflag.Flag) error {
+	if f.Hidden || f.Deprecated != "" {
+		return nil
+	}
+	line := "complete -c svcat"
+	if condition != "" {
+		line += fmt.Sprintf(" -n \"%s\"", condition)
+	}
+	line += " -l " + f.Name
+	if f.Shorthand != "" && f.ShorthandDeprecated == "" {
+		line += " -s " + f.Shorthand
+	}
+	if fishFlagTakesValue(f) {
+		line += " -r"
+	}
+	line += " -d " + fishQuote(f.Usage)
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// collectFishValueFlags appends the names of every flag in the command tree
+// that takes a value, so that the value is not mistaken for a command name.
+func collectFishValueFlags(cmd *cobra.Command, names *[]string) {
+	seen := make(map[string]bool)
+	for _, n := range *names {
+		seen[n] = true
+	}
+	add := func(f *pflag.Flag) {
+		if !fishFlagTakesValue(f) {
+			return
+		}
+		for _, n := range []string{"--" + f.Name, "-" + f.Shorthand} {
+			if n != "-" && !seen[n] {
+				seen[n] = true
+				*names = append(*names, n)
+			}
+		}
+	}
+	cmd.LocalFlags().VisitAll(add)
+	for _, sub := range cmd.Commands() {
+		collectFishValueFlags(sub, names)
+	}
+}
+
+// fishFlagTakesValue returns whether the flag requires a value, as opposed
+// to a boolean flag or one with a default used when no value is given.
+func fishFlagTakesValue(f *pflag.Flag) bool {
+	return f.NoOptDefVal == ""
+}
+
+// fishQuote quotes s as a single-quoted fish string.
+func fishQuote(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `'`, `\'`, -1)
+	return "'" + s + "'"
+}
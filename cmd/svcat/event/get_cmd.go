@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/poy/service-catalog/cmd/svcat/command"
+	"github.com/poy/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	typeInstance = "instance"
+	typeBinding  = "binding"
+	typeBroker   = "broker"
+)
+
+type getCmd struct {
+	*command.Namespaced
+	*command.Formatted
+	object     string
+	objectType string
+	objectName string
+}
+
+// NewGetCmd builds a "svcat get events" command
+func NewGetCmd(cxt *command.Context) *cobra.Command {
+	getCmd := &getCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+	}
+	cmd := &cobra.Command{
+		Use:     "events --for TYPE/NAME",
+		Aliases: []string{"event", "ev"},
+		Short:   "List the events of an instance, binding or broker",
+		Example: command.NormalizeExamples(`
+  svcat get events --for instance/wordpress-mysql-instance
+  svcat get events --for binding/wordpress-mysql-binding -n ci
+  svcat get events --for broker/ups-broker
+`),
+		PreRunE: command.PreRunE(getCmd),
+		RunE:    command.RunE(getCmd),
+	}
+	cmd.Flags().StringVar(
+		&getCmd.object,
+		"for",
+		"",
+		"The object to list the events of, as TYPE/NAME, where TYPE is instance, binding or broker",
+	)
+	getCmd.AddNamespaceFlags(cmd.Flags(), false)
+	getCmd.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *getCmd) Validate(args []string) error {
+	if c.object == "" {
+		return fmt.Errorf("--for is required")
+	}
+
+	parts := strings.SplitN(c.object, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return fmt.Errorf("invalid --for %q, must be TYPE/NAME", c.object)
+	}
+	c.objectName = parts[1]
+
+	switch parts[0] {
+	case typeInstance, "instances":
+		c.objectType = typeInstance
+	case typeBinding, "bindings":
+		c.objectType = typeBinding
+	case typeBroker, "brokers":
+		c.objectType = typeBroker
+	default:
+		return fmt.Errorf("invalid --for type %q, allowed values are: instance, binding and broker", parts[0])
+	}
+
+	return nil
+}
+
+func (c *getCmd) Run() error {
+	// Look up the object first, so that a typo in its name is reported
+	// instead of listing no events
+	var kind, namespace string
+	switch c.objectType {
+	case typeInstance:
+		instance, err := c.App.RetrieveInstance(c.Namespace, c.objectName)
+		if err != nil {
+			return err
+		}
+		kind, namespace = "ServiceInstance", instance.Namespace
+	case typeBinding:
+		binding, err := c.App.RetrieveBinding(c.Namespace, c.objectName)
+		if err != nil {
+			return err
+		}
+		kind, namespace = "ServiceBinding", binding.Namespace
+	case typeBroker:
+		if _, err := c.App.RetrieveBroker(c.objectName); err != nil {
+			return err
+		}
+		kind = "ClusterServiceBroker"
+	}
+
+	events, err := c.App.RetrieveEvents(kind, namespace, c.objectName)
+	if err != nil {
+		return err
+	}
+
+	output.WriteEventList(c.Output, c.OutputFormat, c.TableOptions(), events)
+	return nil
+}
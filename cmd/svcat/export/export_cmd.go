@@ -0,0 +1,191 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/poy/service-catalog/cmd/svcat/command"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	brokers   = "brokers"
+	instances = "instances"
+	bindings  = "bindings"
+	all       = "all"
+)
+
+// serverManagedFields are the fields set by the API server or the controller
+// that are removed from exported objects, so that the manifests can be
+// applied to create the objects again.
+var serverManagedFields = [][]string{
+	{"status"},
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "selfLink"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "deletionGracePeriodSeconds"},
+	{"metadata", "managedFields"},
+	{"spec", "clusterServiceClassRef"},
+	{"spec", "clusterServicePlanRef"},
+	{"spec", "serviceClassRef"},
+	{"spec", "servicePlanRef"},
+	{"spec", "userInfo"},
+}
+
+type exportCmd struct {
+	*command.Namespaced
+	resource string
+}
+
+// NewExportCmd builds a "svcat export" command
+func NewExportCmd(cxt *command.Context) *cobra.Command {
+	exportCmd := &exportCmd{Namespaced: command.NewNamespaced(cxt)}
+	cmd := &cobra.Command{
+		Use:   "export (brokers|instances|bindings|all)",
+		Short: "Export brokers, instances or bindings as YAML manifests",
+		Long: `Export prints the requested objects as YAML manifests that can be applied
+to create them again, for example to back them up or to move them to another
+cluster. Their status and the fields set by the server are left out.
+
+Cluster-scoped brokers are always exported along with the namespaced brokers.
+The secrets that brokers authenticate with and that bindings write their
+credentials to are not exported.`,
+		Example: command.NormalizeExamples(`
+  svcat export instances
+  svcat export bindings --namespace dev
+  svcat export all --all-namespaces > catalog.yaml
+`),
+		PreRunE: command.PreRunE(exportCmd),
+		RunE:    command.RunE(exportCmd),
+	}
+	exportCmd.AddNamespaceFlags(cmd.Flags(), true)
+	return cmd
+}
+
+func (c *exportCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a resource type is required, one of: %s, %s, %s, %s", brokers, instances, bindings, all)
+	}
+	switch args[0] {
+	case brokers, instances, bindings, all:
+		c.resource = args[0]
+	default:
+		return fmt.Errorf("invalid resource type %q, allowed values are: %s, %s, %s, %s", args[0], brokers, instances, bindings, all)
+	}
+	return nil
+}
+
+func (c *exportCmd) Run() error {
+	var objs []runtime.Object
+
+	if c.resource == brokers || c.resource == all {
+		list, err := c.App.RetrieveBrokers(servicecatalog.ScopeOptions{
+			Namespace: c.Namespace,
+			Scope:     servicecatalog.AllScope,
+		})
+		if err != nil {
+			return err
+		}
+		for _, broker := range list {
+			switch b := broker.(type) {
+			case *v1beta1.ClusterServiceBroker:
+				b.Kind = "ClusterServiceBroker"
+				objs = append(objs, b)
+			case *v1beta1.ServiceBroker:
+				b.Kind = "ServiceBroker"
+				objs = append(objs, b)
+			}
+		}
+	}
+
+	if c.resource == instances || c.resource == all {
+		list, err := c.App.RetrieveInstances(c.Namespace, "", "")
+		if err != nil {
+			return err
+		}
+		for i := range list.Items {
+			list.Items[i].Kind = "ServiceInstance"
+			objs = append(objs, &list.Items[i])
+		}
+	}
+
+	if c.resource == bindings || c.resource == all {
+		list, err := c.App.RetrieveBindings(c.Namespace)
+		if err != nil {
+			return err
+		}
+		for i := range list.Items {
+			list.Items[i].Kind = "ServiceBinding"
+			objs = append(objs, &list.Items[i])
+		}
+	}
+
+	return writeManifests(c.Output, objs)
+}
+
+// writeManifests writes the given objects, without their server-managed
+// fields, as a stream of YAML documents.
+func writeManifests(w io.Writer, objs []runtime.Object) error {
+	for i, obj := range objs {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("unable to convert object to a manifest (%s)", err)
+		}
+		u["apiVersion"] = v1beta1.SchemeGroupVersion.String()
+		for _, field := range serverManagedFields {
+			unstructured.RemoveNestedField(u, field...)
+		}
+		removeServiceCatalogFinalizer(u)
+
+		y, err := yaml.Marshal(u)
+		if err != nil {
+			return fmt.Errorf("unable to marshal manifest (%s)", err)
+		}
+		if i > 0 {
+			fmt.Fprintln(w, "---")
+		}
+		fmt.Fprint(w, string(y))
+	}
+	return nil
+}
+
+// removeServiceCatalogFinalizer removes the finalizer that the API server adds
+// to every service catalog object, keeping any other finalizers.
+func removeServiceCatalogFinalizer(u map[string]interface{}) {
+	finalizers, _, _ := unstructured.NestedStringSlice(u, "metadata", "finalizers")
+	var kept []string
+	for _, f := range finalizers {
+		if f != v1beta1.FinalizerServiceCatalog {
+			kept = append(kept, f)
+		}
+	}
+	if len(kept) == 0 {
+		unstructured.RemoveNestedField(u, "metadata", "finalizers")
+		return
+	}
+	unstructured.SetNestedStringSlice(u, kept, "metadata", "finalizers")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestWriteManifests(t *testing.T) {
+	now := metav1.Now()
+	instance := &v1beta1.ServiceInstance{
+		TypeMeta: metav1.TypeMeta{Kind: "ServiceInstance"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "myinstance",
+			Namespace:         "myns",
+			UID:               "uid",
+			ResourceVersion:   "42",
+			Generation:        3,
+			CreationTimestamp: now,
+			Labels:            map[string]string{"app": "wordpress"},
+			Finalizers:        []string{v1beta1.FinalizerServiceCatalog, "example.com/cleanup"},
+		},
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference: v1beta1.PlanReference{
+				ClusterServiceClassExternalName: "mysql",
+				ClusterServicePlanExternalName:  "small",
+			},
+			ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "class-guid"},
+			ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: "plan-guid"},
+			ExternalID:             "instance-guid",
+			UserInfo:               &v1beta1.UserInfo{Username: "alice"},
+		},
+		Status: v1beta1.ServiceInstanceStatus{
+			ProvisionStatus: v1beta1.ServiceInstanceProvisionStatusProvisioned,
+		},
+	}
+	binding := &v1beta1.ServiceBinding{
+		TypeMeta: metav1.TypeMeta{Kind: "ServiceBinding"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "mybinding",
+			Namespace:  "myns",
+			Finalizers: []string{v1beta1.FinalizerServiceCatalog},
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			InstanceRef: v1beta1.LocalObjectReference{Name: "myinstance"},
+			SecretName:  "mysecret",
+		},
+	}
+
+	output := &bytes.Buffer{}
+	if err := writeManifests(output, []runtime.Object{instance, binding}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `apiVersion: servicecatalog.k8s.io/v1beta1
+kind: ServiceInstance
+metadata:
+  finalizers:
+  - example.com/cleanup
+  labels:
+    app: wordpress
+  name: myinstance
+  namespace: myns
+spec:
+  clusterServiceClassExternalName: mysql
+  clusterServicePlanExternalName: small
+  externalID: instance-guid
+  updateRequests: 0
+---
+apiVersion: servicecatalog.k8s.io/v1beta1
+kind: ServiceBinding
+metadata:
+  name: mybinding
+  namespace: myns
+spec:
+  externalID: ""
+  instanceRef:
+    name: myinstance
+  secretName: mysecret
+`
+	if got := output.String(); got != want {
+		t.Fatalf("unexpected output:\n\nExpected:\n%s\n\nActual:\n%s", want, got)
+	}
+}
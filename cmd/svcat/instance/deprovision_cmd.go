@@ -24,6 +24,7 @@ import (
 
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 type deprovisonCmd struct {
@@ -44,6 +45,7 @@ func NewDeprovisionCmd(cxt *command.Context) *cobra.Command {
 		Short: "Deletes an instance of a service",
 		Example: command.NormalizeExamples(`
   svcat deprovision wordpress-mysql-instance
+  svcat deprovision wordpress-mysql-instance --wait --timeout 10m
 `),
 		PreRunE: command.PreRunE(deprovisonCmd),
 		RunE:    command.RunE(deprovisonCmd),
@@ -76,11 +78,33 @@ func (c *deprovisonCmd) deprovision() error {
 	if c.Wait {
 		fmt.Fprintln(c.Output, "Waiting for the instance to be deleted...")
 
+		// Report progress only when the status of the instance changes
+		var lastStatus *v1beta1.ServiceInstanceCondition
+		reportProgress := func(instance *v1beta1.ServiceInstance) {
+			var status v1beta1.ServiceInstanceCondition
+			if n := len(instance.Status.Conditions); n > 0 {
+				status = instance.Status.Conditions[n-1]
+			}
+			if lastStatus != nil && lastStatus.Reason == status.Reason && lastStatus.Status == status.Status {
+				return
+			}
+			lastStatus = &status
+			output.WriteInstanceDeletionProgress(c.Output, instance)
+		}
+
 		var instance *v1beta1.ServiceInstance
-		instance, err = c.App.WaitForInstanceToNotExist(c.Namespace, c.instanceName, c.Interval, c.Timeout)
+		instance, err = c.App.WaitForInstanceToNotExist(c.Namespace, c.instanceName, c.Interval, c.Timeout, reportProgress)
 
-		// The instance failed to deprovision cleanly, dump out more information on why
-		if instance != nil && c.App.IsInstanceFailed(instance) {
+		switch {
+		case err == wait.ErrWaitTimeout && instance != nil:
+			// Show what is still blocking the deletion of the instance
+			fmt.Fprintln(c.Output, "Timed out waiting for the instance to be deleted")
+			output.WriteInstanceDetails(c.Output, instance)
+		case err == nil && instance != nil:
+			// The instance failed to deprovision cleanly, dump out more information on why
+			output.WriteInstanceDetails(c.Output, instance)
+			err = fmt.Errorf("failed to deprovision instance %s", c.instanceName)
+		case instance != nil && c.App.IsInstanceFailed(instance):
 			output.WriteInstanceDetails(c.Output, instance)
 		}
 	}
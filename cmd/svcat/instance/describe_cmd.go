@@ -26,7 +26,8 @@ import (
 
 type describeCmd struct {
 	*command.Namespaced
-	name string
+	name         string
+	showBindings bool
 }
 
 // NewDescribeCmd builds a "svcat describe instance" command
@@ -38,11 +39,18 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		Short:   "Show details of a specific instance",
 		Example: command.NormalizeExamples(`
   svcat describe instance wordpress-mysql-instance
+  svcat describe instance wordpress-mysql-instance --show-bindings
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
 	}
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().BoolVar(
+		&describeCmd.showBindings,
+		"show-bindings",
+		false,
+		"List the bindings of the instance with their status",
+	)
 	return cmd
 }
 
@@ -67,6 +75,10 @@ func (c *describeCmd) describe() error {
 
 	output.WriteInstanceDetails(c.Output, instance)
 
+	if !c.showBindings {
+		return nil
+	}
+
 	bindings, err := c.App.RetrieveBindingsByInstance(instance)
 	if err != nil {
 		return err
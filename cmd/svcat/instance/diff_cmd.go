@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/poy/service-catalog/cmd/svcat/command"
+	"github.com/poy/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type diffCmd struct {
+	*command.Namespaced
+	*command.Formatted
+	name string
+}
+
+// NewDiffCmd builds a "svcat diff instance" command
+func NewDiffCmd(cxt *command.Context) *cobra.Command {
+	diffCmd := &diffCmd{
+		Namespaced: command.NewNamespaced(cxt),
+		Formatted:  command.NewFormatted(),
+	}
+	cmd := &cobra.Command{
+		Use:   "instance NAME",
+		Short: "Show how the parameters of an instance differ from the ones last sent to its broker",
+		Long: `Diff instance compares the parameters of an instance, including the current
+values of the secrets of its parametersFrom, with the parameters last sent to
+its broker, and tells whether the controller is going to send an update.
+Nothing is changed.`,
+		Example: command.NormalizeExamples(`
+  svcat diff instance wordpress-mysql-instance
+  svcat diff instance wordpress-mysql-instance -n ci -o json
+`),
+		PreRunE: command.PreRunE(diffCmd),
+		RunE:    command.RunE(diffCmd),
+	}
+	diffCmd.AddNamespaceFlags(cmd.Flags(), false)
+	diffCmd.AddOutputFlags(cmd.Flags())
+	return cmd
+}
+
+func (c *diffCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *diffCmd) Run() error {
+	instance, err := c.App.RetrieveInstance(c.Namespace, c.name)
+	if err != nil {
+		return err
+	}
+
+	diff, err := c.App.DiffInstanceParameters(instance)
+	if err != nil {
+		return err
+	}
+
+	output.WriteInstanceParametersDiff(c.Output, c.OutputFormat, instance, diff)
+	return nil
+}
@@ -21,7 +21,12 @@ import (
 
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/poy/service-catalog/cmd/svcat/output"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type getCmd struct {
@@ -29,7 +34,25 @@ type getCmd struct {
 	*command.Formatted
 	*command.PlanFiltered
 	*command.ClassFiltered
+	*command.Selected
+	*command.Paged
+	*command.Watched
+	*command.AgeFiltered
 	name string
+
+	// filterByRefResolved is set when only instances whose class and plan
+	// references are, or are not, resolved should be listed, as given by
+	// refResolved.
+	filterByRefResolved bool
+	refResolved         bool
+
+	// showClassPlan is set when the external names of the classes and plans
+	// of the instances should be resolved and shown.
+	showClassPlan bool
+
+	// showParams is set when the parameters sent to the brokers for the
+	// instances should be added to the JSON and YAML output.
+	showParams bool
 }
 
 // NewGetCmd builds a "svcat get instances" command
@@ -39,6 +62,10 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		Formatted:     command.NewFormatted(),
 		ClassFiltered: command.NewClassFiltered(),
 		PlanFiltered:  command.NewPlanFiltered(),
+		Selected:      command.NewSelected(),
+		Paged:         command.NewPaged(),
+		Watched:       command.NewWatched(),
+		AgeFiltered:   command.NewAgeFiltered(),
 	}
 	cmd := &cobra.Command{
 		Use:     "instances [NAME]",
@@ -48,17 +75,51 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
   svcat get instances
   svcat get instances --class redis
   svcat get instances --plan default
+  svcat get instances --selector app=wordpress
+  svcat get instances --field-selector spec.externalID=4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468
+  svcat get instances --plan-ref-resolved=false
+  svcat get instances --show-class-plan
+  svcat get instances -o json --show-params
+  svcat get instances --limit 50
+  svcat get instances --older-than 30d
+  svcat get instances --watch
   svcat get instances --all-namespaces
+  svcat get instances -A
   svcat get instance wordpress-mysql-instance
   svcat get instance -n ci concourse-postgres-instance
 `),
-		PreRunE: command.PreRunE(getCmd),
-		RunE:    command.RunE(getCmd),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			getCmd.filterByRefResolved = cmd.Flags().Changed("plan-ref-resolved")
+			return command.PreRunE(getCmd)(cmd, args)
+		},
+		RunE: command.RunE(getCmd),
 	}
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	getCmd.AddOutputFlags(cmd.Flags())
 	getCmd.AddClassFlag(cmd)
 	getCmd.AddPlanFlag(cmd)
+	getCmd.AddSelectorFlag(cmd)
+	getCmd.AddPagingFlags(cmd)
+	getCmd.AddWatchFlag(cmd)
+	getCmd.AddAgeFlags(cmd)
+	cmd.Flags().BoolVar(
+		&getCmd.refResolved,
+		"plan-ref-resolved",
+		true,
+		"If present, only list instances whose class and plan references have (true) or have not (false) been resolved by the controller",
+	)
+	cmd.Flags().BoolVar(
+		&getCmd.showClassPlan,
+		"show-class-plan",
+		false,
+		"If present, show the external names of the classes and plans of the instances, resolved from their references, in the table output",
+	)
+	cmd.Flags().BoolVar(
+		&getCmd.showParams,
+		"show-params",
+		false,
+		"If present, add the parameters of the instances, including the ones from secrets with their values redacted, to the json and yaml output",
+	)
 
 	return cmd
 }
@@ -74,6 +135,35 @@ func (c *getCmd) Validate(args []string) error {
 		if c.PlanFilter != "" {
 			return fmt.Errorf("plan filter is not supported when specifiying instance name")
 		}
+
+		if c.filterByRefResolved {
+			return fmt.Errorf("plan-ref-resolved filter is not supported when specifiying instance name")
+		}
+
+		if c.showClassPlan {
+			return fmt.Errorf("show-class-plan is not supported when specifiying instance name")
+		}
+
+		if c.FiltersByAge() {
+			return fmt.Errorf("--older-than and --newer-than are not supported when specifying instance name")
+		}
+	}
+
+	if c.Watch && c.FiltersByAge() {
+		return fmt.Errorf("--older-than and --newer-than are not supported with --watch")
+	}
+
+	if c.Watch && (c.Limit > 0 || c.Continue != "") {
+		return fmt.Errorf("--limit and --continue are not supported with --watch")
+	}
+
+	if c.showParams {
+		if c.OutputFormat != output.FormatJSON && c.OutputFormat != output.FormatYAML {
+			return fmt.Errorf("--show-params is only supported with the json and yaml output formats")
+		}
+		if c.Watch {
+			return fmt.Errorf("--show-params is not supported with --watch")
+		}
 	}
 
 	return nil
@@ -88,12 +178,67 @@ func (c *getCmd) Run() error {
 }
 
 func (c *getCmd) getAll() error {
-	instances, err := c.App.RetrieveInstances(c.Namespace, c.ClassFilter, c.PlanFilter)
+	opts := servicecatalog.ScopeOptions{
+		Namespace:     c.Namespace,
+		ClassFilter:   c.ClassFilter,
+		PlanFilter:    c.PlanFilter,
+		LabelSelector: c.LabelSelector,
+		FieldSelector: c.FieldSelector,
+		Limit:         c.Limit,
+		Continue:      c.Continue,
+		ChunkSize:     c.ChunkSize,
+		OlderThan:     c.OlderThan,
+		NewerThan:     c.NewerThan,
+	}
+	if c.filterByRefResolved {
+		opts.PlanRefResolved = &c.refResolved
+	}
+	instances, err := c.App.RetrieveInstancesPage(opts)
 	if err != nil {
 		return err
 	}
 
-	output.WriteInstanceList(c.Output, c.OutputFormat, instances)
+	writeInstance := func(opts output.TableOptions, instance v1beta1.ServiceInstance) {
+		output.WriteInstance(c.Output, c.OutputFormat, opts, instance)
+	}
+	if c.showClassPlan && c.OutputFormat == output.FormatTable {
+		// List all the classes and plans once rather than retrieving them for
+		// each instance.
+		scopeOpts := servicecatalog.ScopeOptions{
+			Scope:     servicecatalog.AllScope,
+			Namespace: c.Namespace,
+		}
+		classes, err := c.App.RetrieveClasses(scopeOpts)
+		if err != nil {
+			return err
+		}
+		plans, err := c.App.RetrievePlans("", scopeOpts)
+		if err != nil {
+			return err
+		}
+		output.WriteInstanceListWithClassPlans(c.Output, c.OutputFormat, c.TableOptions(), instances, classes, plans)
+		writeInstance = func(opts output.TableOptions, instance v1beta1.ServiceInstance) {
+			list := &v1beta1.ServiceInstanceList{Items: []v1beta1.ServiceInstance{instance}}
+			output.WriteInstanceListWithClassPlans(c.Output, c.OutputFormat, opts, list, classes, plans)
+		}
+	} else if c.showParams {
+		params := make([]map[string]interface{}, 0, len(instances.Items))
+		for i := range instances.Items {
+			instanceParams, err := c.App.RetrieveInstanceParameters(&instances.Items[i])
+			if err != nil {
+				return err
+			}
+			params = append(params, instanceParams)
+		}
+		output.WriteInstanceListWithParameters(c.Output, c.OutputFormat, c.TableOptions(), instances, params)
+	} else {
+		output.WriteInstanceList(c.Output, c.OutputFormat, c.TableOptions(), instances)
+	}
+	c.WriteContinueHint(c.Output, c.OutputFormat, instances.Continue)
+
+	if c.Watch {
+		return c.watch(instances.ResourceVersion, c.FieldSelector, writeInstance)
+	}
 	return nil
 }
 
@@ -103,7 +248,57 @@ func (c *getCmd) get() error {
 		return err
 	}
 
-	output.WriteInstance(c.Output, c.OutputFormat, *instance)
+	if c.showParams {
+		params, err := c.App.RetrieveInstanceParameters(instance)
+		if err != nil {
+			return err
+		}
+		output.WriteInstanceWithParameters(c.Output, c.OutputFormat, c.TableOptions(), *instance, params)
+		return nil
+	}
+
+	output.WriteInstance(c.Output, c.OutputFormat, c.TableOptions(), *instance)
 
+	if c.Watch {
+		return c.watch(instance.ResourceVersion, fields.OneTermEqualSelector("metadata.name", c.name).String(),
+			func(opts output.TableOptions, instance v1beta1.ServiceInstance) {
+				output.WriteInstance(c.Output, c.OutputFormat, opts, instance)
+			})
+	}
 	return nil
 }
+
+// watch prints the instances that change after the given resource version
+// with writeInstance, without the header row of tables.
+func (c *getCmd) watch(resourceVersion, fieldSelector string, writeInstance func(output.TableOptions, v1beta1.ServiceInstance)) error {
+	opts := c.TableOptions()
+	opts.NoHeaders = true
+	start := func(resourceVersion string) (watch.Interface, error) {
+		return c.App.WatchInstances(servicecatalog.ScopeOptions{
+			Namespace:       c.Namespace,
+			LabelSelector:   c.LabelSelector,
+			FieldSelector:   fieldSelector,
+			ResourceVersion: resourceVersion,
+		})
+	}
+	return c.RunWatch(resourceVersion, start, func(obj runtime.Object) {
+		if instance, ok := obj.(*v1beta1.ServiceInstance); ok && c.matches(instance) {
+			writeInstance(opts, *instance)
+		}
+	})
+}
+
+// matches returns whether a watched instance passes the filters of the
+// command, which are applied to the listed instances by the SDK.
+func (c *getCmd) matches(instance *v1beta1.ServiceInstance) bool {
+	if c.ClassFilter != "" && instance.Spec.GetSpecifiedClusterServiceClass() != c.ClassFilter {
+		return false
+	}
+	if c.PlanFilter != "" && instance.Spec.GetSpecifiedClusterServicePlan() != c.PlanFilter {
+		return false
+	}
+	if c.filterByRefResolved && c.App.IsInstanceRefResolved(instance) != c.refResolved {
+		return false
+	}
+	return true
+}
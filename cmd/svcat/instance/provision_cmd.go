@@ -22,6 +22,7 @@ import (
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/poy/service-catalog/cmd/svcat/output"
 	"github.com/poy/service-catalog/cmd/svcat/parameters"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	servicecatalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	"github.com/spf13/cobra"
 )
@@ -30,15 +31,16 @@ type provisonCmd struct {
 	*command.Namespaced
 	*command.Waitable
 
-	instanceName string
-	externalID   string
-	className    string
-	planName     string
-	rawParams    []string
-	jsonParams   string
-	params       interface{}
-	rawSecrets   []string
-	secrets      map[string]string
+	instanceName     string
+	externalID       string
+	className        string
+	planName         string
+	fromInstanceName string
+	rawParams        []string
+	jsonParams       string
+	params           interface{}
+	rawSecrets       []string
+	secrets          map[string]string
 }
 
 // NewProvisionCmd builds a "svcat provision" command
@@ -54,6 +56,7 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -p location=eastus -p sslEnforcement=disabled
   svcat provision wordpress-mysql-instance --external-id a7c00676-4398-11e8-842f-0ed5f89f718b --class mysqldb --plan free
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -s mysecret[dbparams]
+  svcat provision wordpress-mysql-instance-2 --from-instance wordpress-mysql-instance -p location=westus
   svcat provision secure-instance --class mysqldb --plan secureDB --params-json '{
     "encrypt" : true,
     "firewallRules" : [
@@ -72,11 +75,11 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 	cmd.Flags().StringVar(&provisionCmd.externalID, "external-id", "",
 		"The ID of the instance for use with the OSB SB API (Optional)")
 	cmd.Flags().StringVar(&provisionCmd.className, "class", "",
-		"The class name (Required)")
-	cmd.MarkFlagRequired("class")
+		"The class name (Required unless --from-instance is used)")
 	cmd.Flags().StringVar(&provisionCmd.planName, "plan", "",
-		"The plan name (Required)")
-	cmd.MarkFlagRequired("plan")
+		"The plan name (Required unless --from-instance is used)")
+	cmd.Flags().StringVar(&provisionCmd.fromInstanceName, "from-instance", "",
+		"The name of an existing instance in the same namespace whose class, plan, parameters and secret parameters are used for the new instance. Parameters given with --param, --params-json and --secret are added to them, and replace the top-level parameters with the same names")
 	cmd.Flags().StringSliceVarP(&provisionCmd.rawParams, "param", "p", nil,
 		"Additional parameter to use when provisioning the service, format: NAME=VALUE. Cannot be combined with --params-json, Sensitive information should be placed in a secret and specified with --secret")
 	cmd.Flags().StringSliceVarP(&provisionCmd.rawSecrets, "secret", "s", nil,
@@ -94,6 +97,14 @@ func (c *provisonCmd) Validate(args []string) error {
 	}
 	c.instanceName = args[0]
 
+	if c.fromInstanceName != "" {
+		if c.className != "" || c.planName != "" {
+			return fmt.Errorf("--class and --plan cannot be used with --from-instance")
+		}
+	} else if c.className == "" || c.planName == "" {
+		return fmt.Errorf("--class and --plan are required")
+	}
+
 	var err error
 
 	if c.jsonParams != "" && len(c.rawParams) > 0 {
@@ -131,7 +142,13 @@ func (c *provisonCmd) Provision() error {
 		Params:     c.params,
 		Secrets:    c.secrets,
 	}
-	instance, err := c.App.Provision(c.instanceName, c.className, c.planName, opts)
+	var instance *v1beta1.ServiceInstance
+	var err error
+	if c.fromInstanceName != "" {
+		instance, err = c.App.ProvisionFromInstance(c.instanceName, c.fromInstanceName, opts)
+	} else {
+		instance, err = c.App.Provision(c.instanceName, c.className, c.planName, opts)
+	}
 	if err != nil {
 		return err
 	}
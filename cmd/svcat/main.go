@@ -32,6 +32,8 @@ import (
 	"github.com/poy/service-catalog/cmd/svcat/class"
 	"github.com/poy/service-catalog/cmd/svcat/command"
 	"github.com/poy/service-catalog/cmd/svcat/completion"
+	"github.com/poy/service-catalog/cmd/svcat/event"
+	"github.com/poy/service-catalog/cmd/svcat/export"
 	"github.com/poy/service-catalog/cmd/svcat/instance"
 	"github.com/poy/service-catalog/cmd/svcat/plan"
 	"github.com/poy/service-catalog/cmd/svcat/plugin"
@@ -93,10 +95,12 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 
 			// Initialize the context if not already configured (by tests)
 			if cxt.App == nil {
-				k8sClient, svcatClient, namespace, err := getClients(opts.KubeConfig, opts.KubeContext)
+				k8sClient, svcatClient, namespace, kubeContext, err := getClients(opts.KubeConfig, opts.KubeContext)
 				if err != nil {
 					return err
 				}
+				cxt.KubeConfig = opts.KubeConfig
+				cxt.KubeContext = kubeContext
 
 				app, err := svcat.NewApp(k8sClient, svcatClient, namespace)
 				if err != nil {
@@ -120,6 +124,7 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 	cmd.AddCommand(newCreateCmd(cxt))
 	cmd.AddCommand(newGetCmd(cxt))
 	cmd.AddCommand(newDescribeCmd(cxt))
+	cmd.AddCommand(newDiffCmd(cxt))
 	cmd.AddCommand(broker.NewRegisterCmd(cxt))
 	cmd.AddCommand(broker.NewDeregisterCmd(cxt))
 	cmd.AddCommand(instance.NewProvisionCmd(cxt))
@@ -128,6 +133,7 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 	cmd.AddCommand(binding.NewUnbindCmd(cxt))
 	cmd.AddCommand(browsing.NewMarketplaceCmd(cxt))
 	cmd.AddCommand(newSyncCmd(cxt))
+	cmd.AddCommand(export.NewExportCmd(cxt))
 	if !plugin.IsPlugin() {
 		cmd.AddCommand(newInstallCmd(cxt))
 	}
@@ -167,6 +173,7 @@ func newGetCmd(cxt *command.Context) *cobra.Command {
 	cmd.AddCommand(binding.NewGetCmd(cxt))
 	cmd.AddCommand(broker.NewGetCmd(cxt))
 	cmd.AddCommand(class.NewGetCmd(cxt))
+	cmd.AddCommand(event.NewGetCmd(cxt))
 	cmd.AddCommand(instance.NewGetCmd(cxt))
 	cmd.AddCommand(plan.NewGetCmd(cxt))
 
@@ -187,6 +194,16 @@ func newDescribeCmd(cxt *command.Context) *cobra.Command {
 	return cmd
 }
 
+func newDiffCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show pending changes of a resource",
+	}
+	cmd.AddCommand(instance.NewDiffCmd(cxt))
+
+	return cmd
+}
+
 func newInstallCmd(cxt *command.Context) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "install",
@@ -203,6 +220,7 @@ func newTouchCmd(cxt *command.Context) *cobra.Command {
 		Short: "Force Service Catalog to reprocess a resource",
 	}
 	cmd.AddCommand(instance.NewTouchCommand(cxt))
+	cmd.AddCommand(binding.NewTouchCommand(cxt))
 	return cmd
 }
 
@@ -210,29 +228,45 @@ func newCompletionCmd(ctx *command.Context) *cobra.Command {
 	return completion.NewCompletionCmd(ctx)
 }
 
-// getClients loads api clients based on the plugin context if present, otherwise the specified kube config.
-func getClients(kubeConfig, kubeContext string) (k8sClient k8sclient.Interface, svcatClient svcatclient.Interface, namespaces string, err error) {
+// getClients builds the clients for the selected kubeconfig context, and
+// returns them along with the namespace and name of the resolved context.
+func getClients(kubeConfig, kubeContext string) (k8sClient k8sclient.Interface, svcatClient svcatclient.Interface, namespace string, resolvedContext string, err error) {
 	var restConfig *rest.Config
 	var config clientcmd.ClientConfig
 
 	if plugin.IsPlugin() {
 		restConfig, config, err = pluginutils.InitClientAndConfig()
 		if err != nil {
-			return nil, nil, "", fmt.Errorf("could not get Kubernetes config from kubectl plugin context: %s", err)
+			return nil, nil, "", "", fmt.Errorf("could not get Kubernetes config from kubectl plugin context: %s", err)
 		}
 	} else {
 		config = kube.GetConfig(kubeContext, kubeConfig)
 		restConfig, err = config.ClientConfig()
 		if err != nil {
-			return nil, nil, "", fmt.Errorf("could not get Kubernetes config for context %q: %s", kubeContext, err)
+			return nil, nil, "", "", fmt.Errorf("could not get Kubernetes config for context %q: %s", kubeContext, err)
+		}
+	}
+
+	resolvedContext = kubeContext
+	if resolvedContext == "" {
+		rawConfig, err := config.RawConfig()
+		if err != nil {
+			return nil, nil, "", "", fmt.Errorf("could not load kubeconfig (%s)", err)
 		}
+		resolvedContext = rawConfig.CurrentContext
 	}
 
-	namespace, _, err := config.Namespace()
+	namespace, _, err = config.Namespace()
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("could not determine the namespace for context %q: %s", resolvedContext, err)
+	}
 	k8sClient, err = k8sclient.NewForConfig(restConfig)
 	if err != nil {
-		return nil, nil, "", err
+		return nil, nil, "", "", err
 	}
 	svcatClient, err = svcatclient.NewForConfig(restConfig)
-	return k8sClient, svcatClient, namespace, nil
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+	return k8sClient, svcatClient, namespace, resolvedContext, nil
 }
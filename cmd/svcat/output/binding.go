@@ -24,6 +24,7 @@ import (
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	svcatsdk "github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func getBindingStatusShort(status v1beta1.ServiceBindingStatus) string {
@@ -36,50 +37,132 @@ func getBindingStatusFull(status v1beta1.ServiceBindingStatus) string {
 	return formatStatusFull(string(lastCond.Type), lastCond.Status, lastCond.Reason, lastCond.Message, lastCond.LastTransitionTime)
 }
 
-func writeBindingListTable(w io.Writer, bindingList *v1beta1.ServiceBindingList) {
-	t := NewListTable(w)
-	t.SetHeader([]string{
+// writeBindingStatuses prints the overall status of each binding on a line of
+// its own.
+func writeBindingStatuses(w io.Writer, bindings []v1beta1.ServiceBinding) {
+	for _, binding := range bindings {
+		status, _ := svcatsdk.GetBindingStatus(binding.Status)
+		fmt.Fprintf(w, "%s: %s\n", binding.Name, status)
+	}
+}
+
+// getBindingSecretNamespace returns the namespace of the secret of a binding,
+// which the controller creates in the namespace of the binding.
+func getBindingSecretNamespace(binding *v1beta1.ServiceBinding) string {
+	return binding.Namespace
+}
+
+// getBindingSecretName returns the name of the secret of a binding, which
+// the API server defaults to the name of the binding when it isn't set.
+func getBindingSecretName(binding *v1beta1.ServiceBinding) string {
+	if binding.Spec.SecretName != "" {
+		return binding.Spec.SecretName
+	}
+	return binding.Name
+}
+
+// bindingWithSecretNamespace returns a binding with the secretNamespace field
+// added, for the JSON and YAML output formats.
+func bindingWithSecretNamespace(binding *v1beta1.ServiceBinding) interface{} {
+	return struct {
+		*v1beta1.ServiceBinding
+		SecretNamespace string `json:"secretNamespace"`
+	}{binding, getBindingSecretNamespace(binding)}
+}
+
+// bindingListWithSecretNamespaces returns a list of bindings with the
+// secretNamespace field added to each binding, for the JSON and YAML output
+// formats.
+func bindingListWithSecretNamespaces(bindingList *v1beta1.ServiceBindingList) interface{} {
+	items := make([]interface{}, 0, len(bindingList.Items))
+	for i := range bindingList.Items {
+		items = append(items, bindingWithSecretNamespace(&bindingList.Items[i]))
+	}
+	return struct {
+		metav1.TypeMeta `json:",inline"`
+		metav1.ListMeta `json:"metadata"`
+		Items           []interface{} `json:"items"`
+	}{bindingList.TypeMeta, bindingList.ListMeta, items}
+}
+
+func writeBindingListTable(w io.Writer, opts TableOptions, bindingList *v1beta1.ServiceBindingList, wide bool) {
+	t := newListTable(w, opts)
+	header := []string{
 		"Name",
 		"Namespace",
 		"Instance",
 		"Status",
-	})
+	}
+	if wide {
+		header = append(header, "Secret", "Secret-Namespace", "Age")
+	}
+	t.SetHeader(header)
 
 	for _, binding := range bindingList.Items {
-		t.Append([]string{
+		row := []string{
 			binding.Name,
 			binding.Namespace,
 			binding.Spec.InstanceRef.Name,
 			getBindingStatusShort(binding.Status),
-		})
+		}
+		if wide {
+			row = append(row,
+				binding.Spec.SecretName,
+				getBindingSecretNamespace(&binding),
+				formatAge(binding.CreationTimestamp),
+			)
+		}
+		t.AppendWithLabels(row, binding.Labels)
 	}
 	t.Render()
 }
 
 // WriteBindingList prints a list of bindings in the specified output format.
-func WriteBindingList(w io.Writer, outputFormat string, bindingList *v1beta1.ServiceBindingList) {
+func WriteBindingList(w io.Writer, outputFormat string, opts TableOptions, bindingList *v1beta1.ServiceBindingList) {
 	switch outputFormat {
 	case FormatJSON:
-		writeJSON(w, bindingList)
+		writeJSON(w, bindingListWithSecretNamespaces(bindingList))
 	case FormatYAML:
-		writeYAML(w, bindingList, 0)
+		writeYAML(w, bindingListWithSecretNamespaces(bindingList), 0)
 	case FormatTable:
-		writeBindingListTable(w, bindingList)
+		writeBindingListTable(w, opts, bindingList, false)
+	case FormatWide:
+		writeBindingListTable(w, opts, bindingList, true)
+	case FormatName:
+		names := make([]string, 0, len(bindingList.Items))
+		for _, binding := range bindingList.Items {
+			names = append(names, binding.Name)
+		}
+		writeNames(w, names...)
+	case FormatStatusOnly:
+		writeBindingStatuses(w, bindingList.Items)
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, bindingList.Items)
+		}
 	}
 }
 
 // WriteBinding prints a single bindings in the specified output format.
-func WriteBinding(w io.Writer, outputFormat string, binding v1beta1.ServiceBinding) {
+func WriteBinding(w io.Writer, outputFormat string, opts TableOptions, binding v1beta1.ServiceBinding) {
 	switch outputFormat {
 	case FormatJSON:
-		writeJSON(w, binding)
+		writeJSON(w, bindingWithSecretNamespace(&binding))
 	case FormatYAML:
-		writeYAML(w, binding, 0)
-	case FormatTable:
+		writeYAML(w, bindingWithSecretNamespace(&binding), 0)
+	case FormatTable, FormatWide:
 		l := v1beta1.ServiceBindingList{
 			Items: []v1beta1.ServiceBinding{binding},
 		}
-		writeBindingListTable(w, &l)
+		writeBindingListTable(w, opts, &l, outputFormat == FormatWide)
+	case FormatName:
+		writeNames(w, binding.Name)
+	case FormatStatusOnly:
+		writeBindingStatuses(w, []v1beta1.ServiceBinding{binding})
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, []v1beta1.ServiceBinding{binding})
+		}
 	}
 }
 
@@ -90,7 +173,7 @@ func WriteBindingDetails(w io.Writer, binding *v1beta1.ServiceBinding) {
 		{"Name:", binding.Name},
 		{"Namespace:", binding.Namespace},
 		{"Status:", getBindingStatusFull(binding.Status)},
-		{"Secret:", binding.Spec.SecretName},
+		{"Secret:", getBindingSecretName(binding)},
 		{"Instance:", binding.Spec.InstanceRef.Name},
 	})
 	t.Render()
@@ -160,3 +243,14 @@ func WriteDeletedBindingNames(w io.Writer, bindings []v1beta1.ServiceBinding) {
 		WriteDeletedResourceName(w, binding.Name)
 	}
 }
+
+// WriteBindingDeletionProgress prints the status of a binding that is being
+// unbound.
+func WriteBindingDeletionProgress(w io.Writer, binding *v1beta1.ServiceBinding) {
+	fmt.Fprintf(w, "Waiting for binding %s to be unbound (%s)...\n", binding.Name, getBindingStatusShort(binding.Status))
+}
+
+// WriteBindingUnbindFailure prints why a binding could not be unbound.
+func WriteBindingUnbindFailure(w io.Writer, binding *v1beta1.ServiceBinding) {
+	fmt.Fprintf(w, "could not delete binding %s/%s: %s\n", binding.Namespace, binding.Name, getBindingStatusFull(binding.Status))
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWriteBindingListWide(t *testing.T) {
+	current := time.Date(2019, 3, 4, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	bindings := &v1beta1.ServiceBindingList{
+		Items: []v1beta1.ServiceBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "old-binding",
+					Namespace:         "test-ns",
+					CreationTimestamp: metav1.NewTime(current.Add(-50 * time.Hour)),
+				},
+				Spec: v1beta1.ServiceBindingSpec{
+					InstanceRef: v1beta1.LocalObjectReference{Name: "ups-instance"},
+					SecretName:  "old-secret",
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "new-binding",
+					Namespace: "test-ns",
+				},
+				Spec: v1beta1.ServiceBindingSpec{
+					InstanceRef: v1beta1.LocalObjectReference{Name: "ups-instance"},
+					SecretName:  "new-secret",
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name     string   // Test name
+		format   string   // Output format tested
+		contains []string // Expected output
+		excludes []string // Unexpected output
+	}{
+		{"table", FormatTable, []string{"old-binding", "ups-instance"}, []string{"SECRET", "AGE", "old-secret"}},
+		{"wide", FormatWide, []string{"SECRET", "AGE", "old-secret", "2d", "new-secret", "<unknown>"}, nil},
+	}
+
+	for _, tc := range testcases {
+		output := &bytes.Buffer{}
+		WriteBindingList(output, tc.format, TableOptions{}, bindings)
+		for _, s := range tc.contains {
+			if !strings.Contains(output.String(), s) {
+				t.Errorf("%v: expected output to contain %q, got\n%s", tc.name, s, output.String())
+			}
+		}
+		for _, s := range tc.excludes {
+			if strings.Contains(output.String(), s) {
+				t.Errorf("%v: expected output not to contain %q, got\n%s", tc.name, s, output.String())
+			}
+		}
+	}
+}
+
+func TestWriteBindingListStatusOnly(t *testing.T) {
+	bindings := &v1beta1.ServiceBindingList{
+		Items: []v1beta1.ServiceBinding{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "ready-binding"},
+				Status: v1beta1.ServiceBindingStatus{
+					Conditions: []v1beta1.ServiceBindingCondition{
+						{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "pending-binding"},
+				Status: v1beta1.ServiceBindingStatus{
+					Conditions: []v1beta1.ServiceBindingCondition{
+						{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionFalse, Reason: "Binding"},
+					},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "failed-binding"},
+				Status: v1beta1.ServiceBindingStatus{
+					Conditions: []v1beta1.ServiceBindingCondition{
+						{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionFalse, Reason: "BindCallFailed"},
+						{Type: v1beta1.ServiceBindingConditionFailed, Status: v1beta1.ConditionTrue, Reason: "BindCallFailed"},
+					},
+				},
+			},
+		},
+	}
+
+	output := &bytes.Buffer{}
+	WriteBindingList(output, FormatStatusOnly, TableOptions{}, bindings)
+
+	expected := "ready-binding: Ready\npending-binding: NotReady\nfailed-binding: Failed\n"
+	if output.String() != expected {
+		t.Errorf("unexpected output, expected\n%s\ngot\n%s", expected, output.String())
+	}
+}
@@ -40,46 +40,94 @@ func getBrokerStatusFull(status v1beta1.CommonServiceBrokerStatus) string {
 	return formatStatusFull(string(lastCond.Type), lastCond.Status, lastCond.Reason, lastCond.Message, lastCond.LastTransitionTime)
 }
 
-func writeBrokerListTable(w io.Writer, brokers []servicecatalog.Broker) {
-	t := NewListTable(w)
-	t.SetHeader([]string{
+func getBrokerReadyStatus(status v1beta1.CommonServiceBrokerStatus) string {
+	for _, cond := range status.Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionReady {
+			return string(cond.Status)
+		}
+	}
+	return string(v1beta1.ConditionUnknown)
+}
+
+func getBrokerLastRelist(status v1beta1.CommonServiceBrokerStatus) string {
+	if status.LastCatalogRetrievalTime == nil {
+		return ""
+	}
+	return status.LastCatalogRetrievalTime.UTC().String()
+}
+
+func writeBrokerListTable(w io.Writer, opts TableOptions, brokers []servicecatalog.Broker, wide bool) {
+	t := newListTable(w, opts)
+	header := []string{
 		"Name",
 		"Namespace",
 		"URL",
 		"Status",
-	})
+	}
+	if wide {
+		header = append(header, "Ready", "Relist Behavior", "Last Relist")
+	}
+	t.SetHeader(header)
 	for _, broker := range brokers {
-		t.Append([]string{
+		row := []string{
 			broker.GetName(),
 			broker.GetNamespace(),
 			broker.GetURL(),
 			getBrokerStatusShort(broker.GetStatus()),
-		})
+		}
+		if wide {
+			row = append(row,
+				getBrokerReadyStatus(broker.GetStatus()),
+				string(broker.GetSpec().RelistBehavior),
+				getBrokerLastRelist(broker.GetStatus()),
+			)
+		}
+		t.AppendWithLabels(row, broker.GetLabels())
 	}
 	t.Render()
 }
 
 // WriteBrokerList prints a list of brokers in the specified output format.
-func WriteBrokerList(w io.Writer, outputFormat string, brokers ...servicecatalog.Broker) {
+func WriteBrokerList(w io.Writer, outputFormat string, opts TableOptions, brokers ...servicecatalog.Broker) {
 	switch outputFormat {
 	case FormatJSON:
 		writeJSON(w, brokers)
 	case FormatYAML:
 		writeYAML(w, brokers, 0)
 	case FormatTable:
-		writeBrokerListTable(w, brokers)
+		writeBrokerListTable(w, opts, brokers, false)
+	case FormatWide:
+		writeBrokerListTable(w, opts, brokers, true)
+	case FormatName:
+		names := make([]string, 0, len(brokers))
+		for _, broker := range brokers {
+			names = append(names, broker.GetName())
+		}
+		writeNames(w, names...)
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, brokers)
+		}
 	}
 }
 
 // WriteBroker prints a broker in the specified output format.
-func WriteBroker(w io.Writer, outputFormat string, broker v1beta1.ClusterServiceBroker) {
+func WriteBroker(w io.Writer, outputFormat string, opts TableOptions, broker servicecatalog.Broker) {
 	switch outputFormat {
 	case FormatJSON:
 		writeJSON(w, broker)
 	case FormatYAML:
 		writeYAML(w, broker, 0)
 	case FormatTable:
-		writeBrokerListTable(w, []servicecatalog.Broker{&broker})
+		writeBrokerListTable(w, opts, []servicecatalog.Broker{broker}, false)
+	case FormatWide:
+		writeBrokerListTable(w, opts, []servicecatalog.Broker{broker}, true)
+	case FormatName:
+		writeNames(w, broker.GetName())
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, []servicecatalog.Broker{broker})
+		}
 	}
 }
 
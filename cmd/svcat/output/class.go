@@ -18,6 +18,7 @@ package output
 
 import (
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
@@ -30,20 +31,53 @@ func getScope(class servicecatalog.Class) string {
 	return servicecatalog.ClusterScope
 }
 
-func writeClassListTable(w io.Writer, classes []servicecatalog.Class) {
+func writeClassListTable(w io.Writer, opts TableOptions, classes []servicecatalog.Class, showRemoved bool) {
+	t := newListTable(w, opts)
+
+	header := []string{
+		"Name",
+		"Namespace",
+		"Description",
+	}
+	if showRemoved {
+		header = append(header, "Removed")
+	}
+	t.SetHeader(header)
+	t.SetVariableColumn(3)
+
+	for _, class := range classes {
+		row := []string{
+			class.GetExternalName(),
+			class.GetNamespace(),
+			class.GetDescription(),
+		}
+		if showRemoved {
+			row = append(row, strconv.FormatBool(servicecatalog.IsClassRemoved(class)))
+		}
+		t.AppendWithLabels(row, class.GetLabels())
+	}
+
+	t.Render()
+}
+
+// WriteAmbiguousClasses prints the classes matching an ambiguous class name,
+// along with the broker offering each of them.
+func WriteAmbiguousClasses(w io.Writer, classes ...servicecatalog.Class) {
 	t := NewListTable(w)
 
 	t.SetHeader([]string{
 		"Name",
 		"Namespace",
+		"Broker",
 		"Description",
 	})
-	t.SetVariableColumn(3)
+	t.SetVariableColumn(4)
 
 	for _, class := range classes {
 		t.Append([]string{
 			class.GetExternalName(),
 			class.GetNamespace(),
+			class.GetServiceBrokerName(),
 			class.GetDescription(),
 		})
 	}
@@ -52,26 +86,53 @@ func writeClassListTable(w io.Writer, classes []servicecatalog.Class) {
 }
 
 // WriteClassList prints a list of classes in the specified output format.
-func WriteClassList(w io.Writer, outputFormat string, classes ...servicecatalog.Class) {
+func WriteClassList(w io.Writer, outputFormat string, opts TableOptions, classes ...servicecatalog.Class) {
+	writeClassList(w, outputFormat, opts, classes, false)
+}
+
+// WriteClassListWithRemoved prints a list of classes like WriteClassList,
+// adding a column to the table format that shows whether their broker
+// removed them from its catalog.
+func WriteClassListWithRemoved(w io.Writer, outputFormat string, opts TableOptions, classes ...servicecatalog.Class) {
+	writeClassList(w, outputFormat, opts, classes, true)
+}
+
+func writeClassList(w io.Writer, outputFormat string, opts TableOptions, classes []servicecatalog.Class, showRemoved bool) {
 	switch outputFormat {
 	case FormatJSON:
 		writeJSON(w, classes)
 	case FormatYAML:
 		writeYAML(w, classes, 0)
 	case FormatTable:
-		writeClassListTable(w, classes)
+		writeClassListTable(w, opts, classes, showRemoved)
+	case FormatName:
+		names := make([]string, 0, len(classes))
+		for _, class := range classes {
+			names = append(names, class.GetExternalName())
+		}
+		writeNames(w, names...)
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, classes)
+		}
 	}
 }
 
 // WriteClass prints a single class in the specified output format.
-func WriteClass(w io.Writer, outputFormat string, class servicecatalog.Class) {
+func WriteClass(w io.Writer, outputFormat string, opts TableOptions, class servicecatalog.Class) {
 	switch outputFormat {
 	case FormatJSON:
 		writeJSON(w, class)
 	case FormatYAML:
 		writeYAML(w, class, 0)
 	case FormatTable:
-		writeClassListTable(w, []servicecatalog.Class{class})
+		writeClassListTable(w, opts, []servicecatalog.Class{class}, false)
+	case FormatName:
+		writeNames(w, class.GetExternalName())
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, []servicecatalog.Class{class})
+		}
 	}
 }
 
@@ -96,8 +157,8 @@ func WriteClassDetails(w io.Writer, class servicecatalog.Class) {
 }
 
 // WriteClassAndPlanDetails prints details for multiple classes and plans
-func WriteClassAndPlanDetails(w io.Writer, classes []servicecatalog.Class, plans [][]servicecatalog.Plan) {
-	t := NewListTable(w)
+func WriteClassAndPlanDetails(w io.Writer, opts TableOptions, classes []servicecatalog.Class, plans [][]servicecatalog.Plan) {
+	t := newListTable(w, opts)
 	t.SetHeader([]string{
 		"Class",
 		"Plans",
@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// customColumnsPrefix starts the --output flag value of the custom-columns
+// format, which is followed by the column spec.
+const customColumnsPrefix = FormatCustomColumns + "="
+
+// CustomColumn is a column of the custom-columns output format.
+type CustomColumn struct {
+	// Header is the header of the column.
+	Header string
+
+	// FieldSpec is the JSONPath expression evaluated against each resource
+	// to print the value of the column.
+	FieldSpec string
+}
+
+// IsCustomColumns returns whether the output format is custom-columns.
+func IsCustomColumns(outputFormat string) bool {
+	return strings.HasPrefix(outputFormat, customColumnsPrefix)
+}
+
+// ParseCustomColumns parses the columns of a custom-columns output format,
+// such as custom-columns=NAME:.metadata.name,PLAN:.spec.clusterServicePlanExternalName
+func ParseCustomColumns(outputFormat string) ([]CustomColumn, error) {
+	if !IsCustomColumns(outputFormat) {
+		return nil, fmt.Errorf("%q is not a %s output format", outputFormat, FormatCustomColumns)
+	}
+
+	spec := strings.TrimPrefix(outputFormat, customColumnsPrefix)
+	if spec == "" {
+		return nil, fmt.Errorf("%s format specified but no custom columns given", FormatCustomColumns)
+	}
+
+	parts := strings.Split(spec, ",")
+	columns := make([]CustomColumn, 0, len(parts))
+	for _, part := range parts {
+		colSpec := strings.SplitN(part, ":", 2)
+		if len(colSpec) != 2 || colSpec[0] == "" || colSpec[1] == "" {
+			return nil, fmt.Errorf("unexpected %s spec %q, expected <header>:<json-path-expr>", FormatCustomColumns, part)
+		}
+		fieldSpec := relaxedJSONPathExpression(colSpec[1])
+		if err := jsonpath.New(colSpec[0]).Parse(fieldSpec); err != nil {
+			return nil, fmt.Errorf("invalid JSONPath expression %q for column %s: %v", colSpec[1], colSpec[0], err)
+		}
+		columns = append(columns, CustomColumn{Header: colSpec[0], FieldSpec: fieldSpec})
+	}
+	return columns, nil
+}
+
+// relaxedJSONPathExpression accepts both .metadata.name and {.metadata.name}
+// as a column spec, like kubectl does.
+func relaxedJSONPathExpression(expr string) string {
+	if strings.HasPrefix(expr, "{") && strings.HasSuffix(expr, "}") {
+		return expr
+	}
+	if !strings.HasPrefix(expr, ".") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}"
+}
+
+// writeCustomColumns prints a table with the columns of a custom-columns output
+// format, evaluating their JSONPath expressions against each item of the given
+// slice of resources.
+func writeCustomColumns(w io.Writer, outputFormat string, opts TableOptions, items interface{}) {
+	columns, err := ParseCustomColumns(outputFormat)
+	if err != nil {
+		fmt.Fprintf(w, "err parsing custom columns: %v\n", err)
+		return
+	}
+
+	// Round-trip the resources through json so that the JSONPath expressions
+	// match the field names users see in the json and yaml output formats.
+	j, err := json.Marshal(items)
+	if err != nil {
+		fmt.Fprintf(w, "err marshaling json: %v\n", err)
+		return
+	}
+	var objs []interface{}
+	if err := json.Unmarshal(j, &objs); err != nil {
+		fmt.Fprintf(w, "err unmarshaling json: %v\n", err)
+		return
+	}
+
+	parsers := make([]*jsonpath.JSONPath, len(columns))
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		parsers[i] = jsonpath.New(column.Header).AllowMissingKeys(true)
+		// The spec was already validated by ParseCustomColumns
+		parsers[i].Parse(column.FieldSpec)
+		headers[i] = column.Header
+	}
+
+	t := newListTable(w, opts)
+	t.SetHeader(headers)
+	for _, obj := range objs {
+		row := make([]string, len(columns))
+		for i, parser := range parsers {
+			row[i] = customColumnValue(parser, obj)
+		}
+		t.AppendWithLabels(row, customColumnLabels(obj))
+	}
+	t.Render()
+}
+
+// customColumnLabels returns the labels of a resource round-tripped through
+// json.
+func customColumnLabels(obj interface{}) map[string]string {
+	resource, _ := obj.(map[string]interface{})
+	metadata, _ := resource["metadata"].(map[string]interface{})
+	values, _ := metadata["labels"].(map[string]interface{})
+	labels := make(map[string]string, len(values))
+	for key, value := range values {
+		labels[key], _ = value.(string)
+	}
+	return labels
+}
+
+// customColumnValue evaluates a column against a resource, joining multiple
+// results with a comma and printing <none> when there are no results.
+func customColumnValue(parser *jsonpath.JSONPath, obj interface{}) string {
+	results, err := parser.FindResults(obj)
+	if err != nil {
+		return "<none>"
+	}
+
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			buf := &bytes.Buffer{}
+			if err := parser.PrintResults(buf, []reflect.Value{value}); err != nil {
+				continue
+			}
+			values = append(values, buf.String())
+		}
+	}
+	if len(values) == 0 {
+		return "<none>"
+	}
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	_ "github.com/poy/service-catalog/internal/test"
+)
+
+func TestParseCustomColumns(t *testing.T) {
+	testcases := []struct {
+		name    string         // Test name
+		format  string         // Output format tested
+		columns []CustomColumn // Expected columns
+		err     string         // Expected error, if any
+	}{
+		{"Single column", "custom-columns=NAME:.metadata.name",
+			[]CustomColumn{{"NAME", "{.metadata.name}"}}, ""},
+		{"Multiple columns", "custom-columns=NAME:{.metadata.name},PLAN:spec.clusterServicePlanExternalName",
+			[]CustomColumn{{"NAME", "{.metadata.name}"}, {"PLAN", "{.spec.clusterServicePlanExternalName}"}}, ""},
+		{"Not custom columns", "table", nil, "is not a custom-columns output format"},
+		{"No columns", "custom-columns=", nil, "no custom columns given"},
+		{"Missing expression", "custom-columns=NAME", nil, "expected <header>:<json-path-expr>"},
+		{"Missing header", "custom-columns=:.metadata.name", nil, "expected <header>:<json-path-expr>"},
+		{"Invalid expression", "custom-columns=NAME:{.metadata.name", nil, "invalid JSONPath expression"},
+	}
+
+	for _, tc := range testcases {
+		columns, err := ParseCustomColumns(tc.format)
+		if tc.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("%v: expected error containing %q, got %v", tc.name, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.columns, columns) {
+			t.Errorf("%v: expected columns %v, got %v", tc.name, tc.columns, columns)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func writeEventListTable(w io.Writer, opts TableOptions, events []corev1.Event) {
+	if len(events) == 0 {
+		fmt.Fprintln(w, "No events found")
+		return
+	}
+
+	t := newListTable(w, opts)
+	t.SetHeader([]string{
+		"Last Seen",
+		"Type",
+		"Reason",
+		"Count",
+		"Message",
+	})
+	t.SetVariableColumn(5)
+	for _, event := range events {
+		t.Append([]string{
+			servicecatalog.EventTime(event).UTC().String(),
+			event.Type,
+			event.Reason,
+			strconv.Itoa(int(event.Count)),
+			event.Message,
+		})
+	}
+	t.Render()
+}
+
+// WriteEventList prints a list of events in the specified output format.
+func WriteEventList(w io.Writer, outputFormat string, opts TableOptions, events []corev1.Event) {
+	switch outputFormat {
+	case FormatJSON:
+		writeJSON(w, events)
+	case FormatYAML:
+		writeYAML(w, events, 0)
+	case FormatTable:
+		writeEventListTable(w, opts, events)
+	}
+}
@@ -17,16 +17,37 @@ limitations under the License.
 package output
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	"github.com/olekukonko/tablewriter"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// instanceStatusConditionTypes are the types of the conditions reporting the
+// state of an instance. Other conditions, such as PlanDeprecated or
+// ParametersStale, only warn about the instance and may come after them.
+var instanceStatusConditionTypes = map[v1beta1.ServiceInstanceConditionType]bool{
+	v1beta1.ServiceInstanceConditionReady:            true,
+	v1beta1.ServiceInstanceConditionFailed:           true,
+	v1beta1.ServiceInstanceConditionOrphanMitigation: true,
+	v1beta1.ServiceInstanceConditionForceDeleted:     true,
+}
+
+// getInstanceStatusCondition returns the last condition reporting the state of
+// the instance.
 func getInstanceStatusCondition(status v1beta1.ServiceInstanceStatus) v1beta1.ServiceInstanceCondition {
-	if len(status.Conditions) > 0 {
-		return status.Conditions[len(status.Conditions)-1]
+	for i := len(status.Conditions) - 1; i >= 0; i-- {
+		if instanceStatusConditionTypes[status.Conditions[i].Type] {
+			return status.Conditions[i]
+		}
 	}
 	return v1beta1.ServiceInstanceCondition{}
 }
@@ -50,8 +71,76 @@ func appendInstanceDashboardURL(status v1beta1.ServiceInstanceStatus, table *tab
 	}
 }
 
-func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceList) {
-	t := NewListTable(w)
+// classPlanNames maps the names of classes and plans to their external names.
+// Namespaced classes and plans are keyed by "<namespace>/<name>".
+type classPlanNames struct {
+	classes map[string]string
+	plans   map[string]string
+}
+
+func newClassPlanNames(classes []servicecatalog.Class, plans []servicecatalog.Plan) *classPlanNames {
+	names := &classPlanNames{
+		classes: make(map[string]string, len(classes)),
+		plans:   make(map[string]string, len(plans)),
+	}
+	for _, class := range classes {
+		names.classes[classPlanKey(class.GetNamespace(), class.GetName())] = class.GetExternalName()
+	}
+	for _, plan := range plans {
+		names.plans[classPlanKey(plan.GetNamespace(), plan.GetName())] = plan.GetExternalName()
+	}
+	return names
+}
+
+func classPlanKey(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+// class returns the external name of the class of an instance, falling back
+// to the class specified by the instance when its reference is not resolved
+// to a known class.
+func (n *classPlanNames) class(instance v1beta1.ServiceInstance) string {
+	if ref := instance.Spec.ClusterServiceClassRef; ref != nil {
+		if name, ok := n.classes[ref.Name]; ok {
+			return name
+		}
+	}
+	if ref := instance.Spec.ServiceClassRef; ref != nil {
+		if name, ok := n.classes[classPlanKey(instance.Namespace, ref.Name)]; ok {
+			return name
+		}
+	}
+	if class := instance.Spec.GetSpecifiedClusterServiceClass(); class != "" {
+		return class
+	}
+	return instance.Spec.GetSpecifiedServiceClass()
+}
+
+// plan returns the external name of the plan of an instance, falling back to
+// the plan specified by the instance when its reference is not resolved to a
+// known plan.
+func (n *classPlanNames) plan(instance v1beta1.ServiceInstance) string {
+	if ref := instance.Spec.ClusterServicePlanRef; ref != nil {
+		if name, ok := n.plans[ref.Name]; ok {
+			return name
+		}
+	}
+	if ref := instance.Spec.ServicePlanRef; ref != nil {
+		if name, ok := n.plans[classPlanKey(instance.Namespace, ref.Name)]; ok {
+			return name
+		}
+	}
+	if plan := instance.Spec.GetSpecifiedClusterServicePlan(); plan != "" {
+		return plan
+	}
+	return instance.Spec.GetSpecifiedServicePlan()
+}
+
+func writeInstanceListTable(w io.Writer, opts TableOptions, instanceList *v1beta1.ServiceInstanceList, names *classPlanNames) {
+	t := newListTable(w, opts)
 	t.SetHeader([]string{
 		"Name",
 		"Namespace",
@@ -61,32 +150,60 @@ func writeInstanceListTable(w io.Writer, instanceList *v1beta1.ServiceInstanceLi
 	})
 
 	for _, instance := range instanceList.Items {
-		t.Append([]string{
+		class := instance.Spec.GetSpecifiedClusterServiceClass()
+		plan := instance.Spec.GetSpecifiedClusterServicePlan()
+		if names != nil {
+			class = names.class(instance)
+			plan = names.plan(instance)
+		}
+		t.AppendWithLabels([]string{
 			instance.Name,
 			instance.Namespace,
-			instance.Spec.GetSpecifiedClusterServiceClass(),
-			instance.Spec.GetSpecifiedClusterServicePlan(),
+			class,
+			plan,
 			getInstanceStatusShort(instance.Status),
-		})
+		}, instance.Labels)
 	}
 
 	t.Render()
 }
 
 // WriteInstanceList prints a list of instances.
-func WriteInstanceList(w io.Writer, outputFormat string, instanceList *v1beta1.ServiceInstanceList) {
+func WriteInstanceList(w io.Writer, outputFormat string, opts TableOptions, instanceList *v1beta1.ServiceInstanceList) {
+	writeInstanceList(w, outputFormat, opts, instanceList, nil)
+}
+
+// WriteInstanceListWithClassPlans prints a list of instances, showing the
+// external names of their classes and plans in the table format. The
+// classes and plans the instances refer to are looked up in the given lists,
+// so that they are not retrieved once per instance.
+func WriteInstanceListWithClassPlans(w io.Writer, outputFormat string, opts TableOptions, instanceList *v1beta1.ServiceInstanceList, classes []servicecatalog.Class, plans []servicecatalog.Plan) {
+	writeInstanceList(w, outputFormat, opts, instanceList, newClassPlanNames(classes, plans))
+}
+
+func writeInstanceList(w io.Writer, outputFormat string, opts TableOptions, instanceList *v1beta1.ServiceInstanceList, names *classPlanNames) {
 	switch outputFormat {
 	case FormatJSON:
 		writeJSON(w, instanceList)
 	case FormatYAML:
 		writeYAML(w, instanceList, 0)
 	case FormatTable:
-		writeInstanceListTable(w, instanceList)
+		writeInstanceListTable(w, opts, instanceList, names)
+	case FormatName:
+		names := make([]string, 0, len(instanceList.Items))
+		for _, instance := range instanceList.Items {
+			names = append(names, instance.Name)
+		}
+		writeNames(w, names...)
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, instanceList.Items)
+		}
 	}
 }
 
 // WriteInstance prints a single instance
-func WriteInstance(w io.Writer, outputFormat string, instance v1beta1.ServiceInstance) {
+func WriteInstance(w io.Writer, outputFormat string, opts TableOptions, instance v1beta1.ServiceInstance) {
 	switch outputFormat {
 	case FormatJSON:
 		writeJSON(w, instance)
@@ -96,7 +213,59 @@ func WriteInstance(w io.Writer, outputFormat string, instance v1beta1.ServiceIns
 		p := v1beta1.ServiceInstanceList{
 			Items: []v1beta1.ServiceInstance{instance},
 		}
-		writeInstanceListTable(w, &p)
+		writeInstanceListTable(w, opts, &p, nil)
+	case FormatName:
+		writeNames(w, instance.Name)
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, []v1beta1.ServiceInstance{instance})
+		}
+	}
+}
+
+// instanceWithParameters returns an instance with the resolvedParameters field
+// added, for the JSON and YAML output formats.
+func instanceWithParameters(instance *v1beta1.ServiceInstance, params map[string]interface{}) interface{} {
+	return struct {
+		*v1beta1.ServiceInstance
+		ResolvedParameters map[string]interface{} `json:"resolvedParameters"`
+	}{instance, params}
+}
+
+// WriteInstanceListWithParameters prints a list of instances, adding the
+// given parameters of each instance to the JSON and YAML output formats. The
+// other formats are printed like WriteInstanceList.
+func WriteInstanceListWithParameters(w io.Writer, outputFormat string, opts TableOptions, instanceList *v1beta1.ServiceInstanceList, params []map[string]interface{}) {
+	if outputFormat != FormatJSON && outputFormat != FormatYAML {
+		WriteInstanceList(w, outputFormat, opts, instanceList)
+		return
+	}
+	items := make([]interface{}, 0, len(instanceList.Items))
+	for i := range instanceList.Items {
+		items = append(items, instanceWithParameters(&instanceList.Items[i], params[i]))
+	}
+	list := struct {
+		metav1.TypeMeta `json:",inline"`
+		metav1.ListMeta `json:"metadata"`
+		Items           []interface{} `json:"items"`
+	}{instanceList.TypeMeta, instanceList.ListMeta, items}
+	if outputFormat == FormatJSON {
+		writeJSON(w, list)
+	} else {
+		writeYAML(w, list, 0)
+	}
+}
+
+// WriteInstanceWithParameters prints a single instance, adding its given
+// parameters to the JSON and YAML output formats.
+func WriteInstanceWithParameters(w io.Writer, outputFormat string, opts TableOptions, instance v1beta1.ServiceInstance, params map[string]interface{}) {
+	switch outputFormat {
+	case FormatJSON:
+		writeJSON(w, instanceWithParameters(&instance, params))
+	case FormatYAML:
+		writeYAML(w, instanceWithParameters(&instance, params), 0)
+	default:
+		WriteInstance(w, outputFormat, opts, instance)
 	}
 }
 
@@ -136,6 +305,42 @@ func WriteAssociatedInstances(w io.Writer, instances []v1beta1.ServiceInstance)
 	t.Render()
 }
 
+// WriteAssociatedInstancesSummary prints how many instances are associated
+// with a plan, by status.
+func WriteAssociatedInstancesSummary(w io.Writer, instances []v1beta1.ServiceInstance) {
+	if len(instances) == 0 {
+		fmt.Fprintln(w, "\nInstances: none")
+		return
+	}
+
+	counts := map[string]int{}
+	var statuses []string
+	for _, instance := range instances {
+		status := getInstanceStatusShort(instance.Status)
+		if counts[status] == 0 {
+			statuses = append(statuses, status)
+		}
+		counts[status]++
+	}
+	sort.Strings(statuses)
+
+	byStatus := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		byStatus = append(byStatus, fmt.Sprintf("%d %s", counts[status], status))
+	}
+	fmt.Fprintf(w, "\nInstances: %d (%s)\n", len(instances), strings.Join(byStatus, ", "))
+}
+
+// WriteInstanceDeletionProgress prints what the deletion of an instance is
+// currently waiting on.
+func WriteInstanceDeletionProgress(w io.Writer, instance *v1beta1.ServiceInstance) {
+	if servicecatalog.IsInstanceDeprovisionBlockedByBindings(instance) {
+		fmt.Fprintln(w, "Waiting for the bindings of the instance to be deleted...")
+		return
+	}
+	fmt.Fprintf(w, "Waiting for the instance to be deprovisioned (%s)...\n", getInstanceStatusShort(instance.Status))
+}
+
 // WriteInstanceDetails prints an instance.
 func WriteInstanceDetails(w io.Writer, instance *v1beta1.ServiceInstance) {
 	t := NewDetailsTable(w)
@@ -154,3 +359,91 @@ func WriteInstanceDetails(w io.Writer, instance *v1beta1.ServiceInstance) {
 	writeParameters(w, instance.Spec.Parameters)
 	writeParametersFrom(w, instance.Spec.ParametersFrom)
 }
+
+func writeInstanceParametersDiffTable(w io.Writer, instance *v1beta1.ServiceInstance, diff *servicecatalog.InstanceParametersDiff) {
+	parameters := "Unchanged"
+	if diff.ParametersChanged {
+		parameters = "Changed"
+	}
+	var update string
+	switch {
+	case diff.SpecChangePending:
+		update = "Triggered, the spec changed since it was last sent to the broker"
+	case diff.ParametersChanged:
+		update = "Not triggered, touch the instance to send the changed parameters"
+	default:
+		update = "Not needed"
+	}
+
+	t := NewDetailsTable(w)
+	t.AppendBulk([][]string{
+		{"Name:", instance.Name},
+		{"Namespace:", instance.Namespace},
+		{"Parameters:", parameters},
+		{"Update:", update},
+	})
+	t.Render()
+
+	names := map[string]bool{}
+	for name := range diff.Parameters {
+		names[name] = true
+	}
+	for name := range diff.LastSentParameters {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []string
+	for _, name := range sorted {
+		value, current := diff.Parameters[name]
+		lastSent, sent := diff.LastSentParameters[name]
+		switch {
+		case !sent:
+			changes = append(changes, fmt.Sprintf("+ %s: %s", name, parameterValue(value)))
+		case !current:
+			changes = append(changes, fmt.Sprintf("- %s: %s", name, parameterValue(lastSent)))
+		case !reflect.DeepEqual(value, lastSent):
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", name, parameterValue(lastSent), parameterValue(value)))
+		}
+	}
+
+	if len(changes) == 0 {
+		if diff.ParametersChanged {
+			fmt.Fprintln(w, "\nThe values of the parameters from secrets changed")
+		}
+		return
+	}
+	fmt.Fprintln(w, "\nParameter Changes:")
+	for _, change := range changes {
+		fmt.Fprintf(w, "  %s\n", change)
+	}
+}
+
+// parameterValue formats the value of a parameter as JSON.
+func parameterValue(value interface{}) string {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	// Keep redacted values readable
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// WriteInstanceParametersDiff prints how the parameters of an instance differ
+// from the ones last sent to its broker.
+func WriteInstanceParametersDiff(w io.Writer, outputFormat string, instance *v1beta1.ServiceInstance, diff *servicecatalog.InstanceParametersDiff) {
+	switch outputFormat {
+	case FormatJSON:
+		writeJSON(w, diff)
+	case FormatYAML:
+		writeYAML(w, diff, 0)
+	case FormatTable:
+		writeInstanceParametersDiffTable(w, instance, diff)
+	}
+}
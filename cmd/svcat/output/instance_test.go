@@ -21,7 +21,9 @@ import (
 	"testing"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	servicecatalog "github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	"github.com/olekukonko/tablewriter"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_appendInstanceDashboardURL(t *testing.T) {
@@ -53,3 +55,215 @@ func Test_appendInstanceDashboardURL(t *testing.T) {
 		})
 	}
 }
+
+func TestGetInstanceStatusShort(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []v1beta1.ServiceInstanceCondition
+		expected   string
+	}{
+		{"no conditions", nil, ""},
+		{"ready", []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+		}, "Ready"},
+		{"failed", []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionFalse},
+			{Type: v1beta1.ServiceInstanceConditionFailed, Status: v1beta1.ConditionTrue, Reason: "ProvisionCallFailed"},
+		}, "Failed"},
+		{"ready with a deprecated plan", []v1beta1.ServiceInstanceCondition{
+			{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue},
+			{Type: v1beta1.ServiceInstanceConditionPlanDeprecated, Status: v1beta1.ConditionTrue, Reason: "PlanDeprecated"},
+		}, "Ready"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := v1beta1.ServiceInstanceStatus{Conditions: tt.conditions}
+			if actual := getInstanceStatusShort(status); actual != tt.expected {
+				t.Fatalf("expected status %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWriteInstanceDeletionProgress(t *testing.T) {
+	tests := []struct {
+		name           string
+		condition      v1beta1.ServiceInstanceCondition
+		expectedString string
+	}{
+		{"blockedByBindings", v1beta1.ServiceInstanceCondition{
+			Type:   v1beta1.ServiceInstanceConditionReady,
+			Status: v1beta1.ConditionFalse,
+			Reason: "DeprovisionBlockedByExistingCredentials",
+		}, "Waiting for the bindings of the instance to be deleted..."},
+		{"deprovisioning", v1beta1.ServiceInstanceCondition{
+			Type:   v1beta1.ServiceInstanceConditionReady,
+			Status: v1beta1.ConditionFalse,
+			Reason: "Deprovisioning",
+		}, "Waiting for the instance to be deprovisioned (Deprovisioning)..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &v1beta1.ServiceInstance{
+				Status: v1beta1.ServiceInstanceStatus{
+					Conditions: []v1beta1.ServiceInstanceCondition{tt.condition},
+				},
+			}
+			var stringBuilder strings.Builder
+			WriteInstanceDeletionProgress(&stringBuilder, instance)
+			actualString := strings.Trim(stringBuilder.String(), " \n")
+
+			if actualString != tt.expectedString {
+				t.Fatalf("%v failed; expected %v; got %v", tt.name, tt.expectedString, actualString)
+			}
+		})
+	}
+}
+
+func TestWriteAssociatedInstancesSummary(t *testing.T) {
+	instance := func(status v1beta1.ConditionStatus, reason string) v1beta1.ServiceInstance {
+		return v1beta1.ServiceInstance{
+			Status: v1beta1.ServiceInstanceStatus{
+				Conditions: []v1beta1.ServiceInstanceCondition{{
+					Type:   v1beta1.ServiceInstanceConditionReady,
+					Status: status,
+					Reason: reason,
+				}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		instances      []v1beta1.ServiceInstance
+		expectedString string
+	}{
+		{"none", nil, "Instances: none"},
+		{"byStatus", []v1beta1.ServiceInstance{
+			instance(v1beta1.ConditionTrue, "ProvisionedSuccessfully"),
+			instance(v1beta1.ConditionFalse, "Provisioning"),
+			instance(v1beta1.ConditionTrue, "ProvisionedSuccessfully"),
+		}, "Instances: 3 (1 Provisioning, 2 Ready)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stringBuilder strings.Builder
+			WriteAssociatedInstancesSummary(&stringBuilder, tt.instances)
+			actualString := strings.Trim(stringBuilder.String(), " \n")
+
+			if actualString != tt.expectedString {
+				t.Fatalf("%v failed; expected %v; got %v", tt.name, tt.expectedString, actualString)
+			}
+		})
+	}
+}
+
+func TestWriteInstanceListWithClassPlans(t *testing.T) {
+	classes := []servicecatalog.Class{
+		&v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-class-id"},
+			Spec:       v1beta1.ClusterServiceClassSpec{CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "mysql"}},
+		},
+		&v1beta1.ServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "ns-class-id", Namespace: "test-ns"},
+			Spec:       v1beta1.ServiceClassSpec{CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{ExternalName: "redis"}},
+		},
+	}
+	plans := []servicecatalog.Plan{
+		&v1beta1.ClusterServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-plan-id"},
+			Spec:       v1beta1.ClusterServicePlanSpec{CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "small"}},
+		},
+		&v1beta1.ServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "ns-plan-id", Namespace: "test-ns"},
+			Spec:       v1beta1.ServicePlanSpec{CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "large"}},
+		},
+	}
+	instances := &v1beta1.ServiceInstanceList{
+		Items: []v1beta1.ServiceInstance{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-cluster-name", Namespace: "test-ns"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ClusterServiceClassName: "cluster-class-id",
+						ClusterServicePlanName:  "cluster-plan-id",
+					},
+					ClusterServiceClassRef: &v1beta1.ClusterObjectReference{Name: "cluster-class-id"},
+					ClusterServicePlanRef:  &v1beta1.ClusterObjectReference{Name: "cluster-plan-id"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-namespaced-name", Namespace: "test-ns"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ServiceClassName: "ns-class-id",
+						ServicePlanName:  "ns-plan-id",
+					},
+					ServiceClassRef: &v1beta1.LocalObjectReference{Name: "ns-class-id"},
+					ServicePlanRef:  &v1beta1.LocalObjectReference{Name: "ns-plan-id"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "unresolved", Namespace: "test-ns"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ClusterServiceClassName: "missing-class-id",
+						ClusterServicePlanName:  "missing-plan-id",
+					},
+				},
+			},
+		},
+	}
+
+	var sb strings.Builder
+	WriteInstanceListWithClassPlans(&sb, FormatTable, TableOptions{}, instances, classes, plans)
+	got := sb.String()
+
+	for _, want := range []string{
+		"by-cluster-name      test-ns     mysql              small",
+		"by-namespaced-name   test-ns     redis              large",
+		"unresolved           test-ns     missing-class-id   missing-plan-id",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected the output to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteInstanceListLabelColumns(t *testing.T) {
+	instances := &v1beta1.ServiceInstanceList{
+		Items: []v1beta1.ServiceInstance{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "labeled",
+					Namespace: "test-ns",
+					Labels:    map[string]string{"team": "payments", "app.kubernetes.io/component": "checkout"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: "test-ns"},
+			},
+		},
+	}
+
+	for _, format := range []string{FormatTable, "custom-columns=NAME:.metadata.name"} {
+		t.Run(format, func(t *testing.T) {
+			var sb strings.Builder
+			WriteInstanceList(&sb, format, TableOptions{LabelColumns: []string{"team", "app.kubernetes.io/component"}}, instances)
+			lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+			if len(lines) != 4 {
+				t.Fatalf("expected a header, a separator and 2 rows, got:\n%s", sb.String())
+			}
+
+			if got := strings.Fields(lines[0]); got[len(got)-2] != "TEAM" || got[len(got)-1] != "COMPONENT" {
+				t.Errorf("expected the header to end with the label columns, got %q", lines[0])
+			}
+			if got := strings.Fields(lines[2]); got[len(got)-2] != "payments" || got[len(got)-1] != "checkout" {
+				t.Errorf("expected the row to end with the label values, got %q", lines[2])
+			}
+			if got := strings.Fields(lines[3]); got[len(got)-1] == "checkout" {
+				t.Errorf("expected the label columns of an unlabeled instance to be empty, got %q", lines[3])
+			}
+		})
+	}
+}
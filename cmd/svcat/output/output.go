@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 const (
@@ -31,12 +33,28 @@ const (
 )
 
 const (
+	// FormatCustomColumns is the --output flag value for printing a table
+	// of user-defined columns, followed by =<header>:<json-path-expr>,...
+	FormatCustomColumns = "custom-columns"
+
 	// FormatJSON is the --output flag value for json output.
 	FormatJSON = "json"
 
+	// FormatName is the --output flag value for printing only the name of
+	// each resource, one per line.
+	FormatName = "name"
+
+	// FormatStatusOnly is the --output flag value for printing only the
+	// overall status of each resource, one "name: status" line per resource.
+	FormatStatusOnly = "status-only"
+
 	// FormatTable is the --output flag value for tablular output.
 	FormatTable = "table"
 
+	// FormatWide is the --output flag value for tabular output with
+	// additional columns.
+	FormatWide = "wide"
+
 	// FormatYAML is the --output flag value for yaml output.
 	FormatYAML = "yaml"
 )
@@ -58,6 +76,18 @@ func formatStatusFull(condition string, conditionStatus v1beta1.ConditionStatus,
 	return fmt.Sprintf("%s - %s @ %s", status, message, timestamp.UTC())
 }
 
+// now returns the current time. Tests replace it to print stable ages.
+var now = time.Now
+
+// formatAge returns the age of a resource created at the given time, in the
+// same short form as kubectl, e.g. 5m or 3d.
+func formatAge(created v1.Time) string {
+	if created.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(now().Sub(created.Time))
+}
+
 // WriteDeletedResourceName prints the name of a deleted resource
 func WriteDeletedResourceName(w io.Writer, resourceName string) {
 	fmt.Fprintf(w, "deleted %s\n", resourceName)
@@ -17,6 +17,7 @@ limitations under the License.
 package output
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -24,6 +25,7 @@ import (
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func getPlanStatusShort(status v1beta1.ClusterServicePlanStatus) string {
@@ -47,24 +49,94 @@ func (a byClass) Less(i, j int) bool {
 	return a[i].GetClassID() < a[j].GetClassID()
 }
 
-func writePlanListTable(w io.Writer, plans []servicecatalog.Plan, classNames map[string]string) {
+// planSchemas tells whether a plan has schemas for the parameters of its
+// instances and bindings, the ones printed by WritePlanSchemas.
+type planSchemas struct {
+	HasInstanceSchema bool `json:"hasInstanceSchema"`
+	HasBindingSchema  bool `json:"hasBindingSchema"`
+}
+
+func getPlanSchemas(plan servicecatalog.Plan) planSchemas {
+	return planSchemas{
+		HasInstanceSchema: plan.GetInstanceCreateSchema() != nil || plan.GetInstanceUpdateSchema() != nil,
+		HasBindingSchema:  plan.GetBindingCreateSchema() != nil,
+	}
+}
+
+// getPlanParams returns the PARAMS column of the wide output of a plan,
+// listing the resources the plan has parameter schemas for.
+func getPlanParams(plan servicecatalog.Plan) string {
+	schemas := getPlanSchemas(plan)
+	switch {
+	case schemas.HasInstanceSchema && schemas.HasBindingSchema:
+		return "instance,binding"
+	case schemas.HasInstanceSchema:
+		return "instance"
+	case schemas.HasBindingSchema:
+		return "binding"
+	}
+	return "none"
+}
+
+// planWithSchemas returns a plan with the hasInstanceSchema and
+// hasBindingSchema fields added, for the JSON and YAML output formats.
+func planWithSchemas(plan servicecatalog.Plan) interface{} {
+	schemas := getPlanSchemas(plan)
+	switch p := plan.(type) {
+	case *v1beta1.ClusterServicePlan:
+		return struct {
+			*v1beta1.ClusterServicePlan
+			planSchemas
+		}{p, schemas}
+	case *v1beta1.ServicePlan:
+		return struct {
+			*v1beta1.ServicePlan
+			planSchemas
+		}{p, schemas}
+	}
+	return plan
+}
+
+func plansWithSchemas(plans []servicecatalog.Plan) []interface{} {
+	objs := make([]interface{}, 0, len(plans))
+	for _, plan := range plans {
+		objs = append(objs, planWithSchemas(plan))
+	}
+	return objs
+}
+
+func writePlanListTable(w io.Writer, opts TableOptions, plans []servicecatalog.Plan, classNames map[string]string, wide, showRemoved bool) {
 
 	sort.Sort(byClass(plans))
 
-	t := NewListTable(w)
-	t.SetHeader([]string{
+	t := newListTable(w, opts)
+	header := []string{
 		"Name",
 		"Namespace",
 		"Class",
 		"Description",
-	})
+	}
+	if wide {
+		header = append(header, "Params")
+	}
+	if showRemoved {
+		header = append(header, "Removed")
+	}
+	t.SetHeader(header)
 	for _, plan := range plans {
-		t.Append([]string{
+		row := []string{
 			plan.GetExternalName(),
 			plan.GetNamespace(),
 			classNames[plan.GetClassID()],
 			plan.GetDescription(),
-		})
+		}
+		if wide {
+			row = append(row, getPlanParams(plan))
+		}
+		if showRemoved {
+			row = append(row, strconv.FormatBool(servicecatalog.IsPlanRemoved(plan)))
+		}
+		t.AppendWithLabels(row, plan.GetLabels())
 	}
 	t.SetVariableColumn(4)
 
@@ -72,58 +144,113 @@ func writePlanListTable(w io.Writer, plans []servicecatalog.Plan, classNames map
 }
 
 // WritePlanList prints a list of plans in the specified output format.
-func WritePlanList(w io.Writer, outputFormat string, plans []servicecatalog.Plan, classes []servicecatalog.Class) {
+func WritePlanList(w io.Writer, outputFormat string, opts TableOptions, plans []servicecatalog.Plan, classes []servicecatalog.Class) {
+	writePlanList(w, outputFormat, opts, plans, classes, false)
+}
+
+// WritePlanListWithRemoved prints a list of plans like WritePlanList, adding a
+// column to the table and wide formats that shows whether their broker
+// removed them from its catalog.
+func WritePlanListWithRemoved(w io.Writer, outputFormat string, opts TableOptions, plans []servicecatalog.Plan, classes []servicecatalog.Class) {
+	writePlanList(w, outputFormat, opts, plans, classes, true)
+}
+
+func writePlanList(w io.Writer, outputFormat string, opts TableOptions, plans []servicecatalog.Plan, classes []servicecatalog.Class, showRemoved bool) {
 	classNames := map[string]string{}
 	for _, class := range classes {
 		classNames[class.GetName()] = class.GetExternalName()
 	}
 	switch outputFormat {
 	case FormatJSON:
-		writeJSON(w, plans)
+		writeJSON(w, plansWithSchemas(plans))
 	case FormatYAML:
-		writeYAML(w, plans, 0)
+		writeYAML(w, plansWithSchemas(plans), 0)
 	case FormatTable:
-		writePlanListTable(w, plans, classNames)
+		writePlanListTable(w, opts, plans, classNames, false, showRemoved)
+	case FormatWide:
+		writePlanListTable(w, opts, plans, classNames, true, showRemoved)
+	case FormatName:
+		names := make([]string, 0, len(plans))
+		for _, plan := range plans {
+			names = append(names, planName(plan, classNames))
+		}
+		writeNames(w, names...)
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, plans)
+		}
 	}
 }
 
 // WritePlan prints a single plan in the specified output format.
-func WritePlan(w io.Writer, outputFormat string, plan servicecatalog.Plan, class v1beta1.ClusterServiceClass) {
+func WritePlan(w io.Writer, outputFormat string, opts TableOptions, plan servicecatalog.Plan, class v1beta1.ClusterServiceClass) {
 
 	switch outputFormat {
 	case FormatJSON:
-		writeJSON(w, plan)
+		writeJSON(w, planWithSchemas(plan))
 	case FormatYAML:
-		writeYAML(w, plan, 0)
-	case FormatTable:
+		writeYAML(w, planWithSchemas(plan), 0)
+	case FormatTable, FormatWide:
 		classNames := map[string]string{}
 		classNames[class.Name] = class.Spec.ExternalName
-		writePlanListTable(w, []servicecatalog.Plan{plan}, classNames)
+		writePlanListTable(w, opts, []servicecatalog.Plan{plan}, classNames, outputFormat == FormatWide, false)
+	case FormatName:
+		writeNames(w, planName(plan, map[string]string{class.Name: class.Spec.ExternalName}))
+	default:
+		if IsCustomColumns(outputFormat) {
+			writeCustomColumns(w, outputFormat, opts, []servicecatalog.Plan{plan})
+		}
 	}
 }
 
+// planName returns the CLASS/PLAN name of a plan, or only the name of the
+// plan when its class is not known.
+func planName(plan servicecatalog.Plan, classNames map[string]string) string {
+	if className, ok := classNames[plan.GetClassID()]; ok && className != "" {
+		return className + "/" + plan.GetExternalName()
+	}
+	return plan.GetExternalName()
+}
+
 // WriteAssociatedPlans prints a list of plans associated with a class.
-func WriteAssociatedPlans(w io.Writer, plans []servicecatalog.Plan) {
+func WriteAssociatedPlans(w io.Writer, class servicecatalog.Class, plans []servicecatalog.Plan) {
 	fmt.Fprintln(w, "\nPlans:")
 	if len(plans) == 0 {
 		fmt.Fprintln(w, "No plans defined")
 		return
 	}
 
+	classSpec := class.GetSpec()
 	t := NewListTable(w)
 	t.SetHeader([]string{
 		"Name",
 		"Description",
+		"Free",
+		"Bindable",
 	})
 	for _, plan := range plans {
 		t.Append([]string{
 			plan.GetExternalName(),
 			plan.GetDescription(),
+			strconv.FormatBool(plan.GetFree()),
+			strconv.FormatBool(isPlanBindable(&classSpec, plan)),
 		})
 	}
 	t.Render()
 }
 
+// isPlanBindable returns whether instances of a plan of the given class can be
+// bound.
+func isPlanBindable(classSpec *v1beta1.CommonServiceClassSpec, plan servicecatalog.Plan) bool {
+	switch p := plan.(type) {
+	case *v1beta1.ClusterServicePlan:
+		return v1beta1.IsPlanBindable(classSpec, &p.Spec.CommonServicePlanSpec)
+	case *v1beta1.ServicePlan:
+		return v1beta1.IsPlanBindable(classSpec, &p.Spec.CommonServicePlanSpec)
+	}
+	return classSpec.Bindable
+}
+
 // WriteParentPlan prints identifying information for a parent class.
 func WriteParentPlan(w io.Writer, plan *v1beta1.ClusterServicePlan) {
 	fmt.Fprintln(w, "\nPlan:")
@@ -162,10 +289,11 @@ func WriteDefaultProvisionParameters(w io.Writer, plan servicecatalog.Plan) {
 	}
 }
 
-// WritePlanSchemas prints the schemas for a single plan.
+// WritePlanSchemas prints the instance create and binding create schemas for
+// a single plan. The instance update schema is printed by
+// WritePlanUpdateParameters.
 func WritePlanSchemas(w io.Writer, plan servicecatalog.Plan) {
 	instanceCreateSchema := plan.GetInstanceCreateSchema()
-	instanceUpdateSchema := plan.GetInstanceUpdateSchema()
 	bindingCreateSchema := plan.GetBindingCreateSchema()
 
 	if instanceCreateSchema != nil {
@@ -173,13 +301,47 @@ func WritePlanSchemas(w io.Writer, plan servicecatalog.Plan) {
 		writeYAML(w, instanceCreateSchema, 2)
 	}
 
-	if instanceUpdateSchema != nil {
-		fmt.Fprintln(w, "\nInstance Update Parameter Schema:")
-		writeYAML(w, instanceUpdateSchema, 2)
-	}
-
 	if bindingCreateSchema != nil {
 		fmt.Fprintln(w, "\nBinding Create Parameter Schema:")
 		writeYAML(w, bindingCreateSchema, 2)
 	}
 }
+
+// WritePlanUpdateParameters prints the default update parameters and the
+// instance update schema for a single plan, when it has an update schema. The
+// default update parameters are the defaults of the properties of the schema.
+func WritePlanUpdateParameters(w io.Writer, plan servicecatalog.Plan) {
+	instanceUpdateSchema := plan.GetInstanceUpdateSchema()
+	if instanceUpdateSchema == nil {
+		return
+	}
+
+	if defaults := getSchemaDefaults(instanceUpdateSchema); len(defaults) > 0 {
+		fmt.Fprintln(w, "\nDefault Update Parameters:")
+		writeYAML(w, defaults, 2)
+	}
+
+	fmt.Fprintln(w, "\nInstance Update Parameter Schema:")
+	writeYAML(w, instanceUpdateSchema, 2)
+}
+
+// getSchemaDefaults returns the default values of the top level properties of
+// a JSON schema, keyed by property name.
+func getSchemaDefaults(schema *runtime.RawExtension) map[string]interface{} {
+	var s struct {
+		Properties map[string]struct {
+			Default interface{} `json:"default"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(schema.Raw, &s); err != nil {
+		return nil
+	}
+
+	defaults := make(map[string]interface{})
+	for name, property := range s.Properties {
+		if property.Default != nil {
+			defaults[name] = property.Default
+		}
+	}
+	return defaults
+}
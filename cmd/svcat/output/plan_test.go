@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestWritePlanUpdateParameters(t *testing.T) {
+	testcases := []struct {
+		name     string
+		schema   string
+		expected string
+	}{
+		{
+			name:     "no update schema",
+			expected: "",
+		},
+		{
+			name:   "schema without defaults",
+			schema: `{"properties":{"size":{"type":"string"}},"type":"object"}`,
+			expected: `
+Instance Update Parameter Schema:
+  properties:
+    size:
+      type: string
+  type: object
+`,
+		},
+		{
+			name:   "schema with defaults",
+			schema: `{"properties":{"size":{"default":"small","type":"string"},"replicas":{"default":2,"type":"integer"},"name":{"type":"string"}},"type":"object"}`,
+			expected: `
+Default Update Parameters:
+  replicas: 2
+  size: small
+
+Instance Update Parameter Schema:
+  properties:
+    name:
+      type: string
+    replicas:
+      default: 2
+      type: integer
+    size:
+      default: small
+      type: string
+  type: object
+`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := &v1beta1.ClusterServicePlan{}
+			if tc.schema != "" {
+				plan.Spec.InstanceUpdateParameterSchema = &runtime.RawExtension{Raw: []byte(tc.schema)}
+			}
+
+			output := &bytes.Buffer{}
+			WritePlanUpdateParameters(output, plan)
+
+			if e, a := tc.expected, output.String(); strings.TrimSpace(e) != strings.TrimSpace(a) {
+				t.Errorf("unexpected output:\nexpected:\n%s\ngot:\n%s", e, a)
+			}
+		})
+	}
+}
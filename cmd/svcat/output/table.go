@@ -18,6 +18,7 @@ package output
 
 import (
 	"io"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 )
@@ -44,6 +45,8 @@ type ListTable struct {
 	pageWidth      int   // Defaults to 80
 	headers        []string
 	rows           [][]string
+	noHeaders      bool     // Omit the header row, see TableOptions
+	labelColumns   []string // Label keys shown as extra columns, see TableOptions
 }
 
 // SetBorder is a proxy/pass-thru to the tablewriter.Table's func
@@ -63,6 +66,14 @@ func (lt *ListTable) SetPageWidth(w int) { lt.pageWidth = w }
 
 // SetHeader tracks the width of each header value as we save them.
 func (lt *ListTable) SetHeader(keys []string) {
+	if lt.noHeaders {
+		return
+	}
+
+	for _, key := range lt.labelColumns {
+		keys = append(keys, labelColumnHeader(key))
+	}
+
 	// Expand our slice if needed
 	if tmp := (len(keys) - len(lt.columnWidths)); tmp > 0 {
 		lt.columnWidths = append(lt.columnWidths, make([]int, tmp)...)
@@ -80,8 +91,19 @@ func (lt *ListTable) SetHeader(keys []string) {
 
 // Append will look at each column in the row to see if it's longer than any
 // previous value, and save it if so. Then it saves the data for later
-// rendering.
+// rendering. When the table shows label columns, their cells are left empty,
+// see AppendWithLabels.
 func (lt *ListTable) Append(row []string) {
+	lt.AppendWithLabels(row, nil)
+}
+
+// AppendWithLabels appends a row for a resource with the given labels, adding
+// the values of the label columns of the table to the row.
+func (lt *ListTable) AppendWithLabels(row []string, labels map[string]string) {
+	for _, key := range lt.labelColumns {
+		row = append(row, labels[key])
+	}
+
 	// Expand our slice if needed
 	if tmp := (len(row) - len(lt.columnWidths)); tmp > 0 {
 		lt.columnWidths = append(lt.columnWidths, make([]int, tmp)...)
@@ -126,22 +148,48 @@ func (lt *ListTable) Render() {
 	}
 
 	// Pass along all of the data (header and rows) to the real tablewriter
-	lt.table.SetHeader(lt.headers)
+	if len(lt.headers) > 0 {
+		lt.table.SetHeader(lt.headers)
+	}
 	for _, row := range lt.rows {
 		lt.table.Append(row)
 	}
 	lt.table.Render()
 }
 
+// TableOptions are the options of the list tables printed by the table, wide
+// and custom-columns output formats of the Write functions.
+type TableOptions struct {
+	// NoHeaders omits the header row.
+	NoHeaders bool
+	// LabelColumns are label keys whose values for each resource are shown
+	// as extra columns, like kubectl get --label-columns.
+	LabelColumns []string
+}
+
+// labelColumnHeader returns the header of the column of a label key, which
+// omits the prefix of the key like kubectl does.
+func labelColumnHeader(key string) string {
+	return key[strings.LastIndex(key, "/")+1:]
+}
+
 // NewListTable builds a table formatted to list a set of results.
 func NewListTable(w io.Writer) *ListTable {
+	return newListTable(w, TableOptions{})
+}
+
+// newListTable builds a table formatted to list a set of results, with the
+// given options.
+func newListTable(w io.Writer, opts TableOptions) *ListTable {
 	t := tablewriter.NewWriter(w)
 	t.SetBorder(false)
 	t.SetColumnSeparator(" ")
 
 	return &ListTable{
-		table:     t,
-		pageWidth: DefaultPageWidth,
+		table:        t,
+		pageWidth:    DefaultPageWidth,
+		noHeaders:    opts.NoHeaders,
+		labelColumns: opts.LabelColumns,
 	}
 }
 
@@ -26,11 +26,21 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	// instancesFull lists the instances of the plan.
+	instancesFull = "full"
+	// instancesSummary only counts the instances of the plan.
+	instancesSummary = "summary"
+	// instancesNone leaves the instances of the plan out.
+	instancesNone = "none"
+)
+
 type describeCmd struct {
 	*command.Namespaced
 	*command.Scoped
 	lookupByKubeName bool
 	showSchemas      bool
+	instances        string
 	kubeName         string
 	name             string
 }
@@ -50,6 +60,7 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
   svcat describe plan --kube-name 08e4b43a-36bc-447e-a81f-8202b13e339c
   svcat describe plan PLAN_NAME --scope cluster
   svcat describe plan PLAN_NAME --scope namespace --namespace NAMESPACE_NAME
+  svcat describe plan standard800 --instances summary
 `),
 		PreRunE: command.PreRunE(describeCmd),
 		RunE:    command.RunE(describeCmd),
@@ -66,7 +77,13 @@ func NewDescribeCmd(cxt *command.Context) *cobra.Command {
 		"show-schemas",
 		"",
 		true,
-		"Whether or not to show instance and binding parameter schemas",
+		"Whether or not to show instance and binding parameter schemas, and the default update parameters",
+	)
+	cmd.Flags().StringVar(
+		&describeCmd.instances,
+		"instances",
+		instancesFull,
+		"How to show the instances of the plan. Valid options are full, to list them, summary, to only count them, or none",
 	)
 	describeCmd.AddNamespaceFlags(cmd.Flags(), false)
 	describeCmd.AddScopedFlags(cmd.Flags(), false)
@@ -79,6 +96,12 @@ func (c *describeCmd) Validate(args []string) error {
 		return fmt.Errorf("a plan name or Kubernetes name is required")
 	}
 
+	switch c.instances {
+	case instancesFull, instancesSummary, instancesNone:
+	default:
+		return fmt.Errorf("invalid --instances %q, allowed values are: full, summary and none", c.instances)
+	}
+
 	if c.lookupByKubeName {
 		c.kubeName = args[0]
 	} else {
@@ -127,14 +150,21 @@ func (c *describeCmd) describe() error {
 
 	output.WriteDefaultProvisionParameters(c.Output, plan)
 
-	instances, err := c.App.RetrieveInstancesByPlan(plan)
-	if err != nil {
-		return err
+	if c.instances != instancesNone {
+		instances, err := c.App.RetrieveInstancesByPlan(plan)
+		if err != nil {
+			return err
+		}
+		if c.instances == instancesSummary {
+			output.WriteAssociatedInstancesSummary(c.Output, instances)
+		} else {
+			output.WriteAssociatedInstances(c.Output, instances)
+		}
 	}
-	output.WriteAssociatedInstances(c.Output, instances)
 
 	if c.showSchemas {
 		output.WritePlanSchemas(c.Output, plan)
+		output.WritePlanUpdateParameters(c.Output, plan)
 	}
 
 	return nil
@@ -30,6 +30,8 @@ type getCmd struct {
 	*command.Namespaced
 	*command.Scoped
 	*command.Formatted
+	*command.Selected
+	*command.Paged
 	lookupByKubeName bool
 	kubeName         string
 	name             string
@@ -37,6 +39,10 @@ type getCmd struct {
 	classFilter   string
 	classKubeName string
 	className     string
+
+	// showRemoved is set when plans that were removed from their broker's
+	// catalog should be listed too.
+	showRemoved bool
 }
 
 // NewGetCmd builds a "svcat get plans" command
@@ -44,7 +50,9 @@ func NewGetCmd(ctx *command.Context) *cobra.Command {
 	getCmd := &getCmd{
 		Namespaced: command.NewNamespaced(ctx),
 		Scoped:     command.NewScoped(),
-		Formatted:  command.NewFormatted(),
+		Formatted:  command.NewWideFormatted(),
+		Selected:   command.NewSelected(),
+		Paged:      command.NewPaged(),
 	}
 	cmd := &cobra.Command{
 		Use:     "plans [NAME]",
@@ -54,6 +62,10 @@ func NewGetCmd(ctx *command.Context) *cobra.Command {
   svcat get plans
   svcat get plans --scope cluster
   svcat get plans --scope namespace --namespace dev
+  svcat get plans --selector tier=gold
+  svcat get plans --show-removed
+  svcat get plans --limit 50
+  svcat get plans -o wide
   svcat get plan PLAN_NAME
   svcat get plan CLASS_NAME/PLAN_NAME
   svcat get plan --kube-name PLAN_KUBE_NAME
@@ -79,9 +91,23 @@ func NewGetCmd(ctx *command.Context) *cobra.Command {
 		"",
 		"Filter plans based on class. When --kube-name is specified, the class name is interpreted as a kubernetes name.",
 	)
+	cmd.Flags().Bool(
+		"available",
+		false,
+		"Deprecated, plans removed from their broker's catalog are only listed with --show-removed",
+	)
+	cmd.Flags().MarkDeprecated("available", "plans removed from their broker's catalog are no longer listed by default, use --show-removed to list them")
+	cmd.Flags().BoolVar(
+		&getCmd.showRemoved,
+		"show-removed",
+		false,
+		"If present, also list the plans removed from their broker's catalog, with a column showing whether each plan was removed",
+	)
 	getCmd.AddOutputFlags(cmd.Flags())
 	getCmd.AddNamespaceFlags(cmd.Flags(), true)
 	getCmd.AddScopedFlags(cmd.Flags(), true)
+	getCmd.AddSelectorFlag(cmd)
+	getCmd.AddPagingFlags(cmd)
 	return cmd
 }
 
@@ -100,6 +126,9 @@ func (c *getCmd) Validate(args []string) error {
 			c.name = args[0]
 		}
 	}
+	if c.showRemoved && (c.kubeName != "" || c.name != "") {
+		return fmt.Errorf("--show-removed is not supported when specifying plan name")
+	}
 	if c.classFilter != "" {
 		if c.lookupByKubeName {
 			c.classKubeName = c.classFilter
@@ -137,8 +166,14 @@ func (c *getCmd) getAll() error {
 
 	var classID string
 	opts := servicecatalog.ScopeOptions{
-		Namespace: c.Namespace,
-		Scope:     c.Scope,
+		Namespace:      c.Namespace,
+		Scope:          c.Scope,
+		LabelSelector:  c.LabelSelector,
+		FieldSelector:  c.FieldSelector,
+		Limit:          c.Limit,
+		Continue:       c.Continue,
+		ChunkSize:      c.ChunkSize,
+		ExcludeRemoved: !c.showRemoved,
 	}
 	if c.classFilter != "" {
 		if !c.lookupByKubeName {
@@ -153,13 +188,18 @@ func (c *getCmd) getAll() error {
 		classID = c.classKubeName
 	}
 
-	plans, err := c.App.RetrievePlans(classID, opts)
+	plans, next, err := c.App.RetrievePlansPage(classID, opts)
 	fmt.Println("PLANS: ", plans)
 	if err != nil {
 		return fmt.Errorf("unable to list plans (%s)", err)
 	}
 
-	output.WritePlanList(c.Output, c.OutputFormat, plans, classes)
+	if c.showRemoved {
+		output.WritePlanListWithRemoved(c.Output, c.OutputFormat, c.TableOptions(), plans, classes)
+	} else {
+		output.WritePlanList(c.Output, c.OutputFormat, c.TableOptions(), plans, classes)
+	}
+	c.WriteContinueHint(c.Output, c.OutputFormat, next)
 	return nil
 }
 
@@ -192,7 +232,7 @@ func (c *getCmd) get() error {
 		return err
 	}
 
-	output.WritePlan(c.Output, c.OutputFormat, plan, *class)
+	output.WritePlan(c.Output, c.OutputFormat, c.TableOptions(), plan, *class)
 
 	return nil
 }
@@ -156,6 +156,8 @@ func TestListPlans(t *testing.T) {
 				Namespaced: command.NewNamespaced(cxt),
 				Scoped:     command.NewScoped(),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
 			}
 			cmd.Namespace = ns
 			cmd.Scope = tc.scope
@@ -208,7 +210,7 @@ var _ = Describe("Get Plans Command", func() {
 		})
 	})
 	Describe("Run", func() {
-		It("Calls the pkg/svcat libs RetrievePlans with namespace scope and current namespace", func() {
+		It("Calls the pkg/svcat libs RetrievePlansPage with namespace scope and current namespace", func() {
 			planName := "myplan"
 			planNamespace := "default"
 
@@ -227,28 +229,31 @@ var _ = Describe("Get Plans Command", func() {
 
 			fakeApp, _ := svcat.NewApp(nil, nil, "default")
 			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
-			fakeSDK.RetrievePlansReturns([]servicecatalog.Plan{planToReturn}, nil)
+			fakeSDK.RetrievePlansPageReturns([]servicecatalog.Plan{planToReturn}, "", nil)
 			fakeApp.SvcatClient = fakeSDK
 			cmd := getCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
 				Scoped:     command.NewScoped(),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
 			}
 			cmd.Scope = servicecatalog.NamespaceScope
 			cmd.Namespace = planNamespace
 			err := cmd.Run()
 
 			Expect(err).NotTo(HaveOccurred())
-			_, scopeArg := fakeSDK.RetrievePlansArgsForCall(0)
+			_, scopeArg := fakeSDK.RetrievePlansPageArgsForCall(0)
 			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
-				Scope:     servicecatalog.NamespaceScope,
-				Namespace: planNamespace,
+				Scope:          servicecatalog.NamespaceScope,
+				Namespace:      planNamespace,
+				ExcludeRemoved: true,
 			}))
 
 			output := outputBuffer.String()
 			Expect(output).To(ContainSubstring(planName))
 		})
-		It("Calls the pkg/svcat libs RetrievePlans with namespace scope and all namespaces", func() {
+		It("Calls the pkg/svcat libs RetrievePlansPage with namespace scope and all namespaces", func() {
 			planOneName := "myplan"
 			planOneNamespace := "default"
 
@@ -281,29 +286,32 @@ var _ = Describe("Get Plans Command", func() {
 
 			fakeApp, _ := svcat.NewApp(nil, nil, "default")
 			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
-			fakeSDK.RetrievePlansReturns([]servicecatalog.Plan{planOneToReturn, planTwoToReturn}, nil)
+			fakeSDK.RetrievePlansPageReturns([]servicecatalog.Plan{planOneToReturn, planTwoToReturn}, "", nil)
 			fakeApp.SvcatClient = fakeSDK
 			cmd := getCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
 				Scoped:     command.NewScoped(),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
 			}
 			cmd.Scope = servicecatalog.NamespaceScope
 			cmd.Namespace = ""
 			err := cmd.Run()
 
 			Expect(err).NotTo(HaveOccurred())
-			_, scopeArg := fakeSDK.RetrievePlansArgsForCall(0)
+			_, scopeArg := fakeSDK.RetrievePlansPageArgsForCall(0)
 			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
-				Scope:     servicecatalog.NamespaceScope,
-				Namespace: "",
+				Scope:          servicecatalog.NamespaceScope,
+				Namespace:      "",
+				ExcludeRemoved: true,
 			}))
 
 			output := outputBuffer.String()
 			Expect(output).To(ContainSubstring(planOneName))
 			Expect(output).To(ContainSubstring(planTwoName))
 		})
-		It("Calls the pkg/svcat libs RetrievePlans with all scope and current namespaces", func() {
+		It("Calls the pkg/svcat libs RetrievePlansPage with all scope and current namespaces", func() {
 			planOneName := "myplan"
 
 			planTwoName := "anotherplan"
@@ -332,22 +340,25 @@ var _ = Describe("Get Plans Command", func() {
 
 			fakeApp, _ := svcat.NewApp(nil, nil, "default")
 			fakeSDK := new(servicecatalogfakes.FakeSvcatClient)
-			fakeSDK.RetrievePlansReturns([]servicecatalog.Plan{planOneToReturn, planTwoToReturn}, nil)
+			fakeSDK.RetrievePlansPageReturns([]servicecatalog.Plan{planOneToReturn, planTwoToReturn}, "", nil)
 			fakeApp.SvcatClient = fakeSDK
 			cmd := getCmd{
 				Namespaced: &command.Namespaced{Context: svcattest.NewContext(outputBuffer, fakeApp)},
 				Scoped:     command.NewScoped(),
 				Formatted:  command.NewFormatted(),
+				Selected:   command.NewSelected(),
+				Paged:      command.NewPaged(),
 			}
 			cmd.Scope = servicecatalog.AllScope
 			cmd.Namespace = planTwoNamespace
 			err := cmd.Run()
 
 			Expect(err).NotTo(HaveOccurred())
-			_, scopeArg := fakeSDK.RetrievePlansArgsForCall(0)
+			_, scopeArg := fakeSDK.RetrievePlansPageArgsForCall(0)
 			Expect(scopeArg).To(Equal(servicecatalog.ScopeOptions{
-				Scope:     servicecatalog.AllScope,
-				Namespace: planTwoNamespace,
+				Scope:          servicecatalog.AllScope,
+				Namespace:      planTwoNamespace,
+				ExcludeRemoved: true,
 			}))
 
 			output := outputBuffer.String()
@@ -25,10 +25,12 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"text/template"
 
@@ -47,6 +49,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	clientgotesting "k8s.io/client-go/testing"
 	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/klog"
@@ -151,23 +154,72 @@ func TestCommandValidation(t *testing.T) {
 		{"describe broker requires name", "describe broker", "a broker name is required"},
 		{"describe class requires name", "describe class", "a class name or Kubernetes name is required"},
 		{"describe plan requires name", "describe plan", "a plan name or Kubernetes name is required"},
+		{"describe plan requires a valid instances option", "describe plan default --instances some", "invalid --instances \"some\""},
 		{"describe instance requires name", "describe instance", "an instance name is required"},
 		{"describe binding requires name", "describe binding", "a binding name is required"},
 		{"bind requires arg", "bind", "an instance name is required"},
 		{"unbind requires arg", "unbind", "an instance or binding name is required"},
+		{"touch binding requires arg", "touch binding", "a binding name is required"},
 		{"sync requires names", "sync broker", "a broker name is required"},
 		{"deprovision requires name", "deprovision", "an instance name is required"},
 		{"provision does not accept --param and --params-json",
 			`provision name --class class --plan plan --params-json '{}' --param k=v`,
 			"--params-json cannot be used with --param"},
+		{"provision requires --class and --plan",
+			"provision name --class class",
+			"--class and --plan are required"},
+		{"provision does not accept --class or --plan with --from-instance",
+			"provision name --from-instance other --plan plan",
+			"--class and --plan cannot be used with --from-instance"},
 		{"bind does not accept --param and --params-json",
 			`bind name --params-json '{}' --param k=v`,
 			"--params-json cannot be used with --param"},
+		{"bind requires a valid binding name",
+			"bind name --name Invalid_Name",
+			"invalid --name value \"Invalid_Name\""},
+		{"bind requires --params-from-key with --params-from-secret",
+			"bind name --params-from-secret mysecret",
+			"--params-from-secret and --params-from-key must be provided together"},
+		{"get classes does not accept wide output", "get classes -o wide", "allowed values are: table, json, yaml, name and custom-columns"},
+		{"get instances does not accept status-only output", "get instances -o status-only", "allowed values are: table, json, yaml, name and custom-columns"},
+		{"get instances requires custom columns", "get instances -o custom-columns=", "no custom columns given"},
+		{"get instances requires a valid custom column spec", "get instances -o custom-columns=NAME", "expected <header>:<json-path-expr>"},
+		{"get instances requires a valid custom column JSONPath", "get instances -o custom-columns=NAME:{.metadata.name", "invalid JSONPath expression"},
+		{"get instances requires a valid selector", "get instances --selector app=(", "invalid --selector"},
+		{"get instances requires a valid field selector", "get instances --field-selector spec.externalID", "invalid --field-selector"},
+		{"get instance does not show class and plan names", "get instance ups-instance -n test-ns --show-class-plan", "show-class-plan is not supported when specifiying instance name"},
+		{"get instances shows parameters only in json and yaml", "get instances --show-params", "--show-params is only supported with the json and yaml output formats"},
+		{"get instances does not show parameters when watching", "get instances -o json --show-params --watch", "--show-params is not supported with --watch"},
+		{"get classes requires a non-negative limit", "get classes --limit -1", "invalid --limit -1"},
+		{"get instances requires a non-negative chunk size", "get instances --chunk-size -1", "invalid --chunk-size -1"},
+		{"get class by name does not accept tags", "get class user-provided-service --tag database", "--tag cannot be used when getting a class by name"},
+		{"get bindings does not accept age filters when watching", "get bindings -w --newer-than 1h", "--older-than and --newer-than are not supported with --watch"},
+		{"get instances requires a valid age", "get instances --older-than 30days", "invalid --older-than"},
+		{"get instances requires a positive age", "get instances --newer-than 0d", "invalid --newer-than"},
+		{"get instances requires an age range that can match", "get instances --older-than 30d --newer-than 7d", "--newer-than must be longer than --older-than"},
+		{"get instance by name does not support age filters", "get instance foo --older-than 30d", "--older-than and --newer-than are not supported when specifying instance name"},
+		{"get bindings does not accept a limit when watching", "get bindings -w --limit 10", "--limit and --continue are not supported with --watch"},
+		{"get instances does not accept a continue token when watching", "get instances --watch --continue abc", "--limit and --continue are not supported with --watch"},
+		{"register requires a valid relist behavior", "register ups-broker --url http://upsbroker.com --relist-behavior sometimes", "invalid --relist-behavior value \"sometimes\""},
+		{"register requires a positive relist duration", "register ups-broker --url http://upsbroker.com --relist-duration -5m", "invalid --relist-duration value -5m0s"},
+		{"register does not accept a relist duration with manual relists", "register ups-broker --url http://upsbroker.com --relist-behavior manual --relist-duration 1h", "--relist-duration cannot be used with --relist-behavior manual"},
+		{"register requires a parsable relist duration", "register ups-broker --url http://upsbroker.com --relist-duration soon", "invalid argument \"soon\" for \"--relist-duration\""},
+		{"export requires a resource type", "export", "a resource type is required"},
+		{"export requires a valid resource type", "export classes", "invalid resource type \"classes\""},
+		{"get plan by name does not support show-removed", "get plan default --show-removed", "--show-removed is not supported"},
+		{"get instance by name does not support plan-ref-resolved", "get instance foo --plan-ref-resolved=false", "plan-ref-resolved filter is not supported"},
+		{"get binding does not accept --instance with a name", "get binding mybinding --instance myinstance", "instance filter is not supported when specifying binding name"},
+		{"get events requires an object", "get events", "--for is required"},
+		{"get events requires a valid object", "get events --for ups-instance", "invalid --for \"ups-instance\", must be TYPE/NAME"},
+		{"get events requires a valid object type", "get events --for class/user-provided-service", "invalid --for type \"class\""},
+		{"diff instance requires a name", "diff instance", "an instance name is required"},
+		{"unknown kubeconfig context", "get brokers --context missing", "could not get Kubernetes config for context \"missing\""},
 		{"completion no shell specified", "completion", "Shell not specified"},
 		{"completion too many args", "completion arg0 arg1", "Too many arguments. Expected only the shell type"},
 		{"completion unsupported shell", "completion unsupportedShell", "Unsupported shell type \"unsupportedShell\""},
 		{"completion unsupported shell", "completion bash", ""},
 		{"completion unsupported shell", "completion zsh", ""},
+		{"completion unsupported shell", "completion fish", ""},
 	}
 
 	for _, tc := range testcases {
@@ -184,12 +236,19 @@ func TestCommandOutput(t *testing.T) {
 		golden          string // Relative path to a golden file, compared to the command output
 		continueOnError bool   // Should the test stop immediately if the command fails or continue and capture the console output
 	}{
+		{name: "export all", cmd: "export all -n test-ns", golden: "output/export-all.yaml"},
+		{name: "export instances in all namespaces", cmd: "export instances --all-namespaces", golden: "output/export-instances-all-namespaces.yaml"},
 		{name: "list all brokers", cmd: "get brokers", golden: "output/get-brokers.txt"},
+		{name: "list all brokers without headers", cmd: "get brokers --no-headers", golden: "output/get-brokers-no-headers.txt"},
 		{name: "list all brokers (json)", cmd: "get brokers -o json", golden: "output/get-brokers.json"},
+		{name: "list all brokers (name)", cmd: "get brokers -o name", golden: "output/get-brokers-name.txt"},
+		{name: "list all brokers (custom-columns)", cmd: "get brokers -o custom-columns=NAME:.metadata.name,URL:.spec.url", golden: "output/get-brokers-custom-columns.txt"},
 		{name: "list all brokers (yaml)", cmd: "get brokers -o yaml", golden: "output/get-brokers.yaml"},
+		{name: "list all brokers (wide)", cmd: "get brokers -o wide", golden: "output/get-brokers-wide.txt"},
 		{name: "get broker", cmd: "get broker ups-broker", golden: "output/get-broker.txt"},
 		{name: "get broker (json)", cmd: "get broker ups-broker -o json", golden: "output/get-broker.json"},
 		{name: "get broker (yaml)", cmd: "get broker ups-broker -o yaml", golden: "output/get-broker.yaml"},
+		{name: "get namespaced broker", cmd: "get broker ups-broker --scope namespace -n default", golden: "output/get-broker-namespaced.txt"},
 		{name: "describe broker", cmd: "describe broker ups-broker", golden: "output/describe-broker.txt"},
 		{name: "register broker", cmd: "register ups-broker --url http://upsbroker.com", golden: "output/register-broker.txt"},
 		{name: "deregister broker", cmd: "deregister ups-broker", golden: "output/deregister-broker.txt"},
@@ -197,7 +256,13 @@ func TestCommandOutput(t *testing.T) {
 		{name: "sync broker", cmd: "sync broker ups-broker", golden: "output/sync-broker.txt"},
 		{name: "sync broker in namespace", cmd: "sync broker ups-broker-ns -n test-ns", golden: "output/sync-broker-ns.txt"},
 		{name: "list all classes", cmd: "get classes", golden: "output/get-classes.txt"},
+		{name: "list all classes without headers", cmd: "get classes --no-headers", golden: "output/get-classes-no-headers.txt"},
+		{name: "list all classes including removed ones", cmd: "get classes --show-removed", golden: "output/get-classes-show-removed.txt"},
+		{name: "list classes with a tag", cmd: "get classes --tag User-Provided", golden: "output/get-classes-tag.txt"},
+		{name: "list classes of a broker", cmd: "get classes --broker namespaced-ups-broker", golden: "output/get-classes-broker.txt"},
 		{name: "list all classes (json)", cmd: "get classes -o json", golden: "output/get-classes.json"},
+		{name: "list all classes (name)", cmd: "get classes -o name", golden: "output/get-classes-name.txt"},
+		{name: "list all classes (custom-columns)", cmd: "get classes -o custom-columns=CLASS:.spec.externalName,BINDABLE:.spec.bindable", golden: "output/get-classes-custom-columns.txt"},
 		{name: "list all classes (yaml)", cmd: "get classes -o yaml", golden: "output/get-classes.yaml"},
 		{name: "get class by name", cmd: "get class user-provided-service", golden: "output/get-class.txt"},
 		{name: "get class not found（cluster scope）", cmd: "get class foo --scope cluster", golden: "output/get-class-not-found-cluster.txt", continueOnError: true},
@@ -208,13 +273,20 @@ func TestCommandOutput(t *testing.T) {
 		{name: "get class by Kubernetes name", cmd: "get class --kube-name 4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468", golden: "output/get-class.txt"},
 		{name: "describe class by name", cmd: "describe class user-provided-service", golden: "output/describe-class.txt"},
 		{name: "describe class by Kubernetes name", cmd: "describe class --kube-name 4f6e6cf6-ffdd-425f-a2c7-3c9258ad2468", golden: "output/describe-class.txt"},
+		{name: "describe class without plans", cmd: "describe class user-provided-service --show-plans=false", golden: "output/describe-class-no-plans.txt"},
 		{name: "create cluster class", cmd: "create class new-class --from user-provided-service --scope cluster", golden: "output/create-cluster-class.txt"},
 		{name: "create cluster class not found", cmd: "create class new-class --from foo --scope cluster", golden: "output/create-cluster-class-not-found.txt", continueOnError: true},
 		{name: "create namespace class", cmd: "create class new-class --from user-provided-namespaced-service --scope namespace --namespace default", golden: "output/create-namespace-class.txt"},
 		{name: "create namespace class not found", cmd: "create class new-class --from foo --scope namespace --namespace default", golden: "output/create-namespace-class-not-found.txt", continueOnError: true},
 
 		{name: "list all plans", cmd: "get plans", golden: "output/get-plans.txt"},
+		{name: "list available plans", cmd: "get plans --available", golden: "output/get-plans-available.txt"},
+		{name: "list all plans including removed ones", cmd: "get plans --show-removed", golden: "output/get-plans-show-removed.txt"},
+		{name: "list all plans including removed ones (wide)", cmd: "get plans --show-removed -o wide", golden: "output/get-plans-show-removed-wide.txt"},
 		{name: "list all plans (json)", cmd: "get plans -o json", golden: "output/get-plans.json"},
+		{name: "list all plans (name)", cmd: "get plans -o name", golden: "output/get-plans-name.txt"},
+		{name: "list all plans (wide)", cmd: "get plans -o wide", golden: "output/get-plans-wide.txt"},
+		{name: "list all plans (custom-columns)", cmd: "get plans -o custom-columns=PLAN:.spec.externalName,FREE:.spec.free", golden: "output/get-plans-custom-columns.txt"},
 		{name: "list all plans (yaml)", cmd: "get plans -o yaml", golden: "output/get-plans.yaml"},
 		{name: "list all namespaced plans", cmd: "get plans --scope namespace", golden: "output/get-namespaced-plans.txt"},
 		{name: "list all namespaced plans (json)", cmd: "get plans --scope namespace -o json", golden: "output/get-namespaced-plans.json"},
@@ -236,33 +308,67 @@ func TestCommandOutput(t *testing.T) {
 		{name: "describe namespace plan by class/plan name combo", cmd: "describe plan user-provided-namespaced-service/namespacedplan", golden: "output/describe-namespace-plan.txt"},
 		{name: "describe plan with schemas", cmd: "describe plan --scope cluster premium", golden: "output/describe-plan-with-schemas.txt"},
 		{name: "describe plan without schemas", cmd: "describe plan --scope cluster premium --show-schemas=false", golden: "output/describe-plan-without-schemas.txt"},
+		{name: "describe plan with an instance summary", cmd: "describe plan --scope cluster default --instances summary", golden: "output/describe-plan-instances-summary.txt"},
+		{name: "describe plan without instances", cmd: "describe plan --scope cluster default --instances none", golden: "output/describe-plan-instances-none.txt"},
 
 		{name: "list all instances in a namespace", cmd: "get instances -n test-ns", golden: "output/get-instances.txt"},
+		{name: "list all instances in a namespace without headers", cmd: "get instances -n test-ns --no-headers", golden: "output/get-instances-no-headers.txt"},
+		{name: "list all instances in a namespace with label columns", cmd: "get instances -n test-ns -L team", golden: "output/get-instances-label-columns.txt"},
 		{name: "list all instances in a namespace (json)", cmd: "get instances -n test-ns -o json", golden: "output/get-instances.json"},
+		{name: "list all instances in a namespace (name)", cmd: "get instances -n test-ns -o name", golden: "output/get-instances-name.txt"},
+		{name: "list all instances in a namespace (custom-columns)", cmd: "get instances -n test-ns -o custom-columns=NAME:.metadata.name,PLAN:.spec.clusterServicePlanExternalName,MISSING:.spec.missing", golden: "output/get-instances-custom-columns.txt"},
 		{name: "list all instances in a namespace (yaml)", cmd: "get instances -n test-ns -o yaml", golden: "output/get-instances.yaml"},
 		{name: "list all instances filtered by existing plan", cmd: "get instances --all-namespaces --plan default", golden: "output/get-instances-all-namespaces-by-plan.txt"},
 		{name: "list all instances filtered by not existing plan", cmd: "get instances --all-namespaces --plan wrong", golden: "output/get-instances-all-namespaces-by-wrong-plan.txt"},
+		{name: "list all instances older than 30 days", cmd: "get instances -n test-ns --older-than 30d", golden: "output/get-instances.txt"},
+		{name: "list all instances newer than 30 days", cmd: "get instances -n test-ns --newer-than 30d", golden: "output/get-instances-newer-than.txt"},
 		{name: "list all instances filtered by existing class", cmd: "get instances --all-namespaces --class user-provided-service", golden: "output/get-instances-all-namespaces-by-class.txt"},
 		{name: "list all instances filtered by not existing class", cmd: "get instances --all-namespaces --class wrong", golden: "output/get-instances-all-namespaces-by-wrong-class.txt"},
 		{name: "list all instances", cmd: "get instances --all-namespaces", golden: "output/get-instances-all-namespaces.txt"},
+		{name: "list all instances in chunks", cmd: "get instances --all-namespaces --chunk-size 1", golden: "output/get-instances-all-namespaces.txt"},
+		{name: "list all instances with resolved references", cmd: "get instances --all-namespaces --plan-ref-resolved", golden: "output/get-instances-all-namespaces-ref-resolved.txt"},
+		{name: "list all instances with unresolved references", cmd: "get instances --all-namespaces --plan-ref-resolved=false", golden: "output/get-instances-all-namespaces-ref-unresolved.txt"},
+		{name: "list all instances with class and plan names", cmd: "get instances --all-namespaces --show-class-plan", golden: "output/get-instances-all-namespaces-show-class-plan.txt"},
+		{name: "list all instances (json)", cmd: "get instances --all-namespaces -o json", golden: "output/get-instances-all-namespaces.json"},
 		{name: "get instance", cmd: "get instance ups-instance -n test-ns", golden: "output/get-instance.txt"},
 		{name: "get instance (json)", cmd: "get instance ups-instance -n test-ns -o json", golden: "output/get-instance.json"},
 		{name: "get instance (yaml)", cmd: "get instance ups-instance -n test-ns -o yaml", golden: "output/get-instance.yaml"},
-		{name: "describe instance", cmd: "describe instance ups-instance -n test-ns", golden: "output/describe-instance.txt"},
+		{name: "get instance with parameters (json)", cmd: "get instance ups-instance -n test-ns -o json --show-params", golden: "output/get-instance-show-params.json"},
+		{name: "get instance with parameters (yaml)", cmd: "get instance ups-instance -n test-ns -o yaml --show-params", golden: "output/get-instance-show-params.yaml"},
+		{name: "get instance (custom-columns)", cmd: "get instance ups-instance -n test-ns -o custom-columns=NAME:{.metadata.name},CLASS:.spec.clusterServiceClassExternalName", golden: "output/get-instance-custom-columns.txt"},
+		{name: "get instance with stale parameters", cmd: "get instance stale-instance -n test-ns", golden: "output/get-instance-parameters-stale.txt"},
+		{name: "describe instance with stale parameters", cmd: "describe instance stale-instance -n test-ns", golden: "output/describe-instance-parameters-stale.txt"},
+		{name: "describe instance", cmd: "describe instance ups-instance -n test-ns", golden: "output/describe-instance-no-bindings.txt"},
+		{name: "describe instance with bindings", cmd: "describe instance ups-instance -n test-ns --show-bindings", golden: "output/describe-instance.txt"},
 		{name: "bind instance", cmd: "bind ups-instance --name ups-binding -n test-ns", golden: "output/bind-instance.txt"},
 		{name: "bind instance and wait", cmd: "bind ups-instance --name ups-binding -n test-ns --wait", golden: "output/bind-instance-and-wait.txt"},
+		{name: "bind instance (json)", cmd: "bind ups-instance --name ups-binding -n test-ns -o json", golden: "output/bind-instance.json"},
+		{name: "bind instance (yaml)", cmd: "bind ups-instance --name ups-binding -n test-ns -o yaml", golden: "output/bind-instance.yaml"},
 		{name: "unbind instance", cmd: "unbind ups-instance -n test-ns", golden: "output/unbind-instance.txt"},
 		{name: "unbind instance and wait", cmd: "unbind ups-instance -n test-ns --wait", golden: "output/unbind-instance-and-wait.txt"},
 		{name: "provision instance", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default", golden: "output/provision-instance.txt"},
+		{name: "provision instance from another instance", cmd: "provision ups-instance-copy -n test-ns --from-instance ups-instance -p param1=override", golden: "output/provision-instance-from-instance.txt"},
 		{name: "provision instance and wait", cmd: "provision ups-instance -n test-ns --class user-provided-service --plan default --wait", golden: "output/provision-instance-and-wait.txt"},
 		{name: "deprovision instance", cmd: "deprovision ups-instance -n test-ns", golden: "output/deprovision-instance.txt"},
 		{name: "list all bindings in a namespace", cmd: "get bindings -n test-ns", golden: "output/get-bindings.txt"},
+		{name: "list all bindings older than 30 days", cmd: "get bindings -n test-ns --older-than 30d", golden: "output/get-bindings.txt"},
+		{name: "list the bindings of an instance older than 30 days", cmd: "get bindings -n test-ns --instance ups-instance --older-than 30d", golden: "output/get-bindings.txt"},
+		{name: "list the bindings of an instance without bindings", cmd: "get bindings -n test-ns --instance other-instance", golden: "output/get-bindings-other-instance.txt"},
+		{name: "list all bindings in a namespace (wide)", cmd: "get bindings -n test-ns -o wide", golden: "output/get-bindings-wide.txt"},
 		{name: "list all bindings in a namespace (json)", cmd: "get bindings -n test-ns -o json", golden: "output/get-bindings.json"},
+		{name: "list all bindings in a namespace (name)", cmd: "get bindings -n test-ns -o name", golden: "output/get-bindings-name.txt"},
+		{name: "list all bindings in a namespace (custom-columns)", cmd: "get bindings -n test-ns -o custom-columns=NAME:.metadata.name,SECRET:.spec.secretName", golden: "output/get-bindings-custom-columns.txt"},
 		{name: "list all bindings in a namespace (yaml)", cmd: "get bindings -n test-ns -o yaml", golden: "output/get-bindings.yaml"},
+		{name: "list all bindings in a namespace (status-only)", cmd: "get bindings -n test-ns -o status-only", golden: "output/get-bindings-status-only.txt"},
+		{name: "get binding (status-only)", cmd: "get binding ups-binding -n test-ns -o status-only", golden: "output/get-binding-status-only.txt"},
 		{name: "list all bindings", cmd: "get bindings --all-namespaces", golden: "output/get-bindings-all-namespaces.txt"},
 		{name: "get binding", cmd: "get binding ups-binding -n test-ns", golden: "output/get-binding.txt"},
 		{name: "get binding (json)", cmd: "get binding ups-binding -n test-ns -o json", golden: "output/get-binding.json"},
 		{name: "get binding (yaml)", cmd: "get binding ups-binding -n test-ns -o yaml", golden: "output/get-binding.yaml"},
+		{name: "get events of an instance", cmd: "get events --for instance/ups-instance -n test-ns", golden: "output/get-events.txt"},
+		{name: "get events of an instance (json)", cmd: "get events --for instance/ups-instance -n test-ns -o json", golden: "output/get-events.json"},
+		{name: "diff instance", cmd: "diff instance ups-instance -n test-ns", golden: "output/diff-instance.txt"},
+		{name: "diff instance (json)", cmd: "diff instance ups-instance -n test-ns -o json", golden: "output/diff-instance.json"},
 		{name: "describe binding", cmd: "describe binding ups-binding -n test-ns", golden: "output/describe-binding.txt"},
 		{name: "describe binding and decode secret", cmd: "describe binding ups-binding -n test-ns --show-secrets", golden: "output/describe-binding-show-secrets.txt"},
 		{name: "delete binding", cmd: "unbind --name ups-binding -n test-ns", golden: "output/delete-binding.txt"},
@@ -270,6 +376,7 @@ func TestCommandOutput(t *testing.T) {
 
 		{name: "completion bash", cmd: "completion bash", golden: "output/completion-bash.txt"},
 		{name: "completion zsh", cmd: "completion zsh", golden: "output/completion-zsh.txt"},
+		{name: "completion fish", cmd: "completion fish", golden: "output/completion-fish.txt"},
 	}
 
 	for _, tc := range testcases {
@@ -306,6 +413,52 @@ func TestGenerateManifest(t *testing.T) {
 	test.AssertEqualsGoldenFile(t, "plugin.yaml", string(got))
 }
 
+// TestKubeContext verifies that the selected kubeconfig and context are
+// recorded on the command context used by all subcommands.
+func TestKubeContext(t *testing.T) {
+	testcases := []struct {
+		name        string
+		cmd         string
+		wantContext string
+	}{
+		{"current context", "get brokers", "fakek8s"},
+		{"explicit context", "get brokers --context fakek8s", "fakek8s"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			apisvr := newAPIServer()
+			defer apisvr.Close()
+
+			kubeconfig, err := writeTestKubeconfig(apisvr.URL)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			defer os.Remove(kubeconfig)
+
+			cxt := newContext()
+			svcat, targetCmd, err := buildCommand(tc.cmd, cxt, kubeconfig)
+			if err != nil {
+				t.Fatalf("%+v", err)
+			}
+			targetCmd.RunE = func(cmd *cobra.Command, args []string) error {
+				return nil
+			}
+			svcat.SetOutput(&bytes.Buffer{})
+
+			if err := svcat.Execute(); err != nil {
+				t.Fatalf("%+v", err)
+			}
+			if cxt.KubeConfig != kubeconfig {
+				t.Errorf("expected KubeConfig %q, got %q", kubeconfig, cxt.KubeConfig)
+			}
+			if cxt.KubeContext != tc.wantContext {
+				t.Errorf("expected KubeContext %q, got %q", tc.wantContext, cxt.KubeContext)
+			}
+		})
+	}
+}
+
 // TestNamespacedCommands verifies that all commands that are namespace scoped
 // handle setting the namespace using the current context, --namespace and --all-namespaces flags.
 func TestNamespacedCommands(t *testing.T) {
@@ -321,6 +474,7 @@ func TestNamespacedCommands(t *testing.T) {
 		{name: "get instances with flag namespace", cmd: "get instances --namespace " + flagNS, wantNS: flagNS},
 		{name: "get instances with context namespace", cmd: "get instances", wantNS: contextNS},
 		{name: "get all instances", cmd: "get instances --all-namespaces", wantNS: allNS},
+		{name: "get all instances with shorthand", cmd: "get instances -A", wantNS: allNS},
 
 		{name: "describe instance with flag namespace", cmd: "describe instance NAME --namespace " + flagNS, wantNS: flagNS},
 		{name: "describe instance with context namespace", cmd: "describe instances NAME", wantNS: contextNS},
@@ -340,6 +494,7 @@ func TestNamespacedCommands(t *testing.T) {
 		{name: "get bindings with flag namespace", cmd: "get bindings --namespace " + flagNS, wantNS: flagNS},
 		{name: "get bindings with context namespace", cmd: "get bindings", wantNS: contextNS},
 		{name: "get all bindings", cmd: "get bindings --all-namespaces", wantNS: allNS},
+		{name: "get all bindings with shorthand", cmd: "get bindings -A", wantNS: allNS},
 
 		{name: "describe binding with flag namespace", cmd: "describe binding NAME --namespace " + flagNS, wantNS: flagNS},
 		{name: "describe binding with context namespace", cmd: "describe binding NAME", wantNS: contextNS},
@@ -626,8 +781,37 @@ func newContext() *command.Context {
 	}
 }
 
+// newAPIServer fakes the k8s api server. Resources deleted through it are
+// reported as not found afterwards, so that commands waiting for a resource
+// to be deleted complete.
 func newAPIServer() *httptest.Server {
-	return httptest.NewServer(http.HandlerFunc(apihandler))
+	var mutex sync.Mutex
+	deleted := map[string]bool{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		if r.Method == http.MethodDelete {
+			deleted[r.URL.Path] = true
+		}
+		isDeleted := r.Method == http.MethodGet && deleted[r.URL.Path]
+		mutex.Unlock()
+
+		if isDeleted {
+			status := k8serrors.NewNotFound(schema.GroupResource{}, path.Base(r.URL.Path)).Status()
+			body, err := json.Marshal(status)
+			if err != nil {
+				w.WriteHeader(500)
+				w.Write([]byte(err.Error()))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(body)
+			return
+		}
+
+		apihandler(w, r)
+	}))
 }
 
 // apihandler handles requests to the service catalog endpoint.
@@ -39,9 +39,11 @@ type ControllerManagerConfiguration struct {
 	// ContentType is the content type for requests sent to API servers.
 	ContentType string
 
-	// kubeAPIQPS is the QPS to use while talking with kubernetes apiserver.
+	// KubeAPIQPS is the QPS to use while talking with the kubernetes and
+	// service-catalog API servers.
 	KubeAPIQPS float32
-	// kubeAPIBurst is the burst to use while talking with kubernetes apiserver.
+	// KubeAPIBurst is the burst to use while talking with the kubernetes and
+	// service-catalog API servers.
 	KubeAPIBurst int32
 
 	// K8sAPIServerURL is the URL for the k8s API server.
@@ -68,15 +70,33 @@ type ControllerManagerConfiguration struct {
 	// all informers.
 	ResyncInterval time.Duration
 
+	// BrokerResyncInterval, InstanceResyncInterval and BindingResyncInterval
+	// override ResyncInterval for the informers of brokers, instances and
+	// bindings respectively. Zero uses ResyncInterval.
+	BrokerResyncInterval   time.Duration
+	InstanceResyncInterval time.Duration
+	BindingResyncInterval  time.Duration
+
 	// ServiceBrokerRelistInterval is the interval on which Broker's catalogs are re-
 	// listed.
 	ServiceBrokerRelistInterval time.Duration
 
+	// BrokerDefaultQPS is the maximum rate of calls per second made to each
+	// broker. Zero disables rate limiting.
+	BrokerDefaultQPS float32
+	// BrokerDefaultBurst is the maximum burst of calls made to each broker.
+	BrokerDefaultBurst int
+
 	// Whether or not to send the proposed optional
 	// OpenServiceBroker API Context Profile field
 	OSBAPIContextProfile   bool
 	OSBAPIPreferredVersion string
 
+	// BrokerUserAgent is the User-Agent sent with requests to brokers. If
+	// empty, one identifying the service catalog version and the cluster ID
+	// is sent.
+	BrokerUserAgent string
+
 	// ConcurrentSyncs is the number of resources, per resource type,
 	// that are allowed to sync concurrently. Larger number = more responsive
 	// SC operations, but more CPU (and network) load.
@@ -109,4 +129,53 @@ type ControllerManagerConfiguration struct {
 	ClusterIDConfigMapName string
 	// ClusterIDConfigMapNamespace is the k8s namespace that the clusterid configmap will be stored in.
 	ClusterIDConfigMapNamespace string
+
+	// SecretKeyCase is the case that the keys of binding credentials are
+	// converted to before they are written to the binding's secret.
+	SecretKeyCase string
+	// SecretKeyPrefix is prepended to the keys of binding credentials before
+	// they are written to the binding's secret.
+	SecretKeyPrefix string
+	// SanitizeSecretKeys replaces the characters of credential keys that are
+	// not valid in secret keys with underscores, instead of failing the
+	// binding.
+	SanitizeSecretKeys bool
+
+	// ForceDeleteFailedDeprovisionAttempts is the number of failed
+	// deprovision attempts after which the finalizer of a deleted instance is
+	// removed without deprovisioning it. Zero disables force deletion.
+	ForceDeleteFailedDeprovisionAttempts int
+	// ForceDeleteWindow is the time that must have elapsed since the deletion
+	// of an instance was requested before its finalizer is force removed.
+	ForceDeleteWindow time.Duration
+
+	// QuotaExceededBrokerErrors are the error codes, or fragments of the
+	// error descriptions, that brokers return for a provision when a quota
+	// was exceeded.
+	QuotaExceededBrokerErrors []string
+	// QuotaExceededRetryDelay is the minimum time waited before retrying a
+	// provision that failed because a quota was exceeded.
+	QuotaExceededRetryDelay time.Duration
+
+	// BrokerAuthSecretNamespaces are the namespaces, other than their own,
+	// in which namespaced brokers may reference their auth secrets.
+	BrokerAuthSecretNamespaces []string
+
+	// RequestContextNamespaceLabels are the keys of the namespace labels
+	// added to the context of the requests sent to brokers.
+	RequestContextNamespaceLabels []string
+	// RequestContextValues are custom KEY=VALUE entries added to the
+	// context of the requests sent to brokers.
+	RequestContextValues []string
+
+	// OrphanMitigationPolicy is the orphan mitigation mode of the instances
+	// that do not set their own: Automatic, Delayed or Manual.
+	OrphanMitigationPolicy string
+	// OrphanMitigationDelay is the time waited after a failed provision
+	// before deprovisioning an instance whose orphan mitigation is Delayed.
+	OrphanMitigationDelay time.Duration
+
+	// BindingSecretUpdateStrategy tells whether the existing secrets of
+	// bindings are updated in place or recreated: InPlace or Recreate.
+	BindingSecretUpdateStrategy string
 }
@@ -123,6 +123,10 @@ func servicecatalogFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
 			for bs.SecretName == "" {
 				bs.SecretName = c.RandString()
 			}
+			// The same goes for SecretType
+			for bs.SecretType == "" {
+				bs.SecretType = c.RandString()
+			}
 			parameters, err := createParameter(c)
 			if err != nil {
 				panic(fmt.Sprintf("Failed to create parameter object: %v", err))
@@ -80,6 +80,13 @@ type CommonServiceBrokerSpec struct {
 	// +optional
 	InsecureSkipTLSVerify bool
 
+	// AllowInsecure allows the Broker URL to use a scheme other than https.
+	// Without it, the controller will not send the Broker's credentials over
+	// an insecure connection. This is intended for local and development
+	// Brokers only.
+	// +optional
+	AllowInsecure bool
+
 	// CABundle is a PEM encoded CA bundle which will be used to validate a Broker's serving certificate.
 	// +optional
 	CABundle []byte
@@ -105,6 +112,19 @@ type CommonServiceBrokerSpec struct {
 	// CatalogRestrictions is a set of restrictions on which of a broker's services
 	// and plans have resources created for them.
 	CatalogRestrictions *CatalogRestrictions
+
+	// Quiesced stops the creation of new ServiceInstances of the broker's
+	// classes, while existing ServiceInstances and ServiceBindings keep
+	// being updated and deprovisioned as usual.
+	// +optional
+	Quiesced bool
+
+	// ProxyURL is the URL of the HTTP proxy that the controller uses to
+	// contact the broker, overriding the proxy configured by the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the
+	// controller. The environment variables are used when it is not set.
+	// +optional
+	ProxyURL string
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -153,11 +173,21 @@ type CommonServiceBrokerSpec struct {
 //   spec.free - the value set to [Cluster]ServicePlan.Spec.Free
 //   spec.serviceClassName - the value set to ServicePlan.Spec.ServiceClassRef.Name
 //   spec.clusterServiceClass.name - the value set to ClusterServicePlan.Spec.ClusterServiceClassRef.Name
+//
+// AllowedTags and DeniedTags restrict the classes by the tags the broker
+// reports for them. A class is only accepted when it has at least one of the
+// allowed tags, if any are given, and none of the denied tags. Plans of a class
+// that is not accepted are not accepted either.
 type CatalogRestrictions struct {
 	// ServiceClass represents a selector for plans, used to filter catalog re-lists.
 	ServiceClass []string
 	// ServicePlan represents a selector for classes, used to filter catalog re-lists.
 	ServicePlan []string
+	// AllowedTags are the tags of which a class must have at least one to be
+	// accepted.
+	AllowedTags []string
+	// DeniedTags are the tags of which a class must have none to be accepted.
+	DeniedTags []string
 }
 
 // ClusterServiceBrokerSpec represents a description of a Broker.
@@ -249,7 +279,10 @@ type BasicAuthConfig struct {
 	// Required at least one of the fields:
 	// - Secret.Data["username"] - username used for authentication
 	// - Secret.Data["password"] - password or token needed for authentication
-	SecretRef *LocalObjectReference
+	//
+	// The Secret is in the namespace of the ServiceBroker unless a namespace
+	// is given, which the controller must be configured to allow.
+	SecretRef *ObjectReference
 }
 
 // BearerTokenAuthConfig provides config for the bearer token
@@ -260,7 +293,10 @@ type BearerTokenAuthConfig struct {
 	//
 	// Required field:
 	// - Secret.Data["token"] - bearer token for authentication
-	SecretRef *LocalObjectReference
+	//
+	// The Secret is in the namespace of the ServiceBroker unless a namespace
+	// is given, which the controller must be configured to allow.
+	SecretRef *ObjectReference
 }
 
 const (
@@ -288,6 +324,16 @@ type CommonServiceBrokerStatus struct {
 	// LastCatalogRetrievalTime is the time the Catalog was last fetched from
 	// the Service Broker
 	LastCatalogRetrievalTime *metav1.Time
+
+	// LastCatalogHash is a hash of the catalog returned by the Service Broker
+	// on the last successful relist. When a relist returns a catalog with the
+	// same hash, the controller skips reconciling its classes and plans.
+	LastCatalogHash string
+
+	// OSBAPIVersion is the version of the Open Service Broker API negotiated
+	// with the Service Broker on the last successful relist. It is the
+	// version used for all the requests made to the Service Broker.
+	OSBAPIVersion string
 }
 
 // ClusterServiceBrokerStatus represents the current status of a
@@ -333,6 +379,14 @@ const (
 	// ServiceBrokerConditionFailed represents information about a final failure
 	// that should not be retried.
 	ServiceBrokerConditionFailed ServiceBrokerConditionType = "Failed"
+
+	// ServiceBrokerConditionInsecure represents that the controller
+	// communicates with the Broker over a URL that does not use https.
+	ServiceBrokerConditionInsecure ServiceBrokerConditionType = "Insecure"
+
+	// ServiceBrokerConditionQuiesced represents that the broker is quiesced
+	// and new ServiceInstances of its classes cannot be created.
+	ServiceBrokerConditionQuiesced ServiceBrokerConditionType = "Quiesced"
 )
 
 // ConditionStatus represents a condition's status.
@@ -836,6 +890,20 @@ type ServiceInstanceSpec struct {
 	// allows for parameters to be updated with any out-of-band changes that have
 	// been made to the secrets from which the parameters are sourced.
 	UpdateRequests int64
+
+	// DisableOrphanMitigation prevents the controller from deprovisioning the
+	// instance after a failed provision request that may have left an orphaned
+	// resource on the broker. Use it for brokers that clean up failed
+	// provisions themselves.
+	// +optional
+	DisableOrphanMitigation bool
+
+	// DependsOn lists the instances in the same namespace that must be Ready
+	// before the controller provisions this instance. Until then, the
+	// instance has a Ready condition with the WaitingForDependencies reason.
+	// Instances cannot depend on each other in a cycle.
+	// +optional
+	DependsOn []LocalObjectReference
 }
 
 // ServiceInstanceStatus represents the current status of an Instance.
@@ -852,6 +920,15 @@ type ServiceInstanceStatus struct {
 	// mitigation operation against this ServiceInstance in progress.
 	OrphanMitigationInProgress bool
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// OrphanMitigationStartTime is the time at which the orphan mitigation of
+	// the ServiceInstance was required. The orphan mitigation of an instance
+	// whose policy is Delayed starts once the delay has elapsed since then.
+	// +optional
+	OrphanMitigationStartTime *metav1.Time
+
 	// LastOperation is the string that the broker may have returned when
 	// an async operation started, it should be sent back to the broker
 	// on poll requests as a query param.
@@ -888,6 +965,13 @@ type ServiceInstanceStatus struct {
 	// broker knows about.
 	ExternalProperties *ServiceInstancePropertiesState
 
+	// ExternalID is the ID the ServiceInstance was provisioned with at the
+	// broker. It is recorded when the provision request is first sent, and
+	// the same ID is sent on every retry of the request, so that the broker
+	// can recognize retries of a provision that partially succeeded.
+	// +optional
+	ExternalID string
+
 	// ProvisionStatus describes whether the instance is in the provisioned state.
 	ProvisionStatus ServiceInstanceProvisionStatus
 
@@ -895,6 +979,10 @@ type ServiceInstanceStatus struct {
 	// ServiceInstance.
 	DeprovisionStatus ServiceInstanceDeprovisionStatus
 
+	// FailedDeprovisionAttempts is the number of requests to deprovision the
+	// ServiceInstance that failed since its deletion was requested.
+	FailedDeprovisionAttempts int64
+
 	// DefaultProvisionParameters are the default parameters applied to this
 	// instance.
 	DefaultProvisionParameters *runtime.RawExtension
@@ -936,6 +1024,22 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionParametersStale represents that a secret
+	// referenced by the instance's parametersFrom has changed since the
+	// parameters were last sent to the broker.
+	ServiceInstanceConditionParametersStale ServiceInstanceConditionType = "ParametersStale"
+
+	// ServiceInstanceConditionForceDeleted represents that the finalizer of
+	// the instance was removed without deprovisioning it, after too many
+	// deprovision attempts failed.
+	ServiceInstanceConditionForceDeleted ServiceInstanceConditionType = "ForceDeleted"
+
+	// ServiceInstanceConditionPlanDeprecated represents that the plan of the
+	// instance is marked as deprecated in the metadata of the broker's
+	// catalog. The instance keeps working, but should be moved to another
+	// plan.
+	ServiceInstanceConditionPlanDeprecated ServiceInstanceConditionType = "PlanDeprecated"
 )
 
 // ServiceInstanceOperation represents a type of operation the controller can
@@ -975,6 +1079,22 @@ type ServiceInstancePropertiesState struct {
 	// broker knows this ServiceInstance to be on.
 	ServicePlanExternalID string
 
+	// ClusterServiceClassExternalID is the external ID of the class that the
+	// broker knows this ServiceInstance to be of.
+	ClusterServiceClassExternalID string
+
+	// ClusterServiceBrokerName is the name of the ClusterServiceBroker that
+	// this ServiceInstance was provisioned against.
+	ClusterServiceBrokerName string
+
+	// ServiceClassExternalID is the external ID of the class that the
+	// broker knows this ServiceInstance to be of.
+	ServiceClassExternalID string
+
+	// ServiceBrokerName is the name of the ServiceBroker that this
+	// ServiceInstance was provisioned against.
+	ServiceBrokerName string
+
 	// Parameters is a blob of the parameters and their values that the broker
 	// knows about for this ServiceInstance.  If a parameter was sourced from
 	// a secret, its value will be "<redacted>" in this blob.
@@ -1082,10 +1202,26 @@ type ServiceBindingSpec struct {
 	// namespace that will hold the credentials associated with the ServiceBinding.
 	SecretName string
 
+	// SecretType is the type of the secret that holds the credentials, such
+	// as kubernetes.io/tls for integrations that expect a TLS secret. The
+	// credentials are written to the secret regardless of its type, so they
+	// must have the keys required by the type, which SecretTransforms can
+	// add. Defaults to Opaque.
+	SecretType string
+
 	// List of transformations that should be applied to the credentials returned
 	// by the broker before they are inserted into the Secret
 	SecretTransforms []SecretTransform
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// RotationRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to rotate the credentials of
+	// the ServiceBinding. The controller unbinds and binds again at the
+	// broker, and replaces the data of the secret with the new credentials.
+	RotationRequests int64
+
 	// ExternalID is the identity of this object for use with the OSB API.
 	//
 	// Immutable.
@@ -1148,6 +1284,38 @@ type ServiceBindingStatus struct {
 
 	// UnbindStatus describes what has been done to unbind a ServiceBinding
 	UnbindStatus ServiceBindingUnbindStatus
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// OperationKey identifies the bind operation in progress. It is generated
+	// when the operation starts and sent to the broker in the context of every
+	// bind request made for the operation, so that a broker can recognize a
+	// retried request as one it has already received.
+	OperationKey string
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// SecretChecksum is the checksum of the data the controller last wrote
+	// to the secret of the ServiceBinding. It is only set when the
+	// BindingSecretDriftDetection feature is enabled, and is used to detect
+	// when the secret has been changed or deleted by someone else.
+	SecretChecksum string
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// RotationRequests is the value of Spec.RotationRequests the credentials
+	// of the ServiceBinding were last rotated for.
+	RotationRequests int64
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// LastRotationTime is the time at which the credentials of the
+	// ServiceBinding were last rotated.
+	LastRotationTime *metav1.Time
 }
 
 // ServiceBindingCondition condition information for a ServiceBinding.
@@ -1243,6 +1411,19 @@ type ParametersFromSource struct {
 	// The value must be a JSON object.
 	// +optional
 	SecretKeyRef *SecretKeyReference
+	// An output recorded in the status of the ServiceInstance the
+	// ServiceBinding refers to. Only valid for ServiceBindings.
+	// +optional
+	InstanceOutputRef *InstanceOutputReference
+}
+
+// InstanceOutputReference references an output recorded in the status of a
+// ServiceInstance.
+type InstanceOutputReference struct {
+	// The name of the output to select. The value is passed to the broker
+	// as a parameter of the same name. Currently the only supported output
+	// is "dashboardURL".
+	Key string
 }
 
 // SecretKeyReference references a key of a Secret.
@@ -1283,6 +1464,7 @@ type SecretTransform struct {
 	AddKey      *AddKeyTransform
 	AddKeysFrom *AddKeysFromTransform
 	RemoveKey   *RemoveKeyTransform
+	TemplateKey *TemplateKeyTransform
 }
 
 // RenameKeyTransform specifies that one of the credentials keys returned
@@ -1312,3 +1494,12 @@ type AddKeysFromTransform struct {
 type RemoveKeyTransform struct {
 	Key string
 }
+
+// TemplateKeyTransform specifies that Service Catalog should add an
+// entry to the Secret whose value is rendered from a template referencing
+// the other credentials keys.
+type TemplateKeyTransform struct {
+	Key      string
+	Template string
+	Default  *string
+}
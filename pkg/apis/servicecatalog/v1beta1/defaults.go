@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -43,4 +44,7 @@ func SetDefaults_ServiceBinding(binding *ServiceBinding) {
 	if binding.Spec.SecretName == "" {
 		binding.Spec.SecretName = binding.Name
 	}
+	if binding.Spec.SecretType == "" {
+		binding.Spec.SecretType = string(corev1.SecretTypeOpaque)
+	}
 }
@@ -96,6 +96,16 @@ func (p *ServicePlan) GetClassID() string {
 	return p.Spec.ServiceClassRef.Name
 }
 
+// GetServiceBrokerName returns the name of the service broker for the plan.
+func (p *ClusterServicePlan) GetServiceBrokerName() string {
+	return p.Spec.ClusterServiceBrokerName
+}
+
+// GetServiceBrokerName returns the name of the service broker for the plan.
+func (p *ServicePlan) GetServiceBrokerName() string {
+	return p.Spec.ServiceBrokerName
+}
+
 // GetDefaultProvisionParameters returns the default provision parameters from plan.
 func (p *ClusterServicePlan) GetDefaultProvisionParameters() *runtime.RawExtension {
 	return p.Spec.DefaultProvisionParameters
@@ -135,3 +145,16 @@ func (p *ClusterServicePlan) GetBindingCreateSchema() *runtime.RawExtension {
 func (p *ServicePlan) GetBindingCreateSchema() *runtime.RawExtension {
 	return p.Spec.ServiceBindingCreateParameterSchema
 }
+
+// IsPlanBindable returns whether instances of a plan of the given class can
+// be bound. As in the Open Service Broker API, the plan's bindable field
+// overrides the class's one when it is set.
+//
+// Note: enforcing that the plan belongs to the given class is the
+// responsibility of the caller.
+func IsPlanBindable(class *CommonServiceClassSpec, plan *CommonServicePlanSpec) bool {
+	if plan.Bindable != nil {
+		return *plan.Bindable
+	}
+	return class.Bindable
+}
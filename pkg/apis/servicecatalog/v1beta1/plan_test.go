@@ -0,0 +1,100 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+)
+
+func TestIsPlanBindable(t *testing.T) {
+	truePtr := func() *bool { b := true; return &b }
+	falsePtr := func() *bool { b := false; return &b }
+
+	cases := []struct {
+		name          string
+		classBindable bool
+		planBindable  *bool
+		bindable      bool
+	}{
+		{
+			name:          "class true, plan not set",
+			classBindable: true,
+			bindable:      true,
+		},
+		{
+			name:          "class true, plan false",
+			classBindable: true,
+			planBindable:  falsePtr(),
+			bindable:      false,
+		},
+		{
+			name:          "class true, plan true",
+			classBindable: true,
+			planBindable:  truePtr(),
+			bindable:      true,
+		},
+		{
+			name:          "class false, plan not set",
+			classBindable: false,
+			bindable:      false,
+		},
+		{
+			name:          "class false, plan false",
+			classBindable: false,
+			planBindable:  falsePtr(),
+			bindable:      false,
+		},
+		{
+			name:          "class false, plan true",
+			classBindable: false,
+			planBindable:  truePtr(),
+			bindable:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterClass := &ClusterServiceClass{
+				Spec: ClusterServiceClassSpec{
+					CommonServiceClassSpec: CommonServiceClassSpec{Bindable: tc.classBindable},
+				},
+			}
+			clusterPlan := &ClusterServicePlan{
+				Spec: ClusterServicePlanSpec{
+					CommonServicePlanSpec: CommonServicePlanSpec{Bindable: tc.planBindable},
+				},
+			}
+			if e, a := tc.bindable, IsPlanBindable(&clusterClass.Spec.CommonServiceClassSpec, &clusterPlan.Spec.CommonServicePlanSpec); e != a {
+				t.Errorf("cluster-scoped: expected %v, got %v", e, a)
+			}
+
+			class := &ServiceClass{
+				Spec: ServiceClassSpec{
+					CommonServiceClassSpec: CommonServiceClassSpec{Bindable: tc.classBindable},
+				},
+			}
+			plan := &ServicePlan{
+				Spec: ServicePlanSpec{
+					CommonServicePlanSpec: CommonServicePlanSpec{Bindable: tc.planBindable},
+				},
+			}
+			if e, a := tc.bindable, IsPlanBindable(&class.Spec.CommonServiceClassSpec, &plan.Spec.CommonServicePlanSpec); e != a {
+				t.Errorf("namespace-scoped: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
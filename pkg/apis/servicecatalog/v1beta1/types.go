@@ -98,6 +98,13 @@ type CommonServiceBrokerSpec struct {
 	// +optional
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 
+	// AllowInsecure allows the Broker URL to use a scheme other than https.
+	// Without it, the controller will not send the Broker's credentials over
+	// an insecure connection. This is intended for local and development
+	// Brokers only.
+	// +optional
+	AllowInsecure bool `json:"allowInsecure,omitempty"`
+
 	// CABundle is a PEM encoded CA bundle which will be used to validate a Broker's serving certificate.
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
@@ -126,6 +133,19 @@ type CommonServiceBrokerSpec struct {
 	// and plans have resources created for them.
 	// +optional
 	CatalogRestrictions *CatalogRestrictions `json:"catalogRestrictions,omitempty"`
+
+	// Quiesced stops the creation of new ServiceInstances of the broker's
+	// classes, while existing ServiceInstances and ServiceBindings keep
+	// being updated and deprovisioned as usual.
+	// +optional
+	Quiesced bool `json:"quiesced,omitempty"`
+
+	// ProxyURL is the URL of the HTTP proxy that the controller uses to
+	// contact the broker, overriding the proxy configured by the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the
+	// controller. The environment variables are used when it is not set.
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -174,11 +194,23 @@ type CommonServiceBrokerSpec struct {
 //   spec.free - the value set to [Cluster]ServicePlan.Spec.Free
 //   spec.serviceClass.name - the value set to ServicePlan.Spec.ServiceClassRef.Name
 //   spec.clusterServiceClass.name - the value set to ClusterServicePlan.Spec.ClusterServiceClassRef.Name
+//
+// AllowedTags and DeniedTags restrict the classes by the tags the broker
+// reports for them. A class is only accepted when it has at least one of the
+// allowed tags, if any are given, and none of the denied tags. Plans of a class
+// that is not accepted are not accepted either.
 type CatalogRestrictions struct {
 	// ServiceClass represents a selector for plans, used to filter catalog re-lists.
 	ServiceClass []string `json:"serviceClass,omitempty"`
 	// ServicePlan represents a selector for classes, used to filter catalog re-lists.
 	ServicePlan []string `json:"servicePlan,omitempty"`
+	// AllowedTags are the tags of which a class must have at least one to be
+	// accepted.
+	// +optional
+	AllowedTags []string `json:"allowedTags,omitempty"`
+	// DeniedTags are the tags of which a class must have none to be accepted.
+	// +optional
+	DeniedTags []string `json:"deniedTags,omitempty"`
 }
 
 // ClusterServiceBrokerSpec represents a description of a Broker.
@@ -270,7 +302,10 @@ type BasicAuthConfig struct {
 	// Required at least one of the fields:
 	// - Secret.Data["username"] - username used for authentication
 	// - Secret.Data["password"] - password or token needed for authentication
-	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+	//
+	// The Secret is in the namespace of the ServiceBroker unless a namespace
+	// is given, which the controller must be configured to allow.
+	SecretRef *ObjectReference `json:"secretRef,omitempty"`
 }
 
 // BearerTokenAuthConfig provides config for the bearer token
@@ -281,7 +316,10 @@ type BearerTokenAuthConfig struct {
 	//
 	// Required field:
 	// - Secret.Data["token"] - bearer token for authentication
-	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+	//
+	// The Secret is in the namespace of the ServiceBroker unless a namespace
+	// is given, which the controller must be configured to allow.
+	SecretRef *ObjectReference `json:"secretRef,omitempty"`
 }
 
 const (
@@ -309,6 +347,16 @@ type CommonServiceBrokerStatus struct {
 	// LastCatalogRetrievalTime is the time the Catalog was last fetched from
 	// the Service Broker
 	LastCatalogRetrievalTime *metav1.Time `json:"lastCatalogRetrievalTime,omitempty"`
+
+	// LastCatalogHash is a hash of the catalog returned by the Service Broker
+	// on the last successful relist. When a relist returns a catalog with the
+	// same hash, the controller skips reconciling its classes and plans.
+	LastCatalogHash string `json:"lastCatalogHash,omitempty"`
+
+	// OSBAPIVersion is the version of the Open Service Broker API negotiated
+	// with the Service Broker on the last successful relist. It is the
+	// version used for all the requests made to the Service Broker.
+	OSBAPIVersion string `json:"osbAPIVersion,omitempty"`
 }
 
 // ClusterServiceBrokerStatus represents the current status of a
@@ -354,6 +402,14 @@ const (
 	// ServiceBrokerConditionFailed represents information about a final failure
 	// that should not be retried.
 	ServiceBrokerConditionFailed ServiceBrokerConditionType = "Failed"
+
+	// ServiceBrokerConditionInsecure represents that the controller
+	// communicates with the Broker over a URL that does not use https.
+	ServiceBrokerConditionInsecure ServiceBrokerConditionType = "Insecure"
+
+	// ServiceBrokerConditionQuiesced represents that the broker is quiesced
+	// and new ServiceInstances of its classes cannot be created.
+	ServiceBrokerConditionQuiesced ServiceBrokerConditionType = "Quiesced"
 )
 
 // ConditionStatus represents a condition's status.
@@ -925,6 +981,20 @@ type ServiceInstanceSpec struct {
 	// been made to the secrets from which the parameters are sourced.
 	// +optional
 	UpdateRequests int64 `json:"updateRequests"`
+
+	// DisableOrphanMitigation prevents the controller from deprovisioning the
+	// instance after a failed provision request that may have left an orphaned
+	// resource on the broker. Use it for brokers that clean up failed
+	// provisions themselves.
+	// +optional
+	DisableOrphanMitigation bool `json:"disableOrphanMitigation,omitempty"`
+
+	// DependsOn lists the instances in the same namespace that must be Ready
+	// before the controller provisions this instance. Until then, the
+	// instance has a Ready condition with the WaitingForDependencies reason.
+	// Instances cannot depend on each other in a cycle.
+	// +optional
+	DependsOn []LocalObjectReference `json:"dependsOn,omitempty"`
 }
 
 // ServiceInstanceStatus represents the current status of an Instance.
@@ -941,6 +1011,15 @@ type ServiceInstanceStatus struct {
 	// mitigation operation against this ServiceInstance in progress.
 	OrphanMitigationInProgress bool `json:"orphanMitigationInProgress"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// OrphanMitigationStartTime is the time at which the orphan mitigation of
+	// the ServiceInstance was required. The orphan mitigation of an instance
+	// whose policy is Delayed starts once the delay has elapsed since then.
+	// +optional
+	OrphanMitigationStartTime *metav1.Time `json:"orphanMitigationStartTime,omitempty"`
+
 	// LastOperation is the string that the broker may have returned when
 	// an async operation started, it should be sent back to the broker
 	// on poll requests as a query param.
@@ -977,6 +1056,13 @@ type ServiceInstanceStatus struct {
 	// broker knows about.
 	ExternalProperties *ServiceInstancePropertiesState `json:"externalProperties,omitempty"`
 
+	// ExternalID is the ID the ServiceInstance was provisioned with at the
+	// broker. It is recorded when the provision request is first sent, and
+	// the same ID is sent on every retry of the request, so that the broker
+	// can recognize retries of a provision that partially succeeded.
+	// +optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// ProvisionStatus describes whether the instance is in the provisioned state.
 	ProvisionStatus ServiceInstanceProvisionStatus `json:"provisionStatus"`
 
@@ -984,6 +1070,10 @@ type ServiceInstanceStatus struct {
 	// ServiceInstance.
 	DeprovisionStatus ServiceInstanceDeprovisionStatus `json:"deprovisionStatus"`
 
+	// FailedDeprovisionAttempts is the number of requests to deprovision the
+	// ServiceInstance that failed since its deletion was requested.
+	FailedDeprovisionAttempts int64 `json:"failedDeprovisionAttempts,omitempty"`
+
 	// DefaultProvisionParameters are the default parameters applied to this
 	// instance.
 	DefaultProvisionParameters *runtime.RawExtension `json:"defaultProvisionParameters,omitempty"`
@@ -1025,6 +1115,22 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionParametersStale represents that a secret
+	// referenced by the instance's parametersFrom has changed since the
+	// parameters were last sent to the broker.
+	ServiceInstanceConditionParametersStale ServiceInstanceConditionType = "ParametersStale"
+
+	// ServiceInstanceConditionForceDeleted represents that the finalizer of
+	// the instance was removed without deprovisioning it, after too many
+	// deprovision attempts failed.
+	ServiceInstanceConditionForceDeleted ServiceInstanceConditionType = "ForceDeleted"
+
+	// ServiceInstanceConditionPlanDeprecated represents that the plan of the
+	// instance is marked as deprecated in the metadata of the broker's
+	// catalog. The instance keeps working, but should be moved to another
+	// plan.
+	ServiceInstanceConditionPlanDeprecated ServiceInstanceConditionType = "PlanDeprecated"
 )
 
 // ServiceInstanceOperation represents a type of operation the controller can
@@ -1064,6 +1170,22 @@ type ServiceInstancePropertiesState struct {
 	// broker knows this ServiceInstance to be on.
 	ServicePlanExternalID string `json:"servicePlanExternalID,omitempty"`
 
+	// ClusterServiceClassExternalID is the external ID of the class that the
+	// broker knows this ServiceInstance to be of.
+	ClusterServiceClassExternalID string `json:"clusterServiceClassExternalID,omitempty"`
+
+	// ClusterServiceBrokerName is the name of the ClusterServiceBroker that
+	// this ServiceInstance was provisioned against.
+	ClusterServiceBrokerName string `json:"clusterServiceBrokerName,omitempty"`
+
+	// ServiceClassExternalID is the external ID of the class that the
+	// broker knows this ServiceInstance to be of.
+	ServiceClassExternalID string `json:"serviceClassExternalID,omitempty"`
+
+	// ServiceBrokerName is the name of the ServiceBroker that this
+	// ServiceInstance was provisioned against.
+	ServiceBrokerName string `json:"serviceBrokerName,omitempty"`
+
 	// Parameters is a blob of the parameters and their values that the broker
 	// knows about for this ServiceInstance.  If a parameter was sourced from
 	// a secret, its value will be "<redacted>" in this blob.
@@ -1181,10 +1303,28 @@ type ServiceBindingSpec struct {
 	// namespace that will hold the credentials associated with the ServiceBinding.
 	SecretName string `json:"secretName,omitempty"`
 
+	// SecretType is the type of the secret that holds the credentials, such
+	// as kubernetes.io/tls for integrations that expect a TLS secret. The
+	// credentials are written to the secret regardless of its type, so they
+	// must have the keys required by the type, which SecretTransforms can
+	// add. Defaults to Opaque.
+	// +optional
+	SecretType string `json:"secretType,omitempty"`
+
 	// List of transformations that should be applied to the credentials
 	// associated with the ServiceBinding before they are inserted into the Secret.
 	SecretTransforms []SecretTransform `json:"secretTransforms,omitempty"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// RotationRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to rotate the credentials of
+	// the ServiceBinding. The controller unbinds and binds again at the
+	// broker, and replaces the data of the secret with the new credentials.
+	// +optional
+	RotationRequests int64 `json:"rotationRequests,omitempty"`
+
 	// ExternalID is the identity of this object for use with the OSB API.
 	//
 	// Immutable.
@@ -1248,6 +1388,40 @@ type ServiceBindingStatus struct {
 
 	// UnbindStatus describes what has been done to unbind the ServiceBinding.
 	UnbindStatus ServiceBindingUnbindStatus `json:"unbindStatus"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// OperationKey identifies the bind operation in progress. It is generated
+	// when the operation starts and sent to the broker in the context of every
+	// bind request made for the operation, so that a broker can recognize a
+	// retried request as one it has already received.
+	OperationKey string `json:"operationKey,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// SecretChecksum is the checksum of the data the controller last wrote
+	// to the secret of the ServiceBinding. It is only set when the
+	// BindingSecretDriftDetection feature is enabled, and is used to detect
+	// when the secret has been changed or deleted by someone else.
+	SecretChecksum string `json:"secretChecksum,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// RotationRequests is the value of Spec.RotationRequests the credentials
+	// of the ServiceBinding were last rotated for.
+	// +optional
+	RotationRequests int64 `json:"rotationRequests,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// LastRotationTime is the time at which the credentials of the
+	// ServiceBinding were last rotated.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
 }
 
 // ServiceBindingCondition condition information for a ServiceBinding.
@@ -1343,6 +1517,19 @@ type ParametersFromSource struct {
 	// The value must be a JSON object.
 	// +optional
 	SecretKeyRef *SecretKeyReference `json:"secretKeyRef,omitempty"`
+	// An output recorded in the status of the ServiceInstance the
+	// ServiceBinding refers to. Only valid for ServiceBindings.
+	// +optional
+	InstanceOutputRef *InstanceOutputReference `json:"instanceOutputRef,omitempty"`
+}
+
+// InstanceOutputReference references an output recorded in the status of a
+// ServiceInstance.
+type InstanceOutputReference struct {
+	// The name of the output to select. The value is passed to the broker
+	// as a parameter of the same name. Currently the only supported output
+	// is "dashboardURL".
+	Key string `json:"key"`
 }
 
 // SecretKeyReference references a key of a Secret.
@@ -1418,6 +1605,9 @@ type SecretTransform struct {
 	AddKeysFrom *AddKeysFromTransform `json:"addKeysFrom,omitempty"`
 	// RemoveKey represents a transform that removes a credentials Secret entry
 	RemoveKey *RemoveKeyTransform `json:"removeKey,omitempty"`
+	// TemplateKey represents a transform that adds a key to the credentials Secret
+	// whose value is composed from the other credentials entries
+	TemplateKey *TemplateKeyTransform `json:"templateKey,omitempty"`
 }
 
 // RenameKeyTransform specifies that one of the credentials keys returned
@@ -1478,3 +1668,26 @@ type RemoveKeyTransform struct {
 	// The key to remove from the Secret
 	Key string `json:"key"`
 }
+
+// TemplateKeyTransform specifies that Service Catalog should add an
+// additional entry to the Secret whose value is rendered from a Go
+// text/template referencing the other credentials entries by key.
+// For example, given the following credentials entries:
+//     "user": "johndoe", "host": "db.example.com"
+// and the following TemplateKeyTransform:
+//     {"key": "DSN", "template": "postgres://{{.user}}@{{.host}}/app"}
+// the following entry will appear in the Secret:
+//     "DSN": "postgres://johndoe@db.example.com/app"
+// If the template references a key that is not present in the credentials,
+// the binding fails unless a default value is provided.
+type TemplateKeyTransform struct {
+	// The name of the key to add
+	Key string `json:"key"`
+	// The Go text/template used to render the value. Credentials entries are
+	// available to the template by key, for example {{.username}}.
+	Template string `json:"template"`
+	// The value stored under the key when the template references a key
+	// that is not present in the credentials.
+	// +optional
+	Default *string `json:"default,omitempty"`
+}
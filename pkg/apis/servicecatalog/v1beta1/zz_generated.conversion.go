@@ -306,6 +306,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InstanceOutputReference)(nil), (*servicecatalog.InstanceOutputReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_InstanceOutputReference_To_servicecatalog_InstanceOutputReference(a.(*InstanceOutputReference), b.(*servicecatalog.InstanceOutputReference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.InstanceOutputReference)(nil), (*InstanceOutputReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_InstanceOutputReference_To_v1beta1_InstanceOutputReference(a.(*servicecatalog.InstanceOutputReference), b.(*InstanceOutputReference), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LocalObjectReference)(nil), (*servicecatalog.LocalObjectReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(a.(*LocalObjectReference), b.(*servicecatalog.LocalObjectReference), scope)
 	}); err != nil {
@@ -646,6 +656,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*TemplateKeyTransform)(nil), (*servicecatalog.TemplateKeyTransform)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform(a.(*TemplateKeyTransform), b.(*servicecatalog.TemplateKeyTransform), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*servicecatalog.TemplateKeyTransform)(nil), (*TemplateKeyTransform)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform(a.(*servicecatalog.TemplateKeyTransform), b.(*TemplateKeyTransform), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*UserInfo)(nil), (*servicecatalog.UserInfo)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_UserInfo_To_servicecatalog_UserInfo(a.(*UserInfo), b.(*servicecatalog.UserInfo), scope)
 	}); err != nil {
@@ -706,7 +726,7 @@ func Convert_servicecatalog_AddKeysFromTransform_To_v1beta1_AddKeysFromTransform
 }
 
 func autoConvert_v1beta1_BasicAuthConfig_To_servicecatalog_BasicAuthConfig(in *BasicAuthConfig, out *servicecatalog.BasicAuthConfig, s conversion.Scope) error {
-	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.SecretRef = (*servicecatalog.ObjectReference)(unsafe.Pointer(in.SecretRef))
 	return nil
 }
 
@@ -716,7 +736,7 @@ func Convert_v1beta1_BasicAuthConfig_To_servicecatalog_BasicAuthConfig(in *Basic
 }
 
 func autoConvert_servicecatalog_BasicAuthConfig_To_v1beta1_BasicAuthConfig(in *servicecatalog.BasicAuthConfig, out *BasicAuthConfig, s conversion.Scope) error {
-	out.SecretRef = (*LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.SecretRef = (*ObjectReference)(unsafe.Pointer(in.SecretRef))
 	return nil
 }
 
@@ -726,7 +746,7 @@ func Convert_servicecatalog_BasicAuthConfig_To_v1beta1_BasicAuthConfig(in *servi
 }
 
 func autoConvert_v1beta1_BearerTokenAuthConfig_To_servicecatalog_BearerTokenAuthConfig(in *BearerTokenAuthConfig, out *servicecatalog.BearerTokenAuthConfig, s conversion.Scope) error {
-	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.SecretRef = (*servicecatalog.ObjectReference)(unsafe.Pointer(in.SecretRef))
 	return nil
 }
 
@@ -736,7 +756,7 @@ func Convert_v1beta1_BearerTokenAuthConfig_To_servicecatalog_BearerTokenAuthConf
 }
 
 func autoConvert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConfig(in *servicecatalog.BearerTokenAuthConfig, out *BearerTokenAuthConfig, s conversion.Scope) error {
-	out.SecretRef = (*LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.SecretRef = (*ObjectReference)(unsafe.Pointer(in.SecretRef))
 	return nil
 }
 
@@ -748,6 +768,8 @@ func Convert_servicecatalog_BearerTokenAuthConfig_To_v1beta1_BearerTokenAuthConf
 func autoConvert_v1beta1_CatalogRestrictions_To_servicecatalog_CatalogRestrictions(in *CatalogRestrictions, out *servicecatalog.CatalogRestrictions, s conversion.Scope) error {
 	out.ServiceClass = *(*[]string)(unsafe.Pointer(&in.ServiceClass))
 	out.ServicePlan = *(*[]string)(unsafe.Pointer(&in.ServicePlan))
+	out.AllowedTags = *(*[]string)(unsafe.Pointer(&in.AllowedTags))
+	out.DeniedTags = *(*[]string)(unsafe.Pointer(&in.DeniedTags))
 	return nil
 }
 
@@ -759,6 +781,8 @@ func Convert_v1beta1_CatalogRestrictions_To_servicecatalog_CatalogRestrictions(i
 func autoConvert_servicecatalog_CatalogRestrictions_To_v1beta1_CatalogRestrictions(in *servicecatalog.CatalogRestrictions, out *CatalogRestrictions, s conversion.Scope) error {
 	out.ServiceClass = *(*[]string)(unsafe.Pointer(&in.ServiceClass))
 	out.ServicePlan = *(*[]string)(unsafe.Pointer(&in.ServicePlan))
+	out.AllowedTags = *(*[]string)(unsafe.Pointer(&in.AllowedTags))
+	out.DeniedTags = *(*[]string)(unsafe.Pointer(&in.DeniedTags))
 	return nil
 }
 
@@ -1170,11 +1194,14 @@ func Convert_servicecatalog_ClusterServicePlanStatus_To_v1beta1_ClusterServicePl
 func autoConvert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrokerSpec(in *CommonServiceBrokerSpec, out *servicecatalog.CommonServiceBrokerSpec, s conversion.Scope) error {
 	out.URL = in.URL
 	out.InsecureSkipTLSVerify = in.InsecureSkipTLSVerify
+	out.AllowInsecure = in.AllowInsecure
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.RelistBehavior = servicecatalog.ServiceBrokerRelistBehavior(in.RelistBehavior)
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*servicecatalog.CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.Quiesced = in.Quiesced
+	out.ProxyURL = in.ProxyURL
 	return nil
 }
 
@@ -1186,11 +1213,14 @@ func Convert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrok
 func autoConvert_servicecatalog_CommonServiceBrokerSpec_To_v1beta1_CommonServiceBrokerSpec(in *servicecatalog.CommonServiceBrokerSpec, out *CommonServiceBrokerSpec, s conversion.Scope) error {
 	out.URL = in.URL
 	out.InsecureSkipTLSVerify = in.InsecureSkipTLSVerify
+	out.AllowInsecure = in.AllowInsecure
 	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
 	out.RelistBehavior = ServiceBrokerRelistBehavior(in.RelistBehavior)
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.Quiesced = in.Quiesced
+	out.ProxyURL = in.ProxyURL
 	return nil
 }
 
@@ -1204,6 +1234,8 @@ func autoConvert_v1beta1_CommonServiceBrokerStatus_To_servicecatalog_CommonServi
 	out.ReconciledGeneration = in.ReconciledGeneration
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.LastCatalogRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastCatalogRetrievalTime))
+	out.LastCatalogHash = in.LastCatalogHash
+	out.OSBAPIVersion = in.OSBAPIVersion
 	return nil
 }
 
@@ -1217,6 +1249,8 @@ func autoConvert_servicecatalog_CommonServiceBrokerStatus_To_v1beta1_CommonServi
 	out.ReconciledGeneration = in.ReconciledGeneration
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.LastCatalogRetrievalTime = (*v1.Time)(unsafe.Pointer(in.LastCatalogRetrievalTime))
+	out.LastCatalogHash = in.LastCatalogHash
+	out.OSBAPIVersion = in.OSBAPIVersion
 	return nil
 }
 
@@ -1343,6 +1377,26 @@ func Convert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlan
 	return autoConvert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_InstanceOutputReference_To_servicecatalog_InstanceOutputReference(in *InstanceOutputReference, out *servicecatalog.InstanceOutputReference, s conversion.Scope) error {
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_v1beta1_InstanceOutputReference_To_servicecatalog_InstanceOutputReference is an autogenerated conversion function.
+func Convert_v1beta1_InstanceOutputReference_To_servicecatalog_InstanceOutputReference(in *InstanceOutputReference, out *servicecatalog.InstanceOutputReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_InstanceOutputReference_To_servicecatalog_InstanceOutputReference(in, out, s)
+}
+
+func autoConvert_servicecatalog_InstanceOutputReference_To_v1beta1_InstanceOutputReference(in *servicecatalog.InstanceOutputReference, out *InstanceOutputReference, s conversion.Scope) error {
+	out.Key = in.Key
+	return nil
+}
+
+// Convert_servicecatalog_InstanceOutputReference_To_v1beta1_InstanceOutputReference is an autogenerated conversion function.
+func Convert_servicecatalog_InstanceOutputReference_To_v1beta1_InstanceOutputReference(in *servicecatalog.InstanceOutputReference, out *InstanceOutputReference, s conversion.Scope) error {
+	return autoConvert_servicecatalog_InstanceOutputReference_To_v1beta1_InstanceOutputReference(in, out, s)
+}
+
 func autoConvert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(in *LocalObjectReference, out *servicecatalog.LocalObjectReference, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -1387,6 +1441,7 @@ func Convert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference(in *servi
 
 func autoConvert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource(in *ParametersFromSource, out *servicecatalog.ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*servicecatalog.SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
+	out.InstanceOutputRef = (*servicecatalog.InstanceOutputReference)(unsafe.Pointer(in.InstanceOutputRef))
 	return nil
 }
 
@@ -1397,6 +1452,7 @@ func Convert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource
 
 func autoConvert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource(in *servicecatalog.ParametersFromSource, out *ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
+	out.InstanceOutputRef = (*InstanceOutputReference)(unsafe.Pointer(in.InstanceOutputRef))
 	return nil
 }
 
@@ -1516,6 +1572,7 @@ func autoConvert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform(in *S
 	out.AddKey = (*servicecatalog.AddKeyTransform)(unsafe.Pointer(in.AddKey))
 	out.AddKeysFrom = (*servicecatalog.AddKeysFromTransform)(unsafe.Pointer(in.AddKeysFrom))
 	out.RemoveKey = (*servicecatalog.RemoveKeyTransform)(unsafe.Pointer(in.RemoveKey))
+	out.TemplateKey = (*servicecatalog.TemplateKeyTransform)(unsafe.Pointer(in.TemplateKey))
 	return nil
 }
 
@@ -1529,6 +1586,7 @@ func autoConvert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform(in *s
 	out.AddKey = (*AddKeyTransform)(unsafe.Pointer(in.AddKey))
 	out.AddKeysFrom = (*AddKeysFromTransform)(unsafe.Pointer(in.AddKeysFrom))
 	out.RemoveKey = (*RemoveKeyTransform)(unsafe.Pointer(in.RemoveKey))
+	out.TemplateKey = (*TemplateKeyTransform)(unsafe.Pointer(in.TemplateKey))
 	return nil
 }
 
@@ -1650,7 +1708,9 @@ func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
+	out.SecretType = in.SecretType
 	out.SecretTransforms = *(*[]servicecatalog.SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.RotationRequests = in.RotationRequests
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
@@ -1668,7 +1728,9 @@ func autoConvert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
+	out.SecretType = in.SecretType
 	out.SecretTransforms = *(*[]SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.RotationRequests = in.RotationRequests
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
@@ -1690,6 +1752,10 @@ func autoConvert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingSt
 	out.ExternalProperties = (*servicecatalog.ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = servicecatalog.ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.OperationKey = in.OperationKey
+	out.SecretChecksum = in.SecretChecksum
+	out.RotationRequests = in.RotationRequests
+	out.LastRotationTime = (*v1.Time)(unsafe.Pointer(in.LastRotationTime))
 	return nil
 }
 
@@ -1709,6 +1775,10 @@ func autoConvert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingSt
 	out.ExternalProperties = (*ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.OperationKey = in.OperationKey
+	out.SecretChecksum = in.SecretChecksum
+	out.RotationRequests = in.RotationRequests
+	out.LastRotationTime = (*v1.Time)(unsafe.Pointer(in.LastRotationTime))
 	return nil
 }
 
@@ -2062,6 +2132,10 @@ func autoConvert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_Servic
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
 	out.ServicePlanExternalName = in.ServicePlanExternalName
 	out.ServicePlanExternalID = in.ServicePlanExternalID
+	out.ClusterServiceClassExternalID = in.ClusterServiceClassExternalID
+	out.ClusterServiceBrokerName = in.ClusterServiceBrokerName
+	out.ServiceClassExternalID = in.ServiceClassExternalID
+	out.ServiceBrokerName = in.ServiceBrokerName
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParameterChecksum = in.ParameterChecksum
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
@@ -2078,6 +2152,10 @@ func autoConvert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_Servic
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
 	out.ServicePlanExternalName = in.ServicePlanExternalName
 	out.ServicePlanExternalID = in.ServicePlanExternalID
+	out.ClusterServiceClassExternalID = in.ClusterServiceClassExternalID
+	out.ClusterServiceBrokerName = in.ClusterServiceBrokerName
+	out.ServiceClassExternalID = in.ServiceClassExternalID
+	out.ServiceBrokerName = in.ServiceBrokerName
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParameterChecksum = in.ParameterChecksum
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
@@ -2102,6 +2180,8 @@ func autoConvert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSp
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
+	out.DisableOrphanMitigation = in.DisableOrphanMitigation
+	out.DependsOn = *(*[]servicecatalog.LocalObjectReference)(unsafe.Pointer(&in.DependsOn))
 	return nil
 }
 
@@ -2123,6 +2203,8 @@ func autoConvert_servicecatalog_ServiceInstanceSpec_To_v1beta1_ServiceInstanceSp
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
+	out.DisableOrphanMitigation = in.DisableOrphanMitigation
+	out.DependsOn = *(*[]LocalObjectReference)(unsafe.Pointer(&in.DependsOn))
 	return nil
 }
 
@@ -2135,6 +2217,7 @@ func autoConvert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstance
 	out.Conditions = *(*[]servicecatalog.ServiceInstanceCondition)(unsafe.Pointer(&in.Conditions))
 	out.AsyncOpInProgress = in.AsyncOpInProgress
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
+	out.OrphanMitigationStartTime = (*v1.Time)(unsafe.Pointer(in.OrphanMitigationStartTime))
 	out.LastOperation = (*string)(unsafe.Pointer(in.LastOperation))
 	out.DashboardURL = (*string)(unsafe.Pointer(in.DashboardURL))
 	out.CurrentOperation = servicecatalog.ServiceInstanceOperation(in.CurrentOperation)
@@ -2143,8 +2226,10 @@ func autoConvert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstance
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.InProgressProperties = (*servicecatalog.ServiceInstancePropertiesState)(unsafe.Pointer(in.InProgressProperties))
 	out.ExternalProperties = (*servicecatalog.ServiceInstancePropertiesState)(unsafe.Pointer(in.ExternalProperties))
+	out.ExternalID = in.ExternalID
 	out.ProvisionStatus = servicecatalog.ServiceInstanceProvisionStatus(in.ProvisionStatus)
 	out.DeprovisionStatus = servicecatalog.ServiceInstanceDeprovisionStatus(in.DeprovisionStatus)
+	out.FailedDeprovisionAttempts = in.FailedDeprovisionAttempts
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
 	return nil
 }
@@ -2158,6 +2243,7 @@ func autoConvert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstance
 	out.Conditions = *(*[]ServiceInstanceCondition)(unsafe.Pointer(&in.Conditions))
 	out.AsyncOpInProgress = in.AsyncOpInProgress
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
+	out.OrphanMitigationStartTime = (*v1.Time)(unsafe.Pointer(in.OrphanMitigationStartTime))
 	out.LastOperation = (*string)(unsafe.Pointer(in.LastOperation))
 	out.DashboardURL = (*string)(unsafe.Pointer(in.DashboardURL))
 	out.CurrentOperation = ServiceInstanceOperation(in.CurrentOperation)
@@ -2166,8 +2252,10 @@ func autoConvert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstance
 	out.OperationStartTime = (*v1.Time)(unsafe.Pointer(in.OperationStartTime))
 	out.InProgressProperties = (*ServiceInstancePropertiesState)(unsafe.Pointer(in.InProgressProperties))
 	out.ExternalProperties = (*ServiceInstancePropertiesState)(unsafe.Pointer(in.ExternalProperties))
+	out.ExternalID = in.ExternalID
 	out.ProvisionStatus = ServiceInstanceProvisionStatus(in.ProvisionStatus)
 	out.DeprovisionStatus = ServiceInstanceDeprovisionStatus(in.DeprovisionStatus)
+	out.FailedDeprovisionAttempts = in.FailedDeprovisionAttempts
 	out.DefaultProvisionParameters = (*runtime.RawExtension)(unsafe.Pointer(in.DefaultProvisionParameters))
 	return nil
 }
@@ -2287,6 +2375,30 @@ func Convert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus(in *s
 	return autoConvert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform(in *TemplateKeyTransform, out *servicecatalog.TemplateKeyTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Template = in.Template
+	out.Default = (*string)(unsafe.Pointer(in.Default))
+	return nil
+}
+
+// Convert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform is an autogenerated conversion function.
+func Convert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform(in *TemplateKeyTransform, out *servicecatalog.TemplateKeyTransform, s conversion.Scope) error {
+	return autoConvert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform(in, out, s)
+}
+
+func autoConvert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform(in *servicecatalog.TemplateKeyTransform, out *TemplateKeyTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Template = in.Template
+	out.Default = (*string)(unsafe.Pointer(in.Default))
+	return nil
+}
+
+// Convert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform is an autogenerated conversion function.
+func Convert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform(in *servicecatalog.TemplateKeyTransform, out *TemplateKeyTransform, s conversion.Scope) error {
+	return autoConvert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform(in, out, s)
+}
+
 func autoConvert_v1beta1_UserInfo_To_servicecatalog_UserInfo(in *UserInfo, out *servicecatalog.UserInfo, s conversion.Scope) error {
 	out.Username = in.Username
 	out.UID = in.UID
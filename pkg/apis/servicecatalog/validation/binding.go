@@ -17,17 +17,43 @@ limitations under the License.
 package validation
 
 import (
+	"sort"
+
 	sc "github.com/poy/service-catalog/pkg/apis/servicecatalog"
 	scfeatures "github.com/poy/service-catalog/pkg/features"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/util/jsonpath"
 	"sigs.k8s.io/yaml"
 )
 
 // validateServiceBindingName is the validation function for ServiceBinding names.
 var validateServiceBindingName = apivalidation.NameIsDNSSubdomain
 
+// validServiceBindingSecretTypes are the types of secrets that the
+// controller can write credentials to. Types whose secrets are managed by
+// Kubernetes itself, like service account tokens, are left out.
+var validServiceBindingSecretTypes = map[string]bool{
+	string(corev1.SecretTypeOpaque):           true,
+	string(corev1.SecretTypeTLS):              true,
+	string(corev1.SecretTypeBasicAuth):        true,
+	string(corev1.SecretTypeSSHAuth):          true,
+	string(corev1.SecretTypeDockercfg):        true,
+	string(corev1.SecretTypeDockerConfigJson): true,
+}
+
+var validServiceBindingSecretTypeValues = func() []string {
+	validValues := make([]string, 0, len(validServiceBindingSecretTypes))
+	for t := range validServiceBindingSecretTypes {
+		validValues = append(validValues, t)
+	}
+	sort.Strings(validValues)
+	return validValues
+}()
+
 var validServiceBindingOperations = map[sc.ServiceBindingOperation]bool{
 	sc.ServiceBindingOperation(""):   true,
 	sc.ServiceBindingOperationBind:   true,
@@ -63,7 +89,9 @@ var validServiceBindingUnbindStatusValues = func() []string {
 
 // ValidateServiceBinding validates a ServiceBinding and returns a list of errors.
 func ValidateServiceBinding(binding *sc.ServiceBinding) field.ErrorList {
-	return internalValidateServiceBinding(binding, true)
+	allErrs := internalValidateServiceBinding(binding, true)
+	allErrs = append(allErrs, validateSecretTransforms(binding.Spec.SecretTransforms, field.NewPath("spec", "secretTransforms"))...)
+	return allErrs
 }
 
 func internalValidateServiceBinding(binding *sc.ServiceBinding, create bool) field.ErrorList {
@@ -91,8 +119,35 @@ func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("secretName"), spec.SecretName, msg))
 	}
 
+	// An empty type is the same as Opaque
+	if spec.SecretType != "" && !validServiceBindingSecretTypes[spec.SecretType] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("secretType"), spec.SecretType, validServiceBindingSecretTypeValues))
+	}
+
 	if spec.ParametersFrom != nil {
-		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
+		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, true, fldPath)...)
+	}
+
+	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(spec.RotationRequests, fldPath.Child("rotationRequests"))...)
+
+	return allErrs
+}
+
+// validateSecretTransforms validates the secret transforms of a binding.
+// JSONPath expressions are parsed with the same library that the controller
+// evaluates them with, so that a binding whose transforms are bound to fail
+// is rejected up front.
+func validateSecretTransforms(transforms []sc.SecretTransform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, transform := range transforms {
+		if transform.AddKey == nil || transform.AddKey.JSONPathExpression == nil {
+			continue
+		}
+		expression := *transform.AddKey.JSONPathExpression
+		if err := jsonpath.New("expression").Parse(expression); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("addKey", "jsonPathExpression"), expression, err.Error()))
+		}
 	}
 
 	return allErrs
@@ -239,6 +294,14 @@ func ValidateServiceBindingUpdate(new *sc.ServiceBinding, old *sc.ServiceBinding
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, internalValidateServiceBindingUpdateAllowed(new, old)...)
 	allErrs = append(allErrs, internalValidateServiceBinding(new, false)...)
+	// Transforms are only validated when they change, so that bindings
+	// created before they were validated can still be updated.
+	if !apiequality.Semantic.DeepEqual(new.Spec.SecretTransforms, old.Spec.SecretTransforms) {
+		allErrs = append(allErrs, validateSecretTransforms(new.Spec.SecretTransforms, field.NewPath("spec", "secretTransforms"))...)
+	}
+	if new.Spec.RotationRequests < old.Spec.RotationRequests {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "rotationRequests"), new.Spec.RotationRequests, "new rotationRequests value must not be less than the old one"))
+	}
 	return allErrs
 }
 
@@ -92,6 +92,15 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "negative rotation requests",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.RotationRequests = -1
+				return b
+			}(),
+			valid: false,
+		},
 		{
 			name: "missing instance name",
 			binding: func() *servicecatalog.ServiceBinding {
@@ -128,6 +137,24 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid secretType",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretType = "kubernetes.io/basic-auth"
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid secretType",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.SecretType = "kubernetes.io/service-account-token"
+				return b
+			}(),
+			valid: false,
+		},
 		{
 			name: "valid parametersFrom",
 			binding: func() *servicecatalog.ServiceBinding {
@@ -171,6 +198,41 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid instance output in parametersFrom",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{InstanceOutputRef: &servicecatalog.InstanceOutputReference{Key: "dashboardURL"}}}
+				return b
+			}(),
+			valid: true,
+		},
+		{
+			name: "instance output key is missing in parametersFrom",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{InstanceOutputRef: &servicecatalog.InstanceOutputReference{Key: ""}}}
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "multiple sources in one parametersFrom entry",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{{
+						SecretKeyRef:      &servicecatalog.SecretKeyReference{Name: "test-key-name", Key: "test-key"},
+						InstanceOutputRef: &servicecatalog.InstanceOutputReference{Key: "dashboardURL"},
+					}}
+				return b
+			}(),
+			valid: false,
+		},
 
 		{
 			name:    "valid with in-progress bind",
@@ -657,3 +719,86 @@ func TestInternalValidateServiceBindingUpdateAllowed(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateServiceBindingSecretTransforms(t *testing.T) {
+	withJSONPath := func(expression string) *servicecatalog.ServiceBinding {
+		b := validServiceBinding()
+		b.Generation = 1
+		b.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+			{RenameKey: &servicecatalog.RenameKeyTransform{From: "a", To: "b"}},
+			{AddKey: &servicecatalog.AddKeyTransform{Key: "host", JSONPathExpression: &expression}},
+		}
+		return b
+	}
+
+	cases := []struct {
+		name       string
+		expression string
+		valid      bool
+	}{
+		{
+			name:       "valid expression",
+			expression: "{.services[0].credentials.host}",
+			valid:      true,
+		},
+		{
+			name:       "unclosed action",
+			expression: "{.services[0].credentials.host",
+			valid:      false,
+		},
+		{
+			name:       "unterminated array index",
+			expression: "{.services[0.credentials}",
+			valid:      false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := ValidateServiceBinding(withJSONPath(tc.expression))
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("unexpected error: %v", errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Error("unexpected success")
+			}
+			if !tc.valid && errs[0].Field != "spec.secretTransforms[1].addKey.jsonPathExpression" {
+				t.Errorf("unexpected field in error: %v", errs[0].Field)
+			}
+		})
+	}
+}
+
+func TestValidateServiceBindingUpdateSecretTransforms(t *testing.T) {
+	bad := "{.host"
+	oldBinding := validServiceBinding()
+	oldBinding.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+		{AddKey: &servicecatalog.AddKeyTransform{Key: "host", JSONPathExpression: &bad}},
+	}
+
+	newBinding := oldBinding.DeepCopy()
+	newBinding.Labels = map[string]string{"app": "wordpress"}
+	if errs := ValidateServiceBindingUpdate(newBinding, oldBinding); len(errs) != 0 {
+		t.Fatalf("unexpected error when the transforms are unchanged: %v", errs)
+	}
+
+	newBinding.Spec.SecretTransforms[0].AddKey.Key = "hostname"
+	if errs := ValidateServiceBindingUpdate(newBinding, oldBinding); len(errs) == 0 {
+		t.Fatal("unexpected success when changing the transforms")
+	}
+}
+
+func TestValidateServiceBindingUpdateRotationRequests(t *testing.T) {
+	oldBinding := validServiceBinding()
+	oldBinding.Spec.RotationRequests = 1
+
+	newBinding := oldBinding.DeepCopy()
+	newBinding.Spec.RotationRequests = 2
+	if errs := ValidateServiceBindingUpdate(newBinding, oldBinding); len(errs) != 0 {
+		t.Fatalf("unexpected error when incrementing the rotation requests: %v", errs)
+	}
+
+	newBinding.Spec.RotationRequests = 0
+	if errs := ValidateServiceBindingUpdate(newBinding, oldBinding); len(errs) == 0 {
+		t.Fatal("unexpected success when decrementing the rotation requests")
+	}
+}
@@ -18,6 +18,7 @@ package validation
 
 import (
 	"fmt"
+	"net/url"
 
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,27 @@ import (
 	"github.com/poy/service-catalog/pkg/filter"
 )
 
+// validateProxyURL validates the URL of the proxy used to contact a broker,
+// which must be an absolute http, https or socks5 URL.
+func validateProxyURL(proxyURL string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, proxyURL, fmt.Sprintf("proxyURL must be a valid URL: %v", err)))
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		allErrs = append(allErrs, field.Invalid(fldPath, proxyURL, `proxyURL must use the "http", "https" or "socks5" scheme`))
+	}
+	if u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, proxyURL, "proxyURL must have a host"))
+	}
+
+	return allErrs
+}
+
 // validateCommonServiceBrokerName is the validation function for common
 // broker names.
 var validateCommonServiceBrokerName = apivalidation.NameIsDNSSubdomain
@@ -123,6 +145,12 @@ func validateServiceBrokerSpec(spec *sc.ServiceBrokerSpec, fldPath *field.Path)
 		if spec.AuthInfo.Basic != nil {
 			secretRef := spec.AuthInfo.Basic.SecretRef
 			if secretRef != nil {
+				// the namespace is optional and defaults to the one of the broker
+				if secretRef.Namespace != "" {
+					for _, msg := range apivalidation.ValidateNamespaceName(secretRef.Namespace, false /* prefix */) {
+						allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "basic", "secretRef", "namespace"), secretRef.Namespace, msg))
+					}
+				}
 				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
 					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "basic", "secretRef", "name"), secretRef.Name, msg))
 				}
@@ -135,6 +163,12 @@ func validateServiceBrokerSpec(spec *sc.ServiceBrokerSpec, fldPath *field.Path)
 		} else if spec.AuthInfo.Bearer != nil {
 			secretRef := spec.AuthInfo.Bearer.SecretRef
 			if secretRef != nil {
+				// the namespace is optional and defaults to the one of the broker
+				if secretRef.Namespace != "" {
+					for _, msg := range apivalidation.ValidateNamespaceName(secretRef.Namespace, false /* prefix */) {
+						allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "bearer", "secretRef", "namespace"), secretRef.Namespace, msg))
+					}
+				}
 				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
 					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "bearer", "secretRef", "name"), secretRef.Name, msg))
 				}
@@ -175,6 +209,10 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 		commonErrs = append(commonErrs, field.Invalid(fldPath.Child("caBundle"), spec.CABundle, "caBundle cannot be used when insecureSkipTLSVerify is true"))
 	}
 
+	if spec.ProxyURL != "" {
+		commonErrs = append(commonErrs, validateProxyURL(spec.ProxyURL, fldPath.Child("proxyURL"))...)
+	}
+
 	if "" == spec.RelistBehavior {
 		commonErrs = append(commonErrs,
 			field.Required(fldPath.Child("relistBehavior"),
@@ -246,6 +284,9 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 			}
 		}
 	}
+	if spec.CatalogRestrictions != nil {
+		commonErrs = append(commonErrs, validateCatalogRestrictionTags(spec.CatalogRestrictions, fldPath.Child("catalogRestrictions"))...)
+	}
 
 	return commonErrs
 }
@@ -289,3 +330,26 @@ func ValidateServiceBrokerStatusUpdate(new *sc.ServiceBroker, old *sc.ServiceBro
 	allErrs = append(allErrs, ValidateServiceBrokerUpdate(new, old)...)
 	return allErrs
 }
+
+// validateCatalogRestrictionTags validates the tags the classes of a broker are
+// restricted by. A tag can't be both allowed and denied.
+func validateCatalogRestrictionTags(restrictions *sc.CatalogRestrictions, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allowed := map[string]bool{}
+	for i, tag := range restrictions.AllowedTags {
+		if tag == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("allowedTags").Index(i), "tags must not be empty"))
+		}
+		allowed[tag] = true
+	}
+	for i, tag := range restrictions.DeniedTags {
+		if tag == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("deniedTags").Index(i), "tags must not be empty"))
+		} else if allowed[tag] {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("deniedTags").Index(i), tag, "tag is also allowed"))
+		}
+	}
+
+	return allErrs
+}
@@ -258,6 +258,57 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "valid clusterservicebroker - proxy URL",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						ProxyURL:       "http://proxy.example.com:3128",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - proxy URL with unsupported scheme",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						ProxyURL:       "ftp://proxy.example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - proxy URL without host",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						ProxyURL:       "http://",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
 		{
 			name: "valid clusterservicebroker - manual behavior with RelistDuration",
 			broker: &servicecatalog.ClusterServiceBroker{
@@ -556,6 +607,82 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "valid clusterservicebroker - catalogRequirements tags",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							AllowedTags: []string{"database", "mysql"},
+							DeniedTags:  []string{"beta"},
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - empty catalogRequirements.allowedTags",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							AllowedTags: []string{""},
+							DeniedTags:  []string{},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - empty catalogRequirements.deniedTags",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							AllowedTags: []string{},
+							DeniedTags:  []string{""},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - tag both allowed and denied",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorManual,
+						CatalogRestrictions: &servicecatalog.CatalogRestrictions{
+							AllowedTags: []string{"database", "beta"},
+							DeniedTags:  []string{"beta"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -713,7 +840,7 @@ func TestValidateServiceBroker(t *testing.T) {
 				Spec: servicecatalog.ServiceBrokerSpec{
 					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
 						Basic: &servicecatalog.BasicAuthConfig{
-							SecretRef: &servicecatalog.LocalObjectReference{
+							SecretRef: &servicecatalog.ObjectReference{
 								Name: "test-secret",
 							},
 						},
@@ -737,7 +864,7 @@ func TestValidateServiceBroker(t *testing.T) {
 				Spec: servicecatalog.ServiceBrokerSpec{
 					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
 						Bearer: &servicecatalog.BearerTokenAuthConfig{
-							SecretRef: &servicecatalog.LocalObjectReference{
+							SecretRef: &servicecatalog.ObjectReference{
 								Name: "test-secret",
 							},
 						},
@@ -751,6 +878,56 @@ func TestValidateServiceBroker(t *testing.T) {
 			},
 			valid: true,
 		},
+		{
+			name: "valid servicebroker - basic auth - secret in another namespace",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						Basic: &servicecatalog.BasicAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "secrets",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid servicebroker - bearer auth - secret with invalid namespace",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						Bearer: &servicecatalog.BearerTokenAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "Invalid_Namespace",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
 		{
 			name: "invalid servicebroker - servicebroker without namespace",
 			broker: &servicecatalog.ServiceBroker{
@@ -777,7 +954,7 @@ func TestValidateServiceBroker(t *testing.T) {
 				Spec: servicecatalog.ServiceBrokerSpec{
 					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
 						Basic: &servicecatalog.BasicAuthConfig{
-							SecretRef: &servicecatalog.LocalObjectReference{},
+							SecretRef: &servicecatalog.ObjectReference{},
 						},
 					},
 					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
@@ -799,7 +976,7 @@ func TestValidateServiceBroker(t *testing.T) {
 				Spec: servicecatalog.ServiceBrokerSpec{
 					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
 						Bearer: &servicecatalog.BearerTokenAuthConfig{
-							SecretRef: &servicecatalog.LocalObjectReference{},
+							SecretRef: &servicecatalog.ObjectReference{},
 						},
 					},
 					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
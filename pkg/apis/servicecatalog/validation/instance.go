@@ -77,7 +77,22 @@ func internalValidateServiceInstance(instance *sc.ServiceInstance, create bool)
 	allErrs = append(allErrs, apivalidation.ValidateObjectMeta(&instance.ObjectMeta, true, /*namespace*/
 		validateServiceInstanceName,
 		field.NewPath("metadata"))...)
+	if value, ok := instance.Annotations[controller.ProvisionTimeoutAnnotation]; ok {
+		if _, err := controller.ParseProvisionTimeout(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "annotations").Key(controller.ProvisionTimeoutAnnotation), value, "must be a positive duration, such as 30m"))
+		}
+	}
+	if value, ok := instance.Annotations[controller.OrphanMitigationPolicyAnnotation]; ok {
+		path := field.NewPath("metadata", "annotations").Key(controller.OrphanMitigationPolicyAnnotation)
+		if mode, err := controller.ParseOrphanMitigationMode(value); err != nil {
+			allErrs = append(allErrs, field.NotSupported(path, value,
+				[]string{string(controller.OrphanMitigationAutomatic), string(controller.OrphanMitigationDelayed), string(controller.OrphanMitigationManual)}))
+		} else if instance.Spec.DisableOrphanMitigation && mode != controller.OrphanMitigationManual {
+			allErrs = append(allErrs, field.Invalid(path, value, "must be Manual when spec.disableOrphanMitigation is set"))
+		}
+	}
 	allErrs = append(allErrs, validateServiceInstanceSpec(&instance.Spec, field.NewPath("spec"), create)...)
+	allErrs = append(allErrs, validateServiceInstanceDependsOn(instance, field.NewPath("spec", "dependsOn"))...)
 	allErrs = append(allErrs, validateServiceInstanceStatus(&instance.Status, field.NewPath("status"), create)...)
 	if create {
 		allErrs = append(allErrs, validateServiceInstanceCreate(instance)...)
@@ -94,7 +109,7 @@ func validateServiceInstanceSpec(spec *sc.ServiceInstanceSpec, fldPath *field.Pa
 	allErrs = append(allErrs, validatePlanReference(&spec.PlanReference, fldPath)...)
 
 	if spec.ParametersFrom != nil {
-		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
+		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, false, fldPath)...)
 	}
 	if spec.Parameters != nil {
 		if len(spec.Parameters.Raw) == 0 {
@@ -110,6 +125,31 @@ func validateServiceInstanceSpec(spec *sc.ServiceInstanceSpec, fldPath *field.Pa
 	return allErrs
 }
 
+// validateServiceInstanceDependsOn validates the instances an instance
+// depends on: each must be named once, and not be the instance itself.
+// Longer dependency cycles are rejected by the ServiceInstanceDependencies
+// admission plugin, which can look up the other instances.
+func validateServiceInstanceDependsOn(instance *sc.ServiceInstance, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := make(map[string]bool)
+	for i, dependency := range instance.Spec.DependsOn {
+		idxPath := fldPath.Index(i).Child("name")
+		for _, msg := range validateServiceInstanceName(dependency.Name, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(idxPath, dependency.Name, msg))
+		}
+		if dependency.Name == instance.Name {
+			allErrs = append(allErrs, field.Invalid(idxPath, dependency.Name, "an instance cannot depend on itself"))
+		}
+		if names[dependency.Name] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, dependency.Name))
+		}
+		names[dependency.Name] = true
+	}
+
+	return allErrs
+}
+
 func validateServiceInstanceStatus(status *sc.ServiceInstanceStatus, fldPath *field.Path, create bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -353,6 +393,12 @@ func internalValidateServiceInstanceStatusUpdateAllowed(new *sc.ServiceInstance,
 	errors := field.ErrorList{}
 	// TODO(vaikas): Are there any cases where we do not allow updates to
 	// Status during Async updates in progress?
+
+	// The external ID the instance was provisioned with must be kept for
+	// the retries of the provision request once it was recorded.
+	if old.Status.ExternalID != "" {
+		errors = append(errors, apivalidation.ValidateImmutableField(new.Status.ExternalID, old.Status.ExternalID, field.NewPath("status").Child("externalID"))...)
+	}
 	return errors
 }
 
@@ -27,6 +27,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+	"github.com/poy/service-catalog/pkg/controller"
 )
 
 const (
@@ -228,6 +229,107 @@ func TestValidateServiceInstance(t *testing.T) {
 			}(),
 			valid: false,
 		},
+		{
+			name: "valid provision timeout",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Annotations = map[string]string{controller.ProvisionTimeoutAnnotation: "1h30m"}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid provision timeout",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Annotations = map[string]string{controller.ProvisionTimeoutAnnotation: "an hour"}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "negative provision timeout",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Annotations = map[string]string{controller.ProvisionTimeoutAnnotation: "-5m"}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid orphan mitigation policy",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Annotations = map[string]string{controller.OrphanMitigationPolicyAnnotation: "Delayed"}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "invalid orphan mitigation policy",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Annotations = map[string]string{controller.OrphanMitigationPolicyAnnotation: "Never"}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "Manual orphan mitigation policy with orphan mitigation disabled",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Annotations = map[string]string{controller.OrphanMitigationPolicyAnnotation: "Manual"}
+				i.Spec.DisableOrphanMitigation = true
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "Automatic orphan mitigation policy with orphan mitigation disabled",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Annotations = map[string]string{controller.OrphanMitigationPolicyAnnotation: "Automatic"}
+				i.Spec.DisableOrphanMitigation = true
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "valid dependsOn",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.DependsOn = []servicecatalog.LocalObjectReference{{Name: "database"}, {Name: "cache"}}
+				return i
+			}(),
+			valid: true,
+		},
+		{
+			name: "dependsOn with an invalid name",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.DependsOn = []servicecatalog.LocalObjectReference{{Name: "Database_1"}}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "dependsOn with a duplicate instance",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.DependsOn = []servicecatalog.LocalObjectReference{{Name: "database"}, {Name: "database"}}
+				return i
+			}(),
+			valid: false,
+		},
+		{
+			name: "dependsOn with the instance itself",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.DependsOn = []servicecatalog.LocalObjectReference{{Name: i.Name}}
+				return i
+			}(),
+			valid: false,
+		},
 		{
 			name: "missing clusterServiceClassExternalName and clusterServiceClassName",
 			instance: func() *servicecatalog.ServiceInstance {
@@ -266,6 +368,17 @@ func TestValidateServiceInstance(t *testing.T) {
 			}(),
 			valid: true,
 		},
+		{
+			name: "instance output in parametersFrom",
+			instance: func() *servicecatalog.ServiceInstance {
+				i := validClusterRefServiceInstance()
+				i.Spec.ParametersFrom =
+					[]servicecatalog.ParametersFromSource{
+						{InstanceOutputRef: &servicecatalog.InstanceOutputReference{Key: "dashboardURL"}}}
+				return i
+			}(),
+			valid: false,
+		},
 		{
 			name: "missing key reference in parametersFrom",
 			instance: func() *servicecatalog.ServiceInstance {
@@ -1399,6 +1512,31 @@ func TestValidateServiceInstanceStatusUpdate(t *testing.T) {
 			valid: false,
 			err:   "",
 		},
+		{
+			name: "Record external ID",
+			old: &servicecatalog.ServiceInstanceStatus{
+				DeprovisionStatus: servicecatalog.ServiceInstanceDeprovisionStatusRequired,
+			},
+			new: &servicecatalog.ServiceInstanceStatus{
+				ExternalID:        "instance-id",
+				DeprovisionStatus: servicecatalog.ServiceInstanceDeprovisionStatusRequired,
+			},
+			valid: true,
+			err:   "",
+		},
+		{
+			name: "External ID cannot be changed",
+			old: &servicecatalog.ServiceInstanceStatus{
+				ExternalID:        "instance-id",
+				DeprovisionStatus: servicecatalog.ServiceInstanceDeprovisionStatusRequired,
+			},
+			new: &servicecatalog.ServiceInstanceStatus{
+				ExternalID:        "other-id",
+				DeprovisionStatus: servicecatalog.ServiceInstanceDeprovisionStatusRequired,
+			},
+			valid: false,
+			err:   "field is immutable",
+		},
 	}
 
 	for _, tc := range cases {
@@ -28,17 +28,28 @@ func stringIsHexadecimal(s string) bool {
 	return hexademicalStringRegexp.MatchString(s)
 }
 
-func validateParametersFromSource(parametersFrom []sc.ParametersFromSource, fldPath *field.Path) field.ErrorList {
+// validateParametersFromSource validates a list of parameter sources.
+// InstanceOutputRef sources are only accepted when allowInstanceOutputRef is
+// set, as only bindings have an instance to take outputs from.
+func validateParametersFromSource(parametersFrom []sc.ParametersFromSource, allowInstanceOutputRef bool, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	for _, paramsFrom := range parametersFrom {
-		if paramsFrom.SecretKeyRef != nil {
+		if paramsFrom.SecretKeyRef != nil && paramsFrom.InstanceOutputRef != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("parametersFrom"), paramsFrom, "only one source may be specified"))
+		} else if paramsFrom.SecretKeyRef != nil {
 			if paramsFrom.SecretKeyRef.Name == "" {
 				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretKeyRef.name"), "name is required"))
 			}
 			if paramsFrom.SecretKeyRef.Key == "" {
 				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretKeyRef.key"), "key is required"))
 			}
+		} else if paramsFrom.InstanceOutputRef != nil {
+			if !allowInstanceOutputRef {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("parametersFrom.instanceOutputRef"), "instance outputs may only be referenced from a binding"))
+			} else if paramsFrom.InstanceOutputRef.Key == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.instanceOutputRef.key"), "key is required"))
+			}
 		} else {
 			allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom"), "source must not be empty if present"))
 		}
@@ -82,7 +82,7 @@ func (in *BasicAuthConfig) DeepCopyInto(out *BasicAuthConfig) {
 	*out = *in
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(LocalObjectReference)
+		*out = new(ObjectReference)
 		**out = **in
 	}
 	return
@@ -103,7 +103,7 @@ func (in *BearerTokenAuthConfig) DeepCopyInto(out *BearerTokenAuthConfig) {
 	*out = *in
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(LocalObjectReference)
+		*out = new(ObjectReference)
 		**out = **in
 	}
 	return
@@ -132,6 +132,16 @@ func (in *CatalogRestrictions) DeepCopyInto(out *CatalogRestrictions) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AllowedTags != nil {
+		in, out := &in.AllowedTags, &out.AllowedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedTags != nil {
+		in, out := &in.DeniedTags, &out.DeniedTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -721,6 +731,22 @@ func (in ExtraValue) DeepCopy() ExtraValue {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceOutputReference) DeepCopyInto(out *InstanceOutputReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceOutputReference.
+func (in *InstanceOutputReference) DeepCopy() *InstanceOutputReference {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceOutputReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalObjectReference) DeepCopyInto(out *LocalObjectReference) {
 	*out = *in
@@ -761,6 +787,11 @@ func (in *ParametersFromSource) DeepCopyInto(out *ParametersFromSource) {
 		*out = new(SecretKeyReference)
 		**out = **in
 	}
+	if in.InstanceOutputRef != nil {
+		in, out := &in.InstanceOutputRef, &out.InstanceOutputRef
+		*out = new(InstanceOutputReference)
+		**out = **in
+	}
 	return
 }
 
@@ -861,6 +892,11 @@ func (in *SecretTransform) DeepCopyInto(out *SecretTransform) {
 		*out = new(RemoveKeyTransform)
 		**out = **in
 	}
+	if in.TemplateKey != nil {
+		in, out := &in.TemplateKey, &out.TemplateKey
+		*out = new(TemplateKeyTransform)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1048,6 +1084,10 @@ func (in *ServiceBindingStatus) DeepCopyInto(out *ServiceBindingStatus) {
 		*out = new(ServiceBindingPropertiesState)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -1444,6 +1484,11 @@ func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
 		*out = new(UserInfo)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1467,6 +1512,10 @@ func (in *ServiceInstanceStatus) DeepCopyInto(out *ServiceInstanceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OrphanMitigationStartTime != nil {
+		in, out := &in.OrphanMitigationStartTime, &out.OrphanMitigationStartTime
+		*out = (*in).DeepCopy()
+	}
 	if in.LastOperation != nil {
 		in, out := &in.LastOperation, &out.LastOperation
 		*out = new(string)
@@ -1605,6 +1654,27 @@ func (in *ServicePlanStatus) DeepCopy() *ServicePlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateKeyTransform) DeepCopyInto(out *TemplateKeyTransform) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateKeyTransform.
+func (in *TemplateKeyTransform) DeepCopy() *TemplateKeyTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateKeyTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfo) DeepCopyInto(out *UserInfo) {
 	*out = *in
@@ -123,7 +123,7 @@ func (c *FakeClusterServicePlans) DeleteCollection(options *v1.DeleteOptions, li
 // Patch applies the patch and returns the patched clusterServicePlan.
 func (c *FakeClusterServicePlans) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.ClusterServicePlan, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewRootPatchSubresourceAction(clusterserviceplansResource, name, data, subresources...), &v1beta1.ClusterServicePlan{})
+		Invokes(testing.NewRootPatchSubresourceAction(clusterserviceplansResource, name, pt, data, subresources...), &v1beta1.ClusterServicePlan{})
 	if obj == nil {
 		return nil, err
 	}
@@ -131,7 +131,7 @@ func (c *FakeServiceInstances) DeleteCollection(options *v1.DeleteOptions, listO
 // Patch applies the patch and returns the patched serviceInstance.
 func (c *FakeServiceInstances) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.ServiceInstance, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(serviceinstancesResource, c.ns, name, data, subresources...), &v1beta1.ServiceInstance{})
+		Invokes(testing.NewPatchSubresourceAction(serviceinstancesResource, c.ns, name, pt, data, subresources...), &v1beta1.ServiceInstance{})
 
 	if obj == nil {
 		return nil, err
@@ -131,7 +131,7 @@ func (c *FakeServicePlans) DeleteCollection(options *v1.DeleteOptions, listOptio
 // Patch applies the patch and returns the patched servicePlan.
 func (c *FakeServicePlans) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1beta1.ServicePlan, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(serviceplansResource, c.ns, name, data, subresources...), &v1beta1.ServicePlan{})
+		Invokes(testing.NewPatchSubresourceAction(serviceplansResource, c.ns, name, pt, data, subresources...), &v1beta1.ServicePlan{})
 
 	if obj == nil {
 		return nil, err
@@ -0,0 +1,186 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	secretDriftedReason  string = "SecretDrifted"
+	secretRestoredReason string = "SecretRestored"
+)
+
+// secretChecksum returns a checksum of the data of a binding's secret.
+func secretChecksum(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		// Prefix keys and values with their length so that different
+		// secrets can't produce the same input.
+		fmt.Fprintf(h, "%d:%s%d:", len(k), k, len(data[k]))
+		h.Write(data[k])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// reconcileServiceBindingSecretDrift checks that the secret of a bound
+// ServiceBinding still holds the data the controller last wrote to it. A
+// secret that was changed or deleted is restored from the credentials fetched
+// from the broker. When that is not possible, the Ready condition of the
+// binding reports that the secret has drifted.
+func (c *controller) reconcileServiceBindingSecretDrift(binding *v1beta1.ServiceBinding) error {
+	pcb := c.newBindingContextBuilder(binding)
+
+	var drift string
+	secret, err := c.kubeClient.CoreV1().Secrets(binding.Namespace).Get(binding.Spec.SecretName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		drift = "was deleted"
+	case err != nil:
+		return fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, binding.Namespace, binding.Spec.SecretName, err)
+	case !metav1.IsControlledBy(secret, binding):
+		// The controller never overwrites a secret it does not own.
+		klog.V(4).Info(pcb.Messagef(`Not checking Secret "%s/%s" for drift because it is not owned by the ServiceBinding`, binding.Namespace, secret.Name))
+		return nil
+	case secretChecksum(secret.Data) != binding.Status.SecretChecksum:
+		drift = "was modified"
+	default:
+		if isServiceBindingSecretDrifted(binding) {
+			msg := fmt.Sprintf(`Secret "%s/%s" holds the credentials of the ServiceBinding again`, binding.Namespace, binding.Spec.SecretName)
+			return c.recordServiceBindingSecretRestored(binding.DeepCopy(), msg)
+		}
+		return nil
+	}
+
+	klog.V(4).Info(pcb.Messagef(`Secret "%s/%s" %s, restoring it`, binding.Namespace, binding.Spec.SecretName, drift))
+
+	binding = binding.DeepCopy()
+	credentials, delayed, err := c.fetchServiceBindingCredentials(binding)
+	if delayed {
+		return nil
+	}
+	if err == nil {
+		err = c.injectServiceBinding(binding, credentials)
+	}
+	if err != nil {
+		msg := fmt.Sprintf(`Secret "%s/%s" %s and cannot be restored: %v`, binding.Namespace, binding.Spec.SecretName, drift, err)
+		return c.recordServiceBindingSecretDrifted(binding, msg)
+	}
+
+	msg := fmt.Sprintf(`Secret "%s/%s" %s and was restored from the credentials fetched from the broker`, binding.Namespace, binding.Spec.SecretName, drift)
+	return c.recordServiceBindingSecretRestored(binding, msg)
+}
+
+// fetchServiceBindingCredentials fetches the credentials of the binding from
+// the broker. When the call has to be delayed to honor the rate limit of the
+// broker, the binding is requeued and true is returned instead.
+func (c *controller) fetchServiceBindingCredentials(binding *v1beta1.ServiceBinding) (map[string]interface{}, bool, error) {
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.InstanceRef.Name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var bindingRetrievable bool
+	var brokerClient osb.Client
+	if instance.Spec.ClusterServiceClassSpecified() {
+		serviceClass, _, bClient, err := c.getClusterServiceClassAndClusterServiceBrokerForServiceBinding(instance, binding)
+		if err != nil {
+			return nil, false, err
+		}
+		bindingRetrievable, brokerClient = serviceClass.Spec.BindingRetrievable, bClient
+	} else {
+		serviceClass, _, bClient, err := c.getServiceClassAndServiceBrokerForServiceBinding(instance, binding)
+		if err != nil {
+			return nil, false, err
+		}
+		bindingRetrievable, brokerClient = serviceClass.Spec.BindingRetrievable, bClient
+	}
+
+	if !bindingRetrievable {
+		return nil, false, fmt.Errorf("the broker does not support fetching bindings")
+	}
+
+	if c.delayServiceBindingBrokerCall(binding, brokerClient) {
+		return nil, true, nil
+	}
+	response, err := brokerClient.GetBinding(&osb.GetBindingRequest{
+		InstanceID: instance.Spec.ExternalID,
+		BindingID:  binding.Spec.ExternalID,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("could not do a GET on binding resource: %v", err)
+	}
+	return response.Credentials, false, nil
+}
+
+// isServiceBindingSecretDrifted returns whether the Ready condition of the
+// binding reports that its secret has drifted.
+func isServiceBindingSecretDrifted(binding *v1beta1.ServiceBinding) bool {
+	for _, condition := range binding.Status.Conditions {
+		if condition.Type == v1beta1.ServiceBindingConditionReady {
+			return condition.Reason == secretDriftedReason
+		}
+	}
+	return false
+}
+
+// recordServiceBindingSecretDrifted reports on the Ready condition of the
+// binding that its secret has drifted and could not be restored. The status is
+// left alone when it already reports the same drift, so that the binding is
+// not requeued over and over.
+func (c *controller) recordServiceBindingSecretDrifted(binding *v1beta1.ServiceBinding, msg string) error {
+	for _, condition := range binding.Status.Conditions {
+		if condition.Type == v1beta1.ServiceBindingConditionReady &&
+			condition.Reason == secretDriftedReason && condition.Message == msg {
+			return nil
+		}
+	}
+
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionFalse, secretDriftedReason, msg)
+	if _, err := c.updateServiceBindingStatus(binding); err != nil {
+		return err
+	}
+	c.recorder.Event(binding, corev1.EventTypeWarning, secretDriftedReason, msg)
+	return nil
+}
+
+// recordServiceBindingSecretRestored marks the binding as ready again once its
+// secret holds its credentials again.
+func (c *controller) recordServiceBindingSecretRestored(binding *v1beta1.ServiceBinding, msg string) error {
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, secretRestoredReason, msg)
+	if _, err := c.updateServiceBindingStatus(binding); err != nil {
+		return err
+	}
+	c.recorder.Event(binding, corev1.EventTypeNormal, secretRestoredReason, msg)
+	return nil
+}
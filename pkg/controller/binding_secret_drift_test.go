@@ -0,0 +1,226 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/poy/service-catalog/pkg/features"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+func TestSecretChecksum(t *testing.T) {
+	checksum := secretChecksum(map[string][]byte{"a": []byte("bc"), "d": []byte("e")})
+	if e, a := checksum, secretChecksum(map[string][]byte{"d": []byte("e"), "a": []byte("bc")}); e != a {
+		t.Fatalf("expected the checksum to be stable, got %q and %q", e, a)
+	}
+
+	others := []map[string][]byte{
+		{"a": []byte("bc")},
+		{"a": []byte("bd"), "d": []byte("e")},
+		{"ab": []byte("c"), "d": []byte("e")},
+		{},
+	}
+	for _, data := range others {
+		if secretChecksum(data) == checksum {
+			t.Errorf("expected the checksum of %v to differ", data)
+		}
+	}
+}
+
+// TestReconcileServiceBindingSecretDrift tests how the secret of a bound
+// binding is checked for drift and restored.
+func TestReconcileServiceBindingSecretDrift(t *testing.T) {
+	credentials := map[string][]byte{"a": []byte("b")}
+	ownedSecret := func(data map[string][]byte) *corev1.Secret {
+		binding := getTestServiceBindingWithSecretChecksum(credentials)
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testServiceBindingSecretName,
+				Namespace: testNamespace,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(binding, bindingControllerKind),
+				},
+			},
+			Data: data,
+		}
+	}
+
+	cases := []struct {
+		name               string
+		disabled           bool
+		drifted            bool
+		secret             *corev1.Secret
+		bindingRetrievable bool
+		secretVerbs        []string
+		getBinding         bool
+		readyStatus        v1beta1.ConditionStatus
+		readyReason        string
+	}{
+		{
+			name:        "unchanged secret",
+			secret:      ownedSecret(credentials),
+			secretVerbs: []string{"get"},
+		},
+		{
+			name:     "feature disabled",
+			disabled: true,
+			secret:   ownedSecret(map[string][]byte{"a": []byte("x")}),
+		},
+		{
+			name: "secret not owned by the binding",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: testServiceBindingSecretName, Namespace: testNamespace},
+				Data:       map[string][]byte{"a": []byte("x")},
+			},
+			secretVerbs: []string{"get"},
+		},
+		{
+			name:               "modified secret is restored",
+			secret:             ownedSecret(map[string][]byte{"a": []byte("x")}),
+			bindingRetrievable: true,
+			secretVerbs:        []string{"get", "get", "update"},
+			getBinding:         true,
+			readyStatus:        v1beta1.ConditionTrue,
+			readyReason:        secretRestoredReason,
+		},
+		{
+			name:               "deleted secret is restored",
+			bindingRetrievable: true,
+			secretVerbs:        []string{"get", "get", "create"},
+			getBinding:         true,
+			readyStatus:        v1beta1.ConditionTrue,
+			readyReason:        secretRestoredReason,
+		},
+		{
+			name:        "modified secret cannot be restored",
+			secret:      ownedSecret(map[string][]byte{"a": []byte("x")}),
+			secretVerbs: []string{"get"},
+			readyStatus: v1beta1.ConditionFalse,
+			readyReason: secretDriftedReason,
+		},
+		{
+			name:        "drifted secret was restored by someone else",
+			drifted:     true,
+			secret:      ownedSecret(credentials),
+			secretVerbs: []string{"get"},
+			readyStatus: v1beta1.ConditionTrue,
+			readyReason: secretRestoredReason,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.disabled {
+				utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingSecretDriftDetection))
+				defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingSecretDriftDetection))
+			}
+
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				GetBindingReaction: &fakeosb.GetBindingReaction{
+					Response: &osb.GetBindingResponse{
+						Credentials: map[string]interface{}{"a": "b"},
+					},
+				},
+			})
+
+			fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				if tc.secret == nil {
+					return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), testServiceBindingSecretName)
+				}
+				return true, tc.secret.DeepCopy(), nil
+			})
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			if tc.bindingRetrievable {
+				sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+			} else {
+				sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			}
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+			binding := getTestServiceBindingWithSecretChecksum(credentials)
+			if tc.drifted {
+				binding.Status.Conditions[0].Status = v1beta1.ConditionFalse
+				binding.Status.Conditions[0].Reason = secretDriftedReason
+			}
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			kubeActions := fakeKubeClient.Actions()
+			if e, a := len(tc.secretVerbs), len(kubeActions); e != a {
+				t.Fatalf("expected %d kube client actions, got %d: %v", e, a, kubeActions)
+			}
+			for i, verb := range tc.secretVerbs {
+				if !kubeActions[i].Matches(verb, "secrets") {
+					t.Fatalf("action %d: expected %s secrets, got %v", i, verb, kubeActions[i])
+				}
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			if tc.getBinding {
+				assertNumberOfBrokerActions(t, brokerActions, 1)
+				assertGetBinding(t, brokerActions[0], &osb.GetBindingRequest{
+					InstanceID: testServiceInstanceGUID,
+					BindingID:  testServiceBindingGUID,
+				})
+			} else {
+				assertNumberOfBrokerActions(t, brokerActions, 0)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			if tc.readyReason == "" {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding)
+			assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionReady, tc.readyStatus, tc.readyReason)
+			if e, a := secretChecksum(credentials), updatedServiceBinding.(*v1beta1.ServiceBinding).Status.SecretChecksum; e != a {
+				t.Fatalf("unexpected secret checksum: expected %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+// getTestServiceBindingWithSecretChecksum returns a bound binding whose
+// secret was last written with the given data.
+func getTestServiceBindingWithSecretChecksum(data map[string][]byte) *v1beta1.ServiceBinding {
+	binding := getTestServiceBinding()
+	binding.UID = testServiceBindingGUID
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Status.ReconciledGeneration = binding.Generation
+	binding.Status.Conditions = []v1beta1.ServiceBindingCondition{{
+		Type:   v1beta1.ServiceBindingConditionReady,
+		Status: v1beta1.ConditionTrue,
+		Reason: successInjectedBindResultReason,
+	}}
+	binding.Status.SecretChecksum = secretChecksum(data)
+	return binding
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	"github.com/poy/service-catalog/pkg/metrics"
+)
+
+// The operations the latency of the calls to brokers is broken out by.
+const (
+	brokerOperationGetCatalog  = "getcatalog"
+	brokerOperationProvision   = "provision"
+	brokerOperationUpdate      = "update"
+	brokerOperationDeprovision = "deprovision"
+	brokerOperationPoll        = "poll"
+	brokerOperationPollBinding = "pollbinding"
+	brokerOperationBind        = "bind"
+	brokerOperationUnbind      = "unbind"
+	brokerOperationGetBinding  = "getbinding"
+	brokerOperationGetInstance = "getinstance"
+)
+
+// latencyObservingClient is an osb.Client that observes how long the calls
+// made to the broker take, labeled by operation and by the scope of the
+// broker.
+type latencyObservingClient struct {
+	osb.Client
+	scope string
+}
+
+// newLatencyObservingClient wraps the client of the broker with the given
+// key so that the latency of its calls is observed.
+func newLatencyObservingClient(client osb.Client, brokerKey BrokerKey) *latencyObservingClient {
+	return &latencyObservingClient{Client: client, scope: brokerScope(brokerKey)}
+}
+
+// brokerScope returns the scope label of the broker with the given key.
+func brokerScope(brokerKey BrokerKey) string {
+	if brokerKey.IsClusterScoped() {
+		return "cluster"
+	}
+	return "namespace"
+}
+
+// observe records the latency of a call that started at start.
+func (c *latencyObservingClient) observe(operation string, start time.Time) {
+	metrics.BrokerOperationLatency.WithLabelValues(operation, c.scope).Observe(time.Since(start).Seconds())
+}
+
+// observeBrokerOperationLatency records the latency of a call to the broker
+// with the given key that started at start, for the calls that are not made
+// through an osb.Client.
+func observeBrokerOperationLatency(operation string, brokerKey BrokerKey, start time.Time) {
+	metrics.BrokerOperationLatency.WithLabelValues(operation, brokerScope(brokerKey)).Observe(time.Since(start).Seconds())
+}
+
+func (c *latencyObservingClient) GetCatalog() (*osb.CatalogResponse, error) {
+	defer c.observe(brokerOperationGetCatalog, time.Now())
+	return c.Client.GetCatalog()
+}
+
+func (c *latencyObservingClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	defer c.observe(brokerOperationProvision, time.Now())
+	return c.Client.ProvisionInstance(r)
+}
+
+func (c *latencyObservingClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	defer c.observe(brokerOperationUpdate, time.Now())
+	return c.Client.UpdateInstance(r)
+}
+
+func (c *latencyObservingClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	defer c.observe(brokerOperationDeprovision, time.Now())
+	return c.Client.DeprovisionInstance(r)
+}
+
+func (c *latencyObservingClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	defer c.observe(brokerOperationPoll, time.Now())
+	return c.Client.PollLastOperation(r)
+}
+
+func (c *latencyObservingClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	defer c.observe(brokerOperationPollBinding, time.Now())
+	return c.Client.PollBindingLastOperation(r)
+}
+
+func (c *latencyObservingClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	defer c.observe(brokerOperationBind, time.Now())
+	return c.Client.Bind(r)
+}
+
+func (c *latencyObservingClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	defer c.observe(brokerOperationUnbind, time.Now())
+	return c.Client.Unbind(r)
+}
+
+func (c *latencyObservingClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	defer c.observe(brokerOperationGetBinding, time.Now())
+	return c.Client.GetBinding(r)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/poy/service-catalog/pkg/metrics"
+)
+
+func brokerOperationSampleCount(t *testing.T, operation, scope string) uint64 {
+	m := &dto.Metric{}
+	histogram := metrics.BrokerOperationLatency.WithLabelValues(operation, scope).(prometheus.Histogram)
+	if err := histogram.Write(m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+// TestLatencyObservingClient verifies that the calls made to brokers are
+// observed by operation and scope, whether they succeed or not.
+func TestLatencyObservingClient(t *testing.T) {
+	fakeClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{Response: &osb.CatalogResponse{}},
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: osb.HTTPStatusCodeError{StatusCode: 500},
+		},
+	})
+
+	catalogs := brokerOperationSampleCount(t, brokerOperationGetCatalog, "cluster")
+	provisions := brokerOperationSampleCount(t, brokerOperationProvision, "namespace")
+	clusterProvisions := brokerOperationSampleCount(t, brokerOperationProvision, "cluster")
+
+	clusterClient := newLatencyObservingClient(fakeClient, NewClusterServiceBrokerKey("broker"))
+	namespacedClient := newLatencyObservingClient(fakeClient, NewServiceBrokerKey("ns", "broker"))
+
+	if _, err := clusterClient.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := namespacedClient.ProvisionInstance(&osb.ProvisionRequest{}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if e, a := catalogs+1, brokerOperationSampleCount(t, brokerOperationGetCatalog, "cluster"); e != a {
+		t.Errorf("expected %v catalog calls to be observed, got %v", e, a)
+	}
+	if e, a := provisions+1, brokerOperationSampleCount(t, brokerOperationProvision, "namespace"); e != a {
+		t.Errorf("expected %v namespaced provision calls to be observed, got %v", e, a)
+	}
+	if e, a := clusterProvisions, brokerOperationSampleCount(t, brokerOperationProvision, "cluster"); e != a {
+		t.Errorf("expected %v cluster provision calls to be observed, got %v", e, a)
+	}
+}
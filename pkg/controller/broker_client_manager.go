@@ -18,10 +18,15 @@ package controller
 
 import (
 	"fmt"
+	"net/http"
 	"reflect"
 	"sync"
+	"time"
 
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/pretty"
+	"golang.org/x/time/rate"
 	"k8s.io/klog"
 )
 
@@ -62,36 +67,87 @@ func NewClusterServiceBrokerKey(name string) BrokerKey {
 
 // BrokerClientManager stores OSB client instances per broker
 type BrokerClientManager struct {
-	mu      sync.RWMutex
-	clients map[BrokerKey]clientWithConfig
+	mu       sync.RWMutex
+	clients  map[BrokerKey]clientWithConfig
+	limiters map[BrokerKey]*rate.Limiter
 
 	brokerClientCreateFunc osb.CreateFunc
+
+	// brokerQPS and brokerBurst configure the token bucket used to rate
+	// limit the calls made to each broker. A brokerQPS of zero disables
+	// rate limiting.
+	brokerQPS   float32
+	brokerBurst int
+
+	// observeLatency wraps the created clients so that the latency of the
+	// calls they make to brokers is observed.
+	observeLatency bool
+
+	// delayedNegotiations holds the version of the OSB API at which the
+	// negotiation of the version to use with a broker was delayed by the
+	// rate limit of the broker.
+	delayedNegotiations map[BrokerKey]osb.APIVersion
 }
 
-// NewBrokerClientManager creates BrokerClientManager instance
-func NewBrokerClientManager(brokerClientCreateFunc osb.CreateFunc) *BrokerClientManager {
+// NewBrokerClientManager creates BrokerClientManager instance. The calls made
+// by the created clients are limited to brokerQPS queries per second per
+// broker, with bursts of up to brokerBurst calls. A brokerQPS of zero
+// disables rate limiting.
+func NewBrokerClientManager(brokerClientCreateFunc osb.CreateFunc, brokerQPS float32, brokerBurst int) *BrokerClientManager {
 	return &BrokerClientManager{
 		clients:                map[BrokerKey]clientWithConfig{},
+		limiters:               map[BrokerKey]*rate.Limiter{},
+		delayedNegotiations:    map[BrokerKey]osb.APIVersion{},
 		brokerClientCreateFunc: brokerClientCreateFunc,
+		brokerQPS:              brokerQPS,
+		brokerBurst:            brokerBurst,
 	}
 }
 
 // UpdateBrokerClient creates new broker client if necessary (the ClientConfig has changed or there is no client for the broker),
 // the method returns created or stored osb.Client instance.
 func (m *BrokerClientManager) UpdateBrokerClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration) (osb.Client, error) {
+	return m.UpdateBrokerClientWithTransport(brokerKey, clientConfig, BrokerTransportConfiguration{})
+}
+
+// UpdateBrokerClientWithTransport is like UpdateBrokerClient, and also
+// configures the HTTP transport of the client. The client is created again
+// when the configuration of its transport has changed.
+func (m *BrokerClientManager) UpdateBrokerClientWithTransport(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration, transportConfig BrokerTransportConfiguration) (osb.Client, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	existing, found := m.clients[brokerKey]
 
-	if !found || configHasChanged(existing.clientConfig, clientConfig) {
+	if !found || configHasChanged(existing.clientConfig, clientConfig) || existing.transportConfig != transportConfig {
 		klog.V(4).Infof("Updating OSB client for broker %q, URL: %s", brokerKey.String(), clientConfig.URL)
-		return m.createClient(brokerKey, clientConfig)
+		return m.createClient(brokerKey, clientConfig, transportConfig)
 	}
 
 	return existing.OSBClient, nil
 }
 
+// UpdateBrokerClientAPIVersion changes the version of the OSB API used by the
+// client of a broker, keeping the rest of its configuration. It returns an
+// error if there is no client for the broker.
+func (m *BrokerClientManager) UpdateBrokerClientAPIVersion(brokerKey BrokerKey, version osb.APIVersion) (osb.Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, found := m.clients[brokerKey]
+	if !found {
+		return nil, fmt.Errorf("no client found for broker %q", brokerKey.String())
+	}
+	if existing.clientConfig.APIVersion == version {
+		return existing.OSBClient, nil
+	}
+
+	clientConfig := *existing.clientConfig
+	clientConfig.APIVersion = version
+	klog.V(4).Infof("Updating OSB client for broker %q to API version %s", brokerKey.String(), version.HeaderValue())
+	return m.createClient(brokerKey, &clientConfig, existing.transportConfig)
+}
+
 // RemoveBrokerClient removes broker client broker
 func (m *BrokerClientManager) RemoveBrokerClient(brokerKey BrokerKey) {
 	m.mu.Lock()
@@ -99,6 +155,8 @@ func (m *BrokerClientManager) RemoveBrokerClient(brokerKey BrokerKey) {
 
 	klog.V(4).Infof("Removing OSB client for broker %q", brokerKey.String())
 	delete(m.clients, brokerKey)
+	delete(m.limiters, brokerKey)
+	delete(m.delayedNegotiations, brokerKey)
 }
 
 // BrokerClient returns broker client for a broker specified by the brokerKey
@@ -110,17 +168,123 @@ func (m *BrokerClientManager) BrokerClient(brokerKey BrokerKey) (osb.Client, boo
 	return existing.OSBClient, found
 }
 
-func (m *BrokerClientManager) createClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration) (osb.Client, error) {
-	client, err := m.brokerClientCreateFunc(clientConfig)
+// getInstance fetches the instance with the given ID from the broker with the
+// given key, with the configuration and HTTP client of the client of the
+// broker. The call is not rate limited, callers are expected to have honored
+// the rate limit of the broker already.
+func (m *BrokerClientManager) getInstance(brokerKey BrokerKey, instanceID string) (*getInstanceResponse, error) {
+	m.mu.RLock()
+	existing, found := m.clients[brokerKey]
+	m.mu.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("no client found for broker %q", brokerKey.String())
+	}
+	if existing.httpClient == nil {
+		return nil, getInstanceNotAllowedError{reason: "the client of the broker cannot fetch instances"}
+	}
+
+	if m.observeLatency {
+		defer observeBrokerOperationLatency(brokerOperationGetInstance, brokerKey, time.Now())
+	}
+	return getBrokerInstance(existing.clientConfig, existing.httpClient, instanceID)
+}
+
+// newBrokerClientWithAPIVersion creates a client for the broker with the given
+// key that uses the given version of the OSB API, and otherwise the
+// configuration of the current client of the broker. The created client is
+// not stored, so the current client keeps being returned for the broker, but
+// its calls count towards the same rate limit. It returns an error if there
+// is no client for the broker.
+func (m *BrokerClientManager) newBrokerClientWithAPIVersion(brokerKey BrokerKey, version osb.APIVersion) (osb.Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, found := m.clients[brokerKey]
+	if !found {
+		return nil, fmt.Errorf("no client found for broker %q", brokerKey.String())
+	}
+
+	clientConfig := *existing.clientConfig
+	clientConfig.APIVersion = version
+	created, err := m.newClient(brokerKey, &clientConfig, existing.transportConfig)
+	if err != nil {
+		return nil, err
+	}
+	return created.OSBClient, nil
+}
+
+// recordDelayedOSBAPIVersionNegotiation records that the negotiation of the
+// version of the OSB API to use with the broker with the given key was
+// delayed before trying the given version.
+func (m *BrokerClientManager) recordDelayedOSBAPIVersionNegotiation(brokerKey BrokerKey, version osb.APIVersion) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.delayedNegotiations[brokerKey] = version
+}
+
+// takeDelayedOSBAPIVersionNegotiation returns and forgets the version of the
+// OSB API at which the negotiation with the broker with the given key was
+// delayed, if it was.
+func (m *BrokerClientManager) takeDelayedOSBAPIVersionNegotiation(brokerKey BrokerKey) (osb.APIVersion, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	version, found := m.delayedNegotiations[brokerKey]
+	delete(m.delayedNegotiations, brokerKey)
+	return version, found
+}
+
+func (m *BrokerClientManager) createClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration, transportConfig BrokerTransportConfiguration) (osb.Client, error) {
+	created, err := m.newClient(brokerKey, clientConfig, transportConfig)
 	if err != nil {
 		return nil, err
 	}
+	m.clients[brokerKey] = created
+	return created.OSBClient, nil
+}
 
-	m.clients[brokerKey] = clientWithConfig{
-		OSBClient:    client,
-		clientConfig: clientConfig,
+// newClient creates a client for the broker with the given key, without
+// storing it. It must be called with the lock held.
+func (m *BrokerClientManager) newClient(brokerKey BrokerKey, clientConfig *osb.ClientConfiguration, transportConfig BrokerTransportConfiguration) (clientWithConfig, error) {
+	client, err := m.brokerClientCreateFunc(clientConfig)
+	if err != nil {
+		return clientWithConfig{}, err
 	}
-	return client, nil
+	if err := configureBrokerTransport(client, transportConfig); err != nil {
+		return clientWithConfig{}, err
+	}
+	// Kept to fetch instances from the broker, which osb.Client cannot do.
+	httpClient, err := brokerHTTPClient(client)
+	if err != nil {
+		return clientWithConfig{}, err
+	}
+
+	if m.observeLatency {
+		client = newLatencyObservingClient(client, brokerKey)
+	}
+
+	if m.brokerQPS > 0 {
+		// The limiter is kept across client updates so that changing the
+		// broker's configuration does not reset its rate limit.
+		limiter, found := m.limiters[brokerKey]
+		if !found {
+			burst := m.brokerBurst
+			if burst < 1 {
+				burst = 1
+			}
+			limiter = rate.NewLimiter(rate.Limit(m.brokerQPS), burst)
+			m.limiters[brokerKey] = limiter
+		}
+		client = &rateLimitedClient{Client: client, limiter: limiter}
+	}
+
+	return clientWithConfig{
+		OSBClient:       client,
+		clientConfig:    clientConfig,
+		transportConfig: transportConfig,
+		httpClient:      httpClient,
+	}, nil
 }
 
 func configHasChanged(cfg1 *osb.ClientConfiguration, cfg2 *osb.ClientConfiguration) bool {
@@ -128,6 +292,87 @@ func configHasChanged(cfg1 *osb.ClientConfiguration, cfg2 *osb.ClientConfigurati
 }
 
 type clientWithConfig struct {
-	OSBClient    osb.Client
-	clientConfig *osb.ClientConfiguration
+	OSBClient       osb.Client
+	clientConfig    *osb.ClientConfiguration
+	transportConfig BrokerTransportConfiguration
+	// httpClient is the HTTP client that OSBClient sends its requests with,
+	// or nil if it was not created by osb.NewClient.
+	httpClient *http.Client
+}
+
+// rateLimitedClient is an osb.Client whose calls to the broker are limited by
+// a token bucket shared by all the clients of that broker.
+type rateLimitedClient struct {
+	osb.Client
+	limiter *rate.Limiter
+}
+
+// brokerCallDelay returns how long a call to the broker behind the given
+// client has to be delayed to honor the rate limit of the broker. If the call
+// can be made right away, a token is taken from the bucket and zero is
+// returned. Callers are expected to requeue rather than block the worker when
+// the returned delay is not zero.
+func brokerCallDelay(client osb.Client) time.Duration {
+	rlc, ok := client.(*rateLimitedClient)
+	if !ok {
+		return 0
+	}
+
+	r := rlc.limiter.Reserve()
+	delay := r.Delay()
+	if delay > 0 {
+		r.Cancel()
+	}
+	return delay
+}
+
+// delayServiceInstanceBrokerCall requeues the instance when the call to the
+// broker behind the given client has to be delayed to honor the rate limit of
+// the broker, and returns whether it did.
+func (c *controller) delayServiceInstanceBrokerCall(instance *v1beta1.ServiceInstance, client osb.Client) bool {
+	delay := brokerCallDelay(client)
+	if delay <= 0 {
+		return false
+	}
+	klog.V(4).Info(c.newInstanceContextBuilder(instance).Messagef("Requeuing after %v to honor the rate limit of the broker", delay))
+	c.enqueueInstanceAfter(instance, delay)
+	return true
+}
+
+// delayServiceBindingBrokerCall requeues the binding when the call to the
+// broker behind the given client has to be delayed to honor the rate limit of
+// the broker, and returns whether it did.
+func (c *controller) delayServiceBindingBrokerCall(binding *v1beta1.ServiceBinding, client osb.Client) bool {
+	delay := brokerCallDelay(client)
+	if delay <= 0 {
+		return false
+	}
+	klog.V(4).Info(c.newBindingContextBuilder(binding).Messagef("Requeuing after %v to honor the rate limit of the broker", delay))
+	c.enqueueBindingAfter(binding, delay)
+	return true
+}
+
+// delayClusterServiceBrokerCall requeues the broker when the call to it has
+// to be delayed to honor its rate limit, and returns whether it did.
+func (c *controller) delayClusterServiceBrokerCall(broker *v1beta1.ClusterServiceBroker, client osb.Client) bool {
+	delay := brokerCallDelay(client)
+	if delay <= 0 {
+		return false
+	}
+	klog.V(4).Info(pretty.NewClusterServiceBrokerContextBuilder(broker).Messagef("Requeuing after %v to honor the rate limit of the broker", delay))
+	c.clusterServiceBrokerQueue.AddAfter(broker.Name, delay)
+	return true
+}
+
+// delayServiceBrokerCall requeues the broker when the call to it has to be
+// delayed to honor its rate limit, and returns whether it did.
+func (c *controller) delayServiceBrokerCall(broker *v1beta1.ServiceBroker, client osb.Client) bool {
+	delay := brokerCallDelay(client)
+	if delay <= 0 {
+		return false
+	}
+	klog.V(4).Info(pretty.NewServiceBrokerContextBuilder(broker).Messagef("Requeuing after %v to honor the rate limit of the broker", delay))
+	brokerKey := NewServiceBrokerKey(broker.Namespace, broker.Name)
+	c.serviceBrokerQueue.AddAfter(brokerKey.String(), delay)
+	return true
 }
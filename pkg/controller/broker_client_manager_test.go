@@ -27,7 +27,7 @@ func TestBrokerClientManager_CreateBrokerClient(t *testing.T) {
 	osbCl1, _ := osb.NewClient(testOsbConfig("osb-1"))
 	osbCl2, _ := osb.NewClient(testOsbConfig("osb-2"))
 	brokerClientFunc := clientFunc(osbCl1, osbCl2)
-	manager := controller.NewBrokerClientManager(brokerClientFunc)
+	manager := controller.NewBrokerClientManager(brokerClientFunc, 0, 0)
 
 	// WHEN
 	createdClient1, _ := manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), testOsbConfig("osb-1"))
@@ -67,7 +67,7 @@ func TestBrokerClientManager_RemoveBrokerClient(t *testing.T) {
 	osbCl1, _ := osb.NewClient(testOsbConfig("osb-1"))
 	osbCl2, _ := osb.NewClient(testOsbConfig("osb-2"))
 	brokerClientFunc := clientFunc(osbCl1, osbCl2)
-	manager := controller.NewBrokerClientManager(brokerClientFunc)
+	manager := controller.NewBrokerClientManager(brokerClientFunc, 0, 0)
 
 	// WHEN
 	manager.UpdateBrokerClient(controller.NewClusterServiceBrokerKey("broker1"), testOsbConfig("osb-1"))
@@ -91,7 +91,7 @@ func TestBrokerClientManager_UpdateBrokerClient(t *testing.T) {
 	osbCl2, _ := osb.NewClient(testOsbConfig("osb-2"))
 	osbCl3, _ := osb.NewClient(testOsbConfig("osb-3"))
 	brokerClientFunc := clientFunc(osbCl1, osbCl2, osbCl3)
-	manager := controller.NewBrokerClientManager(brokerClientFunc)
+	manager := controller.NewBrokerClientManager(brokerClientFunc, 0, 0)
 
 	osbCfg := testOsbConfig("osb-1")
 	osbCfg.AuthConfig = &osb.AuthConfig{
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// getInstanceResponse is the response of a broker to fetching an instance.
+type getInstanceResponse struct {
+	// ServiceID is the ID of the service the instance was provisioned from.
+	ServiceID string `json:"service_id,omitempty"`
+	// PlanID is the ID of the plan the instance was provisioned from.
+	PlanID string `json:"plan_id,omitempty"`
+	// DashboardURL is the URL of a web-based management user interface for
+	// the instance.
+	DashboardURL *string `json:"dashboard_url,omitempty"`
+	// Parameters are the configuration parameters of the instance.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// getInstanceNotAllowedError is returned when an instance cannot be fetched
+// from a broker.
+type getInstanceNotAllowedError struct {
+	reason string
+}
+
+func (e getInstanceNotAllowedError) Error() string {
+	return fmt.Sprintf("GetInstance not allowed: %s", e.reason)
+}
+
+// getBrokerInstance fetches the instance with the given ID from the broker
+// with the given client configuration, with GET
+// /v2/service_instances/:instance_id, since osb.Client has no method for it.
+// The request is sent with the given HTTP client, which is the one of the
+// osb.Client of the broker. Like the alpha API methods of osb.Client, it
+// requires alpha features to be enabled and the latest API version.
+func getBrokerInstance(config *osb.ClientConfiguration, httpClient *http.Client, instanceID string) (*getInstanceResponse, error) {
+	if !config.EnableAlphaFeatures {
+		return nil, getInstanceNotAllowedError{reason: "alpha features must be enabled"}
+	}
+	if !config.APIVersion.AtLeast(osb.LatestAPIVersion()) {
+		return nil, getInstanceNotAllowedError{
+			reason: fmt.Sprintf(
+				"must have latest API Version. Current: %s, Expected: %s",
+				config.APIVersion.HeaderValue(), osb.LatestAPIVersion().HeaderValue(),
+			),
+		}
+	}
+
+	url := fmt.Sprintf("%s/v2/service_instances/%s", strings.TrimRight(config.URL, "/"), instanceID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set(osb.APIVersionHeader, config.APIVersion.HeaderValue())
+	if auth := config.AuthConfig; auth != nil {
+		if auth.BasicAuthConfig != nil {
+			request.SetBasicAuth(auth.BasicAuthConfig.Username, auth.BasicAuthConfig.Password)
+		} else if auth.BearerConfig != nil {
+			request.Header.Set("Authorization", "Bearer "+auth.BearerConfig.Token)
+		}
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, io.LimitReader(response.Body, 4096))
+		response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, brokerFailureResponseError(response)
+	}
+
+	instance := &getInstanceResponse{}
+	if err := json.NewDecoder(response.Body).Decode(instance); err != nil {
+		return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+	}
+	return instance, nil
+}
+
+// brokerFailureResponseError returns the error for a failed response of a
+// broker, with the error code and description the broker gave, as osb.Client
+// does.
+func brokerFailureResponseError(response *http.Response) error {
+	httpErr := osb.HTTPStatusCodeError{
+		StatusCode: response.StatusCode,
+	}
+
+	body := struct {
+		Error       *string `json:"error"`
+		Description *string `json:"description"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		httpErr.ResponseError = err
+		return httpErr
+	}
+	httpErr.ErrorMessage = body.Error
+	httpErr.Description = body.Description
+	return httpErr
+}
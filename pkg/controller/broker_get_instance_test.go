@@ -0,0 +1,156 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+)
+
+// TestGetBrokerInstance tests fetching an instance from a broker with the HTTP
+// client of the osb.Client created for it by the BrokerClientManager.
+func TestGetBrokerInstance(t *testing.T) {
+	dashboardURL := "https://dashboard.example.com"
+	cases := []struct {
+		name             string
+		apiVersion       osb.APIVersion
+		alpha            bool
+		status           int
+		body             string
+		expectedResponse *getInstanceResponse
+		expectedError    error
+		expectedRequests int
+	}{
+		{
+			name:       "instance found",
+			apiVersion: osb.LatestAPIVersion(),
+			alpha:      true,
+			status:     http.StatusOK,
+			body:       `{"service_id":"service","plan_id":"plan","dashboard_url":"https://dashboard.example.com","parameters":{"a":"b"}}`,
+			expectedResponse: &getInstanceResponse{
+				ServiceID:    "service",
+				PlanID:       "plan",
+				DashboardURL: &dashboardURL,
+				Parameters:   map[string]interface{}{"a": "b"},
+			},
+			expectedRequests: 1,
+		},
+		{
+			name:       "broker error",
+			apiVersion: osb.LatestAPIVersion(),
+			alpha:      true,
+			status:     http.StatusNotFound,
+			body:       `{"error":"NotFound","description":"no such instance"}`,
+			expectedError: osb.HTTPStatusCodeError{
+				StatusCode:   http.StatusNotFound,
+				ErrorMessage: strPtr("NotFound"),
+				Description:  strPtr("no such instance"),
+			},
+			expectedRequests: 1,
+		},
+		{
+			name:          "alpha features disabled",
+			apiVersion:    osb.LatestAPIVersion(),
+			expectedError: getInstanceNotAllowedError{reason: "alpha features must be enabled"},
+		},
+		{
+			name:       "older API version",
+			apiVersion: osb.Version2_11(),
+			alpha:      true,
+			expectedError: getInstanceNotAllowedError{
+				reason: "must have latest API Version. Current: 2.11, Expected: " + osb.LatestAPIVersion().HeaderValue(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []*http.Request
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r)
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			clientConfig := osb.DefaultClientConfiguration()
+			clientConfig.URL = server.URL + "/"
+			clientConfig.APIVersion = tc.apiVersion
+			clientConfig.EnableAlphaFeatures = tc.alpha
+			clientConfig.AuthConfig = &osb.AuthConfig{
+				BasicAuthConfig: &osb.BasicAuthConfig{Username: "user", Password: "pass"},
+			}
+			brokerKey := NewClusterServiceBrokerKey("broker")
+			manager := NewBrokerClientManager(osb.NewClient, 1, 1)
+			manager.observeLatency = true
+			transportConfig := BrokerTransportConfiguration{UserAgent: "my-catalog/1.0"}
+			if _, err := manager.UpdateBrokerClientWithTransport(brokerKey, clientConfig, transportConfig); err != nil {
+				t.Fatalf("unexpected error creating the client: %v", err)
+			}
+
+			response, err := manager.getInstance(brokerKey, "instance-id")
+			if e, a := tc.expectedError, err; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected error: %v", expectedGot(e, a))
+			}
+			if e, a := tc.expectedResponse, response; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected response: %v", expectedGot(e, a))
+			}
+
+			if e, a := tc.expectedRequests, len(requests); e != a {
+				t.Fatalf("unexpected number of requests: %v", expectedGot(e, a))
+			}
+			if len(requests) == 0 {
+				return
+			}
+			request := requests[0]
+			if e, a := "/v2/service_instances/instance-id", request.URL.Path; e != a {
+				t.Fatalf("unexpected path: %v", expectedGot(e, a))
+			}
+			if e, a := tc.apiVersion.HeaderValue(), request.Header.Get(osb.APIVersionHeader); e != a {
+				t.Fatalf("unexpected API version header: %v", expectedGot(e, a))
+			}
+			if username, password, _ := request.BasicAuth(); username != "user" || password != "pass" {
+				t.Fatalf("unexpected credentials %q:%q", username, password)
+			}
+			if e, a := transportConfig.UserAgent, request.UserAgent(); e != a {
+				t.Fatalf("unexpected User-Agent: %v", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestGetBrokerInstanceUnsupportedClient tests that instances cannot be
+// fetched from a broker whose client was not created by osb.NewClient, like
+// the fakes.
+func TestGetBrokerInstanceUnsupportedClient(t *testing.T) {
+	brokerKey := NewClusterServiceBrokerKey("broker")
+	manager := NewBrokerClientManager(fakeosb.NewFakeClientFunc(fakeosb.FakeClientConfiguration{}), 0, 0)
+	if _, err := manager.UpdateBrokerClient(brokerKey, osb.DefaultClientConfiguration()); err != nil {
+		t.Fatalf("unexpected error creating the client: %v", err)
+	}
+
+	if _, err := manager.getInstance(brokerKey, "instance-id"); err == nil {
+		t.Fatal("expected an error")
+	} else if _, ok := err.(getInstanceNotAllowedError); !ok {
+		t.Fatalf("unexpected error %T: %v", err, err)
+	}
+}
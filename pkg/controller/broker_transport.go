@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"unsafe"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	"k8s.io/client-go/transport"
+)
+
+// BrokerTransportConfiguration configures the HTTP transport of the client of
+// a broker, for the settings that osb.ClientConfiguration has no fields for.
+type BrokerTransportConfiguration struct {
+	// ProxyURL is the URL of the proxy that the requests to the broker are
+	// sent through. If empty, the proxy is taken from the HTTP_PROXY,
+	// HTTPS_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+	// UserAgent is the User-Agent sent with the requests to the broker. If
+	// empty, the default of the http package is sent.
+	UserAgent string
+}
+
+// osbClientType is the type of the clients created by osb.NewClient.
+var osbClientType = func() reflect.Type {
+	client, _ := osb.NewClient(osb.DefaultClientConfiguration())
+	return reflect.TypeOf(client)
+}()
+
+// unwrappingClient is implemented by the clients that wrap another client,
+// like the ones of package osbclientproxy.
+type unwrappingClient interface {
+	Unwrap() osb.Client
+}
+
+// brokerHTTPClient returns the HTTP client that the given broker client sends
+// its requests with, or nil if the client was not created by osb.NewClient,
+// like the fakes used in tests.
+//
+// osb.NewClient builds its HTTP client itself and offers no way to configure
+// its transport beyond TLS, so the HTTP client is looked up by reflection. An
+// error is returned when a client created by osb.NewClient does not have it,
+// so that updating the osb package cannot silently drop the configuration of
+// the transport.
+func brokerHTTPClient(client osb.Client) (*http.Client, error) {
+	for {
+		wrapper, ok := client.(unwrappingClient)
+		if !ok {
+			break
+		}
+		client = wrapper.Unwrap()
+	}
+
+	v := reflect.ValueOf(client)
+	if !v.IsValid() || v.Type() != osbClientType {
+		return nil, nil
+	}
+	field := v.Elem().FieldByName("httpClient")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(&http.Client{}) {
+		return nil, fmt.Errorf("the clients created by osb.NewClient have no HTTP client to configure")
+	}
+	return (*http.Client)(unsafe.Pointer(field.Pointer())), nil
+}
+
+// configureBrokerTransport applies the given configuration to the transport
+// of the given broker client. Clients that were not created by osb.NewClient
+// are left as is.
+func configureBrokerTransport(client osb.Client, config BrokerTransportConfiguration) error {
+	if config == (BrokerTransportConfiguration{}) {
+		return nil
+	}
+
+	httpClient, err := brokerHTTPClient(client)
+	if err != nil || httpClient == nil {
+		return err
+	}
+
+	if config.ProxyURL != "" {
+		httpTransport, ok := httpClient.Transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("cannot set the proxy of the transport %T of the broker client", httpClient.Transport)
+		}
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("invalid proxy URL %q: %v", config.ProxyURL, err)
+		}
+		httpTransport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config.UserAgent != "" {
+		httpClient.Transport = transport.NewUserAgentRoundTripper(config.UserAgent, httpClient.Transport)
+	}
+
+	return nil
+}
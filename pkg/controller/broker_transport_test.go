@@ -0,0 +1,127 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+
+	"github.com/poy/service-catalog/pkg/metrics/osbclientproxy"
+)
+
+// TestBrokerHTTPClient tests that the HTTP client of the clients created by
+// osb.NewClient is found, so that their transport can be configured, and that
+// other clients are left alone.
+func TestBrokerHTTPClient(t *testing.T) {
+	cases := []struct {
+		name       string
+		createFunc osb.CreateFunc
+		found      bool
+	}{
+		{
+			name:       "osb client",
+			createFunc: osb.NewClient,
+			found:      true,
+		},
+		{
+			name:       "metrics proxy client",
+			createFunc: osbclientproxy.NewClient,
+			found:      true,
+		},
+		{
+			name:       "fake client",
+			createFunc: fakeosb.NewFakeClientFunc(fakeosb.FakeClientConfiguration{}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client, err := tc.createFunc(osb.DefaultClientConfiguration())
+			if err != nil {
+				t.Fatalf("unexpected error creating the client: %v", err)
+			}
+
+			httpClient, err := brokerHTTPClient(client)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.found, httpClient != nil; e != a {
+				t.Fatalf("unexpected HTTP client found: %v", expectedGot(e, a))
+			}
+			if httpClient != nil {
+				if _, ok := httpClient.Transport.(*http.Transport); !ok {
+					t.Fatalf("unexpected transport %T", httpClient.Transport)
+				}
+			}
+		})
+	}
+}
+
+// TestConfigureBrokerTransportInvalidProxyURL tests that a client cannot be
+// configured with a proxy URL that cannot be parsed.
+func TestConfigureBrokerTransportInvalidProxyURL(t *testing.T) {
+	client, err := osb.NewClient(osb.DefaultClientConfiguration())
+	if err != nil {
+		t.Fatalf("unexpected error creating the client: %v", err)
+	}
+
+	if err := configureBrokerTransport(client, BrokerTransportConfiguration{ProxyURL: "http://proxy:port"}); err == nil {
+		t.Fatal("expected an error for an invalid proxy URL")
+	}
+}
+
+// TestConfigureBrokerTransportProxyAndUserAgent tests that a client configured
+// with both a proxy and a User-Agent sends its requests through the proxy with
+// that User-Agent.
+func TestConfigureBrokerTransportProxyAndUserAgent(t *testing.T) {
+	var requestURL, userAgent string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURL = r.URL.String()
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"services":[]}`))
+	}))
+	defer proxy.Close()
+
+	clientConfig := osb.DefaultClientConfiguration()
+	clientConfig.URL = "http://broker.example.com"
+	client, err := osb.NewClient(clientConfig)
+	if err != nil {
+		t.Fatalf("unexpected error creating the client: %v", err)
+	}
+
+	transportConfig := BrokerTransportConfiguration{
+		ProxyURL:  proxy.URL,
+		UserAgent: "my-catalog/1.0",
+	}
+	if err := configureBrokerTransport(client, transportConfig); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error getting the catalog: %v", err)
+	}
+
+	if e, a := "http://broker.example.com/v2/catalog", requestURL; e != a {
+		t.Fatalf("unexpected request URL received by the proxy: %v", expectedGot(e, a))
+	}
+	if e, a := transportConfig.UserAgent, userAgent; e != a {
+		t.Fatalf("unexpected User-Agent: %v", expectedGot(e, a))
+	}
+}
@@ -19,9 +19,11 @@ package controller
 import (
 	"bytes"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -33,6 +35,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -51,6 +54,7 @@ import (
 	scfeatures "github.com/poy/service-catalog/pkg/features"
 	"github.com/poy/service-catalog/pkg/filter"
 	"github.com/poy/service-catalog/pkg/pretty"
+	"github.com/poy/service-catalog/pkg/version"
 )
 
 const (
@@ -85,19 +89,49 @@ func NewController(
 	clusterServicePlanInformer informers.ClusterServicePlanInformer,
 	servicePlanInformer informers.ServicePlanInformer,
 	brokerClientCreateFunc osb.CreateFunc,
+	brokerQPS float32,
+	brokerBurst int,
 	brokerRelistInterval time.Duration,
 	osbAPIPreferredVersion string,
+	brokerUserAgent string,
 	recorder record.EventRecorder,
 	reconciliationRetryDuration time.Duration,
 	operationPollingMaximumBackoffDuration time.Duration,
 	clusterIDConfigMapName string,
 	clusterIDConfigMapNamespace string,
+	secretKeyConvention SecretKeyConvention,
+	forceDeletionPolicy ForceDeletionPolicy,
+	quotaExceededPolicy QuotaExceededPolicy,
+	brokerAuthSecretNamespaces []string,
+	requestContextPolicy RequestContextPolicy,
+	orphanMitigationPolicy OrphanMitigationPolicy,
+	secretUpdateStrategy SecretUpdateStrategy,
 ) (Controller, error) {
+	if err := secretKeyConvention.Validate(); err != nil {
+		return nil, err
+	}
+	if err := forceDeletionPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := quotaExceededPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := requestContextPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := orphanMitigationPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	if err := secretUpdateStrategy.Validate(); err != nil {
+		return nil, err
+	}
+
 	controller := &controller{
 		kubeClient:                  kubeClient,
 		serviceCatalogClient:        serviceCatalogClient,
 		brokerRelistInterval:        brokerRelistInterval,
 		OSBAPIPreferredVersion:      osbAPIPreferredVersion,
+		brokerUserAgent:             brokerUserAgent,
 		recorder:                    recorder,
 		reconciliationRetryDuration: reconciliationRetryDuration,
 		clusterServiceBrokerQueue:   workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "cluster-service-broker"),
@@ -112,9 +146,18 @@ func NewController(
 		bindingPollingQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "binding-poller"),
 		clusterIDConfigMapName:      clusterIDConfigMapName,
 		clusterIDConfigMapNamespace: clusterIDConfigMapNamespace,
-		brokerClientManager:         NewBrokerClientManager(brokerClientCreateFunc),
+		brokerClientManager:         NewBrokerClientManager(brokerClientCreateFunc, brokerQPS, brokerBurst),
+		secretKeyConvention:         secretKeyConvention,
+		forceDeletionPolicy:         forceDeletionPolicy,
+		quotaExceededPolicy:         quotaExceededPolicy,
+		brokerAuthSecretNamespaces:  brokerAuthSecretNamespaces,
+		requestContextPolicy:        requestContextPolicy,
+		orphanMitigationPolicy:      orphanMitigationPolicy,
+		secretUpdateStrategy:        secretUpdateStrategy,
 	}
 
+	controller.brokerClientManager.observeLatency = true
+
 	controller.clusterServiceBrokerLister = clusterServiceBrokerInformer.Lister()
 	clusterServiceBrokerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.clusterServiceBrokerAdd,
@@ -228,6 +271,34 @@ type controller struct {
 	instanceOperationRetryQueue instanceOperationBackoff
 	// BrokerClientManager holds all OSB clients for brokers.
 	brokerClientManager *BrokerClientManager
+	// operationIDs holds the IDs used to correlate the log lines of
+	// instance and binding operations.
+	operationIDs operationIDs
+	// secretKeyConvention renames the credential keys of every binding
+	// before they are written to its secret.
+	secretKeyConvention SecretKeyConvention
+	// forceDeletionPolicy describes when the finalizer of an instance that
+	// cannot be deprovisioned is removed anyway.
+	forceDeletionPolicy ForceDeletionPolicy
+	// quotaExceededPolicy describes which provision errors returned by
+	// brokers mean that a quota was exceeded.
+	quotaExceededPolicy QuotaExceededPolicy
+	// brokerAuthSecretNamespaces are the namespaces, other than their own,
+	// in which the auth secrets of namespaced brokers may be.
+	brokerAuthSecretNamespaces []string
+	// requestContextPolicy describes what is added to the context of the
+	// requests sent to brokers.
+	requestContextPolicy RequestContextPolicy
+	// orphanMitigationPolicy describes how the orphaned resources that
+	// failed provisions may leave on brokers are mitigated.
+	orphanMitigationPolicy OrphanMitigationPolicy
+	// secretUpdateStrategy tells whether the existing secrets of bindings
+	// are updated in place or recreated.
+	secretUpdateStrategy SecretUpdateStrategy
+
+	// brokerUserAgent is the User-Agent sent to brokers. If empty, one
+	// identifying the service catalog version and cluster ID is used.
+	brokerUserAgent string
 }
 
 // Run runs the controller until the given stop channel can be read from.
@@ -580,7 +651,7 @@ func (c *controller) getClusterServiceClassAndClusterServiceBrokerForServiceBind
 }
 
 func (c *controller) getClusterServiceClassForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ClusterServiceClass, error) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	serviceClass, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
 	if err != nil {
 		s := fmt.Sprintf(
@@ -602,7 +673,7 @@ func (c *controller) getClusterServiceClassForServiceBinding(instance *v1beta1.S
 }
 
 func (c *controller) getClusterServicePlanForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding, serviceClass *v1beta1.ClusterServiceClass) (*v1beta1.ClusterServicePlan, error) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	servicePlan, err := c.clusterServicePlanLister.Get(instance.Spec.ClusterServicePlanRef.Name)
 	if nil != err {
 		s := fmt.Sprintf(
@@ -624,7 +695,7 @@ func (c *controller) getClusterServicePlanForServiceBinding(instance *v1beta1.Se
 }
 
 func (c *controller) getClusterServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding, serviceClass *v1beta1.ClusterServiceClass) (*v1beta1.ClusterServiceBroker, error) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 
 	broker, err := c.clusterServiceBrokerLister.Get(serviceClass.Spec.ClusterServiceBrokerName)
 	if err != nil {
@@ -731,7 +802,9 @@ func getAuthCredentialsFromClusterServiceBroker(client kubernetes.Interface, bro
 
 // getAuthCredentialsFromServiceBroker returns the auth credentials, if any, or
 // returns an error. If the AuthInfo field is nil, empty values are returned.
-func getAuthCredentialsFromServiceBroker(client kubernetes.Interface, broker *v1beta1.ServiceBroker) (*osb.AuthConfig, error) {
+// The auth secret may only be in a namespace other than the one of the broker
+// if that namespace is one of allowedSecretNamespaces.
+func getAuthCredentialsFromServiceBroker(client kubernetes.Interface, broker *v1beta1.ServiceBroker, allowedSecretNamespaces []string) (*osb.AuthConfig, error) {
 	if broker.Spec.AuthInfo == nil {
 		return nil, nil
 	}
@@ -739,7 +812,11 @@ func getAuthCredentialsFromServiceBroker(client kubernetes.Interface, broker *v1
 	authInfo := broker.Spec.AuthInfo
 	if authInfo.Basic != nil {
 		secretRef := authInfo.Basic.SecretRef
-		secret, err := client.CoreV1().Secrets(broker.Namespace).Get(secretRef.Name, metav1.GetOptions{})
+		namespace, err := serviceBrokerSecretNamespace(broker, secretRef, allowedSecretNamespaces)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := client.CoreV1().Secrets(namespace).Get(secretRef.Name, metav1.GetOptions{})
 		if err != nil {
 			return nil, err
 		}
@@ -752,7 +829,11 @@ func getAuthCredentialsFromServiceBroker(client kubernetes.Interface, broker *v1
 		}, nil
 	} else if authInfo.Bearer != nil {
 		secretRef := authInfo.Bearer.SecretRef
-		secret, err := client.CoreV1().Secrets(broker.Namespace).Get(secretRef.Name, metav1.GetOptions{})
+		namespace, err := serviceBrokerSecretNamespace(broker, secretRef, allowedSecretNamespaces)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := client.CoreV1().Secrets(namespace).Get(secretRef.Name, metav1.GetOptions{})
 		if err != nil {
 			return nil, err
 		}
@@ -767,6 +848,21 @@ func getAuthCredentialsFromServiceBroker(client kubernetes.Interface, broker *v1
 	return nil, fmt.Errorf("empty auth info or unsupported auth mode: %s", authInfo)
 }
 
+// serviceBrokerSecretNamespace returns the namespace of the auth secret of a
+// namespaced broker: the namespace of the broker unless the secret reference
+// names another one, which must then be one of allowedSecretNamespaces.
+func serviceBrokerSecretNamespace(broker *v1beta1.ServiceBroker, secretRef *v1beta1.ObjectReference, allowedSecretNamespaces []string) (string, error) {
+	if secretRef.Namespace == "" || secretRef.Namespace == broker.Namespace {
+		return broker.Namespace, nil
+	}
+	for _, namespace := range allowedSecretNamespaces {
+		if namespace == secretRef.Namespace {
+			return namespace, nil
+		}
+	}
+	return "", fmt.Errorf("auth secrets of namespaced brokers are not allowed in namespace %q", secretRef.Namespace)
+}
+
 func getBasicAuthConfig(secret *corev1.Secret) (*osb.BasicAuthConfig, error) {
 	usernameBytes, ok := secret.Data["username"]
 	if !ok {
@@ -852,7 +948,8 @@ func convertAndFilterCatalogToNamespacedTypes(namespace string, in *osb.CatalogR
 		serviceClass.SetNamespace(namespace)
 
 		// If this service class passes the predicate, process the plans for the class.
-		if fields := v1beta1.ConvertServiceClassToProperties(serviceClass); predicate.Accepts(fields) {
+		fields := v1beta1.ConvertServiceClassToProperties(serviceClass)
+		if predicate.Accepts(fields) && acceptsServiceClassTags(restrictions, serviceClass.Spec.Tags) {
 			// set up the plans using the ServiceClass Name
 			plans, err := convertServicePlans(namespace, svc.Plans, serviceClass.Name, existingServicePlans)
 			if err != nil {
@@ -981,7 +1078,8 @@ func convertAndFilterCatalog(in *osb.CatalogResponse, restrictions *v1beta1.Cata
 		}
 
 		// If this service class passes the predicate, process the plans for the class.
-		if fields := v1beta1.ConvertClusterServiceClassToProperties(serviceClass); predicate.Accepts(fields) {
+		fields := v1beta1.ConvertClusterServiceClassToProperties(serviceClass)
+		if predicate.Accepts(fields) && acceptsServiceClassTags(restrictions, serviceClass.Spec.Tags) {
 			// set up the plans using the ClusterServiceClass Name
 			plans, err := convertClusterServicePlans(svc.Plans, serviceClass.Name, existingServicePlans)
 			if err != nil {
@@ -1003,6 +1101,21 @@ func convertAndFilterCatalog(in *osb.CatalogResponse, restrictions *v1beta1.Cata
 	return serviceClasses, servicePlans, nil
 }
 
+// acceptsServiceClassTags returns whether a class with the given tags passes
+// the tag restrictions of its broker: it must have one of the allowed tags, if
+// any are given, and none of the denied tags.
+func acceptsServiceClassTags(restrictions *v1beta1.CatalogRestrictions, tags []string) bool {
+	if restrictions == nil {
+		return true
+	}
+
+	tagSet := sets.NewString(tags...)
+	if tagSet.HasAny(restrictions.DeniedTags...) {
+		return false
+	}
+	return len(restrictions.AllowedTags) == 0 || tagSet.HasAny(restrictions.AllowedTags...)
+}
+
 func filterNamespacedServicePlans(restrictions *v1beta1.CatalogRestrictions, servicePlans []*v1beta1.ServicePlan) ([]*v1beta1.ServicePlan, []*v1beta1.ServicePlan, error) {
 	var predicate filter.Predicate
 	var err error
@@ -1294,6 +1407,14 @@ func NewClientConfigurationForBroker(meta metav1.ObjectMeta, commonSpec *v1beta1
 	return clientConfig
 }
 
+// NewTransportConfigurationForBroker creates a new BrokerTransportConfiguration
+// for connecting to the specified Broker
+func NewTransportConfigurationForBroker(commonSpec *v1beta1.CommonServiceBrokerSpec) BrokerTransportConfiguration {
+	return BrokerTransportConfiguration{
+		ProxyURL: commonSpec.ProxyURL,
+	}
+}
+
 // reconciliationRetryDurationExceeded returns whether the given operation
 // start time has exceeded the controller's set reconciliation retry duration.
 func (c *controller) reconciliationRetryDurationExceeded(operationStartTime *metav1.Time) bool {
@@ -1358,6 +1479,16 @@ func (c *controller) setClusterID(id string) {
 	c.clusterIDLock.Unlock()
 }
 
+// getBrokerUserAgent returns the User-Agent to send to brokers: the
+// configured one, or one identifying the version of service catalog and the
+// cluster it runs in so that broker operators can tell callers apart.
+func (c *controller) getBrokerUserAgent() string {
+	if c.brokerUserAgent != "" {
+		return c.brokerUserAgent
+	}
+	return fmt.Sprintf("service-catalog/%s (cluster %s)", version.Get().GitVersion, c.getClusterID())
+}
+
 // getServiceClassPlanAndServiceBrokerForServiceBinding is a sequence of operations that's
 // done to validate service plan, service class exist, and handles creating
 // a brokerclient to use for a given ServiceInstance.
@@ -1395,7 +1526,7 @@ func (c *controller) getServiceClassAndServiceBrokerForServiceBinding(instance *
 }
 
 func (c *controller) getServiceClassForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ServiceClass, error) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	serviceClass, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
 	if err != nil {
 		s := fmt.Sprintf(
@@ -1417,7 +1548,7 @@ func (c *controller) getServiceClassForServiceBinding(instance *v1beta1.ServiceI
 }
 
 func (c *controller) getServicePlanForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding, serviceClass *v1beta1.ServiceClass) (*v1beta1.ServicePlan, error) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	servicePlan, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
 	if nil != err {
 		s := fmt.Sprintf(
@@ -1439,7 +1570,7 @@ func (c *controller) getServicePlanForServiceBinding(instance *v1beta1.ServiceIn
 }
 
 func (c *controller) getServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding, serviceClass *v1beta1.ServiceClass) (*v1beta1.ServiceBroker, error) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 
 	broker, err := c.serviceBrokerLister.ServiceBrokers(instance.Namespace).Get(serviceClass.Spec.ServiceBrokerName)
 	if err != nil {
@@ -1517,6 +1648,129 @@ func shouldReconcileServiceBrokerCommon(pcb *pretty.ContextBuilder, brokerMeta *
 	return true
 }
 
+// isBrokerURLInsecure returns true if the broker URL does not use https.
+// Invalid URLs are reported when the broker client is created.
+func isBrokerURLInsecure(spec *v1beta1.CommonServiceBrokerSpec) bool {
+	u, err := url.Parse(spec.URL)
+	if err != nil {
+		return false
+	}
+	return !strings.EqualFold(u.Scheme, "https")
+}
+
+// hasServiceBrokerCondition returns true if the conditions contain a
+// condition of the given type.
+func hasServiceBrokerCondition(conditions []v1beta1.ServiceBrokerCondition, conditionType v1beta1.ServiceBrokerConditionType) bool {
+	for _, cond := range conditions {
+		if cond.Type == conditionType {
+			return true
+		}
+	}
+	return false
+}
+
+// setServiceBrokerInsecureCondition returns the broker conditions with the
+// Insecure condition set if the broker URL does not use https, or removed
+// otherwise, and whether the conditions changed. The Insecure condition is
+// kept ahead of the Ready and Failed conditions, since clients read the
+// broker status from the last condition.
+func setServiceBrokerInsecureCondition(conditions []v1beta1.ServiceBrokerCondition, spec *v1beta1.CommonServiceBrokerSpec) ([]v1beta1.ServiceBrokerCondition, bool) {
+	var existing *v1beta1.ServiceBrokerCondition
+	others := make([]v1beta1.ServiceBrokerCondition, 0, len(conditions))
+	for i := range conditions {
+		if conditions[i].Type == v1beta1.ServiceBrokerConditionInsecure {
+			existing = &conditions[i]
+		} else {
+			others = append(others, conditions[i])
+		}
+	}
+
+	if !isBrokerURLInsecure(spec) {
+		return others, existing != nil
+	}
+
+	reason, message := insecureBrokerURLReason, insecureBrokerURLMessage
+	if spec.AllowInsecure {
+		reason, message = insecureBrokerURLAllowedReason, insecureBrokerURLAllowedMessage
+	}
+	if existing != nil && existing.Reason == reason {
+		return conditions, false
+	}
+
+	newCondition := v1beta1.ServiceBrokerCondition{
+		Type:               v1beta1.ServiceBrokerConditionInsecure,
+		Status:             v1beta1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	}
+	if existing != nil {
+		newCondition.LastTransitionTime = existing.LastTransitionTime
+	}
+	return append([]v1beta1.ServiceBrokerCondition{newCondition}, others...), true
+}
+
+// setServiceBrokerQuiescedCondition returns the broker conditions with the
+// Quiesced condition set if the broker is quiesced, or removed otherwise, and
+// whether the conditions changed. Like the Insecure condition, the Quiesced
+// condition is kept ahead of the Ready and Failed conditions.
+func setServiceBrokerQuiescedCondition(conditions []v1beta1.ServiceBrokerCondition, spec *v1beta1.CommonServiceBrokerSpec) ([]v1beta1.ServiceBrokerCondition, bool) {
+	var existing *v1beta1.ServiceBrokerCondition
+	others := make([]v1beta1.ServiceBrokerCondition, 0, len(conditions))
+	for i := range conditions {
+		if conditions[i].Type == v1beta1.ServiceBrokerConditionQuiesced {
+			existing = &conditions[i]
+		} else {
+			others = append(others, conditions[i])
+		}
+	}
+
+	if !spec.Quiesced {
+		return others, existing != nil
+	}
+	if existing != nil {
+		return conditions, false
+	}
+
+	newCondition := v1beta1.ServiceBrokerCondition{
+		Type:               v1beta1.ServiceBrokerConditionQuiesced,
+		Status:             v1beta1.ConditionTrue,
+		Reason:             quiescedBrokerReason,
+		Message:            quiescedBrokerMessage,
+		LastTransitionTime: metav1.Now(),
+	}
+	return append([]v1beta1.ServiceBrokerCondition{newCondition}, others...), true
+}
+
+// catalogHash returns a hash of the catalog returned by a broker, or an empty
+// string if the catalog could not be hashed.
+func catalogHash(catalog *osb.CatalogResponse) string {
+	catalogAsJSON, err := json.Marshal(catalog)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(catalogAsJSON))
+}
+
+// isCatalogUnchanged returns true if the given catalog hash matches the hash
+// recorded by the last successful relist of the broker's current spec, in
+// which case the broker's classes and plans are already up to date. An empty
+// hash always requires a full relist.
+func isCatalogUnchanged(brokerMeta *metav1.ObjectMeta, brokerStatus *v1beta1.CommonServiceBrokerStatus, hash string) bool {
+	if hash == "" || brokerStatus.LastCatalogHash != hash {
+		return false
+	}
+	if brokerStatus.ReconciledGeneration != brokerMeta.Generation {
+		return false
+	}
+	for _, condition := range brokerStatus.Conditions {
+		if condition.Type == v1beta1.ServiceBrokerConditionReady {
+			return condition.Status == v1beta1.ConditionTrue
+		}
+	}
+	return false
+}
+
 func toJSON(obj interface{}) string {
 	bytes, _ := json.Marshal(obj)
 	return string(bytes)
@@ -18,8 +18,12 @@ package controller
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"net"
+	"strings"
+	"text/template"
+	"time"
 
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
@@ -32,7 +36,10 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/jsonpath"
 )
@@ -41,6 +48,7 @@ const (
 	errorNonexistentServiceInstanceReason     string = "ReferencesNonexistentInstance"
 	errorBindCallReason                       string = "BindCallFailed"
 	errorInjectingBindResultReason            string = "ErrorInjectingBindResult"
+	errorInvalidSecretKeysReason              string = "InvalidSecretKeys"
 	errorEjectingBindReason                   string = "ErrorEjectingServiceBinding"
 	errorUnbindCallReason                     string = "UnbindCallFailed"
 	errorNonbindableClusterServiceClassReason string = "ErrorNonbindableServiceClass"
@@ -48,7 +56,11 @@ const (
 	errorServiceInstanceNotReadyReason        string = "ErrorInstanceNotReady"
 	errorServiceBindingOrphanMitigation       string = "ServiceBindingNeedsOrphanMitigation"
 	errorFetchingBindingFailedReason          string = "FetchingBindingFailed"
+	errorRotatingCredentialsReason            string = "RotatingCredentialsFailed"
 	errorAsyncOpTimeoutReason                 string = "AsyncOperationTimeout"
+	waitingForInstanceOutputReason            string = "WaitingForInstanceOutput"
+	errorWithParametersSchemaReason           string = "ErrorWithParametersSchema"
+	unsupportedParametersSchemaReason         string = "UnsupportedParametersSchemaKeywords"
 
 	successInjectedBindResultReason  string = "InjectedBindResult"
 	successInjectedBindResultMessage string = "Injected bind result"
@@ -61,6 +73,14 @@ const (
 	bindingInFlightMessage           string = "Binding request for ServiceBinding in-flight to Broker"
 	unbindingInFlightReason          string = "UnbindingRequestInFlight"
 	unbindingInFlightMessage         string = "Unbind request for ServiceBinding in-flight to Broker"
+	rotatingCredentialsReason        string = "RotatingCredentials"
+	rotatingCredentialsMessage       string = "Unbound to rotate the credentials, the old credentials were revoked by the broker"
+	successRotatedCredentialsReason  string = "RotatedCredentials"
+	successRotatedCredentialsMessage string = "Rotated the credentials of the binding"
+
+	// operationKeyContextKey is the key of the binding's operation key in
+	// the context sent with bind requests.
+	operationKeyContextKey string = "operation_key"
 )
 
 // bindingControllerKind contains the schema.GroupVersionKind for this controller type.
@@ -91,6 +111,18 @@ func (c *controller) bindingAdd(obj interface{}) {
 	c.bindingQueue.Add(key)
 }
 
+// enqueueBindingAfter adds the binding key to the work queue after the
+// specified duration elapses
+func (c *controller) enqueueBindingAfter(obj interface{}, d time.Duration) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		pcb := pretty.NewContextBuilder(pretty.ServiceBinding, "", "", "")
+		klog.Errorf(pcb.Messagef("Couldn't get key for object %+v: %v", obj, err))
+		return
+	}
+	c.bindingQueue.AddAfter(key, d)
+}
+
 func (c *controller) bindingUpdate(oldObj, newObj interface{}) {
 	// Bindings with ongoing asynchronous operations will be manually added
 	// to the polling queue by the reconciler. They should be ignored here in
@@ -107,8 +139,9 @@ func (c *controller) bindingDelete(obj interface{}) {
 		return
 	}
 
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 	klog.V(4).Info(pcb.Messagef("Received DELETE event; no further processing will occur; resourceVersion %v", binding.ResourceVersion))
+	c.operationIDs.forget(binding.UID)
 }
 
 func (c *controller) reconcileServiceBindingKey(key string) error {
@@ -156,7 +189,7 @@ func getReconciliationActionForServiceBinding(binding *v1beta1.ServiceBinding) R
 // An error is returned to indicate that the binding has not been fully
 // processed and should be resubmitted at a later time.
 func (c *controller) reconcileServiceBinding(binding *v1beta1.ServiceBinding) error {
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 	klog.V(6).Info(pcb.Messagef(`beginning to process resourceVersion: %v`, binding.ResourceVersion))
 
 	reconciliationAction := getReconciliationActionForServiceBinding(binding)
@@ -175,7 +208,7 @@ func (c *controller) reconcileServiceBinding(binding *v1beta1.ServiceBinding) er
 // reconcileServiceBindingAdd is responsible for handling the creation of new
 // service bindings.
 func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding) error {
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 
 	if isServiceBindingFailed(binding) {
 		klog.V(4).Info(pcb.Message("not processing event; status showed that it has failed"))
@@ -183,6 +216,9 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	}
 
 	if binding.Status.ReconciledGeneration == binding.Generation {
+		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingSecretDriftDetection) && binding.Status.SecretChecksum != "" {
+			return c.reconcileServiceBindingSecretDrift(binding)
+		}
 		klog.V(4).Info(pcb.Message("Not processing event; reconciled generation showed there is no work to do"))
 		return nil
 	}
@@ -200,6 +236,7 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 
 	var prettyName string
 	var brokerClient osb.Client
+	var bindingRetrievable bool
 	var request *osb.BindRequest
 	var inProgressProperties *v1beta1.ServiceBindingPropertiesState
 
@@ -217,8 +254,9 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		}
 
 		brokerClient = bClient
+		bindingRetrievable = serviceClass.Spec.BindingRetrievable
 
-		if !isClusterServicePlanBindable(serviceClass, servicePlan) {
+		if !v1beta1.IsPlanBindable(&serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec) {
 			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ClusterServiceClassName(serviceClass), instance.Spec.ClusterServicePlanExternalName)
 			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonbindableClusterServiceClassReason, msg)
 			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorNonbindableClusterServiceClassReason, msg)
@@ -254,9 +292,10 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		}
 
 		brokerClient = bClient
+		bindingRetrievable = serviceClass.Spec.BindingRetrievable
 
-		if !isServicePlanBindable(serviceClass, servicePlan) {
-			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ServiceClassName(serviceClass), instance.Spec.ClusterServicePlanExternalName)
+		if !v1beta1.IsPlanBindable(&serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec) {
+			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ServiceClassName(serviceClass), instance.Spec.ServicePlanExternalName)
 			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonbindableClusterServiceClassReason, msg)
 			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorNonbindableClusterServiceClassReason, msg)
 			return c.processBindFailure(binding, readyCond, failedCond, false)
@@ -278,7 +317,30 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		prettyName = pretty.FromServiceInstanceOfServiceClassAtBrokerName(instance, serviceClass, brokerName)
 	}
 
+	// An increase of the rotation requests of a binding asks for new
+	// credentials. The rotation is a bind operation that starts by unbinding,
+	// so that the broker revokes the old credentials, and then binds again
+	// with the same binding ID.
+	rotationRequested := binding.Spec.RotationRequests > binding.Status.RotationRequests
+
+	// A spec change that does not change the parameters sent to the broker,
+	// such as a change to the secret transforms, only needs the secret to be
+	// written again. The original credentials are fetched from the broker
+	// rather than binding again. Brokers that do not support fetching
+	// bindings are sent the same bind request again instead, which returns
+	// the credentials of the existing binding.
+	if binding.Status.CurrentOperation == "" && !rotationRequested && bindingRetrievable &&
+		binding.Status.ExternalProperties != nil &&
+		binding.Status.ExternalProperties.ParameterChecksum == inProgressProperties.ParameterChecksum {
+		return c.reinjectServiceBinding(binding, instance, brokerClient)
+	}
+
 	if binding.Status.CurrentOperation == "" {
+		if binding.Status.ExternalProperties == nil {
+			// A binding the broker does not know about gets fresh
+			// credentials, so no rotation is pending.
+			binding.Status.RotationRequests = binding.Spec.RotationRequests
+		}
 		binding, err = c.recordStartOfServiceBindingOperation(binding, v1beta1.ServiceBindingOperationBind, inProgressProperties)
 		if err != nil {
 			// There has been an update to the binding. Start reconciliation
@@ -289,6 +351,17 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		return nil
 	}
 
+	if rotationRequested && binding.Status.ExternalProperties != nil {
+		unboundBinding, err := c.unbindToRotateServiceBindingCredentials(binding, instance, brokerClient)
+		if unboundBinding == nil {
+			return err
+		}
+		binding = unboundBinding
+	}
+
+	if c.delayServiceBindingBrokerCall(binding, brokerClient) {
+		return nil
+	}
 	response, err := brokerClient.Bind(request)
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
@@ -329,7 +402,7 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	err = c.injectServiceBinding(binding, response.Credentials)
 	if err != nil {
 		msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
-		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorInjectingBindResultReason, msg)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, injectServiceBindingErrorReason(err), msg)
 
 		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
 			msg := "Stopping reconciliation retries, too much time has elapsed"
@@ -345,9 +418,101 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	return c.processBindSuccess(binding)
 }
 
+// reinjectServiceBinding writes the secret of a binding that the broker
+// already knows about again, from the credentials fetched from the broker, to
+// apply changes to the spec of the binding such as its secret transforms.
+func (c *controller) reinjectServiceBinding(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, brokerClient osb.Client) error {
+	pcb := c.newBindingContextBuilder(binding)
+	klog.V(4).Info(pcb.Message("Updating the secret from the credentials fetched from the broker"))
+
+	if c.delayServiceBindingBrokerCall(binding, brokerClient) {
+		return nil
+	}
+	response, err := brokerClient.GetBinding(&osb.GetBindingRequest{
+		InstanceID: instance.Spec.ExternalID,
+		BindingID:  binding.Spec.ExternalID,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Could not do a GET on binding resource: %v", err)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorFetchingBindingFailedReason, msg)
+		return c.processServiceBindingOperationError(binding, readyCond)
+	}
+
+	if err := c.injectServiceBinding(binding, response.Credentials); err != nil {
+		msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, injectServiceBindingErrorReason(err), msg)
+		return c.processServiceBindingOperationError(binding, readyCond)
+	}
+
+	return c.processBindSuccess(binding)
+}
+
+// unbindToRotateServiceBindingCredentials unbinds a binding whose credentials
+// are being rotated, so that the broker revokes the old credentials before
+// the binding is bound again. The unbind is synchronous, so that the old
+// credentials are revoked before the bind request is sent. Once unbound, the
+// external properties of the binding are cleared, since the broker no longer
+// knows about it, and its status is updated right away, so that the binding
+// is not unbound again when the bind request has to wait for the rate limit
+// of the broker. It returns the updated binding once unbound; otherwise the
+// binding has been requeued or its status updated, and the returned error, if
+// any, is to be returned by the reconciler.
+func (c *controller) unbindToRotateServiceBindingCredentials(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, brokerClient osb.Client) (*v1beta1.ServiceBinding, error) {
+	pcb := c.newBindingContextBuilder(binding)
+	klog.V(4).Info(pcb.Messagef("Unbinding to rotate the credentials for rotation request %v", binding.Spec.RotationRequests))
+
+	request, err := c.prepareUnbindRequest(binding, instance)
+	if err != nil {
+		return nil, c.handleServiceBindingReconciliationError(binding, err)
+	}
+	request.AcceptsIncomplete = false
+
+	if c.delayServiceBindingBrokerCall(binding, brokerClient) {
+		return nil, nil
+	}
+	// A binding that a previous attempt already unbound is reported as gone,
+	// which the client treats as a success.
+	if _, err := brokerClient.Unbind(request); err != nil {
+		if _, ok := osb.IsHTTPError(err); ok {
+			// Retrying would get the same answer from the broker, so the
+			// rotation is given up until it is requested again. The binding
+			// still works with its old credentials. The rotation request is
+			// recorded as handled, so that a later change to the spec of
+			// the binding does not retry it.
+			msg := fmt.Sprintf("The credentials were not rotated because the broker refused to unbind, the old credentials are still valid: %v", err)
+			c.recorder.Event(binding, corev1.EventTypeWarning, errorRotatingCredentialsReason, msg)
+			setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, errorRotatingCredentialsReason, msg)
+			binding.Status.RotationRequests = binding.Spec.RotationRequests
+			clearServiceBindingCurrentOperation(binding)
+			_, err := c.updateServiceBindingStatus(binding)
+			return nil, err
+		}
+
+		msg := fmt.Sprintf("Error unbinding to rotate the credentials, the old credentials are still valid: %v", err)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorRotatingCredentialsReason, msg)
+		return nil, c.processServiceBindingOperationError(binding, readyCond)
+	}
+
+	binding.Status.ExternalProperties = nil
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionFalse, rotatingCredentialsReason, rotatingCredentialsMessage)
+	return c.updateServiceBindingStatus(binding)
+}
+
+// withRevokedCredentials appends to the message of a condition of a binding
+// that failed to bind again while rotating its credentials that the old
+// credentials were revoked, since the secret still holds them.
+func withRevokedCredentials(binding *v1beta1.ServiceBinding, message string) string {
+	if binding.Status.CurrentOperation != v1beta1.ServiceBindingOperationBind ||
+		binding.Status.ExternalProperties != nil ||
+		binding.Spec.RotationRequests <= binding.Status.RotationRequests {
+		return message
+	}
+	return message + "; the old credentials were revoked by the broker when rotating them and no longer work"
+}
+
 func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBinding) error {
 	var err error
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 
 	if binding.DeletionTimestamp == nil && !binding.Status.OrphanMitigationInProgress {
 		// nothing to do...
@@ -461,6 +626,9 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		return c.handleServiceBindingReconciliationError(binding, err)
 	}
 
+	if c.delayServiceBindingBrokerCall(binding, brokerClient) {
+		return nil
+	}
 	response, err := brokerClient.Unbind(request)
 	if err != nil {
 		msg := fmt.Sprintf(
@@ -484,38 +652,8 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 	return c.processUnbindSuccess(binding)
 }
 
-// isClusterServicePlanBindable returns whether the given ClusterServiceClass and ClusterServicePlan
-// combination is bindable.  Plans may override the service-level bindable
-// attribute, so if the plan provides a value, return that value.  Otherwise,
-// return the Bindable field of the ClusterServiceClass.
-//
-// Note: enforcing that the plan belongs to the given service class is the
-// responsibility of the caller.
-func isClusterServicePlanBindable(serviceClass *v1beta1.ClusterServiceClass, plan *v1beta1.ClusterServicePlan) bool {
-	if plan.Spec.Bindable != nil {
-		return *plan.Spec.Bindable
-	}
-
-	return serviceClass.Spec.Bindable
-}
-
-// isServicePlanBindable returns whether the given ServiceClass and ServicePlan
-// combination is bindable.  Plans may override the service-level bindable
-// attribute, so if the plan provides a value, return that value.  Otherwise,
-// return the Bindable field of the ServiceClass.
-//
-// Note: enforcing that the plan belongs to the given service class is the
-// responsibility of the caller.
-func isServicePlanBindable(serviceClass *v1beta1.ServiceClass, plan *v1beta1.ServicePlan) bool {
-	if plan.Spec.Bindable != nil {
-		return *plan.Spec.Bindable
-	}
-
-	return serviceClass.Spec.Bindable
-}
-
 func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 	klog.V(5).Info(pcb.Messagef(`Creating/updating Secret "%s/%s" with %d keys`,
 		binding.Namespace, binding.Spec.SecretName, len(credentials),
 	))
@@ -524,6 +662,24 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		return fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
 	}
 
+	// The naming convention is applied last, so SecretTransforms always
+	// refer to the keys as returned by the broker.
+	credentials, sanitizedKeys, err := c.secretKeyConvention.apply(credentials)
+	if err != nil {
+		if _, ok := err.(*invalidSecretKeysError); ok {
+			return err
+		}
+		return fmt.Errorf(`Unexpected error while renaming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+	}
+	var annotations map[string]string
+	if len(sanitizedKeys) > 0 {
+		originalKeys, err := json.Marshal(sanitizedKeys)
+		if err != nil {
+			return err
+		}
+		annotations = map[string]string{OriginalSecretKeysAnnotation: string(originalKeys)}
+	}
+
 	secretData := make(map[string][]byte)
 	for k, v := range credentials {
 		var err error
@@ -541,46 +697,110 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 			controllerRef := metav1.GetControllerOf(existingSecret)
 			return fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingSecret.Name, controllerRef)
 		}
-		existingSecret.Data = secretData
-		if _, err = secretClient.Update(existingSecret); err != nil {
-			if apierrors.IsConflict(err) {
-				// Conflicting update detected, try again later
-				return fmt.Errorf(`Conflicting Secret "%s/%s" update detected`, binding.Namespace, existingSecret.Name)
+		// The type of a secret cannot be changed, so a secret whose type no
+		// longer matches the binding is always recreated.
+		if c.secretUpdateStrategy == SecretUpdateStrategyRecreate || !secretHasType(existingSecret, serviceBindingSecretType(binding)) {
+			// Delete the secret, only if it has not been replaced meanwhile,
+			// and create it again with the new credentials.
+			preconditions := metav1.NewUIDPreconditions(string(existingSecret.UID))
+			if err = secretClient.Delete(existingSecret.Name, &metav1.DeleteOptions{Preconditions: preconditions}); err != nil && !apierrors.IsNotFound(err) {
+				if apierrors.IsConflict(err) {
+					// Conflicting deletion detected, try again later
+					return fmt.Errorf(`Conflicting Secret "%s/%s" deletion detected`, binding.Namespace, existingSecret.Name)
+				}
+				return fmt.Errorf(`Unexpected error deleting Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
+			}
+			if err = createServiceBindingSecret(secretClient, binding, secretData, annotations); err != nil {
+				return err
+			}
+		} else {
+			existingSecret.Data = secretData
+			if annotations != nil {
+				metav1.SetMetaDataAnnotation(&existingSecret.ObjectMeta, OriginalSecretKeysAnnotation, annotations[OriginalSecretKeysAnnotation])
+			} else {
+				delete(existingSecret.Annotations, OriginalSecretKeysAnnotation)
+			}
+			if _, err = secretClient.Update(existingSecret); err != nil {
+				if apierrors.IsConflict(err) {
+					// Conflicting update detected, try again later
+					return fmt.Errorf(`Conflicting Secret "%s/%s" update detected`, binding.Namespace, existingSecret.Name)
+				}
+				return fmt.Errorf(`Unexpected error updating Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
 			}
-			return fmt.Errorf(`Unexpected error updating Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
 		}
 	} else {
 		if !apierrors.IsNotFound(err) {
 			// Terminal error
 			return fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, binding.Namespace, existingSecret.Name, err)
 		}
-		err = nil
-		// Create new secret
-		secret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      binding.Spec.SecretName,
-				Namespace: binding.Namespace,
-				OwnerReferences: []metav1.OwnerReference{
-					*metav1.NewControllerRef(binding, bindingControllerKind),
-				},
-			},
-			Data: secretData,
+		if err = createServiceBindingSecret(secretClient, binding, secretData, annotations); err != nil {
+			return err
 		}
+	}
 
-		if _, err = secretClient.Create(secret); err != nil {
-			if apierrors.IsAlreadyExists(err) {
-				// Concurrent controller has created secret under the same name,
-				// Update the secret at the next retry iteration
-				return fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, binding.Namespace, secret.Name)
-			}
-			// Terminal error
-			return fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, binding.Namespace, secret.Name, err)
-		}
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingSecretDriftDetection) {
+		binding.Status.SecretChecksum = secretChecksum(secretData)
 	}
 
 	return err
 }
 
+// createServiceBindingSecret creates the secret of a binding. The secret always
+// lives in the binding's namespace, so it can be controlled by the binding and
+// garbage collected by Kubernetes once the binding is deleted.
+func createServiceBindingSecret(secretClient corev1client.SecretInterface, binding *v1beta1.ServiceBinding, secretData map[string][]byte, annotations map[string]string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        binding.Spec.SecretName,
+			Namespace:   binding.Namespace,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(binding, bindingControllerKind),
+			},
+		},
+		Type: serviceBindingSecretType(binding),
+		Data: secretData,
+	}
+
+	if _, err := secretClient.Create(secret); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// Concurrent controller has created secret under the same name,
+			// Update the secret at the next retry iteration
+			return fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, binding.Namespace, secret.Name)
+		}
+		// Terminal error
+		return fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, binding.Namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// serviceBindingSecretType returns the type of the secret of a binding,
+// which is Opaque unless the binding asks for another one.
+func serviceBindingSecretType(binding *v1beta1.ServiceBinding) corev1.SecretType {
+	if binding.Spec.SecretType == "" {
+		return corev1.SecretTypeOpaque
+	}
+	return corev1.SecretType(binding.Spec.SecretType)
+}
+
+// secretHasType returns whether the secret is of the given type. Secrets
+// without a type are Opaque.
+func secretHasType(secret *corev1.Secret, secretType corev1.SecretType) bool {
+	if secret.Type == "" {
+		return secretType == corev1.SecretTypeOpaque
+	}
+	return secret.Type == secretType
+}
+
+// injectServiceBindingErrorReason returns the reason of the condition that
+// reports an error returned by injectServiceBinding.
+func injectServiceBindingErrorReason(err error) string {
+	if _, ok := err.(*invalidSecretKeysError); ok {
+		return errorInvalidSecretKeysReason
+	}
+	return errorInjectingBindResultReason
+}
+
 func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform, credentials map[string]interface{}) error {
 	for _, t := range transforms {
 		switch {
@@ -616,11 +836,46 @@ func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform,
 			}
 		case t.RemoveKey != nil:
 			delete(credentials, t.RemoveKey.Key)
+		case t.TemplateKey != nil:
+			value, err := evaluateTemplate(t.TemplateKey, credentials)
+			if err != nil {
+				return err
+			}
+			credentials[t.TemplateKey.Key] = value
 		}
 	}
 	return nil
 }
 
+// evaluateTemplate renders the template of a TemplateKeyTransform against the
+// credentials. A reference to a key missing from the credentials is an error,
+// unless the transform provides a default value.
+func evaluateTemplate(transform *v1beta1.TemplateKeyTransform, credentials map[string]interface{}) (string, error) {
+	tmpl, err := template.New(transform.Key).Option("missingkey=error").Parse(transform.Template)
+	if err != nil {
+		return "", fmt.Errorf("invalid template for key %q: %v", transform.Key, err)
+	}
+
+	// Values merged from other Secrets are stored as bytes; render them as
+	// strings rather than as byte slices.
+	data := make(map[string]interface{}, len(credentials))
+	for k, v := range credentials {
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		data[k] = v
+	}
+
+	buf := new(bytes.Buffer)
+	if err := tmpl.Execute(buf, data); err != nil {
+		if transform.Default != nil {
+			return *transform.Default, nil
+		}
+		return "", fmt.Errorf("unable to render template for key %q: %v", transform.Key, err)
+	}
+	return buf.String(), nil
+}
+
 func evaluateJSONPath(jsonPath string, credentials map[string]interface{}) (string, error) {
 	j := jsonpath.New("expression")
 	buf := new(bytes.Buffer)
@@ -635,7 +890,7 @@ func evaluateJSONPath(jsonPath string, credentials map[string]interface{}) (stri
 
 func (c *controller) ejectServiceBinding(binding *v1beta1.ServiceBinding) error {
 	var err error
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 	klog.V(5).Info(pcb.Messagef(`Deleting Secret "%s/%s"`,
 		binding.Namespace, binding.Spec.SecretName,
 	))
@@ -722,7 +977,7 @@ func setServiceBindingConditionInternal(toUpdate *v1beta1.ServiceBinding,
 }
 
 func (c *controller) updateServiceBindingStatus(toUpdate *v1beta1.ServiceBinding) (*v1beta1.ServiceBinding, error) {
-	pcb := pretty.NewBindingContextBuilder(toUpdate)
+	pcb := c.newBindingContextBuilder(toUpdate)
 	klog.V(4).Info(pcb.Message("Updating status"))
 	updatedBinding, err := c.serviceCatalogClient.ServiceBindings(toUpdate.Namespace).UpdateStatus(toUpdate)
 	if err != nil {
@@ -744,7 +999,7 @@ func (c *controller) updateServiceBindingCondition(
 	status v1beta1.ConditionStatus,
 	reason, message string) error {
 
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 	toUpdate := binding.DeepCopy()
 
 	setServiceBindingCondition(toUpdate, conditionType, status, reason, message)
@@ -792,6 +1047,10 @@ func (c *controller) recordStartOfServiceBindingOperation(
 		reason = bindingInFlightReason
 		message = bindingInFlightMessage
 		toUpdate.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusRequired
+		// The key is persisted along with the start of the operation,
+		// before any bind request is sent, so that every retry of the
+		// operation carries the same key.
+		toUpdate.Status.OperationKey = string(uuid.NewUUID())
 	case v1beta1.ServiceBindingOperationUnbind:
 		reason = unbindingInFlightReason
 		message = unbindingInFlightMessage
@@ -817,6 +1076,7 @@ func clearServiceBindingCurrentOperation(toUpdate *v1beta1.ServiceBinding) {
 	toUpdate.Status.ReconciledGeneration = toUpdate.Generation
 	toUpdate.Status.InProgressProperties = nil
 	toUpdate.Status.OrphanMitigationInProgress = false
+	toUpdate.Status.OperationKey = ""
 }
 
 // rollbackBindingReconciledGenerationOnDeletion resets the ReconciledGeneration
@@ -870,7 +1130,7 @@ func (c *controller) finishPollingServiceBinding(binding *v1beta1.ServiceBinding
 }
 
 func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 	klog.V(4).Infof(pcb.Message("Processing"))
 
 	binding = binding.DeepCopy()
@@ -898,6 +1158,9 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		return c.handleServiceBindingReconciliationError(binding, err)
 	}
 
+	if c.delayServiceBindingBrokerCall(binding, brokerClient) {
+		return nil
+	}
 	klog.V(5).Info(pcb.Message("Polling last operation"))
 
 	response, err := brokerClient.PollBindingLastOperation(request)
@@ -977,6 +1240,9 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		}
 
 		// TODO(mkibbe): Break this logic out so that GET and inject are retried separately on error
+		if c.delayServiceBindingBrokerCall(binding, brokerClient) {
+			return nil
+		}
 		getBindingResponse, err := brokerClient.GetBinding(getBindingRequest)
 		if err != nil {
 			reason := errorFetchingBindingFailedReason
@@ -992,7 +1258,7 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		}
 
 		if err := c.injectServiceBinding(binding, getBindingResponse.Credentials); err != nil {
-			reason := errorInjectingBindResultReason
+			reason := injectServiceBindingErrorReason(err)
 			msg := fmt.Sprintf("Error injecting bind results: %v", err)
 
 			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, reason, msg)
@@ -1129,6 +1395,43 @@ func setServiceBindingLastOperation(binding *v1beta1.ServiceBinding, operationKe
 	}
 }
 
+// validateServiceBindingParameters validates the parameters built for a
+// binding against the binding parameter schema of its plan. The returned
+// operationError points at the source of each invalid value, so that users
+// know whether to fix a secret or the parameters of the binding. The keywords
+// of the schema that are not checked are reported in a warning event.
+func (c *controller) validateServiceBindingParameters(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, schema *runtime.RawExtension, parameters map[string]interface{}) error {
+	violations, unsupported, err := validateParametersSchema(schema, parameters)
+	if err != nil {
+		return &operationError{
+			reason:  errorWithParametersSchemaReason,
+			message: fmt.Sprintf("Failed to read the binding parameter schema of the plan: %v", err),
+		}
+	}
+	if len(unsupported) > 0 {
+		// The broker still validates the parameters against the whole
+		// schema, so the binding goes on with the keywords that were checked.
+		msg := fmt.Sprintf("The parameters were not checked against the %s keywords of the binding parameter schema of the plan", strings.Join(unsupported, ", "))
+		c.recorder.Event(binding, corev1.EventTypeWarning, unsupportedParametersSchemaReason, msg)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	// The sources are only looked up again when the parameters are invalid
+	sources, err := parameterSources(c.kubeClient, binding.Namespace, instance, binding.Spec.ParametersFrom, binding.Spec.Parameters)
+	if err != nil {
+		return &operationError{
+			reason:  errorWithParametersReason,
+			message: err.Error(),
+		}
+	}
+	return &operationError{
+		reason:  errorWithParametersSchemaReason,
+		message: fmt.Sprintf("Parameters do not match the binding parameter schema of the plan: %s", describeSchemaViolations(violations, sources)),
+	}
+}
+
 // prepareBindRequest creates a bind request object to be passed to the broker
 // client to create the given binding.
 func (c *controller) prepareBindRequest(
@@ -1138,6 +1441,7 @@ func (c *controller) prepareBindRequest(
 	var scExternalID string
 	var spExternalID string
 	var scBindingRetrievable bool
+	var bindingSchema *runtime.RawExtension
 
 	if instance.Spec.ClusterServiceClassSpecified() {
 
@@ -1160,6 +1464,7 @@ func (c *controller) prepareBindRequest(
 		scExternalID = serviceClass.Spec.ExternalID
 		spExternalID = servicePlan.Spec.ExternalID
 		scBindingRetrievable = serviceClass.Spec.BindingRetrievable
+		bindingSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
 
 	} else if instance.Spec.ServiceClassSpecified() {
 
@@ -1182,6 +1487,7 @@ func (c *controller) prepareBindRequest(
 		scExternalID = serviceClass.Spec.ExternalID
 		spExternalID = servicePlan.Spec.ExternalID
 		scBindingRetrievable = serviceClass.Spec.BindingRetrievable
+		bindingSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
 	}
 
 	ns, err := c.kubeClient.CoreV1().Namespaces().Get(instance.Namespace, metav1.GetOptions{})
@@ -1192,9 +1498,21 @@ func (c *controller) prepareBindRequest(
 		}
 	}
 
+	if missing := missingInstanceOutputs(instance, binding.Spec.ParametersFrom); len(missing) > 0 {
+		// The instance may record its outputs later, so keep retrying.
+		return nil, nil, &operationError{
+			reason: waitingForInstanceOutputReason,
+			message: fmt.Sprintf(
+				"Waiting for %s to record output(s) %s",
+				pretty.ServiceInstanceName(instance), strings.Join(missing, ", "),
+			),
+		}
+	}
+
 	parameters, parametersChecksum, rawParametersWithRedaction, err := prepareInProgressPropertyParameters(
 		c.kubeClient,
 		binding.Namespace,
+		instance,
 		binding.Spec.Parameters,
 		binding.Spec.ParametersFrom,
 	)
@@ -1205,6 +1523,12 @@ func (c *controller) prepareBindRequest(
 		}
 	}
 
+	if bindingSchema != nil && utilfeature.DefaultFeatureGate.Enabled(scfeatures.ParametersSchemaValidation) {
+		if err := c.validateServiceBindingParameters(binding, instance, bindingSchema, parameters); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	inProgressProperties := &v1beta1.ServiceBindingPropertiesState{
 		Parameters:        rawParametersWithRedaction,
 		ParameterChecksum: parametersChecksum,
@@ -1219,6 +1543,10 @@ func (c *controller) prepareBindRequest(
 		"namespace":          instance.Namespace,
 		clusterIdentifierKey: clusterID,
 	}
+	c.requestContextPolicy.enrich(requestContext, ns)
+	if binding.Status.OperationKey != "" {
+		requestContext[operationKeyContextKey] = binding.Status.OperationKey
+	}
 
 	request := &osb.BindRequest{
 		BindingID:    binding.Spec.ExternalID,
@@ -1388,6 +1716,7 @@ func (c *controller) prepareServiceBindingLastOperationRequest(
 // processServiceBindingOperationError handles the logging and updating of a
 // ServiceBinding that hit a retryable error during reconciliation.
 func (c *controller) processServiceBindingOperationError(binding *v1beta1.ServiceBinding, readyCond *v1beta1.ServiceBindingCondition) error {
+	readyCond.Message = withRevokedCredentials(binding, readyCond.Message)
 	c.recorder.Event(binding, corev1.EventTypeWarning, readyCond.Reason, readyCond.Message)
 	setServiceBindingCondition(binding, readyCond.Type, readyCond.Status, readyCond.Reason, readyCond.Message)
 	if _, err := c.updateServiceBindingStatus(binding); err != nil {
@@ -1401,6 +1730,12 @@ func (c *controller) processServiceBindingOperationError(binding *v1beta1.Servic
 // has successfully been created at the broker and has had its credentials
 // injected in the cluster.
 func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
+	rotated := binding.Spec.RotationRequests > binding.Status.RotationRequests
+	if rotated {
+		now := metav1.Now()
+		binding.Status.LastRotationTime = &now
+	}
+	binding.Status.RotationRequests = binding.Spec.RotationRequests
 	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage)
 	currentReconciledGeneration := binding.Status.ReconciledGeneration
 	clearServiceBindingCurrentOperation(binding)
@@ -1411,6 +1746,9 @@ func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
 	}
 
 	c.recorder.Event(binding, corev1.EventTypeNormal, successInjectedBindResultReason, successInjectedBindResultMessage)
+	if rotated {
+		c.recorder.Event(binding, corev1.EventTypeNormal, successRotatedCredentialsReason, successRotatedCredentialsMessage)
+	}
 	return nil
 }
 
@@ -1418,7 +1756,9 @@ func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
 // hit a terminal failure during bind reconciliation.
 func (c *controller) processBindFailure(binding *v1beta1.ServiceBinding, readyCond, failedCond *v1beta1.ServiceBindingCondition, shouldMitigateOrphan bool) error {
 	currentReconciledGeneration := binding.Status.ReconciledGeneration
+	failedCond.Message = withRevokedCredentials(binding, failedCond.Message)
 	if readyCond != nil {
+		readyCond.Message = withRevokedCredentials(binding, readyCond.Message)
 		c.recorder.Event(binding, corev1.EventTypeWarning, readyCond.Reason, readyCond.Message)
 		setServiceBindingCondition(binding, readyCond.Type, readyCond.Status, readyCond.Reason, readyCond.Message)
 	}
@@ -1486,7 +1826,7 @@ func (c *controller) processServiceBindingGracefulDeletionSuccess(binding *v1bet
 		return err
 	}
 
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 	klog.Info(pcb.Message("Cleared finalizer"))
 
 	return nil
@@ -1583,7 +1923,7 @@ func (c *controller) handleServiceBindingPollingError(binding *v1beta1.ServiceBi
 	//	2) attempt to requeue in the polling queue
 	//		- if successful, we can return nil to avoid regular queue
 	//		- if failure, return err to fall back to regular queue
-	pcb := pretty.NewBindingContextBuilder(binding)
+	pcb := c.newBindingContextBuilder(binding)
 	klog.V(4).Info(pcb.Messagef("Error during polling: %v", err))
 	return c.continuePollingServiceBinding(binding)
 }
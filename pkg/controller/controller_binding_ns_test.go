@@ -247,6 +247,64 @@ func TestReconcileServiceBindingAsynchronousBindNamespacedRefs(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceBindingBindableServiceClassNonbindablePlanNamespacedRefs
+// tests reconcileBinding to ensure a binding for an instance with a
+// non-bindable namespaced plan of a bindable class fails as expected.
+func TestReconcileServiceBindingBindableServiceClassNonbindablePlanNamespacedRefs(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	if err != nil {
+		t.Fatalf("Could not enable NamespacedServiceBroker feature flag.")
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	_, fakeCatalogClient, fakeServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	servicePlan := getTestServicePlan()
+	servicePlan.Spec.Bindable = falsePtr()
+
+	sharedInformers.ServiceBrokers().Informer().GetStore().Add(getTestServiceBroker())
+	sharedInformers.ServiceClasses().Informer().GetStore().Add(getTestServiceClass())
+	sharedInformers.ServicePlans().Informer().GetStore().Add(servicePlan)
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithNamespacedRefsAndStatus(v1beta1.ConditionTrue))
+
+	binding := &v1beta1.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testServiceBindingName,
+			Namespace:  testNamespace,
+			Generation: 1,
+		},
+		Spec: v1beta1.ServiceBindingSpec{
+			InstanceRef: v1beta1.LocalObjectReference{Name: testServiceInstanceName},
+			ExternalID:  testServiceBindingGUID,
+		},
+		Status: v1beta1.ServiceBindingStatus{
+			UnbindStatus: v1beta1.ServiceBindingUnbindStatusNotRequired,
+		},
+	}
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("binding against a nonbindable plan should fail")
+	}
+
+	assertNumberOfBrokerActions(t, fakeServiceBrokerClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding)
+	assertServiceBindingFailedBeforeRequest(t, updatedServiceBinding, errorNonbindableClusterServiceClassReason, binding)
+
+	events := getRecordedEvents(testController)
+
+	expectedEvent := warningEventBuilder(errorNonbindableClusterServiceClassReason).msgf(
+		"References a non-bindable ServiceClass (K8S: %q ExternalName: %q) and Plan (%q) combination",
+		testNamespace+"/"+testServiceClassGUID, testServiceClassName, testServicePlanName,
+	).String()
+	if err := checkEvents(events, []string{expectedEvent, expectedEvent}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestReconcileBindingDeleteNamespacedRefs tests reconcileBinding to ensure a
 // binding deletion works as expected.
 func TestReconcileServiceBindingDeleteNamespacedRefs(t *testing.T) {
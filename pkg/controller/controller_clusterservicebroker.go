@@ -55,11 +55,20 @@ const (
 	// these reasons are re-used in other controller files.
 	errorFetchingCatalogReason            string = "ErrorFetchingCatalog"
 	errorFetchingCatalogMessage           string = "Error fetching catalog."
+	errorUnsupportedOSBAPIVersionReason   string = "ErrorUnsupportedOSBAPIVersion"
 	errorSyncingCatalogReason             string = "ErrorSyncingCatalog"
 	errorSyncingCatalogMessage            string = "Error syncing catalog from ClusterServiceBroker."
 	successFetchedCatalogReason           string = "FetchedCatalog"
 	successFetchedCatalogMessage          string = "Successfully fetched catalog entries from broker."
 	errorReconciliationRetryTimeoutReason string = "ErrorReconciliationRetryTimeout"
+	errorInsecureBrokerURLReason          string = "ErrorInsecureBrokerURL"
+	errorInsecureBrokerURLMessage         string = "Not sending credentials to broker URL %q because it does not use https; set spec.allowInsecure to allow it."
+	insecureBrokerURLReason               string = "InsecureBrokerURL"
+	insecureBrokerURLMessage              string = "The broker URL does not use https."
+	insecureBrokerURLAllowedReason        string = "InsecureBrokerURLAllowed"
+	insecureBrokerURLAllowedMessage       string = "The broker URL does not use https; credentials are sent over an insecure connection because spec.allowInsecure is set."
+	quiescedBrokerReason                  string = "BrokerQuiesced"
+	quiescedBrokerMessage                 string = "The broker is quiesced; new instances of its classes cannot be created."
 )
 
 func (c *controller) clusterServiceBrokerAdd(obj interface{}) {
@@ -78,6 +87,11 @@ func (c *controller) clusterServiceBrokerAdd(obj interface{}) {
 }
 
 func (c *controller) clusterServiceBrokerUpdate(oldObj, newObj interface{}) {
+	oldBroker, oldOK := oldObj.(*v1beta1.ClusterServiceBroker)
+	newBroker, newOK := newObj.(*v1beta1.ClusterServiceBroker)
+	if oldOK && newOK && isServiceBrokerStatusUpdate(&oldBroker.ObjectMeta, &newBroker.ObjectMeta) {
+		return
+	}
 	c.clusterServiceBrokerAdd(newObj)
 }
 
@@ -90,6 +104,31 @@ func (c *controller) clusterServiceBrokerDelete(obj interface{}) {
 	klog.V(4).Infof("Received delete event for ClusterServiceBroker %v; no further processing will occur", broker.Name)
 }
 
+// isServiceBrokerStatusUpdate returns whether an update of a broker left its
+// spec alone. The controller makes those updates itself while reconciling the
+// broker, and a broker that failed to reconcile is retried with a backoff of
+// its own, so queueing the broker again for them would let a failing broker
+// bypass its backoff and crowd out the other brokers. Resyncs, which do not
+// change the resource version, still queue the broker to relist it.
+func isServiceBrokerStatusUpdate(oldMeta, newMeta *metav1.ObjectMeta) bool {
+	return oldMeta.ResourceVersion != newMeta.ResourceVersion &&
+		oldMeta.Generation == newMeta.Generation &&
+		newMeta.DeletionTimestamp == nil
+}
+
+// recordBrokerRelist counts a fetch of the catalog of the broker with the
+// given key, labeled by the scope and namespace of the broker.
+func recordBrokerRelist(key BrokerKey, err error) {
+	scope, result := "namespace", "success"
+	if key.IsClusterScoped() {
+		scope = "cluster"
+	}
+	if err != nil {
+		result = "failure"
+	}
+	metrics.BrokerRelistCount.WithLabelValues(scope, key.namespace, key.name, result).Inc()
+}
+
 // shouldReconcileClusterServiceBroker determines whether a broker should be reconciled; it
 // returns true unless the broker has a ready condition with status true and
 // the controller's broker relist interval has not elapsed since the broker's
@@ -138,7 +177,10 @@ func (c *controller) updateClusterServiceBrokerClient(broker *v1beta1.ClusterSer
 		return nil, err
 	}
 	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
-	brokerClient, err := c.brokerClientManager.UpdateBrokerClient(NewClusterServiceBrokerKey(broker.Name), clientConfig)
+	clientConfig.APIVersion = c.osbAPIVersionForBroker(&broker.Status.CommonServiceBrokerStatus)
+	transportConfig := NewTransportConfigurationForBroker(&broker.Spec.CommonServiceBrokerSpec)
+	transportConfig.UserAgent = c.getBrokerUserAgent()
+	brokerClient, err := c.brokerClientManager.UpdateBrokerClientWithTransport(NewClusterServiceBrokerKey(broker.Name), clientConfig, transportConfig)
 	if err != nil {
 		s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
 		klog.Info(pcb.Message(s))
@@ -163,12 +205,37 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
 	if !shouldReconcileClusterServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
+		// Broker clients are only kept in memory, so a controller that has
+		// just started has none for brokers that are not due to be relisted
+		// yet. Create one, so that operations that were in progress on the
+		// broker's instances and bindings can resume.
+		if _, found := c.brokerClientManager.BrokerClient(NewClusterServiceBrokerKey(broker.Name)); !found {
+			klog.V(4).Info(pcb.Message("Creating the broker client without relisting"))
+			_, err := c.updateClusterServiceBrokerClient(broker)
+			return err
+		}
 		return nil
 	}
 
 	if broker.DeletionTimestamp == nil { // Add or update
 		klog.V(4).Info(pcb.Message("Processing adding/update event"))
 
+		if conditions, changed := setServiceBrokerInsecureCondition(broker.Status.Conditions, &broker.Spec.CommonServiceBrokerSpec); changed {
+			broker = broker.DeepCopy()
+			broker.Status.Conditions = conditions
+		}
+		if conditions, changed := setServiceBrokerQuiescedCondition(broker.Status.Conditions, &broker.Spec.CommonServiceBrokerSpec); changed {
+			broker = broker.DeepCopy()
+			broker.Status.Conditions = conditions
+		}
+		if isBrokerURLInsecure(&broker.Spec.CommonServiceBrokerSpec) && !broker.Spec.AllowInsecure && broker.Spec.AuthInfo != nil {
+			s := fmt.Sprintf(errorInsecureBrokerURLMessage, broker.Spec.URL)
+			klog.Warning(pcb.Message(s))
+			c.recorder.Event(broker, corev1.EventTypeWarning, errorInsecureBrokerURLReason, s)
+			c.brokerClientManager.RemoveBrokerClient(NewClusterServiceBrokerKey(broker.Name))
+			return c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorInsecureBrokerURLReason, s)
+		}
+
 		brokerClient, err := c.updateClusterServiceBrokerClient(broker)
 		if err != nil {
 			return err
@@ -176,12 +243,22 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 
 		// get the broker's catalog
 		now := metav1.Now()
-		brokerCatalog, err := brokerClient.GetCatalog()
+		delayBrokerCall := func(client osb.Client) bool { return c.delayClusterServiceBrokerCall(broker, client) }
+		brokerCatalog, osbAPIVersion, err := c.getCatalogNegotiatingOSBAPIVersion(NewClusterServiceBrokerKey(broker.Name), brokerClient, &broker.Status.CommonServiceBrokerStatus, delayBrokerCall)
+		if brokerCatalog == nil && err == nil {
+			// requeued to honor the rate limit of the broker
+			return nil
+		}
+		recordBrokerRelist(NewClusterServiceBrokerKey(broker.Name), err)
 		if err != nil {
+			reason := errorFetchingCatalogReason
+			if _, ok := err.(*unsupportedOSBAPIVersionError); ok {
+				reason = errorUnsupportedOSBAPIVersionReason
+			}
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			klog.Warning(pcb.Message(s))
-			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorFetchingCatalogReason, s)
-			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
+			c.recorder.Eventf(broker, corev1.EventTypeWarning, reason, s)
+			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, reason, errorFetchingCatalogMessage+s); err != nil {
 				return err
 			}
 			if broker.Status.OperationStartTime == nil {
@@ -209,6 +286,14 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 
 		klog.V(5).Info(pcb.Messagef("Successfully fetched %v catalog entries", len(brokerCatalog.Services)))
 
+		// record the version of the OSB API the broker accepted, so that
+		// the requests made for its instances and bindings use it too
+		if broker.Status.OSBAPIVersion != osbAPIVersion.HeaderValue() {
+			klog.V(4).Info(pcb.Messagef("Using version %s of the OSB API", osbAPIVersion.HeaderValue()))
+			broker = broker.DeepCopy()
+			broker.Status.OSBAPIVersion = osbAPIVersion.HeaderValue()
+		}
+
 		// set the operation start time if not already set
 		if broker.Status.OperationStartTime != nil {
 			toUpdate := broker.DeepCopy()
@@ -219,6 +304,14 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			}
 		}
 
+		// skip reconciling the classes and plans when the catalog has not
+		// changed since the last successful relist
+		hash := catalogHash(brokerCatalog)
+		if isCatalogUnchanged(&broker.ObjectMeta, &broker.Status.CommonServiceBrokerStatus, hash) {
+			klog.V(4).Info(pcb.Message("Catalog is unchanged since the last relist; skipping reconciliation of classes and plans"))
+			return c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage)
+		}
+
 		// get the existing services and plans for this broker so that we can
 		// detect when services and plans are removed from the broker's
 		// catalog
@@ -361,9 +454,11 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 			}
 		}
 
-		// everything worked correctly; update the broker's ready condition to
-		// status true
-		if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
+		// everything worked correctly; record the catalog hash and update the
+		// broker's ready condition to status true
+		toUpdate := broker.DeepCopy()
+		toUpdate.Status.LastCatalogHash = hash
+		if err := c.updateClusterServiceBrokerCondition(toUpdate, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
 			return err
 		}
 
@@ -503,6 +598,11 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 	}
 
 	klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ClusterServiceClassName(serviceClass)))
+	if existingServiceClass.Spec.ExternalName != serviceClass.Spec.ExternalName {
+		// instances keep referring to the class by its K8S name, which is
+		// derived from its external ID and does not change
+		klog.V(4).Info(pcb.Messagef("%s was renamed by the broker from %q", pretty.ClusterServiceClassName(serviceClass), existingServiceClass.Spec.ExternalName))
+	}
 
 	// There was an existing service class -- project the update onto it and
 	// update it.
@@ -592,6 +692,11 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 	}
 
 	klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ClusterServicePlanName(servicePlan)))
+	if existingServicePlan.Spec.ExternalName != servicePlan.Spec.ExternalName {
+		// instances keep referring to the plan by its K8S name, which is
+		// derived from its external ID and does not change
+		klog.V(4).Info(pcb.Messagef("%s was renamed by the broker from %q", pretty.ClusterServicePlanName(servicePlan), existingServicePlan.Spec.ExternalName))
+	}
 
 	// There was an existing service plan -- project the update onto it and
 	// update it.
@@ -646,10 +751,10 @@ func (c *controller) updateClusterServiceBrokerCondition(broker *v1beta1.Cluster
 
 	t := time.Now()
 
-	if len(broker.Status.Conditions) == 0 {
+	if !hasServiceBrokerCondition(broker.Status.Conditions, conditionType) {
 		klog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
 		newCondition.LastTransitionTime = metav1.NewTime(t)
-		toUpdate.Status.Conditions = []v1beta1.ServiceBrokerCondition{newCondition}
+		toUpdate.Status.Conditions = append(toUpdate.Status.Conditions, newCondition)
 	} else {
 		for i, cond := range broker.Status.Conditions {
 			if cond.Type == conditionType {
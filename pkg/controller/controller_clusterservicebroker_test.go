@@ -18,6 +18,9 @@ package controller
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
@@ -26,7 +29,11 @@ import (
 	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/metrics"
+	"github.com/poy/service-catalog/pkg/version"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/poy/service-catalog/test/fake"
+	"golang.org/x/time/rate"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -286,6 +293,138 @@ func TestReconcileClusterServiceBrokerExistingServiceClassAndServicePlan(t *test
 	assertNumberOfActions(t, kubeActions, 0)
 }
 
+// TestReconcileClusterServiceBrokerUnchangedCatalog verifies that a relist
+// returning the same catalog as the last successful relist does not
+// reconcile the broker's classes and plans again.
+func TestReconcileClusterServiceBrokerUnchangedCatalog(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	updatedClusterServiceBroker, ok := assertUpdateStatus(t, actions[len(actions)-1], getTestClusterServiceBroker()).(*v1beta1.ClusterServiceBroker)
+	if !ok {
+		t.Fatalf("couldn't convert to *v1beta1.ClusterServiceBroker")
+	}
+	if updatedClusterServiceBroker.Status.LastCatalogHash == "" {
+		t.Fatalf("Expected the catalog hash to be recorded in the broker status")
+	}
+
+	// relist once the relist interval has elapsed
+	lastRelist := metav1.NewTime(time.Now().Add(-25 * time.Hour))
+	updatedClusterServiceBroker.Status.LastCatalogRetrievalTime = &lastRelist
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileClusterServiceBroker(t, testController, updatedClusterServiceBroker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	assertGetCatalog(t, brokerActions[1])
+
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	relistedClusterServiceBroker := assertUpdateStatus(t, actions[0], updatedClusterServiceBroker)
+	assertClusterServiceBrokerReadyTrue(t, relistedClusterServiceBroker)
+}
+
+// TestReconcileClusterServiceBrokerCreatesMissingClient tests that
+// reconciling a broker that is not due to be relisted creates a client for it
+// when there is none, as after a restart of the controller, without fetching
+// its catalog.
+func TestReconcileClusterServiceBrokerCreatesMissingClient(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	brokerKey := NewClusterServiceBrokerKey(getTestClusterServiceBroker().Name)
+	testController.brokerClientManager.RemoveBrokerClient(brokerKey)
+
+	broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	if _, found := testController.brokerClientManager.BrokerClient(brokerKey); !found {
+		t.Fatal("Expected a client to be created for the broker")
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+}
+
+// TestReconcileClusterServiceBrokerUserAgent tests that the client created
+// for a broker identifies service catalog in the User-Agent of its requests.
+func TestReconcileClusterServiceBrokerUserAgent(t *testing.T) {
+	cases := []struct {
+		name            string
+		brokerUserAgent string
+		expected        string
+	}{
+		{
+			name:     "default",
+			expected: fmt.Sprintf("service-catalog/%s (cluster test-cluster-id)", version.Get().GitVersion),
+		},
+		{
+			name:            "configured",
+			brokerUserAgent: "my-catalog/1.0",
+			expected:        "my-catalog/1.0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var userAgent string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				userAgent = r.Header.Get("User-Agent")
+				w.Write([]byte(`{"services":[]}`))
+			}))
+			defer server.Close()
+
+			_, _, _, testController, _ := newTestController(t, noFakeActions())
+			testController.brokerClientManager = NewBrokerClientManager(osb.NewClient, 0, 0)
+			testController.brokerUserAgent = tc.brokerUserAgent
+			testController.setClusterID("test-cluster-id")
+
+			broker := getTestClusterServiceBroker()
+			broker.Spec.URL = server.URL
+			if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+				t.Fatalf("This should not fail: %v", err)
+			}
+
+			if userAgent != tc.expected {
+				t.Fatalf("unexpected User-Agent: %v", expectedGot(tc.expected, userAgent))
+			}
+		})
+	}
+}
+
+// TestReconcileClusterServiceBrokerProxyURL tests that the client created for
+// a broker with a proxy URL sends its requests through that proxy.
+func TestReconcileClusterServiceBrokerProxyURL(t *testing.T) {
+	var requestURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURL = r.URL.String()
+		w.Write([]byte(`{"services":[]}`))
+	}))
+	defer proxy.Close()
+
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	testController.brokerClientManager = NewBrokerClientManager(osb.NewClient, 0, 0)
+
+	broker := getTestClusterServiceBroker()
+	broker.Spec.URL = "http://broker.example.com"
+	broker.Spec.ProxyURL = proxy.URL
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	expected := "http://broker.example.com/v2/catalog"
+	if requestURL != expected {
+		t.Fatalf("unexpected request URL received by the proxy: %v", expectedGot(expected, requestURL))
+	}
+}
+
 func TestReconcileClusterServiceBrokerRemovedClusterServiceClass(t *testing.T) {
 	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, getTestCatalogConfig())
 
@@ -405,6 +544,47 @@ func TestReconcileClusterServiceBrokerRemovedAndRestoredClusterServiceClass(t *t
 	assertNumberOfActions(t, kubeActions, 0)
 }
 
+// TestReconcileClusterServiceBrokerRenamedClusterServiceClass verifies that a
+// relist in which the broker gave a class another external name updates the
+// existing class, which keeps its K8S name, instead of marking it as removed
+// from the broker's catalog.
+func TestReconcileClusterServiceBrokerRenamedClusterServiceClass(t *testing.T) {
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, getTestCatalogConfig())
+
+	testClusterServiceClass := getTestClusterServiceClass()
+	testClusterServiceClass.Spec.ExternalName = "old-" + testClusterServiceClassName
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(testClusterServiceClass)
+
+	fakeCatalogClient.AddReactor("list", "clusterserviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ClusterServiceClassList{
+			Items: []v1beta1.ClusterServiceClass{
+				*testClusterServiceClass,
+			},
+		}, nil
+	})
+
+	if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 6)
+	class := assertUpdate(t, actions[2], testClusterServiceClass).(*v1beta1.ClusterServiceClass)
+	if e, a := testClusterServiceClassGUID, class.Name; e != a {
+		t.Fatalf("unexpected class name: %s", expectedGot(e, a))
+	}
+	if e, a := testClusterServiceClassName, class.Spec.ExternalName; e != a {
+		t.Fatalf("unexpected class external name: %s", expectedGot(e, a))
+	}
+	for _, action := range actions {
+		if action.GetResource().Resource == "clusterserviceclasses" && action.GetSubresource() == "status" {
+			t.Fatalf("the renamed class should not be marked as removed from the broker's catalog")
+		}
+	}
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[5], getTestClusterServiceBroker())
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+}
+
 func TestReconcileClusterServiceBrokerRemovedClusterServicePlan(t *testing.T) {
 	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, getTestCatalogConfig())
 
@@ -627,7 +807,7 @@ func TestReconcileClusterServiceBrokerDelete(t *testing.T) {
 			testController.brokerClientManager = NewBrokerClientManager(func(_ *osb.ClientConfiguration) (osb.Client, error) {
 				updateBrokerClientCalled = true
 				return nil, nil
-			})
+			}, 0, 0)
 
 			fakeCatalogClient.AddReactor(getClusterServiceBrokerReactor(broker))
 			fakeCatalogClient.AddReactor(listClusterServiceClassesReactor([]v1beta1.ClusterServiceClass{*testClusterServiceClass}))
@@ -728,6 +908,440 @@ func TestReconcileClusterServiceBrokerErrorFetchingCatalog(t *testing.T) {
 	}
 }
 
+// TestReconcileClusterServiceBrokerNegotiatesOSBAPIVersion verifies that the
+// controller falls back to an older version of the OSB API when the broker
+// rejects the preferred one, and records the version the broker accepted.
+func TestReconcileClusterServiceBrokerNegotiatesOSBAPIVersion(t *testing.T) {
+	brokerKey := NewClusterServiceBrokerKey(testClusterServiceBrokerName)
+	var brokerClientManager *BrokerClientManager
+	catalogRequests := 0
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: fakeosb.DynamicCatalogReaction(func() (*osb.CatalogResponse, error) {
+			catalogRequests++
+			if catalogRequests == 1 {
+				return nil, osb.HTTPStatusCodeError{StatusCode: http.StatusPreconditionFailed}
+			}
+			// The versions tried while negotiating are not used for the
+			// other requests made to the broker meanwhile.
+			if e, a := osb.Version2_13(), brokerClientManager.clients[brokerKey].clientConfig.APIVersion; e != a {
+				t.Fatalf("Unexpected OSB API version of the broker client while negotiating: expected %v, got %v", e.HeaderValue(), a.HeaderValue())
+			}
+			return getTestCatalog(), nil
+		}),
+	})
+	brokerClientManager = testController.brokerClientManager
+
+	if err := reconcileClusterServiceBroker(t, testController, getTestClusterServiceBroker()); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	assertGetCatalog(t, brokerActions[0])
+	assertGetCatalog(t, brokerActions[1])
+
+	actions := fakeCatalogClient.Actions()
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[len(actions)-1], getTestClusterServiceBroker())
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	if e, a := osb.Version2_12().HeaderValue(), updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.OSBAPIVersion; e != a {
+		t.Fatalf("Unexpected OSB API version in status: expected %q, got %q", e, a)
+	}
+
+	clientConfig := testController.brokerClientManager.clients[NewClusterServiceBrokerKey(testClusterServiceBrokerName)].clientConfig
+	if e, a := osb.Version2_12(), clientConfig.APIVersion; e != a {
+		t.Fatalf("Unexpected OSB API version of the broker client: expected %v, got %v", e.HeaderValue(), a.HeaderValue())
+	}
+}
+
+// TestReconcileClusterServiceBrokerRecordedOSBAPIVersion verifies that the
+// catalog is fetched with the version of the OSB API recorded in the status
+// of the broker, without trying newer versions again.
+func TestReconcileClusterServiceBrokerRecordedOSBAPIVersion(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestClusterServiceBroker()
+	broker.Status.OSBAPIVersion = osb.Version2_12().HeaderValue()
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+	clientConfig := testController.brokerClientManager.clients[NewClusterServiceBrokerKey(testClusterServiceBrokerName)].clientConfig
+	if e, a := osb.Version2_12(), clientConfig.APIVersion; e != a {
+		t.Fatalf("Unexpected OSB API version of the broker client: expected %v, got %v", e.HeaderValue(), a.HeaderValue())
+	}
+
+	actions := fakeCatalogClient.Actions()
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[len(actions)-1], broker)
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	if e, a := osb.Version2_12().HeaderValue(), updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.OSBAPIVersion; e != a {
+		t.Fatalf("Unexpected OSB API version in status: expected %q, got %q", e, a)
+	}
+}
+
+// TestReconcileClusterServiceBrokerOSBAPIVersionNegotiationRateLimited
+// verifies that each catalog request made to negotiate the version of the
+// OSB API honors the rate limit of the broker, and that a delayed negotiation
+// resumes at the version it was delayed at.
+func TestReconcileClusterServiceBrokerOSBAPIVersionNegotiationRateLimited(t *testing.T) {
+	catalogRequests := 0
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: fakeosb.DynamicCatalogReaction(func() (*osb.CatalogResponse, error) {
+			catalogRequests++
+			if catalogRequests == 1 {
+				return nil, osb.HTTPStatusCodeError{StatusCode: http.StatusPreconditionFailed}
+			}
+			return getTestCatalog(), nil
+		}),
+	})
+
+	brokerKey := NewClusterServiceBrokerKey(testClusterServiceBrokerName)
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	testController.brokerClientManager.brokerQPS = 1
+	testController.brokerClientManager.limiters[brokerKey] = limiter
+
+	broker := getTestClusterServiceBroker()
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	clientConfig := testController.brokerClientManager.clients[brokerKey].clientConfig
+	if e, a := osb.Version2_13(), clientConfig.APIVersion; e != a {
+		t.Fatalf("Unexpected OSB API version of the broker client: expected %v, got %v", e.HeaderValue(), a.HeaderValue())
+	}
+
+	limiter.SetLimit(rate.Inf)
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 2)
+	actions := fakeCatalogClient.Actions()
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[len(actions)-1], broker)
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	if e, a := osb.Version2_12().HeaderValue(), updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.OSBAPIVersion; e != a {
+		t.Fatalf("Unexpected OSB API version in status: expected %q, got %q", e, a)
+	}
+}
+
+// TestReconcileClusterServiceBrokerUnsupportedOSBAPIVersion verifies that a
+// broker rejecting every version of the OSB API supported by the controller
+// is reported as not ready with a reason saying so.
+func TestReconcileClusterServiceBrokerUnsupportedOSBAPIVersion(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: osb.HTTPStatusCodeError{StatusCode: http.StatusPreconditionFailed},
+		},
+	})
+
+	broker := getTestClusterServiceBroker()
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("Should have failed to get the catalog.")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, len(supportedOSBAPIVersions))
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker)
+	assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
+	for _, condition := range updatedClusterServiceBroker.(*v1beta1.ClusterServiceBroker).Status.Conditions {
+		if condition.Type == v1beta1.ServiceBrokerConditionReady && condition.Reason != errorUnsupportedOSBAPIVersionReason {
+			t.Fatalf("Unexpected reason of the ready condition: expected %q, got %q", errorUnsupportedOSBAPIVersionReason, condition.Reason)
+		}
+	}
+
+	events := getRecordedEvents(testController)
+
+	expectedEvent := warningEventBuilder(errorUnsupportedOSBAPIVersionReason).msg("Error getting broker catalog:").msg("the broker does not support any of the versions of the Open Service Broker API supported by the controller (2.13, 2.12, 2.11); the broker may be too old")
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestClusterServiceBrokerUpdate verifies that a broker is only queued for
+// updates that may need it to be reconciled.
+func TestClusterServiceBrokerUpdate(t *testing.T) {
+	now := metav1.Now()
+	cases := []struct {
+		name   string
+		update func(*v1beta1.ClusterServiceBroker)
+		queued bool
+	}{
+		{
+			name:   "resync",
+			update: func(*v1beta1.ClusterServiceBroker) {},
+			queued: true,
+		},
+		{
+			name: "status update",
+			update: func(b *v1beta1.ClusterServiceBroker) {
+				b.ResourceVersion = "2"
+				b.Status.OperationStartTime = &now
+			},
+		},
+		{
+			name: "spec update",
+			update: func(b *v1beta1.ClusterServiceBroker) {
+				b.ResourceVersion = "2"
+				b.Generation = 2
+				b.Spec.RelistRequests = 1
+			},
+			queued: true,
+		},
+		{
+			name: "deletion",
+			update: func(b *v1beta1.ClusterServiceBroker) {
+				b.ResourceVersion = "2"
+				b.DeletionTimestamp = &now
+			},
+			queued: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, testController, _ := newTestController(t, noFakeActions())
+
+			oldBroker := getTestClusterServiceBroker()
+			oldBroker.ResourceVersion = "1"
+			oldBroker.Generation = 1
+			newBroker := oldBroker.DeepCopy()
+			tc.update(newBroker)
+
+			testController.clusterServiceBrokerUpdate(oldBroker, newBroker)
+
+			if e, a := tc.queued, testController.clusterServiceBrokerQueue.Len() == 1; e != a {
+				t.Fatalf("expected the broker to be queued: %v, but it was: %v", e, a)
+			}
+		})
+	}
+}
+
+// TestRecordBrokerRelist verifies that catalog fetches are counted apart for
+// brokers of the same name in different scopes and namespaces.
+func TestRecordBrokerRelist(t *testing.T) {
+	name := "relist-metrics-broker"
+	recordBrokerRelist(NewClusterServiceBrokerKey(name), nil)
+	recordBrokerRelist(NewServiceBrokerKey("ns1", name), errors.New("unreachable"))
+	recordBrokerRelist(NewServiceBrokerKey("ns1", name), errors.New("unreachable"))
+	recordBrokerRelist(NewServiceBrokerKey("ns2", name), nil)
+
+	cases := []struct {
+		labels []string
+		count  float64
+	}{
+		{[]string{"cluster", "", name, "success"}, 1},
+		{[]string{"cluster", "", name, "failure"}, 0},
+		{[]string{"namespace", "ns1", name, "failure"}, 2},
+		{[]string{"namespace", "ns1", name, "success"}, 0},
+		{[]string{"namespace", "ns2", name, "success"}, 1},
+	}
+	for _, tc := range cases {
+		m := &dto.Metric{}
+		if err := metrics.BrokerRelistCount.WithLabelValues(tc.labels...).Write(m); err != nil {
+			t.Fatal(err)
+		}
+		if e, a := tc.count, m.GetCounter().GetValue(); e != a {
+			t.Errorf("%v: expected a count of %v, got %v", tc.labels, e, a)
+		}
+	}
+}
+
+// TestReconcileClusterServiceBrokerInsecureURLWithAuth verifies that the
+// controller does not contact a broker with credentials over a URL that does
+// not use https, unless the broker allows it.
+func TestReconcileClusterServiceBrokerInsecureURLWithAuth(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		Bearer: &v1beta1.ClusterBearerTokenAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+		},
+	})
+	broker.Spec.URL = "http://example.com"
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[0], broker).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
+	conditions := updatedClusterServiceBroker.Status.Conditions
+	if e, a := v1beta1.ServiceBrokerConditionInsecure, conditions[0].Type; e != a {
+		t.Fatalf("unexpected first condition: %s", expectedGot(e, a))
+	}
+	if e, a := errorInsecureBrokerURLReason, conditions[len(conditions)-1].Reason; e != a {
+		t.Fatalf("unexpected ready condition reason: %s", expectedGot(e, a))
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(errorInsecureBrokerURLReason).msgf(errorInsecureBrokerURLMessage, broker.Spec.URL)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestSetServiceBrokerInsecureCondition verifies that the Insecure condition
+// is recorded ahead of the other broker conditions only for insecure URLs.
+func TestSetServiceBrokerInsecureCondition(t *testing.T) {
+	readyCondition := v1beta1.ServiceBrokerCondition{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue}
+	insecureCondition := v1beta1.ServiceBrokerCondition{Type: v1beta1.ServiceBrokerConditionInsecure, Status: v1beta1.ConditionTrue, Reason: insecureBrokerURLReason}
+
+	cases := []struct {
+		name           string
+		url            string
+		allowInsecure  bool
+		conditions     []v1beta1.ServiceBrokerCondition
+		expectedReason string
+		changed        bool
+	}{
+		{
+			name:       "https",
+			url:        "https://example.com",
+			conditions: []v1beta1.ServiceBrokerCondition{readyCondition},
+			changed:    false,
+		},
+		{
+			name:       "https, previously insecure",
+			url:        "https://example.com",
+			conditions: []v1beta1.ServiceBrokerCondition{insecureCondition, readyCondition},
+			changed:    true,
+		},
+		{
+			name:           "http",
+			url:            "http://example.com",
+			conditions:     []v1beta1.ServiceBrokerCondition{readyCondition},
+			expectedReason: insecureBrokerURLReason,
+			changed:        true,
+		},
+		{
+			name:           "http, already recorded",
+			url:            "http://example.com",
+			conditions:     []v1beta1.ServiceBrokerCondition{insecureCondition, readyCondition},
+			expectedReason: insecureBrokerURLReason,
+			changed:        false,
+		},
+		{
+			name:           "http, allowed",
+			url:            "http://example.com",
+			allowInsecure:  true,
+			conditions:     []v1beta1.ServiceBrokerCondition{insecureCondition, readyCondition},
+			expectedReason: insecureBrokerURLAllowedReason,
+			changed:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &v1beta1.CommonServiceBrokerSpec{URL: tc.url, AllowInsecure: tc.allowInsecure}
+			conditions, changed := setServiceBrokerInsecureCondition(tc.conditions, spec)
+			if e, a := tc.changed, changed; e != a {
+				t.Fatalf("unexpected changed: %s", expectedGot(e, a))
+			}
+			if e, a := v1beta1.ServiceBrokerConditionReady, conditions[len(conditions)-1].Type; e != a {
+				t.Fatalf("unexpected last condition: %s", expectedGot(e, a))
+			}
+			hasInsecure := hasServiceBrokerCondition(conditions, v1beta1.ServiceBrokerConditionInsecure)
+			if e, a := tc.expectedReason != "", hasInsecure; e != a {
+				t.Fatalf("unexpected insecure condition: %s", expectedGot(e, a))
+			}
+			if hasInsecure && conditions[0].Reason != tc.expectedReason {
+				t.Fatalf("unexpected insecure condition reason: %s", expectedGot(tc.expectedReason, conditions[0].Reason))
+			}
+		})
+	}
+}
+
+// TestSetServiceBrokerQuiescedCondition verifies that the Quiesced condition
+// is recorded ahead of the other broker conditions only for quiesced brokers.
+func TestSetServiceBrokerQuiescedCondition(t *testing.T) {
+	readyCondition := v1beta1.ServiceBrokerCondition{Type: v1beta1.ServiceBrokerConditionReady, Status: v1beta1.ConditionTrue}
+	quiescedCondition := v1beta1.ServiceBrokerCondition{Type: v1beta1.ServiceBrokerConditionQuiesced, Status: v1beta1.ConditionTrue, Reason: quiescedBrokerReason}
+
+	cases := []struct {
+		name       string
+		quiesced   bool
+		conditions []v1beta1.ServiceBrokerCondition
+		changed    bool
+	}{
+		{
+			name:       "not quiesced",
+			conditions: []v1beta1.ServiceBrokerCondition{readyCondition},
+			changed:    false,
+		},
+		{
+			name:       "no longer quiesced",
+			conditions: []v1beta1.ServiceBrokerCondition{quiescedCondition, readyCondition},
+			changed:    true,
+		},
+		{
+			name:       "quiesced",
+			quiesced:   true,
+			conditions: []v1beta1.ServiceBrokerCondition{readyCondition},
+			changed:    true,
+		},
+		{
+			name:       "quiesced, already recorded",
+			quiesced:   true,
+			conditions: []v1beta1.ServiceBrokerCondition{quiescedCondition, readyCondition},
+			changed:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec := &v1beta1.CommonServiceBrokerSpec{Quiesced: tc.quiesced}
+			conditions, changed := setServiceBrokerQuiescedCondition(tc.conditions, spec)
+			if e, a := tc.changed, changed; e != a {
+				t.Fatalf("unexpected changed: %s", expectedGot(e, a))
+			}
+			if e, a := v1beta1.ServiceBrokerConditionReady, conditions[len(conditions)-1].Type; e != a {
+				t.Fatalf("unexpected last condition: %s", expectedGot(e, a))
+			}
+			if e, a := tc.quiesced, hasServiceBrokerCondition(conditions, v1beta1.ServiceBrokerConditionQuiesced); e != a {
+				t.Fatalf("unexpected quiesced condition: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestReconcileClusterServiceBrokerQuiesced verifies that a quiesced broker
+// is still relisted and records the Quiesced condition.
+func TestReconcileClusterServiceBrokerQuiesced(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestClusterServiceBroker()
+	broker.Spec.Quiesced = true
+
+	if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+	actions := fakeCatalogClient.Actions()
+	updatedClusterServiceBroker := assertUpdateStatus(t, actions[len(actions)-1], broker).(*v1beta1.ClusterServiceBroker)
+	assertClusterServiceBrokerReadyTrue(t, updatedClusterServiceBroker)
+	conditions := updatedClusterServiceBroker.Status.Conditions
+	if e, a := v1beta1.ServiceBrokerConditionQuiesced, conditions[0].Type; e != a {
+		t.Fatalf("unexpected first condition: %s", expectedGot(e, a))
+	}
+}
+
 // TestReconcileClusterServiceBrokerZeroServices simulates broker reconciliation where
 // OSB client responds with zero services which is valid
 func TestReconcileClusterServiceBrokerZeroServices(t *testing.T) {
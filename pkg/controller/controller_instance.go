@@ -19,7 +19,9 @@ package controller
 import (
 	stderrors "errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -52,6 +54,8 @@ const (
 	successProvisionMessage        string = "The instance was provisioned successfully"
 	successOrphanMitigationReason  string = "OrphanMitigationSuccessful"
 	successOrphanMitigationMessage string = "Orphan mitigation was completed successfully"
+	successAdoptInstanceReason     string = "AdoptedSuccessfully"
+	successAdoptInstanceMessage    string = "The existing instance was adopted from the broker without provisioning it"
 
 	errorWithParametersReason                  string = "ErrorWithParameters"
 	errorProvisionCallFailedReason             string = "ProvisionCallFailed"
@@ -77,6 +81,10 @@ const (
 	errorFindingNamespaceServiceInstanceReason string = "ErrorFindingNamespaceForInstance"
 	errorOrphanMitigationFailedReason          string = "OrphanMitigationFailed"
 	errorInvalidDeprovisionStatusReason        string = "InvalidDeprovisionStatus"
+	errorAdoptInstanceCallFailedReason         string = "AdoptInstanceCallFailed"
+	errorErrorCallingAdoptInstanceReason       string = "ErrorCallingAdoptInstance"
+	errorAdoptInstanceMismatchReason           string = "AdoptInstanceMismatch"
+	errorQuotaExceededReason                   string = "ErrorQuotaExceeded"
 
 	errorAmbiguousPlanReferenceScope string = "couldn't determine if the instance refers to a Cluster or Namespaced ServiceClass/Plan"
 
@@ -94,6 +102,17 @@ const (
 	deprovisioningInFlightMessage           string = "Deprovision request for ServiceInstance in-flight to Broker"
 	startingInstanceOrphanMitigationReason  string = "StartingInstanceOrphanMitigation"
 	startingInstanceOrphanMitigationMessage string = "The instance provision call failed with an ambiguous error; attempting to deprovision the instance in order to mitigate an orphaned resource"
+	skippedInstanceOrphanMitigationReason   string = "SkippedInstanceOrphanMitigation"
+	skippedInstanceOrphanMitigationMessage  string = "The instance provision call failed with an ambiguous error; orphan mitigation was skipped because the orphan mitigation policy of the instance is Manual"
+	delayedInstanceOrphanMitigationReason   string = "DelayedInstanceOrphanMitigation"
+	delayedInstanceOrphanMitigationMessage  string = "The instance provision call failed with an ambiguous error; the orphan mitigation policy of the instance is Delayed, the instance will be deprovisioned in order to mitigate an orphaned resource after %v"
+	staleParametersReason                   string = "ParametersFromSecretChanged"
+	staleParametersMessage                  string = "A secret referenced by parametersFrom has changed since the parameters were sent to the broker; update the instance to send the new values"
+	waitingForBrokerCatalogReason           string = "WaitingForBrokerCatalog"
+	waitingForDependenciesReason            string = "WaitingForDependencies"
+	waitingForDependenciesMessage           string = "The instance will be provisioned once the instances it depends on are ready: %s"
+	forceDeletedReason                      string = "ForceDeleted"
+	planDeprecatedReason                    string = "PlanDeprecated"
 
 	clusterIdentifierKey string = "clusterid"
 
@@ -103,6 +122,25 @@ const (
 	eventHandlerLogLevel = 4 // TODO: move all logLevel settings to a central location
 )
 
+// AdoptInstanceAnnotation is the annotation of a ServiceInstance that asks the
+// controller to adopt the instance with the same external ID that already
+// exists at the broker, instead of provisioning a new one. Its value must be
+// "true". The broker must support fetching instances.
+const AdoptInstanceAnnotation = "servicecatalog.k8s.io/adopt"
+
+// ParametersChecksumAnnotation is the annotation of a ServiceInstance that
+// holds the checksum of the parameters, including the ones from
+// parametersFrom, that were last sent to the broker successfully. Tools
+// outside of the cluster can compare it to detect parameter drift.
+const ParametersChecksumAnnotation = "servicecatalog.k8s.io/parameters-checksum"
+
+// ProvisionTimeoutAnnotation is the annotation of a ServiceInstance that
+// overrides how long the controller keeps retrying the provision of the
+// instance, including polling the broker for an asynchronous provision,
+// before giving up. Its value is a positive duration such as "30m". The
+// reconciliation retry duration of the controller is used when it is not set.
+const ProvisionTimeoutAnnotation = "servicecatalog.k8s.io/provision-timeout"
+
 type backoffEntry struct {
 	generation          int64
 	calculatedRetryTime time.Time // earliest time we should retry
@@ -143,7 +181,7 @@ func (c *controller) enqueueInstanceAfter(obj interface{}, d time.Duration) {
 func (c *controller) instanceAdd(obj interface{}) {
 	if klog.V(eventHandlerLogLevel) {
 		instance := obj.(*v1beta1.ServiceInstance)
-		pcb := pretty.NewInstanceContextBuilder(instance)
+		pcb := c.newInstanceContextBuilder(instance)
 		klog.Info(pcb.Messagef("Received ADD event: %v", toJSON(instance)))
 	}
 	c.enqueueInstance(obj)
@@ -152,12 +190,18 @@ func (c *controller) instanceAdd(obj interface{}) {
 // instanceUpdate handles the ServiceInstance UPDATED watch event
 func (c *controller) instanceUpdate(oldObj, newObj interface{}) {
 	instance := newObj.(*v1beta1.ServiceInstance)
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	if klog.V(eventHandlerLogLevel) {
-		pcb := pretty.NewInstanceContextBuilder(instance)
+		pcb := c.newInstanceContextBuilder(instance)
 		klog.Info(pcb.Messagef("Received UPDATE event: %v", toJSON(instance)))
 	}
 
+	// Instances waiting for this one to be provisioned can be provisioned
+	// now that it is ready.
+	if oldInstance, ok := oldObj.(*v1beta1.ServiceInstance); ok && !isServiceInstanceReady(oldInstance) && isServiceInstanceReady(instance) {
+		c.enqueueDependentInstances(instance)
+	}
+
 	// Instances with ongoing asynchronous operations will be manually added
 	// to the polling queue by the reconciler. They should be ignored here in
 	// order to enforce polling rate-limiting.
@@ -166,10 +210,48 @@ func (c *controller) instanceUpdate(oldObj, newObj interface{}) {
 		return
 	}
 
+	// A changed spec may fix what made the previous attempts fail, so it is
+	// retried right away instead of waiting out their backoff.
+	if oldInstance, ok := oldObj.(*v1beta1.ServiceInstance); ok && oldInstance.Generation != instance.Generation {
+		c.resetServiceInstanceBackoff(instance)
+	}
+
 	klog.V(eventHandlerLogLevel).Info(pcb.Message("Enqueueing instance"))
 	c.enqueueInstance(newObj)
 }
 
+// resetServiceInstanceBackoff forgets the failed attempts to reconcile an
+// instance, so that neither the next attempt nor its retries are delayed by
+// them.
+func (c *controller) resetServiceInstanceBackoff(instance *v1beta1.ServiceInstance) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(instance)
+	if err != nil {
+		klog.Errorf("Couldn't get key for object %+v: %v", instance, err)
+		return
+	}
+	c.instanceQueue.Forget(key)
+	c.removeInstanceFromRetryMap(instance)
+}
+
+// enqueueDependentInstances adds the instances that depend on the given
+// instance to the work queue.
+func (c *controller) enqueueDependentInstances(instance *v1beta1.ServiceInstance) {
+	instances, err := c.instanceLister.ServiceInstances(instance.Namespace).List(labels.Everything())
+	if err != nil {
+		pcb := c.newInstanceContextBuilder(instance)
+		klog.Error(pcb.Messagef("Couldn't list the instances depending on the instance: %v", err))
+		return
+	}
+	for _, dependent := range instances {
+		for _, dependency := range dependent.Spec.DependsOn {
+			if dependency.Name == instance.Name {
+				c.enqueueInstance(dependent)
+				break
+			}
+		}
+	}
+}
+
 // instanceDelete handles the ServiceInstance DELETED watch event
 func (c *controller) instanceDelete(obj interface{}) {
 	instance, ok := obj.(*v1beta1.ServiceInstance)
@@ -178,10 +260,11 @@ func (c *controller) instanceDelete(obj interface{}) {
 	}
 
 	if klog.V(eventHandlerLogLevel) {
-		pcb := pretty.NewInstanceContextBuilder(instance)
+		pcb := c.newInstanceContextBuilder(instance)
 		klog.Info(pcb.Messagef("Received DELETE event: %v", toJSON(instance)))
 		klog.Info(pcb.Message("no further processing will occur"))
 	}
+	c.operationIDs.forget(instance.UID)
 }
 
 // Async operations on instances have a somewhat convoluted flow in order to
@@ -219,7 +302,7 @@ func (c *controller) requeueServiceInstanceForPoll(key string) error {
 func (c *controller) beginPollingServiceInstance(instance *v1beta1.ServiceInstance) error {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(instance)
 	if err != nil {
-		pcb := pretty.NewInstanceContextBuilder(instance)
+		pcb := c.newInstanceContextBuilder(instance)
 		s := fmt.Sprintf("Couldn't create a key for object %+v: %v", instance, err)
 		klog.Errorf(pcb.Message(s))
 		return fmt.Errorf(s)
@@ -241,7 +324,7 @@ func (c *controller) continuePollingServiceInstance(instance *v1beta1.ServiceIns
 func (c *controller) finishPollingServiceInstance(instance *v1beta1.ServiceInstance) error {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(instance)
 	if err != nil {
-		pcb := pretty.NewInstanceContextBuilder(instance)
+		pcb := c.newInstanceContextBuilder(instance)
 		s := fmt.Sprintf("Couldn't create a key for object %+v: %v", instance, err)
 		klog.Errorf(pcb.Message(s))
 		return fmt.Errorf(s)
@@ -257,7 +340,7 @@ func (c *controller) finishPollingServiceInstance(instance *v1beta1.ServiceInsta
 func (c *controller) resetPollingRateLimiterForServiceInstance(instance *v1beta1.ServiceInstance) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(instance)
 	if err != nil {
-		pcb := pretty.NewInstanceContextBuilder(instance)
+		pcb := c.newInstanceContextBuilder(instance)
 		s := fmt.Sprintf("Couldn't create a key for object %+v: %v", instance, err)
 		klog.Errorf(pcb.Message(s))
 		return
@@ -337,7 +420,7 @@ func (c *controller) reconcileServiceInstance(instance *v1beta1.ServiceInstance)
 	case reconcilePoll:
 		return c.pollServiceInstance(instance)
 	default:
-		pcb := pretty.NewInstanceContextBuilder(instance)
+		pcb := c.newInstanceContextBuilder(instance)
 		return fmt.Errorf(pcb.Messagef("Unknown reconciliation action %v", reconciliationAction))
 	}
 }
@@ -398,7 +481,7 @@ func (c *controller) initOrphanMitigationCondition(instance *v1beta1.ServiceInst
 // will eventually be cleared by the background worker running
 // purgeExpiredRetryEntries() or when the operation is successful.
 func (c *controller) setRetryBackoffRequired(instance *v1beta1.ServiceInstance) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	c.instanceOperationRetryQueue.mutex.Lock()
 	defer c.instanceOperationRetryQueue.mutex.Unlock()
 	key := string(instance.GetUID())
@@ -416,13 +499,35 @@ func (c *controller) setRetryBackoffRequired(instance *v1beta1.ServiceInstance)
 	klog.V(4).Info(pcb.Messagef("BrokerOpRetry: added %v (%v/%v) generation %v to backoffBeforeRetrying map", key, instance.GetNamespace(), instance.GetName(), instance.Generation))
 }
 
+// delayRetry postpones the next provision/update attempt of the specified
+// instance/generation by at least the given delay, for failures that are not
+// worth retrying with the usual exponential backoff.  The instance must have
+// been marked with setRetryBackoffRequired.
+func (c *controller) delayRetry(instance *v1beta1.ServiceInstance, delay time.Duration) {
+	pcb := c.newInstanceContextBuilder(instance)
+	c.instanceOperationRetryQueue.mutex.Lock()
+	defer c.instanceOperationRetryQueue.mutex.Unlock()
+	key := string(instance.GetUID())
+	retryEntry, found := c.instanceOperationRetryQueue.instances[key]
+	if !found || retryEntry.generation != instance.Generation {
+		return
+	}
+	if backoff := c.instanceOperationRetryQueue.rateLimiter.When(key); backoff > delay {
+		delay = backoff
+	}
+	retryEntry.calculatedRetryTime = time.Now().Add(delay)
+	retryEntry.dirty = false
+	c.instanceOperationRetryQueue.instances[key] = retryEntry
+	klog.V(4).Info(pcb.Messagef("BrokerOpRetry: generation %v retryTime delayed to %v", instance.Generation, retryEntry.calculatedRetryTime))
+}
+
 // backoffAndRequeueIfRetrying returns true if this is a retry and a backoff
 // (delay) needs to be observed before retrying.  This only applies to
 // Provisioning and Updating and is generation specific.  If the generation has
 // been bumped since the instance was added to the retry map there will be no
 // backoff delay.
 func (c *controller) backoffAndRequeueIfRetrying(instance *v1beta1.ServiceInstance, operation string) bool {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	key := string(instance.GetUID())
 	delay := time.Millisecond * 0
 
@@ -490,7 +595,7 @@ func (c *controller) purgeExpiredRetryEntries() {
 
 // removeInstanceFromRetryMap removes the instance from the retry & ratelimter maps
 func (c *controller) removeInstanceFromRetryMap(instance *v1beta1.ServiceInstance) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	key := string(instance.GetUID())
 	c.instanceOperationRetryQueue.mutex.Lock()
 	defer c.instanceOperationRetryQueue.mutex.Unlock()
@@ -502,7 +607,7 @@ func (c *controller) removeInstanceFromRetryMap(instance *v1beta1.ServiceInstanc
 // reconcileServiceInstanceAdd is responsible for handling the provisioning
 // of new service instances.
 func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstance) error {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 
 	if isServiceInstanceProcessedAlready(instance) {
 		klog.V(4).Info(pcb.Message("Not processing event because status showed there is no work to do"))
@@ -545,6 +650,13 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 
 	klog.V(4).Info(pcb.Message("Processing adding event"))
 
+	if instance.Status.CurrentOperation == "" {
+		waiting, err := c.waitForServiceInstanceDependencies(instance)
+		if err != nil || waiting {
+			return err
+		}
+	}
+
 	request, inProgressProperties, err := c.prepareProvisionRequest(instance)
 	if err != nil {
 		return c.handleServiceInstanceReconciliationError(instance, err)
@@ -580,14 +692,26 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 	var prettyClass string
 	var brokerName string
 	var brokerClient osb.Client
+	var planSpec *v1beta1.CommonServicePlanSpec
 	if instance.Spec.ClusterServiceClassSpecified() {
 		var serviceClass *v1beta1.ClusterServiceClass
-		serviceClass, _, brokerName, brokerClient, _ = c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		var servicePlan *v1beta1.ClusterServicePlan
+		serviceClass, servicePlan, brokerName, brokerClient, _ = c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		prettyClass = pretty.ClusterServiceClassName(serviceClass)
+		if servicePlan != nil {
+			planSpec = &servicePlan.Spec.CommonServicePlanSpec
+		}
 	} else {
 		var serviceClass *v1beta1.ServiceClass
-		serviceClass, _, brokerName, brokerClient, _ = c.getServiceClassPlanAndServiceBroker(instance)
+		var servicePlan *v1beta1.ServicePlan
+		serviceClass, servicePlan, brokerName, brokerClient, _ = c.getServiceClassPlanAndServiceBroker(instance)
 		prettyClass = pretty.ServiceClassName(serviceClass)
+		if servicePlan != nil {
+			planSpec = &servicePlan.Spec.CommonServicePlanSpec
+		}
+	}
+	if planSpec != nil {
+		c.recordServiceInstancePlanDeprecation(instance, planSpec)
 	}
 
 	klog.V(4).Info(pcb.Messagef(
@@ -595,7 +719,13 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 		prettyClass, brokerName,
 	))
 
+	if c.delayServiceInstanceBrokerCall(instance, brokerClient) {
+		return nil
+	}
 	c.setRetryBackoffRequired(instance)
+	if isServiceInstanceAdoptionRequested(instance) {
+		return c.adoptServiceInstance(instance, request, prettyClass, brokerName)
+	}
 	response, err := brokerClient.ProvisionInstance(request)
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
@@ -606,6 +736,17 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorProvisionCallFailedReason, msg)
 			// Depending on the specific response, we may need to initiate orphan mitigation.
 			shouldMitigateOrphan := shouldStartOrphanMitigation(httpErr.StatusCode)
+			if c.quotaExceededPolicy.isQuotaExceeded(httpErr) {
+				// Retrying right away will not help until the quota is
+				// raised or other instances are removed.
+				msg := fmt.Sprintf(
+					"Error provisioning ServiceInstance of %s at ClusterServiceBroker %q because a quota was exceeded; the provision will be retried in %v or later: %s",
+					prettyClass, brokerName, c.quotaExceededPolicy.RetryDelay, httpErr,
+				)
+				readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorQuotaExceededReason, msg)
+				c.delayRetry(instance, c.quotaExceededPolicy.RetryDelay)
+				return c.processTemporaryProvisionFailure(instance, readyCond, shouldMitigateOrphan)
+			}
 			if isRetriableHTTPStatus(httpErr.StatusCode) {
 				return c.processTemporaryProvisionFailure(instance, readyCond, shouldMitigateOrphan)
 			}
@@ -630,7 +771,7 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 		msg := fmt.Sprintf("The provision call failed and will be retried: Error communicating with broker for provisioning: %v", err)
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, reason, msg)
 
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			msg := "Stopping reconciliation retries because too much time has elapsed"
 			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
 			return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
@@ -646,14 +787,120 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 	return c.processProvisionSuccess(instance, response.DashboardURL)
 }
 
+// ParseProvisionTimeout parses the value of the ProvisionTimeoutAnnotation of
+// an instance. It returns an error if the value is not a positive duration.
+func ParseProvisionTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("provision timeout must be positive, got %v", timeout)
+	}
+	return timeout, nil
+}
+
+// serviceInstanceRetryDurationExceeded returns whether the current operation
+// of the given instance has been retried for longer than the controller's
+// reconciliation retry duration. A provision uses the timeout set by the
+// ProvisionTimeoutAnnotation of the instance instead, if there is a valid one.
+func (c *controller) serviceInstanceRetryDurationExceeded(instance *v1beta1.ServiceInstance) bool {
+	retryDuration := c.reconciliationRetryDuration
+	if instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationProvision && !instance.Status.OrphanMitigationInProgress {
+		if value, ok := instance.Annotations[ProvisionTimeoutAnnotation]; ok {
+			if timeout, err := ParseProvisionTimeout(value); err == nil {
+				retryDuration = timeout
+			}
+		}
+	}
+
+	operationStartTime := instance.Status.OperationStartTime
+	return operationStartTime != nil && !time.Now().Before(operationStartTime.Time.Add(retryDuration))
+}
+
+// isServiceInstanceAdoptionRequested returns whether the instance asks to be
+// adopted from the broker rather than provisioned.
+func isServiceInstanceAdoptionRequested(instance *v1beta1.ServiceInstance) bool {
+	return instance.Annotations[AdoptInstanceAnnotation] == "true"
+}
+
+// adoptServiceInstance fetches the instance from the broker instead of
+// provisioning it. The instance is recorded as provisioned if the broker has
+// an instance with the same external ID that was provisioned from the same
+// service and plan. Since the instance was not created by the controller, it
+// is not deprovisioned when adopting it fails.
+func (c *controller) adoptServiceInstance(instance *v1beta1.ServiceInstance, request *osb.ProvisionRequest, prettyClass, brokerName string) error {
+	pcb := c.newInstanceContextBuilder(instance)
+	klog.V(4).Info(pcb.Messagef(
+		"Adopting the existing instance %q of %s at Broker %q",
+		instance.Spec.ExternalID, prettyClass, brokerName,
+	))
+
+	brokerKey := NewServiceBrokerKey(instance.Namespace, brokerName)
+	if instance.Spec.ClusterServiceClassSpecified() {
+		brokerKey = NewClusterServiceBrokerKey(brokerName)
+	}
+	response, err := c.brokerClientManager.getInstance(brokerKey, instance.Spec.ExternalID)
+	if err != nil {
+		if httpErr, ok := osb.IsHTTPError(err); ok {
+			msg := fmt.Sprintf(
+				"Error fetching ServiceInstance of %s at Broker %q to adopt it: %s",
+				prettyClass, brokerName, httpErr,
+			)
+			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorAdoptInstanceCallFailedReason, msg)
+			// The instance does not exist at the broker, there is nothing
+			// to adopt.
+			if httpErr.StatusCode == http.StatusNotFound || httpErr.StatusCode == http.StatusGone || !isRetriableHTTPStatus(httpErr.StatusCode) {
+				failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorAdoptInstanceCallFailedReason, msg)
+				return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+			}
+			return c.processTemporaryProvisionFailure(instance, readyCond, false)
+		}
+
+		if _, ok := err.(getInstanceNotAllowedError); ok {
+			msg := fmt.Sprintf(
+				"Broker %q does not support fetching instances, which is required to adopt an instance: %v",
+				brokerName, err,
+			)
+			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorAdoptInstanceCallFailedReason, msg)
+			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorAdoptInstanceCallFailedReason, msg)
+			return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+		}
+
+		msg := fmt.Sprintf("The adopt call failed and will be retried: Error communicating with broker for fetching the instance: %v", err)
+		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorErrorCallingAdoptInstanceReason, msg)
+
+		if c.serviceInstanceRetryDurationExceeded(instance) {
+			msg := "Stopping reconciliation retries because too much time has elapsed"
+			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
+			return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+		}
+
+		return c.processServiceInstanceOperationError(instance, readyCond)
+	}
+
+	if (response.ServiceID != "" && response.ServiceID != request.ServiceID) ||
+		(response.PlanID != "" && response.PlanID != request.PlanID) {
+		msg := fmt.Sprintf(
+			"The instance %q at Broker %q was provisioned from service %q and plan %q, which do not match the class and plan of the ServiceInstance",
+			instance.Spec.ExternalID, brokerName, response.ServiceID, response.PlanID,
+		)
+		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorAdoptInstanceMismatchReason, msg)
+		failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorAdoptInstanceMismatchReason, msg)
+		return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+	}
+
+	return c.processAdoptSuccess(instance, response.DashboardURL)
+}
+
 // reconcileServiceInstanceUpdate is responsible for handling updating the plan
 // or parameters of a service instance.
 func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceInstance) error {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 
 	if isServiceInstanceProcessedAlready(instance) {
 		klog.V(4).Info(pcb.Message("Not processing event because status showed there is no work to do"))
-		return nil
+		return c.checkServiceInstanceParametersStale(instance)
 	}
 
 	// don't DOS the broker.  If we already did an update attempt that ended with a non-terminal
@@ -717,6 +964,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 			}
 			instance = updatedInstance
 		}
+		c.recordServiceInstancePlanDeprecation(instance, &servicePlan.Spec.CommonServicePlanSpec)
 
 		klog.V(4).Info(pcb.Messagef(
 			"Updating ServiceInstance of %s at ClusterServiceBroker %q",
@@ -757,6 +1005,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 			}
 			instance = updatedInstance
 		}
+		c.recordServiceInstancePlanDeprecation(instance, &servicePlan.Spec.CommonServicePlanSpec)
 
 		klog.V(4).Info(pcb.Messagef(
 			"Updating ServiceInstance of %s at ServiceBroker %q",
@@ -764,6 +1013,9 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 		))
 	}
 
+	if c.delayServiceInstanceBrokerCall(instance, brokerClient) {
+		return nil
+	}
 	c.setRetryBackoffRequired(instance)
 	response, err := brokerClient.UpdateInstance(request)
 	if err != nil {
@@ -791,7 +1043,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 
 		msg := fmt.Sprintf("The update call failed and will be retried: Error communicating with broker for updating: %s", err)
 
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			// log and record the real error, but process as a
 			// failure with reconciliation retry timeout
 			klog.Info(pcb.Message(msg))
@@ -826,11 +1078,32 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 		return nil
 	}
 
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
+
+	forceDelete, forceDeleteAfter := c.forceDeletionPolicy.shouldForceDelete(instance, time.Now())
+	if forceDelete {
+		// An instance is not force deleted while it has bindings, which
+		// would be left referring to an instance that no longer exists.
+		if err := c.checkServiceInstanceHasExistingBindings(instance); err != nil {
+			return c.handleServiceInstanceReconciliationError(instance.DeepCopy(), err)
+		}
+		return c.processServiceInstanceForceDeletion(instance.DeepCopy())
+	}
 
 	// If deprovisioning has already failed, do not do anything more
 	if instance.Status.DeprovisionStatus == v1beta1.ServiceInstanceDeprovisionStatusFailed {
 		klog.V(4).Info(pcb.Message("Not processing deleting event because deprovisioning has failed"))
+		if forceDeleteAfter > 0 {
+			// Come back once the instance may be force deleted
+			c.enqueueInstanceAfter(instance, forceDeleteAfter)
+		}
+		return nil
+	}
+
+	if remaining := c.orphanMitigationPolicy.remainingDelay(instance, time.Now()); remaining > 0 {
+		klog.V(4).Info(pcb.Messagef("Delaying orphan mitigation for %v", remaining))
+		// Come back once the delay has elapsed
+		c.enqueueInstanceAfter(instance, remaining)
 		return nil
 	}
 
@@ -871,32 +1144,12 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 		return c.handleServiceInstanceReconciliationError(instance, err)
 	}
 
-	var prettyName string
-	var brokerName string
-	var brokerClient osb.Client
-	if instance.Spec.ClusterServiceClassSpecified() {
-		serviceClass, name, bClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
-		if err != nil {
-			return c.handleServiceInstanceReconciliationError(instance, err)
-		}
-
-		brokerName = name
-		brokerClient = bClient
-		// we need the serviceClass SOLELY to get a value for a msg string >:(
-		prettyName = pretty.ClusterServiceClassName(serviceClass)
-	} else if instance.Spec.ServiceClassSpecified() {
-		serviceClass, name, bClient, err := c.getServiceClassAndServiceBroker(instance)
-		if err != nil {
-			return c.handleServiceInstanceReconciliationError(instance, err)
-		}
-
-		brokerName = name
-		brokerClient = bClient
-		// we need the serviceClass SOLELY to get a value for a msg string >:(
-		prettyName = pretty.ServiceClassName(serviceClass)
+	request, inProgressProperties, err := c.prepareDeprovisionRequest(instance)
+	if err != nil {
+		return c.handleServiceInstanceReconciliationError(instance, err)
 	}
 
-	request, inProgressProperties, err := c.prepareDeprovisionRequest(instance)
+	prettyName, brokerName, brokerClient, err := c.getServiceClassAndBrokerForDeprovision(instance, inProgressProperties)
 	if err != nil {
 		return c.handleServiceInstanceReconciliationError(instance, err)
 	}
@@ -915,6 +1168,7 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 				// from the normal deletion
 				removeServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionOrphanMitigation)
 				instance.Status.OrphanMitigationInProgress = false
+				instance.Status.OrphanMitigationStartTime = nil
 			}
 			updatedInstance, err := c.recordStartOfServiceInstanceOperation(instance, v1beta1.ServiceInstanceOperationDeprovision, inProgressProperties)
 			if err != nil {
@@ -930,6 +1184,9 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 		}
 	}
 
+	if c.delayServiceInstanceBrokerCall(instance, brokerClient) {
+		return nil
+	}
 	klog.V(4).Info(pcb.Message("Sending deprovision request to broker"))
 	response, err := brokerClient.DeprovisionInstance(request)
 	if err != nil {
@@ -942,8 +1199,9 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 		}
 
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, errorDeprovisionCallFailedReason, msg)
+		recordFailedDeprovisionAttempt(instance)
 
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			msg := "Stopping reconciliation retries because too much time has elapsed"
 			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
 			return c.processDeprovisionFailure(instance, readyCond, failedCond)
@@ -960,14 +1218,23 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 }
 
 func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) error {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	klog.V(4).Info(pcb.Message("Processing poll event"))
 
 	instance = instance.DeepCopy()
 
+	// There are some conditions that are different depending on which
+	// operation we're polling for. This is more readable than checking the
+	// status in various places.
+	mitigatingOrphan := instance.Status.OrphanMitigationInProgress
+	provisioning := instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationProvision && !mitigatingOrphan
+	deleting := instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationDeprovision || mitigatingOrphan
+
 	var brokerClient osb.Client
 	var err error
-	if instance.Spec.ClusterServiceClassSpecified() {
+	if deleting {
+		_, _, brokerClient, err = c.getServiceClassAndBrokerForDeprovision(instance, instance.Status.InProgressProperties)
+	} else if instance.Spec.ClusterServiceClassSpecified() {
 		_, _, _, brokerClient, err = c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 	} else {
 		_, _, _, brokerClient, err = c.getServiceClassPlanAndServiceBroker(instance)
@@ -976,18 +1243,14 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 		return c.handleServiceInstanceReconciliationError(instance, err)
 	}
 
-	// There are some conditions that are different depending on which
-	// operation we're polling for. This is more readable than checking the
-	// status in various places.
-	mitigatingOrphan := instance.Status.OrphanMitigationInProgress
-	provisioning := instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationProvision && !mitigatingOrphan
-	deleting := instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationDeprovision || mitigatingOrphan
-
 	request, err := c.prepareServiceInstanceLastOperationRequest(instance)
 	if err != nil {
 		return c.handleServiceInstanceReconciliationError(instance, err)
 	}
 
+	if c.delayServiceInstanceBrokerCall(instance, brokerClient) {
+		return nil
+	}
 	klog.V(5).Info(pcb.Message("Polling last operation"))
 
 	response, err := brokerClient.PollLastOperation(request)
@@ -1001,12 +1264,26 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 			return c.finishPollingServiceInstance(instance)
 		}
 
+		// A http.StatusGone for a provision means that the broker no longer
+		// has the instance, so the provision cannot complete. There is
+		// nothing left at the broker to mitigate.
+		if osb.IsGoneError(err) && provisioning {
+			reason := errorProvisionCallFailedReason
+			message := "Provision call failed: the broker reported the instance as gone while polling the last operation"
+			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, reason, message)
+			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, reason, message)
+			if err := c.processTerminalProvisionFailure(instance, readyCond, failedCond, false); err != nil {
+				return c.handleServiceInstancePollingError(instance, err)
+			}
+			return c.finishPollingServiceInstance(instance)
+		}
+
 		reason := errorPollingLastOperationReason
 		message := fmt.Sprintf("Error polling last operation: %v", err)
 		klog.V(4).Info(pcb.Message(message))
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, reason, message)
 
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			return c.processServiceInstancePollingFailureRetryTimeout(instance, readyCond)
 		}
 
@@ -1051,7 +1328,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 		}
 
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, reason, message)
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			return c.processServiceInstancePollingFailureRetryTimeout(instance, readyCond)
 		}
 
@@ -1088,8 +1365,9 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 			// For deprovisioning only, we should reattempt even on failure
 			msg := "Deprovision call failed: " + description
 			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, errorDeprovisionCallFailedReason, msg)
+			recordFailedDeprovisionAttempt(instance)
 
-			if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+			if c.serviceInstanceRetryDurationExceeded(instance) {
 				return c.processServiceInstancePollingFailureRetryTimeout(instance, readyCond)
 			}
 
@@ -1118,7 +1396,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 	default:
 		message := pcb.Messagef("Got invalid state in LastOperationResponse: %q", response.State)
 		klog.Warning(message)
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, errorPollingLastOperationReason, message)
 			return c.processServiceInstancePollingFailureRetryTimeout(instance, readyCond)
 		}
@@ -1147,6 +1425,66 @@ func isServiceInstanceProcessedAlready(instance *v1beta1.ServiceInstance) bool {
 		!instance.Status.OrphanMitigationInProgress
 }
 
+// checkServiceInstanceParametersStale sets the ParametersStale condition on a
+// ready instance when the parameters built from the secrets referenced by its
+// parametersFrom no longer match the parameters last sent to the broker, and
+// removes the condition once they match again. Only the referenced secrets are
+// read, and only for instances that use parametersFrom.
+func (c *controller) checkServiceInstanceParametersStale(instance *v1beta1.ServiceInstance) error {
+	if len(instance.Spec.ParametersFrom) == 0 || instance.Status.ExternalProperties == nil || !isServiceInstanceReady(instance) {
+		return nil
+	}
+	pcb := c.newInstanceContextBuilder(instance)
+
+	_, checksum, _, err := prepareInProgressPropertyParameters(
+		c.kubeClient,
+		instance.Namespace,
+		nil,
+		instance.Spec.Parameters,
+		instance.Spec.ParametersFrom,
+	)
+	if err != nil {
+		// The parameters are reported as an error on the next update of the
+		// instance; there is nothing to compare against until then.
+		klog.V(4).Info(pcb.Messagef("Unable to check parameters for staleness: %v", err))
+		return nil
+	}
+
+	stale := checksum != instance.Status.ExternalProperties.ParameterChecksum
+	if stale == isServiceInstanceParametersStale(instance) {
+		return nil
+	}
+
+	toUpdate := instance.DeepCopy()
+	if stale {
+		c.recorder.Event(toUpdate, corev1.EventTypeWarning, staleParametersReason, staleParametersMessage)
+		setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionParametersStale, v1beta1.ConditionTrue, staleParametersReason, staleParametersMessage)
+	} else {
+		klog.V(4).Info(pcb.Message("Parameters are up to date, removing the ParametersStale condition"))
+		conditions := toUpdate.Status.Conditions[:0]
+		for _, cond := range toUpdate.Status.Conditions {
+			if cond.Type != v1beta1.ServiceInstanceConditionParametersStale {
+				conditions = append(conditions, cond)
+			}
+		}
+		toUpdate.Status.Conditions = conditions
+	}
+
+	_, err = c.updateServiceInstanceStatus(toUpdate)
+	return err
+}
+
+// isServiceInstanceParametersStale returns whether the given instance has a
+// ParametersStale condition with status true.
+func isServiceInstanceParametersStale(instance *v1beta1.ServiceInstance) bool {
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionParametersStale {
+			return cond.Status == v1beta1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // processServiceInstancePollingFailureRetryTimeout marks the instance as having
 // failed polling due to its reconciliation retry duration expiring
 func (c *controller) processServiceInstancePollingFailureRetryTimeout(instance *v1beta1.ServiceInstance, readyCond *v1beta1.ServiceInstanceCondition) error {
@@ -1224,7 +1562,22 @@ func (c *controller) resolveClusterReferences(instance *v1beta1.ServiceInstance)
 	if instance.Spec.ClusterServiceClassRef == nil {
 		sc, err = c.resolveClusterServiceClassRef(instance)
 		if err != nil {
-			pcb := pretty.NewInstanceContextBuilder(instance)
+			pcb := c.newInstanceContextBuilder(instance)
+			if pending := c.clusterServiceBrokersPendingCatalog(); len(pending) > 0 {
+				// The class may only be missing because the broker
+				// offering it has not retrieved its catalog yet.
+				message := fmt.Sprintf("Waiting for the catalog of %s to be retrieved before resolving the ClusterServiceClass. %s", strings.Join(pending, ", "), err.Error())
+				klog.V(4).Info(pcb.Message(message))
+				updatedInstance, _ := c.updateServiceInstanceCondition(
+					instance,
+					v1beta1.ServiceInstanceConditionReady,
+					v1beta1.ConditionFalse,
+					waitingForBrokerCatalogReason,
+					message,
+				)
+				c.recorder.Event(instance, corev1.EventTypeNormal, waitingForBrokerCatalogReason, message)
+				return updatedInstance.ResourceVersion != instance.ResourceVersion, err
+			}
 			klog.Warning(pcb.Message(err.Error()))
 			updatedInstance, _ := c.updateServiceInstanceCondition(
 				instance,
@@ -1248,7 +1601,7 @@ func (c *controller) resolveClusterReferences(instance *v1beta1.ServiceInstance)
 
 		err = c.resolveClusterServicePlanRef(instance, sc.Spec.ClusterServiceBrokerName)
 		if err != nil {
-			pcb := pretty.NewInstanceContextBuilder(instance)
+			pcb := c.newInstanceContextBuilder(instance)
 			klog.Warning(pcb.Message(err.Error()))
 			updatedInstance, _ := c.updateServiceInstanceCondition(
 				instance,
@@ -1275,7 +1628,22 @@ func (c *controller) resolveNamespacedReferences(instance *v1beta1.ServiceInstan
 	if instance.Spec.ServiceClassRef == nil {
 		sc, err = c.resolveServiceClassRef(instance)
 		if err != nil {
-			pcb := pretty.NewInstanceContextBuilder(instance)
+			pcb := c.newInstanceContextBuilder(instance)
+			if pending := c.serviceBrokersPendingCatalog(instance.Namespace); len(pending) > 0 {
+				// The class may only be missing because the broker
+				// offering it has not retrieved its catalog yet.
+				message := fmt.Sprintf("Waiting for the catalog of %s to be retrieved before resolving the ServiceClass. %s", strings.Join(pending, ", "), err.Error())
+				klog.V(4).Info(pcb.Message(message))
+				updatedInstance, _ := c.updateServiceInstanceCondition(
+					instance,
+					v1beta1.ServiceInstanceConditionReady,
+					v1beta1.ConditionFalse,
+					waitingForBrokerCatalogReason,
+					message,
+				)
+				c.recorder.Event(instance, corev1.EventTypeNormal, waitingForBrokerCatalogReason, message)
+				return updatedInstance.ResourceVersion != instance.ResourceVersion, err
+			}
 			klog.Warning(pcb.Message(err.Error()))
 			updatedInstance, _ := c.updateServiceInstanceCondition(
 				instance,
@@ -1299,7 +1667,7 @@ func (c *controller) resolveNamespacedReferences(instance *v1beta1.ServiceInstan
 
 		err = c.resolveServicePlanRef(instance, sc.Spec.ServiceBrokerName)
 		if err != nil {
-			pcb := pretty.NewInstanceContextBuilder(instance)
+			pcb := c.newInstanceContextBuilder(instance)
 			klog.Warning(pcb.Message(err.Error()))
 			updatedInstance, _ := c.updateServiceInstanceCondition(
 				instance,
@@ -1316,6 +1684,43 @@ func (c *controller) resolveNamespacedReferences(instance *v1beta1.ServiceInstan
 	return updatedInstance.ResourceVersion != instance.ResourceVersion, err
 }
 
+// clusterServiceBrokersPendingCatalog returns the names of the
+// ClusterServiceBrokers that have not retrieved their catalog yet.
+func (c *controller) clusterServiceBrokersPendingCatalog() []string {
+	brokers, err := c.clusterServiceBrokerLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("Couldn't list ClusterServiceBrokers: %v", err)
+		return nil
+	}
+	var pending []string
+	for _, broker := range brokers {
+		if broker.Status.LastCatalogRetrievalTime == nil {
+			pending = append(pending, broker.Name)
+		}
+	}
+	return pending
+}
+
+// serviceBrokersPendingCatalog returns the names of the ServiceBrokers in the
+// given namespace that have not retrieved their catalog yet.
+func (c *controller) serviceBrokersPendingCatalog(namespace string) []string {
+	if c.serviceBrokerLister == nil {
+		return nil
+	}
+	brokers, err := c.serviceBrokerLister.ServiceBrokers(namespace).List(labels.Everything())
+	if err != nil {
+		klog.Warningf("Couldn't list ServiceBrokers in namespace %q: %v", namespace, err)
+		return nil
+	}
+	var pending []string
+	for _, broker := range brokers {
+		if broker.Status.LastCatalogRetrievalTime == nil {
+			pending = append(pending, broker.Name)
+		}
+	}
+	return pending
+}
+
 // resolveClusterServiceClassRef resolves a reference  to a ClusterServiceClass
 // and updates the instance.
 // If ClusterServiceClass can not be resolved, returns an error, records an
@@ -1326,7 +1731,7 @@ func (c *controller) resolveClusterServiceClassRef(instance *v1beta1.ServiceInst
 		return nil, fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ClusterServiceClassExternalName, ClusterServiceClassExternalID, nor ClusterServiceClassName is set", instance.Namespace, instance.Name)
 	}
 
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	var sc *v1beta1.ClusterServiceClass
 
 	if instance.Spec.ClusterServiceClassName != "" {
@@ -1357,6 +1762,9 @@ func (c *controller) resolveClusterServiceClassRef(instance *v1beta1.ServiceInst
 			FieldSelector: fields.OneTermEqualSelector(filterField, filterValue).String(),
 		}
 		serviceClasses, err := c.serviceCatalogClient.ClusterServiceClasses().List(listOpts)
+		if err == nil {
+			serviceClasses.Items = withoutRemovedClusterServiceClasses(serviceClasses.Items)
+		}
 		if err == nil && len(serviceClasses.Items) == 1 {
 			sc = &serviceClasses.Items[0]
 			instance.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{
@@ -1387,7 +1795,7 @@ func (c *controller) resolveServiceClassRef(instance *v1beta1.ServiceInstance) (
 		return nil, fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ServiceClassExternalName, ServiceClassExternalID, nor ServiceClassName is set", instance.Namespace, instance.Name)
 	}
 
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	var sc *v1beta1.ServiceClass
 
 	if instance.Spec.ServiceClassName != "" {
@@ -1418,6 +1826,9 @@ func (c *controller) resolveServiceClassRef(instance *v1beta1.ServiceInstance) (
 			FieldSelector: fields.OneTermEqualSelector(filterField, filterValue).String(),
 		}
 		serviceClasses, err := c.serviceCatalogClient.ServiceClasses(instance.Namespace).List(listOpts)
+		if err == nil {
+			serviceClasses.Items = withoutRemovedServiceClasses(serviceClasses.Items)
+		}
 		if err == nil && len(serviceClasses.Items) == 1 {
 			sc = &serviceClasses.Items[0]
 			instance.Spec.ServiceClassRef = &v1beta1.LocalObjectReference{
@@ -1448,7 +1859,7 @@ func (c *controller) resolveClusterServicePlanRef(instance *v1beta1.ServiceInsta
 		return fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ClusterServicePlanExternalName, ClusterServicePlanExternalID, nor ClusterServicePlanName is set", instance.Namespace, instance.Name)
 	}
 
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 
 	if instance.Spec.ClusterServicePlanName != "" {
 		sp, err := c.clusterServicePlanLister.Get(instance.Spec.ClusterServicePlanName)
@@ -1475,6 +1886,9 @@ func (c *controller) resolveClusterServicePlanRef(instance *v1beta1.ServiceInsta
 		fieldSelector := fields.SelectorFromSet(fieldSet).String()
 		listOpts := metav1.ListOptions{FieldSelector: fieldSelector}
 		servicePlans, err := c.serviceCatalogClient.ClusterServicePlans().List(listOpts)
+		if err == nil {
+			servicePlans.Items = withoutRemovedClusterServicePlans(servicePlans.Items)
+		}
 		if err == nil && len(servicePlans.Items) == 1 {
 			sp := &servicePlans.Items[0]
 			instance.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{
@@ -1504,7 +1918,7 @@ func (c *controller) resolveServicePlanRef(instance *v1beta1.ServiceInstance, br
 		return fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ServicePlanExternalName, ServicePlanExternalID, nor ServicePlanName is set", instance.Namespace, instance.Name)
 	}
 
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 
 	if instance.Spec.ServicePlanName != "" {
 		sp, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanName)
@@ -1531,6 +1945,9 @@ func (c *controller) resolveServicePlanRef(instance *v1beta1.ServiceInstance, br
 		fieldSelector := fields.SelectorFromSet(fieldSet).String()
 		listOpts := metav1.ListOptions{FieldSelector: fieldSelector}
 		servicePlans, err := c.serviceCatalogClient.ServicePlans(instance.Namespace).List(listOpts)
+		if err == nil {
+			servicePlans.Items = withoutRemovedServicePlans(servicePlans.Items)
+		}
 		if err == nil && len(servicePlans.Items) == 1 {
 			sp := &servicePlans.Items[0]
 			instance.Spec.ServicePlanRef = &v1beta1.LocalObjectReference{
@@ -1550,6 +1967,69 @@ func (c *controller) resolveServicePlanRef(instance *v1beta1.ServiceInstance, br
 	return nil
 }
 
+// withoutRemovedClusterServiceClasses returns the classes that are still in
+// the catalog of their broker, when there are some. When a broker renames a
+// class and gives its former external name to another class, the renamed
+// class keeps the name until the next relist, or until it can be deleted if
+// it was removed from the catalog, and a lookup by external name finds both.
+func withoutRemovedClusterServiceClasses(classes []v1beta1.ClusterServiceClass) []v1beta1.ClusterServiceClass {
+	var inCatalog []v1beta1.ClusterServiceClass
+	for _, class := range classes {
+		if !class.Status.RemovedFromBrokerCatalog {
+			inCatalog = append(inCatalog, class)
+		}
+	}
+	if len(inCatalog) == 0 {
+		return classes
+	}
+	return inCatalog
+}
+
+// withoutRemovedServiceClasses returns the classes that are still in the
+// catalog of their broker, when there are some.
+func withoutRemovedServiceClasses(classes []v1beta1.ServiceClass) []v1beta1.ServiceClass {
+	var inCatalog []v1beta1.ServiceClass
+	for _, class := range classes {
+		if !class.Status.RemovedFromBrokerCatalog {
+			inCatalog = append(inCatalog, class)
+		}
+	}
+	if len(inCatalog) == 0 {
+		return classes
+	}
+	return inCatalog
+}
+
+// withoutRemovedClusterServicePlans returns the plans that are still in the
+// catalog of their broker, when there are some.
+func withoutRemovedClusterServicePlans(plans []v1beta1.ClusterServicePlan) []v1beta1.ClusterServicePlan {
+	var inCatalog []v1beta1.ClusterServicePlan
+	for _, plan := range plans {
+		if !plan.Status.RemovedFromBrokerCatalog {
+			inCatalog = append(inCatalog, plan)
+		}
+	}
+	if len(inCatalog) == 0 {
+		return plans
+	}
+	return inCatalog
+}
+
+// withoutRemovedServicePlans returns the plans that are still in the catalog
+// of their broker, when there are some.
+func withoutRemovedServicePlans(plans []v1beta1.ServicePlan) []v1beta1.ServicePlan {
+	var inCatalog []v1beta1.ServicePlan
+	for _, plan := range plans {
+		if !plan.Status.RemovedFromBrokerCatalog {
+			inCatalog = append(inCatalog, plan)
+		}
+	}
+	if len(inCatalog) == 0 {
+		return plans
+	}
+	return inCatalog
+}
+
 // applyDefaultProvisioningParameters applies any default provisioning parameters for an instance.
 // If parameter defaults were applied, and the instance status was successfully updated, the method returns true
 // If either can not be resolved, returns an error and sets the InstanceCondition
@@ -1634,7 +2114,7 @@ func (c *controller) getDefaultProvisioningParameters(instance *v1beta1.ServiceI
 
 func (c *controller) prepareProvisionRequest(instance *v1beta1.ServiceInstance) (*osb.ProvisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
 	if instance.Spec.ClusterServiceClassSpecified() {
-		serviceClass, servicePlan, _, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		serviceClass, servicePlan, brokerName, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1647,9 +2127,11 @@ func (c *controller) prepareProvisionRequest(instance *v1beta1.ServiceInstance)
 		if err != nil {
 			return nil, nil, err
 		}
+		inProgressProperties.ClusterServiceClassExternalID = serviceClass.Spec.ExternalID
+		inProgressProperties.ClusterServiceBrokerName = brokerName
 		return request, inProgressProperties, nil
 	} else if instance.Spec.ServiceClassSpecified() {
-		serviceClass, servicePlan, _, _, err := c.getServiceClassPlanAndServiceBroker(instance)
+		serviceClass, servicePlan, brokerName, _, err := c.getServiceClassPlanAndServiceBroker(instance)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1662,6 +2144,8 @@ func (c *controller) prepareProvisionRequest(instance *v1beta1.ServiceInstance)
 		if err != nil {
 			return nil, nil, err
 		}
+		inProgressProperties.ServiceClassExternalID = serviceClass.Spec.ExternalID
+		inProgressProperties.ServiceBrokerName = brokerName
 		return request, inProgressProperties, nil
 	}
 
@@ -1794,7 +2278,7 @@ func setServiceInstanceConditionInternal(toUpdate *v1beta1.ServiceInstance,
 
 // updateServiceInstanceReferences updates the refs for the given instance.
 func (c *controller) updateServiceInstanceReferences(toUpdate *v1beta1.ServiceInstance) (*v1beta1.ServiceInstance, error) {
-	pcb := pretty.NewInstanceContextBuilder(toUpdate)
+	pcb := c.newInstanceContextBuilder(toUpdate)
 	klog.V(4).Info(pcb.Message("Updating references"))
 	status := toUpdate.Status
 	updatedInstance, err := c.serviceCatalogClient.ServiceInstances(toUpdate.Namespace).UpdateReferences(toUpdate)
@@ -1817,7 +2301,7 @@ func (c *controller) updateServiceInstanceWithRetries(
 	instance *v1beta1.ServiceInstance,
 	conflictResolutionFunc func(*v1beta1.ServiceInstance)) (*v1beta1.ServiceInstance, error) {
 
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 
 	const interval = 100 * time.Millisecond
 	const timeout = 10 * time.Second
@@ -1873,7 +2357,7 @@ func (c *controller) updateServiceInstanceStatusWithRetries(
 	instance *v1beta1.ServiceInstance,
 	postConflictUpdateFunc func(*v1beta1.ServiceInstance)) (*v1beta1.ServiceInstance, error) {
 
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 
 	const interval = 100 * time.Millisecond
 	const timeout = 10 * time.Second
@@ -1919,7 +2403,7 @@ func (c *controller) updateServiceInstanceCondition(
 	status v1beta1.ConditionStatus,
 	reason,
 	message string) (*v1beta1.ServiceInstance, error) {
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	toUpdate := instance.DeepCopy()
 
 	setServiceInstanceCondition(toUpdate, conditionType, status, reason, message)
@@ -1933,6 +2417,52 @@ func (c *controller) updateServiceInstanceCondition(
 	return updatedInstance, err
 }
 
+// waitForServiceInstanceDependencies returns whether the provisioning of the
+// instance has to wait for instances it depends on that are missing or not
+// Ready yet. The Ready condition of a waiting instance is set to False with
+// the WaitingForDependencies reason, and the instance is enqueued again once
+// one of its dependencies becomes Ready.
+func (c *controller) waitForServiceInstanceDependencies(instance *v1beta1.ServiceInstance) (bool, error) {
+	var waitingFor []string
+	for _, dependency := range instance.Spec.DependsOn {
+		dependencyInstance, err := c.instanceLister.ServiceInstances(instance.Namespace).Get(dependency.Name)
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		if err != nil || !isServiceInstanceReady(dependencyInstance) {
+			waitingFor = append(waitingFor, dependency.Name)
+		}
+	}
+	if len(waitingFor) == 0 {
+		return false, nil
+	}
+
+	// The condition is only updated when the instances waited for change, so
+	// that requeues of a waiting instance don't update its status again.
+	message := fmt.Sprintf(waitingForDependenciesMessage, strings.Join(waitingFor, ", "))
+	for _, condition := range instance.Status.Conditions {
+		if condition.Type == v1beta1.ServiceInstanceConditionReady &&
+			condition.Reason == waitingForDependenciesReason &&
+			condition.Message == message {
+			return true, nil
+		}
+	}
+
+	pcb := c.newInstanceContextBuilder(instance)
+	klog.V(4).Info(pcb.Message(message))
+	if _, err := c.updateServiceInstanceCondition(
+		instance,
+		v1beta1.ServiceInstanceConditionReady,
+		v1beta1.ConditionFalse,
+		waitingForDependenciesReason,
+		message,
+	); err != nil {
+		return true, err
+	}
+	c.recorder.Event(instance, corev1.EventTypeNormal, waitingForDependenciesReason, message)
+	return true, nil
+}
+
 // prepareObservedGeneration sets the instance's observed generation
 // and clears the conditions, preparing it for any status updates that can occur
 // during the further processing.
@@ -1999,6 +2529,7 @@ func (c *controller) recordStartOfServiceInstanceOperation(toUpdate *v1beta1.Ser
 		reason = provisioningInFlightReason
 		message = provisioningInFlightMessage
 		toUpdate.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+		toUpdate.Status.ExternalID = provisionExternalID(toUpdate)
 	case v1beta1.ServiceInstanceOperationUpdate:
 		reason = instanceUpdatingInFlightReason
 		message = instanceUpdatingInFlightMessage
@@ -2178,6 +2709,7 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, pla
 		parameters, parametersChecksum, rawParametersWithRedaction, err := prepareInProgressPropertyParameters(
 			c.kubeClient,
 			instance.Namespace,
+			nil,
 			instance.Spec.Parameters,
 			instance.Spec.ParametersFrom,
 		)
@@ -2212,9 +2744,21 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, pla
 		"namespace":          instance.Namespace,
 		clusterIdentifierKey: id,
 	}
+	c.requestContextPolicy.enrich(rh.requestContext, ns)
 	return rh, nil
 }
 
+// provisionExternalID returns the ID to provision the instance with at the
+// broker: the ID recorded in the status when the provision request was first
+// sent, so that every retry sends the same ID, or the ID of the spec before
+// the first attempt.
+func provisionExternalID(instance *v1beta1.ServiceInstance) string {
+	if instance.Status.ExternalID != "" {
+		return instance.Status.ExternalID
+	}
+	return instance.Spec.ExternalID
+}
+
 // innerPrepareProvisionRequest creates a provision request object to be passed to
 // the broker client to provision the given instance, with a cluster scoped
 // class and plan
@@ -2226,7 +2770,7 @@ func (c *controller) innerPrepareProvisionRequest(instance *v1beta1.ServiceInsta
 
 	request := &osb.ProvisionRequest{
 		AcceptsIncomplete: true,
-		InstanceID:        instance.Spec.ExternalID,
+		InstanceID:        provisionExternalID(instance),
 		ServiceID:         classCommon.ExternalID,
 		PlanID:            planCommon.ExternalID,
 		Parameters:        rh.parameters,
@@ -2253,7 +2797,7 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 	var request *osb.UpdateInstanceRequest
 
 	if instance.Spec.ClusterServiceClassSpecified() {
-		serviceClass, servicePlan, _, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		serviceClass, servicePlan, brokerName, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		if err != nil {
 			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
 		}
@@ -2262,6 +2806,8 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 		if err != nil {
 			return nil, nil, err
 		}
+		rh.inProgressProperties.ClusterServiceClassExternalID = serviceClass.Spec.ExternalID
+		rh.inProgressProperties.ClusterServiceBrokerName = brokerName
 
 		request = &osb.UpdateInstanceRequest{
 			AcceptsIncomplete:   true,
@@ -2288,7 +2834,7 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 		}
 
 	} else if instance.Spec.ServiceClassSpecified() {
-		serviceClass, servicePlan, _, _, err := c.getServiceClassPlanAndServiceBroker(instance)
+		serviceClass, servicePlan, brokerName, _, err := c.getServiceClassPlanAndServiceBroker(instance)
 		if err != nil {
 			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
 		}
@@ -2297,6 +2843,8 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 		if err != nil {
 			return nil, nil, err
 		}
+		rh.inProgressProperties.ServiceClassExternalID = serviceClass.Spec.ExternalID
+		rh.inProgressProperties.ServiceBrokerName = brokerName
 
 		request = &osb.UpdateInstanceRequest{
 			AcceptsIncomplete:   true,
@@ -2335,23 +2883,6 @@ func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance
 		return nil, nil, err
 	}
 
-	// Get the appropriate external id based for the cluster or namespaced
-	// service class
-	var scExternalID string
-	if instance.Spec.ClusterServiceClassSpecified() {
-		serviceClass, _, _, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
-		if err != nil {
-			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
-		}
-		scExternalID = serviceClass.Spec.ExternalID
-	} else if instance.Spec.ServiceClassSpecified() {
-		serviceClass, _, _, err := c.getServiceClassAndServiceBroker(instance)
-		if err != nil {
-			return nil, nil, c.handleServiceInstanceReconciliationError(instance, err)
-		}
-		scExternalID = serviceClass.Spec.ExternalID
-	}
-
 	// The plan reference in the spec might be updated since the latest
 	// provisioning/update request, thus we need to take values from the original
 	// provisioning request instead that we previously stored in status
@@ -2402,10 +2933,31 @@ func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance
 	}
 
 	// Should come from rh.inProgressProperties.(Cluster)ServicePlanExternalID
+	// and rh.inProgressProperties.(Cluster)ServiceClassExternalID, so that the
+	// instance can be deprovisioned even if the class or plan has since been
+	// removed from the catalog. Instances provisioned before the class
+	// external ID was recorded in status fall back to looking up the class.
+	var scExternalID string
 	var planExternalID string
 	if instance.Spec.ClusterServiceClassSpecified() {
+		scExternalID = rh.inProgressProperties.ClusterServiceClassExternalID
+		if scExternalID == "" {
+			serviceClass, _, _, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
+			if err != nil {
+				return nil, nil, err
+			}
+			scExternalID = serviceClass.Spec.ExternalID
+		}
 		planExternalID = rh.inProgressProperties.ClusterServicePlanExternalID
 	} else if instance.Spec.ServiceClassSpecified() {
+		scExternalID = rh.inProgressProperties.ServiceClassExternalID
+		if scExternalID == "" {
+			serviceClass, _, _, err := c.getServiceClassAndServiceBroker(instance)
+			if err != nil {
+				return nil, nil, err
+			}
+			scExternalID = serviceClass.Spec.ExternalID
+		}
 		planExternalID = rh.inProgressProperties.ServicePlanExternalID
 	}
 
@@ -2420,12 +2972,50 @@ func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance
 	return request, rh.inProgressProperties, nil
 }
 
+// getServiceClassAndBrokerForDeprovision returns a printable name of the
+// class, the name of the broker and the broker client to use to deprovision
+// the given instance. The broker recorded in the given properties state is
+// used if the class can no longer be found in the catalog.
+func (c *controller) getServiceClassAndBrokerForDeprovision(instance *v1beta1.ServiceInstance, properties *v1beta1.ServiceInstancePropertiesState) (string, string, osb.Client, error) {
+	if instance.Spec.ClusterServiceClassSpecified() {
+		serviceClass, brokerName, brokerClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
+		if err == nil {
+			return pretty.ClusterServiceClassName(serviceClass), brokerName, brokerClient, nil
+		}
+		if properties == nil || properties.ClusterServiceBrokerName == "" {
+			return "", "", nil, err
+		}
+		brokerClient, found := c.brokerClientManager.BrokerClient(NewClusterServiceBrokerKey(properties.ClusterServiceBrokerName))
+		if !found {
+			return "", "", nil, err
+		}
+		prettyName := pretty.Name(pretty.ClusterServiceClass, instance.Spec.ClusterServiceClassRef.Name, instance.Spec.ClusterServiceClassExternalName)
+		return prettyName, properties.ClusterServiceBrokerName, brokerClient, nil
+	} else if instance.Spec.ServiceClassSpecified() {
+		serviceClass, brokerName, brokerClient, err := c.getServiceClassAndServiceBroker(instance)
+		if err == nil {
+			return pretty.ServiceClassName(serviceClass), brokerName, brokerClient, nil
+		}
+		if properties == nil || properties.ServiceBrokerName == "" {
+			return "", "", nil, err
+		}
+		brokerClient, found := c.brokerClientManager.BrokerClient(NewServiceBrokerKey(instance.Namespace, properties.ServiceBrokerName))
+		if !found {
+			return "", "", nil, err
+		}
+		prettyName := pretty.Name(pretty.ServiceClass, fmt.Sprintf("%s/%s", instance.Namespace, instance.Spec.ServiceClassRef.Name), instance.Spec.ServiceClassExternalName)
+		return prettyName, properties.ServiceBrokerName, brokerClient, nil
+	}
+
+	return "", "", nil, stderrors.New(errorAmbiguousPlanReferenceScope)
+}
+
 // prepareServiceInstanceLastOperationRequest creates a request object to be passed to
 // the broker client to query the given instance's last operation endpoint.
 func (c *controller) prepareServiceInstanceLastOperationRequest(instance *v1beta1.ServiceInstance) (*osb.LastOperationRequest, error) {
 
 	if instance.Status.InProgressProperties == nil {
-		pcb := pretty.NewInstanceContextBuilder(instance)
+		pcb := c.newInstanceContextBuilder(instance)
 		err := stderrors.New("Instance.Status.InProgressProperties can not be nil")
 		klog.Error(pcb.Message(err.Error()))
 		return nil, err
@@ -2435,7 +3025,27 @@ func (c *controller) prepareServiceInstanceLastOperationRequest(instance *v1beta
 	var scExternalID string
 	var spExternalID string
 
-	if instance.Spec.ClusterServiceClassSpecified() {
+	properties := instance.Status.InProgressProperties
+	deleting := instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationDeprovision || instance.Status.OrphanMitigationInProgress
+
+	if deleting && (properties.ClusterServiceClassExternalID != "" || properties.ServiceClassExternalID != "") {
+		// The class and plan may have been removed from the catalog since
+		// the instance was provisioned, so use the external IDs recorded in
+		// status instead of resolving them against the current catalog.
+		if instance.Spec.ClusterServiceClassSpecified() {
+			scExternalID = properties.ClusterServiceClassExternalID
+			spExternalID = properties.ClusterServicePlanExternalID
+		} else {
+			scExternalID = properties.ServiceClassExternalID
+			spExternalID = properties.ServicePlanExternalID
+		}
+
+		var err error
+		rh, err = c.prepareRequestHelper(instance, "", spExternalID, false)
+		if err != nil {
+			return nil, err
+		}
+	} else if instance.Spec.ClusterServiceClassSpecified() {
 		serviceClass, servicePlan, _, _, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
 		if err != nil {
 			return nil, c.handleServiceInstanceReconciliationError(instance, err)
@@ -2503,13 +3113,27 @@ func (c *controller) processServiceInstanceGracefulDeletionSuccess(instance *v1b
 		return err
 	}
 
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	klog.Info(pcb.Message("Cleared finalizer"))
 
 	c.removeInstanceFromRetryMap(instance)
 	return nil
 }
 
+// processServiceInstanceForceDeletion handles the logging and updating of a
+// ServiceInstance whose finalizer is removed without deprovisioning it, as
+// allowed by the force deletion policy of the controller.
+func (c *controller) processServiceInstanceForceDeletion(instance *v1beta1.ServiceInstance) error {
+	msg := fmt.Sprintf("Removed the finalizer without deprovisioning after %d failed deprovision attempts; the instance may still exist at the broker", instance.Status.FailedDeprovisionAttempts)
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionForceDeleted, v1beta1.ConditionTrue, forceDeletedReason, msg)
+	if err := c.processServiceInstanceGracefulDeletionSuccess(instance); err != nil {
+		return err
+	}
+
+	c.recorder.Event(instance, corev1.EventTypeWarning, forceDeletedReason, msg)
+	return nil
+}
+
 func (c *controller) removeFinalizer(instance *v1beta1.ServiceInstance) {
 	finalizers := sets.NewString(instance.Finalizers...)
 	finalizers.Delete(v1beta1.FinalizerServiceCatalog)
@@ -2550,19 +3174,32 @@ func (c *controller) processServiceInstanceOperationError(instance *v1beta1.Serv
 // processProvisionSuccess handles the logging and updating of a
 // ServiceInstance that has successfully been provisioned at the broker.
 func (c *controller) processProvisionSuccess(instance *v1beta1.ServiceInstance, dashboardURL *string) error {
+	return c.recordServiceInstanceProvisioned(instance, dashboardURL, successProvisionReason, successProvisionMessage)
+}
+
+// processAdoptSuccess handles the logging and updating of a ServiceInstance
+// that has been adopted from the broker.
+func (c *controller) processAdoptSuccess(instance *v1beta1.ServiceInstance, dashboardURL *string) error {
+	return c.recordServiceInstanceProvisioned(instance, dashboardURL, successAdoptInstanceReason, successAdoptInstanceMessage)
+}
+
+// recordServiceInstanceProvisioned marks the instance as provisioned and
+// ready, with the given reason and message.
+func (c *controller) recordServiceInstanceProvisioned(instance *v1beta1.ServiceInstance, dashboardURL *string, reason, message string) error {
 	setServiceInstanceDashboardURL(instance, dashboardURL)
-	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, successProvisionReason, successProvisionMessage)
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, reason, message)
 	instance.Status.ExternalProperties = instance.Status.InProgressProperties
+	setServiceInstanceParametersChecksumAnnotation(instance)
 	clearServiceInstanceCurrentOperation(instance)
 	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
 	instance.Status.ReconciledGeneration = instance.Status.ObservedGeneration
 
-	if _, err := c.updateServiceInstanceStatus(instance); err != nil {
+	if _, err := c.updateServiceInstanceStatusWithRetries(instance, setServiceInstanceParametersChecksumAnnotation); err != nil {
 		return err
 	}
 
 	c.removeInstanceFromRetryMap(instance)
-	c.recorder.Eventf(instance, corev1.EventTypeNormal, successProvisionReason, successProvisionMessage)
+	c.recorder.Eventf(instance, corev1.EventTypeNormal, reason, message)
 	return nil
 }
 
@@ -2598,21 +3235,45 @@ func (c *controller) processProvisionFailure(instance *v1beta1.ServiceInstance,
 		errorMessage = fmt.Errorf(readyCond.Message)
 	}
 
+	orphanMitigationMode, err := c.orphanMitigationPolicy.modeFor(instance)
+	if shouldMitigateOrphan && err != nil {
+		// Only warn when the mode of the instance decides what happens,
+		// rather than on every reconcile.
+		klog.Warning(c.newInstanceContextBuilder(instance).Message(err.Error()))
+	}
+	skippedOrphanMitigation := false
+	if shouldMitigateOrphan && orphanMitigationMode == OrphanMitigationManual {
+		pcb := c.newInstanceContextBuilder(instance)
+		klog.Info(pcb.Message(skippedInstanceOrphanMitigationMessage))
+		c.recorder.Event(instance, corev1.EventTypeWarning, skippedInstanceOrphanMitigationReason, skippedInstanceOrphanMitigationMessage)
+		shouldMitigateOrphan = false
+		skippedOrphanMitigation = true
+	}
+
 	if shouldMitigateOrphan {
+		reason, message := startingInstanceOrphanMitigationReason, startingInstanceOrphanMitigationMessage
+		if orphanMitigationMode == OrphanMitigationDelayed && c.orphanMitigationPolicy.Delay > 0 {
+			reason = delayedInstanceOrphanMitigationReason
+			message = fmt.Sprintf(delayedInstanceOrphanMitigationMessage, c.orphanMitigationPolicy.Delay)
+		}
 		// Copy original failure reason/message to a new OrphanMitigation condition
-		c.recorder.Event(instance, corev1.EventTypeWarning, startingInstanceOrphanMitigationReason, startingInstanceOrphanMitigationMessage)
+		c.recorder.Event(instance, corev1.EventTypeWarning, reason, message)
 		setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionOrphanMitigation,
 			v1beta1.ConditionTrue, readyCond.Reason, readyCond.Message)
 		// Overwrite Ready condition reason/message with reporting on orphan mitigation
 		setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady,
 			v1beta1.ConditionFalse,
-			startingInstanceOrphanMitigationReason,
-			startingInstanceOrphanMitigationMessage)
+			reason,
+			message)
 
 		instance.Status.OrphanMitigationInProgress = true
-	} else {
+		now := metav1.Now()
+		instance.Status.OrphanMitigationStartTime = &now
+	} else if !skippedOrphanMitigation {
 		// Deprovisioning is not required for provisioning that has failed with an
-		// error that doesn't require orphan mitigation
+		// error that doesn't require orphan mitigation. When orphan mitigation
+		// was skipped, the broker may still hold a resource, so deleting the
+		// instance still deprovisions it.
 		instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusNotRequired
 	}
 
@@ -2662,10 +3323,11 @@ func (c *controller) processProvisionAsyncResponse(instance *v1beta1.ServiceInst
 func (c *controller) processUpdateServiceInstanceSuccess(instance *v1beta1.ServiceInstance) error {
 	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, successUpdateInstanceReason, successUpdateInstanceMessage)
 	instance.Status.ExternalProperties = instance.Status.InProgressProperties
+	setServiceInstanceParametersChecksumAnnotation(instance)
 	clearServiceInstanceCurrentOperation(instance)
 	instance.Status.ReconciledGeneration = instance.Status.ObservedGeneration
 
-	if _, err := c.updateServiceInstanceStatus(instance); err != nil {
+	if _, err := c.updateServiceInstanceStatusWithRetries(instance, setServiceInstanceParametersChecksumAnnotation); err != nil {
 		return err
 	}
 
@@ -2747,6 +3409,7 @@ func (c *controller) processDeprovisionSuccess(instance *v1beta1.ServiceInstance
 	if mitigatingOrphan {
 		removeServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionOrphanMitigation)
 		instance.Status.OrphanMitigationInProgress = false
+		instance.Status.OrphanMitigationStartTime = nil
 		reason = successOrphanMitigationReason
 		msg = successOrphanMitigationMessage
 	}
@@ -2832,7 +3495,7 @@ func (c *controller) handleServiceInstancePollingError(instance *v1beta1.Service
 	//	2) attempt to requeue in the polling queue
 	//		- if successful, we can return nil to avoid regular queue
 	//		- if failure, return err to fall back to regular queue
-	pcb := pretty.NewInstanceContextBuilder(instance)
+	pcb := c.newInstanceContextBuilder(instance)
 	klog.V(4).Info(pcb.Messagef("Error during polling: %v", err))
 	return c.continuePollingServiceInstance(instance)
 }
@@ -2845,11 +3508,31 @@ func setServiceInstanceDashboardURL(instance *v1beta1.ServiceInstance, dashboard
 	}
 }
 
-// setServiceInstanceLastOperation sets the last operation key on the given
+// setServiceInstanceParametersChecksumAnnotation sets the annotation holding
+// the checksum of the parameters last sent to the broker on the given
 // instance.
+func setServiceInstanceParametersChecksumAnnotation(instance *v1beta1.ServiceInstance) {
+	if instance.Status.ExternalProperties == nil {
+		return
+	}
+	checksum := instance.Status.ExternalProperties.ParameterChecksum
+	if checksum == "" {
+		// No parameters were sent to the broker.
+		delete(instance.Annotations, ParametersChecksumAnnotation)
+		return
+	}
+	metav1.SetMetaDataAnnotation(&instance.ObjectMeta, ParametersChecksumAnnotation, checksum)
+}
+
+// setServiceInstanceLastOperation sets the last operation key on the given
+// instance, so that the operation is polled with the key the broker returned
+// for it, even after the controller restarts. The key of a previous operation
+// is cleared when the broker did not return one.
 func setServiceInstanceLastOperation(instance *v1beta1.ServiceInstance, operationKey *osb.OperationKey) {
-	if operationKey != nil && *operationKey != "" {
-		key := string(*operationKey)
-		instance.Status.LastOperation = &key
+	if operationKey == nil || *operationKey == "" {
+		instance.Status.LastOperation = nil
+		return
 	}
+	key := string(*operationKey)
+	instance.Status.LastOperation = &key
 }
@@ -37,7 +37,9 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/sets"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	scfeatures "github.com/poy/service-catalog/pkg/features"
@@ -101,6 +103,88 @@ func TestReconcileServiceInstanceNonExistentClusterServiceClass(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceWaitingForBrokerCatalog tests that reconcileInstance
+// reports the instance as waiting, rather than referencing a nonexistent class,
+// while a broker has not retrieved its catalog yet
+func TestReconcileServiceInstanceWaitingForBrokerCatalog(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+
+	instance := &v1beta1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testServiceInstanceName,
+			Generation: 1,
+		},
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference: v1beta1.PlanReference{
+				ClusterServiceClassExternalName: "nothere",
+				ClusterServicePlanExternalName:  "nothere",
+			},
+			ExternalID: testServiceInstanceGUID,
+		},
+	}
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("expected the instance to be requeued while the broker catalog is pending")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[1], instance)
+	assertServiceInstanceErrorBeforeRequest(t, updatedServiceInstance, waitingForBrokerCatalogReason, instance)
+
+	events := getRecordedEvents(testController)
+	assertNumEvents(t, events, 1)
+
+	expectedEvent := normalEventBuilder(waitingForBrokerCatalogReason).msgf(
+		"Waiting for the catalog of %s to be retrieved before resolving the ClusterServiceClass.",
+		testClusterServiceBrokerName,
+	)
+	if err := checkEventPrefixes(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceNonExistentClusterServiceClassBrokerCatalogRetrieved
+// tests that reconcileInstance reports a nonexistent class once every broker has
+// retrieved its catalog
+func TestReconcileServiceInstanceNonExistentClusterServiceClassBrokerCatalogRetrieved(t *testing.T) {
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	broker := getTestClusterServiceBroker()
+	broker.Status.LastCatalogRetrievalTime = &metav1.Time{Time: time.Now()}
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(broker)
+
+	instance := &v1beta1.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testServiceInstanceName,
+			Generation: 1,
+		},
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference: v1beta1.PlanReference{
+				ClusterServiceClassExternalName: "nothere",
+				ClusterServicePlanExternalName:  "nothere",
+			},
+			ExternalID: testServiceInstanceGUID,
+		},
+	}
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("nothere is a service class that cannot be referenced by the service instance as it does not exist.")
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[1], instance)
+	assertServiceInstanceErrorBeforeRequest(t, updatedServiceInstance, errorNonexistentClusterServiceClassReason, instance)
+}
+
 // TestReconcileServiceInstanceNonExistentClusterServiceClass tests that reconcileInstance gets a failure when
 // the specified service class is not found
 func TestReconcileServiceInstanceNonExistentClusterServiceClassWithK8SName(t *testing.T) {
@@ -1030,6 +1114,56 @@ func TestReconcileServiceInstanceWithProvisionCallFailure(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceProvisionRetryReusesExternalID tests that the
+// external ID the instance was first provisioned with is recorded in its
+// status and sent again when the provision request is retried.
+func TestReconcileServiceInstanceProvisionRetryReusesExternalID(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: errors.New("fake creation failure"),
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	if e, a := testServiceInstanceGUID, instance.Status.ExternalID; e != a {
+		t.Fatalf("unexpected external ID in status: expected %q, got %q", e, a)
+	}
+
+	for i := 0; i < 2; i++ {
+		// Retry right away instead of waiting for the backoff
+		testController.instanceOperationRetryQueue.instances = make(map[string]backoffEntry)
+		fakeCatalogClient.ClearActions()
+		if err := reconcileServiceInstance(t, testController, instance); err == nil {
+			t.Fatalf("Should not be able to make the ServiceInstance")
+		}
+		actions := fakeCatalogClient.Actions()
+		assertNumberOfActions(t, actions, 1)
+		instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+		if e, a := testServiceInstanceGUID, instance.Status.ExternalID; e != a {
+			t.Fatalf("unexpected external ID in status after attempt %d: expected %q, got %q", i+1, e, a)
+		}
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	for _, action := range brokerActions {
+		request := action.Request.(*osb.ProvisionRequest)
+		if e, a := testServiceInstanceGUID, request.InstanceID; e != a {
+			t.Fatalf("unexpected instance ID in provision request: expected %q, got %q", e, a)
+		}
+	}
+}
+
 // TestReconcileServiceInstanceWithTemporaryProvisionFailure tests that when the
 // provision call to the broker fails with a retriable HTTP error, the ready condition
 // becomes false, and the failure condition is not set.
@@ -1226,6 +1360,149 @@ func TestReconcileServiceInstanceWithTerminalProvisionFailure(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceWithQuotaExceededProvisionFailure tests that a
+// provision failing with an error the quota exceeded policy matches is
+// reported with the ErrorQuotaExceeded reason and retried after the delay of
+// the policy, even if its HTTP status is otherwise a terminal failure.
+func TestReconcileServiceInstanceWithQuotaExceededProvisionFailure(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: osb.HTTPStatusCodeError{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: strPtr("QuotaExceeded"),
+				Description:  strPtr("You may only have 5 instances"),
+			},
+		},
+	})
+	testController.quotaExceededPolicy = QuotaExceededPolicy{BrokerErrors: []string{"QuotaExceeded"}, RetryDelay: time.Hour}
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("expected the provision to be retried")
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyFalse(t, updatedServiceInstance, errorQuotaExceededReason)
+	assertServiceInstanceConditionMissing(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionFailed)
+	assertServiceInstanceCurrentOperation(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision)
+
+	events := getRecordedEvents(testController)
+
+	message := fmt.Sprintf(
+		"Error provisioning ServiceInstance of ClusterServiceClass (K8S: %q ExternalName: %q) at ClusterServiceBroker %q because a quota was exceeded; the provision will be retried in %v or later: Status: %v; ErrorMessage: %s",
+		"cscguid", "test-clusterserviceclass", "test-clusterservicebroker", time.Hour, 400, "QuotaExceeded; Description: You may only have 5 instances; ResponseError: <nil>",
+	)
+	expectedEvents := []string{
+		warningEventBuilder(errorQuotaExceededReason).msg(message).String(),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+
+	retryEntry := testController.instanceOperationRetryQueue.instances[string(instance.UID)]
+	if retryTime := retryEntry.calculatedRetryTime; retryTime.Before(time.Now().Add(59 * time.Minute)) {
+		t.Fatalf("expected the provision to be retried in an hour, got a retry time of %v", retryTime)
+	}
+
+	// The next reconciliation waits for the delay instead of provisioning.
+	instance = updatedServiceInstance.(*v1beta1.ServiceInstance)
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+}
+
+// TestReconcileServiceInstanceProvisionRetryAfterSpecChange tests that
+// changing the spec of an instance whose provision keeps failing resets its
+// backoff, so the provision is retried right away.
+func TestReconcileServiceInstanceProvisionRetryAfterSpecChange(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: osb.HTTPStatusCodeError{
+				StatusCode:   http.StatusBadRequest,
+				ErrorMessage: strPtr("QuotaExceeded"),
+				Description:  strPtr("You may only have 5 instances"),
+			},
+		},
+	})
+	testController.quotaExceededPolicy = QuotaExceededPolicy{BrokerErrors: []string{"QuotaExceeded"}, RetryDelay: time.Hour}
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	key := testNamespace + "/" + testServiceInstanceName
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("expected the provision to be retried")
+	}
+	// The worker puts failed instances back in the queue with a backoff
+	testController.instanceQueue.AddRateLimited(key)
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+	instance = assertUpdateStatus(t, fakeCatalogClient.Actions()[0], instance).(*v1beta1.ServiceInstance)
+	fakeCatalogClient.ClearActions()
+
+	// The provision is delayed as long as the spec does not change
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+	updatedInstance := instance.DeepCopy()
+	updatedInstance.Generation = instance.Generation + 1
+	updatedInstance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"size":"small"}`)}
+	testController.instanceUpdate(instance, updatedInstance)
+
+	if e, a := 0, testController.instanceQueue.NumRequeues(key); e != a {
+		t.Fatalf("unexpected number of requeues after the spec changed: %s", expectedGot(e, a))
+	}
+	if _, ok := testController.instanceOperationRetryQueue.instances[string(instance.UID)]; ok {
+		t.Fatal("expected the retry entry of the instance to be removed after the spec changed")
+	}
+
+	// The new generation is recorded first, and then provisioned without
+	// any delay
+	if err := reconcileServiceInstance(t, testController, updatedInstance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedInstance = assertUpdateStatus(t, actions[0], updatedInstance).(*v1beta1.ServiceInstance)
+	if err := reconcileServiceInstance(t, testController, updatedInstance); err == nil {
+		t.Fatal("expected the provision to be retried")
+	}
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 2)
+	request := brokerActions[1].Request.(*osb.ProvisionRequest)
+	if e, a := "small", request.Parameters["size"]; e != a {
+		t.Fatalf("unexpected size parameter in the provision request: %s", expectedGot(e, a))
+	}
+}
+
 // TestReconcileServiceInstance tests synchronously provisioning a new service
 func TestReconcileServiceInstance(t *testing.T) {
 	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
@@ -1291,6 +1568,7 @@ func TestReconcileServiceInstance(t *testing.T) {
 	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
 	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
 	assertServiceInstanceDashboardURL(t, updatedServiceInstance, testDashboardURL)
+	assertServiceInstanceExternalPropertiesClusterServiceClass(t, updatedServiceInstance, testClusterServiceClassGUID, testClusterServiceBrokerName)
 
 	events := getRecordedEvents(testController)
 
@@ -1300,73 +1578,341 @@ func TestReconcileServiceInstance(t *testing.T) {
 	}
 }
 
-// TestReconcileServiceInstanceFailsWithDeletedPlan tests that a ServiceInstance is not
-// created if the ServicePlan specified is marked as RemovedFromCatalog.
-func TestReconcileServiceInstanceFailsWithDeletedPlan(t *testing.T) {
-	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+// TestReconcileServiceInstanceWithDeprecatedPlan tests that an instance of a
+// plan marked as deprecated in its metadata is provisioned, with a warning
+// event and the PlanDeprecated condition.
+func TestReconcileServiceInstanceWithDeprecatedPlan(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
 
 	addGetNamespaceReaction(fakeKubeClient)
 
+	plan := getTestClusterServicePlan()
+	plan.Spec.ExternalMetadata = &runtime.RawExtension{Raw: []byte(`{"deprecated": true, "deprecationMessage": "use the premium plan"}`)}
 	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
 	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
-	sp := getTestClusterServicePlan()
-	sp.Status.RemovedFromBrokerCatalog = true
-	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(sp)
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(plan)
 
 	instance := getTestServiceInstanceWithClusterRefs()
-
-	if err := reconcileServiceInstance(t, testController, instance); err == nil {
-		t.Fatalf("This should fail")
+	instance.Generation = 1
+	instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationProvision
+	instance.Status.InProgressProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
 	}
 
-	brokerActions := fakeClusterServiceBrokerClient.Actions()
-	assertNumberOfBrokerActions(t, brokerActions, 0)
-
-	instanceKey := testNamespace + "/" + testServiceInstanceName
-
-	// Since synchronous operation, must not make it into the polling queue.
-	if testController.instancePollingQueue.NumRequeues(instanceKey) != 0 {
-		t.Fatalf("Expected polling queue to not have any record of test instance")
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
 
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
 	actions := fakeCatalogClient.Actions()
 	assertNumberOfActions(t, actions, 1)
-
-	// verify no kube actions
-	kubeActions := fakeKubeClient.Actions()
-	assertNumberOfActions(t, kubeActions, 0)
-
 	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
-	assertServiceInstanceReadyFalse(t, updatedServiceInstance, errorDeletedClusterServicePlanReason)
+	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
 
-	events := getRecordedEvents(testController)
+	message := fmt.Sprintf("The plan %q of the instance is deprecated by the broker; consider moving the instance to another plan: use the premium plan", testClusterServicePlanName)
+	assertServiceInstanceCondition(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionPlanDeprecated, v1beta1.ConditionTrue, planDeprecatedReason)
 
-	expectedEvent := warningEventBuilder(errorDeletedClusterServicePlanReason).msgf(
-		"ClusterServicePlan (K8S: %q ExternalName: %q) has been deleted; cannot provision.",
-		"cspguid", "test-clusterserviceplan",
-	)
-	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+	events := getRecordedEvents(testController)
+	expectedEvents := []string{
+		warningEventBuilder(planDeprecatedReason).msg(message).String(),
+		normalEventBuilder(successProvisionReason).msg(successProvisionMessage).String(),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
 		t.Fatal(err)
 	}
 }
 
-// TestReconcileServiceInstanceFailsWithDeletedClass tests that a ServiceInstance is not
-// created if the ServiceClass specified is marked as RemovedFromCatalog.
-func TestReconcileServiceInstanceFailsWithDeletedClass(t *testing.T) {
-	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+// TestReconcileServiceInstanceWithRequestContextPolicy tests that the
+// provision request sent to the broker has the namespace labels and custom
+// values of the request context policy in its context.
+func TestReconcileServiceInstanceWithRequestContextPolicy(t *testing.T) {
+	fakeKubeClient, _, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+	testController.requestContextPolicy = RequestContextPolicy{
+		NamespaceLabels: []string{"team"},
+		Values:          map[string]string{"region": "eu-west"},
+	}
 
-	addGetNamespaceReaction(fakeKubeClient)
+	fakeKubeClient.PrependReactor("get", "namespaces", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:    testNamespaceGUID,
+				Labels: map[string]string{"team": "payments", "owner": "someone@example.com"},
+			},
+		}, nil
+	})
 
 	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
-	sc := getTestClusterServiceClass()
-	sc.Status.RemovedFromBrokerCatalog = true
-	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(sc)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
 	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
 
 	instance := getTestServiceInstanceWithClusterRefs()
-
-	if err := reconcileServiceInstance(t, testController, instance); err == nil {
-		t.Fatalf("This should have failed")
+	instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationProvision
+	instance.Status.InProgressProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+	}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertProvision(t, brokerActions[0], &osb.ProvisionRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+		OrganizationGUID:  testClusterID,
+		SpaceGUID:         testNamespaceGUID,
+		Context: map[string]interface{}{
+			"platform":                ContextProfilePlatformKubernetes,
+			"namespace":               testNamespace,
+			clusterIdentifierKey:      testClusterID,
+			"region":                  "eu-west",
+			namespaceLabelsContextKey: map[string]string{"team": "payments"},
+		},
+	})
+}
+
+// TestReconcileServiceInstanceAdopt tests that an instance with the adopt
+// annotation is fetched from the broker instead of being provisioned, and is
+// recorded as provisioned.
+func TestReconcileServiceInstanceAdopt(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+	paths, closeServer := serveGetInstance(t, testController, fakeClusterServiceBrokerClient, http.StatusOK, fmt.Sprintf(
+		`{"service_id":%q,"plan_id":%q,"dashboard_url":%q}`,
+		testClusterServiceClassGUID, testClusterServicePlanGUID, testDashboardURL,
+	))
+	defer closeServer()
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Annotations = map[string]string{AdoptInstanceAnnotation: "true"}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertGetInstancePaths(t, *paths, testServiceInstanceGUID)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyCondition(t, updatedServiceInstance, v1beta1.ConditionTrue, successAdoptInstanceReason)
+	assertServiceInstanceProvisioned(t, updatedServiceInstance, v1beta1.ServiceInstanceProvisionStatusProvisioned)
+	assertServiceInstanceCurrentOperationClear(t, updatedServiceInstance)
+	assertServiceInstanceDashboardURL(t, updatedServiceInstance, testDashboardURL)
+	assertServiceInstanceExternalPropertiesClusterServiceClass(t, updatedServiceInstance, testClusterServiceClassGUID, testClusterServiceBrokerName)
+
+	events := getRecordedEvents(testController)
+
+	expectedEvent := normalEventBuilder(successAdoptInstanceReason).msg(successAdoptInstanceMessage)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceAdoptFailure tests that adopting an instance
+// fails without deprovisioning it when the broker does not have the instance
+// or has it for another service or plan.
+func TestReconcileServiceInstanceAdoptFailure(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		body   string
+		reason string
+	}{
+		{
+			name:   "instance not found",
+			status: http.StatusNotFound,
+			body:   `{}`,
+			reason: errorAdoptInstanceCallFailedReason,
+		},
+		{
+			name:   "different plan",
+			status: http.StatusOK,
+			body:   fmt.Sprintf(`{"service_id":%q,"plan_id":"other-plan"}`, testClusterServiceClassGUID),
+			reason: errorAdoptInstanceMismatchReason,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			paths, closeServer := serveGetInstance(t, testController, fakeClusterServiceBrokerClient, tc.status, tc.body)
+			defer closeServer()
+
+			addGetNamespaceReaction(fakeKubeClient)
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithClusterRefs()
+			instance.Annotations = map[string]string{AdoptInstanceAnnotation: "true"}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+			fakeCatalogClient.ClearActions()
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+			assertGetInstancePaths(t, *paths, testServiceInstanceGUID)
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+			assertServiceInstanceProvisionRequestFailingErrorNoOrphanMitigation(
+				t,
+				updatedServiceInstance,
+				v1beta1.ServiceInstanceOperationProvision,
+				tc.reason,
+				tc.reason,
+				instance,
+			)
+		})
+	}
+}
+
+// TestReconcileServiceInstanceBrokerRateLimited tests that the provision
+// request is not sent and the instance is requeued when the rate limit of the
+// broker has been reached.
+func TestReconcileServiceInstanceBrokerRateLimited(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	limiter.Allow()
+	testController.brokerClientManager.clients[NewClusterServiceBrokerKey(testClusterServiceBrokerName)] = clientWithConfig{
+		OSBClient: &rateLimitedClient{Client: fakeClusterServiceBrokerClient, limiter: limiter},
+	}
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+}
+
+// TestReconcileServiceInstanceFailsWithDeletedPlan tests that a ServiceInstance is not
+// created if the ServicePlan specified is marked as RemovedFromCatalog.
+func TestReconcileServiceInstanceFailsWithDeletedPlan(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sp := getTestClusterServicePlan()
+	sp.Status.RemovedFromBrokerCatalog = true
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(sp)
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatalf("This should fail")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 0)
+
+	instanceKey := testNamespace + "/" + testServiceInstanceName
+
+	// Since synchronous operation, must not make it into the polling queue.
+	if testController.instancePollingQueue.NumRequeues(instanceKey) != 0 {
+		t.Fatalf("Expected polling queue to not have any record of test instance")
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	// verify no kube actions
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 0)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyFalse(t, updatedServiceInstance, errorDeletedClusterServicePlanReason)
+
+	events := getRecordedEvents(testController)
+
+	expectedEvent := warningEventBuilder(errorDeletedClusterServicePlanReason).msgf(
+		"ClusterServicePlan (K8S: %q ExternalName: %q) has been deleted; cannot provision.",
+		"cspguid", "test-clusterserviceplan",
+	)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceFailsWithDeletedClass tests that a ServiceInstance is not
+// created if the ServiceClass specified is marked as RemovedFromCatalog.
+func TestReconcileServiceInstanceFailsWithDeletedClass(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sc := getTestClusterServiceClass()
+	sc.Status.RemovedFromBrokerCatalog = true
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(sc)
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatalf("This should have failed")
 	}
 
 	brokerActions := fakeClusterServiceBrokerClient.Actions()
@@ -1637,6 +2183,100 @@ func TestReconcileServiceInstanceAsynchronousNoOperation(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceAsynchronousPollsOperationAfterRestart tests
+// that the operation key returned by the broker for an async provision is
+// persisted in the status of the instance, so that a controller restarted in
+// the middle of the provision polls the same operation.
+func TestReconcileServiceInstanceAsynchronousPollsOperationAfterRestart(t *testing.T) {
+	key := osb.OperationKey(testOperation)
+	fakeKubeClient, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{
+				Async:        true,
+				OperationKey: &key,
+			},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	persistedInstance := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+
+	// Restart the controller in the middle of the provision, with only the
+	// persisted instance to go on.
+	_, restartedCatalogClient, restartedBrokerClient, restartedController, restartedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		PollLastOperationReaction: &fakeosb.PollLastOperationReaction{
+			Response: &osb.LastOperationResponse{
+				State:       osb.StateInProgress,
+				Description: strPtr(lastOperationDescription),
+			},
+		},
+	})
+
+	restartedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	restartedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	restartedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	if err := reconcileServiceInstance(t, restartedController, persistedInstance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := restartedBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertPollLastOperation(t, brokerActions[0], &osb.LastOperationRequest{
+		InstanceID:   testServiceInstanceGUID,
+		ServiceID:    strPtr(testClusterServiceClassGUID),
+		PlanID:       strPtr(testClusterServicePlanGUID),
+		OperationKey: &key,
+	})
+
+	actions = restartedCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], persistedInstance)
+	assertServiceInstanceAsyncStartInProgress(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testOperation, testClusterServicePlanName, testClusterServicePlanGUID, persistedInstance)
+}
+
+// TestSetServiceInstanceLastOperation tests that the last operation key of an
+// instance is always the one of the latest operation.
+func TestSetServiceInstanceLastOperation(t *testing.T) {
+	key := osb.OperationKey("new-operation")
+	emptyKey := osb.OperationKey("")
+	cases := []struct {
+		name         string
+		operationKey *osb.OperationKey
+		expected     *string
+	}{
+		{name: "operation key", operationKey: &key, expected: strPtr("new-operation")},
+		{name: "no operation key", operationKey: nil, expected: nil},
+		{name: "empty operation key", operationKey: &emptyKey, expected: nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := getTestServiceInstanceAsyncProvisioning("previous-operation")
+			setServiceInstanceLastOperation(instance, tc.operationKey)
+			if e, a := tc.expected, instance.Status.LastOperation; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected last operation: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
+
 // TestReconcileServiceInstanceNamespaceError test reconciling an instance where kube
 // client fails to get a namespace to create instance in.
 func TestReconcileServiceInstanceNamespaceError(t *testing.T) {
@@ -2046,145 +2686,322 @@ func TestReconcileServiceInstanceDeleteFailedProvisionWithRequest(t *testing.T)
 	}
 }
 
-// TestReconsileServiceInstanceDeleteWithParameters tests that
-// an instance will be finalized under various conditions defined by test parameters
-func TestReconsileServiceInstanceDeleteWithParameters(t *testing.T) {
+// TestReconsileServiceInstanceDeleteWithParameters tests that
+// an instance will be finalized under various conditions defined by test parameters
+func TestReconsileServiceInstanceDeleteWithParameters(t *testing.T) {
+	cases := []struct {
+		name                 string
+		externalProperties   *v1beta1.ServiceInstancePropertiesState
+		deprovisionStatus    v1beta1.ServiceInstanceDeprovisionStatus
+		serviceBinding       *v1beta1.ServiceBinding
+		generation           int64
+		reconciledGeneration int64
+	}{
+		{
+			name:                 "With a failed to provision instance and without making a provision request",
+			externalProperties:   &v1beta1.ServiceInstancePropertiesState{},
+			deprovisionStatus:    v1beta1.ServiceInstanceDeprovisionStatusNotRequired,
+			serviceBinding:       nil,
+			generation:           1,
+			reconciledGeneration: 0,
+		},
+		{
+			name:                 "With a failed to provision instance, with inactive binding, and without making a provision request",
+			externalProperties:   &v1beta1.ServiceInstancePropertiesState{},
+			deprovisionStatus:    v1beta1.ServiceInstanceDeprovisionStatusNotRequired,
+			serviceBinding:       getTestServiceInactiveBinding(),
+			generation:           1,
+			reconciledGeneration: 0,
+		},
+		{
+			name:                 "With a deprovisioned instance and without making a deprovision request",
+			externalProperties:   nil,
+			deprovisionStatus:    v1beta1.ServiceInstanceDeprovisionStatusSucceeded,
+			serviceBinding:       nil,
+			generation:           2,
+			reconciledGeneration: 1,
+		},
+		{
+			name:                 "With a deprovisioned instance, with inactive binding, and without making a deprovision request",
+			externalProperties:   nil,
+			deprovisionStatus:    v1beta1.ServiceInstanceDeprovisionStatusSucceeded,
+			serviceBinding:       getTestServiceInactiveBinding(),
+			generation:           2,
+			reconciledGeneration: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			if tc.serviceBinding != nil {
+				sharedInformers.ClusterServicePlans().Informer().GetStore().Add(tc.serviceBinding)
+			}
+
+			instance := getTestServiceInstanceWithFailedStatus()
+			instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+			instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+			instance.Status.ExternalProperties = tc.externalProperties
+			instance.Status.DeprovisionStatus = tc.deprovisionStatus
+
+			instance.Generation = tc.generation
+			instance.Status.ReconciledGeneration = tc.reconciledGeneration
+			instance.Status.ObservedGeneration = 1
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusNotProvisioned
+
+			fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, instance, nil
+			})
+
+			err := reconcileServiceInstance(t, testController, instance)
+			if err != nil {
+				t.Fatalf("Unexpected error from reconcileServiceInstance: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			assertNumberOfBrokerActions(t, brokerActions, 0)
+
+			// Verify no core kube actions occurred
+			kubeActions := fakeKubeClient.Actions()
+			assertNumberOfActions(t, kubeActions, 0)
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+			assertEmptyFinalizers(t, updatedServiceInstance)
+
+			events := getRecordedEvents(testController)
+			assertNumEvents(t, events, 0)
+		})
+	}
+}
+
+// TestReconcileServiceInstanceDeleteWhenAlreadyDeprovisionedUnsuccessfully
+// tests that an instance that has already had a failed deprovision request
+// will not be finalized and no further deprovision request will be sent to
+// the broker.
+func TestReconcileServiceInstanceDeleteWhenAlreadyDeprovisionedUnsuccessfully(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithFailedStatus()
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{}
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusFailed
+
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 1
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+
+	err := reconcileServiceInstance(t, testController, instance)
+	if err != nil {
+		t.Fatalf("Unexpected error from reconcileServiceInstance: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 0)
+
+	// Verify no core kube actions occurred
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 0)
+
+	// Verify no catalog client actions occurred
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 0)
+
+	events := getRecordedEvents(testController)
+	assertNumEvents(t, events, 0)
+}
+
+// TestReconcileServiceInstanceDeleteCountsFailedDeprovisionAttempts tests
+// that a failed deprovision request of a deleted instance is counted in its
+// status.
+func TestReconcileServiceInstanceDeleteCountsFailedDeprovisionAttempts(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		DeprovisionReaction: &fakeosb.DeprovisionReaction{
+			Error: fmt.Errorf("fake deprovision failure"),
+		},
+	})
+	testController.forceDeletionPolicy = ForceDeletionPolicy{FailedDeprovisionAttempts: 3, Window: time.Hour}
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+	}
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 2
+	instance.Status.ObservedGeneration = 2
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+	instance.Status.FailedDeprovisionAttempts = 1
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceDeprovisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("expected the failed deprovision to be retried")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyCondition(t, updatedServiceInstance, v1beta1.ConditionUnknown, errorDeprovisionCallFailedReason)
+	if e, a := int64(2), updatedServiceInstance.(*v1beta1.ServiceInstance).Status.FailedDeprovisionAttempts; e != a {
+		t.Fatalf("unexpected failed deprovision attempts: expected %v, got %v", e, a)
+	}
+	if e, a := []string{v1beta1.FinalizerServiceCatalog}, updatedServiceInstance.(*v1beta1.ServiceInstance).Finalizers; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected finalizers: expected %v, got %v", e, a)
+	}
+}
+
+// TestReconcileServiceInstanceDeleteForceDeletes tests that the finalizer of
+// an instance that failed to be deprovisioned is removed once the force
+// deletion policy allows it.
+func TestReconcileServiceInstanceDeleteForceDeletes(t *testing.T) {
 	cases := []struct {
-		name                 string
-		externalProperties   *v1beta1.ServiceInstancePropertiesState
-		deprovisionStatus    v1beta1.ServiceInstanceDeprovisionStatus
-		serviceBinding       *v1beta1.ServiceBinding
-		generation           int64
-		reconciledGeneration int64
+		name        string
+		attempts    int64
+		deletedAgo  time.Duration
+		forceDelete bool
 	}{
 		{
-			name:                 "With a failed to provision instance and without making a provision request",
-			externalProperties:   &v1beta1.ServiceInstancePropertiesState{},
-			deprovisionStatus:    v1beta1.ServiceInstanceDeprovisionStatusNotRequired,
-			serviceBinding:       nil,
-			generation:           1,
-			reconciledGeneration: 0,
-		},
-		{
-			name:                 "With a failed to provision instance, with inactive binding, and without making a provision request",
-			externalProperties:   &v1beta1.ServiceInstancePropertiesState{},
-			deprovisionStatus:    v1beta1.ServiceInstanceDeprovisionStatusNotRequired,
-			serviceBinding:       getTestServiceInactiveBinding(),
-			generation:           1,
-			reconciledGeneration: 0,
+			name:        "enough attempts over the window",
+			attempts:    3,
+			deletedAgo:  2 * time.Hour,
+			forceDelete: true,
 		},
 		{
-			name:                 "With a deprovisioned instance and without making a deprovision request",
-			externalProperties:   nil,
-			deprovisionStatus:    v1beta1.ServiceInstanceDeprovisionStatusSucceeded,
-			serviceBinding:       nil,
-			generation:           2,
-			reconciledGeneration: 1,
+			name:       "not enough attempts",
+			attempts:   2,
+			deletedAgo: 2 * time.Hour,
 		},
 		{
-			name:                 "With a deprovisioned instance, with inactive binding, and without making a deprovision request",
-			externalProperties:   nil,
-			deprovisionStatus:    v1beta1.ServiceInstanceDeprovisionStatusSucceeded,
-			serviceBinding:       getTestServiceInactiveBinding(),
-			generation:           2,
-			reconciledGeneration: 1,
+			name:       "window not elapsed",
+			attempts:   3,
+			deletedAgo: 30 * time.Minute,
 		},
 	}
-
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+			testController.forceDeletionPolicy = ForceDeletionPolicy{FailedDeprovisionAttempts: 3, Window: time.Hour}
 
 			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
 			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
 			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
-			if tc.serviceBinding != nil {
-				sharedInformers.ClusterServicePlans().Informer().GetStore().Add(tc.serviceBinding)
-			}
 
 			instance := getTestServiceInstanceWithFailedStatus()
-			instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+			instance.ObjectMeta.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-tc.deletedAgo)}
 			instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
-			instance.Status.ExternalProperties = tc.externalProperties
-			instance.Status.DeprovisionStatus = tc.deprovisionStatus
+			instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{}
+			instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusFailed
+			instance.Status.FailedDeprovisionAttempts = tc.attempts
 
-			instance.Generation = tc.generation
-			instance.Status.ReconciledGeneration = tc.reconciledGeneration
+			instance.Generation = 2
+			instance.Status.ReconciledGeneration = 1
 			instance.Status.ObservedGeneration = 1
-			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusNotProvisioned
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
 
 			fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
 				return true, instance, nil
 			})
 
-			err := reconcileServiceInstance(t, testController, instance)
-			if err != nil {
-				t.Fatalf("Unexpected error from reconcileServiceInstance: %v", err)
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
 			}
 
 			brokerActions := fakeClusterServiceBrokerClient.Actions()
 			assertNumberOfBrokerActions(t, brokerActions, 0)
 
-			// Verify no core kube actions occurred
 			kubeActions := fakeKubeClient.Actions()
 			assertNumberOfActions(t, kubeActions, 0)
 
 			actions := fakeCatalogClient.Actions()
-			assertNumberOfActions(t, actions, 1)
+			events := getRecordedEvents(testController)
+			if !tc.forceDelete {
+				assertNumberOfActions(t, actions, 0)
+				assertNumEvents(t, events, 0)
+				return
+			}
 
+			assertNumberOfActions(t, actions, 1)
 			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+			assertServiceInstanceCondition(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionForceDeleted, v1beta1.ConditionTrue, forceDeletedReason)
 			assertEmptyFinalizers(t, updatedServiceInstance)
 
-			events := getRecordedEvents(testController)
-			assertNumEvents(t, events, 0)
+			expectedEvent := warningEventBuilder(forceDeletedReason).msg("Removed the finalizer without deprovisioning after 3 failed deprovision attempts; the instance may still exist at the broker")
+			if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+				t.Fatal(err)
+			}
 		})
 	}
 }
 
-// TestReconcileServiceInstanceDeleteWhenAlreadyDeprovisionedUnsuccessfully
-// tests that an instance that has already had a failed deprovision request
-// will not be finalized and no further deprovision request will be sent to
-// the broker.
-func TestReconcileServiceInstanceDeleteWhenAlreadyDeprovisionedUnsuccessfully(t *testing.T) {
-	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+// TestReconcileServiceInstanceDeleteForceDeleteWithBindings tests that the
+// finalizer of an instance that the force deletion policy allows to remove is
+// kept while the instance has bindings.
+func TestReconcileServiceInstanceDeleteForceDeleteWithBindings(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.forceDeletionPolicy = ForceDeletionPolicy{FailedDeprovisionAttempts: 3, Window: time.Hour}
 
 	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
 	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
 	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(getTestServiceBinding())
 
 	instance := getTestServiceInstanceWithFailedStatus()
-	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
 	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
 	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{}
 	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusFailed
+	instance.Status.FailedDeprovisionAttempts = 3
 
-	instance.Generation = 2
-	instance.Status.ReconciledGeneration = 1
-	instance.Status.ObservedGeneration = 1
-	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
-
-	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
-		return true, instance, nil
-	})
-
-	err := reconcileServiceInstance(t, testController, instance)
-	if err != nil {
-		t.Fatalf("Unexpected error from reconcileServiceInstance: %v", err)
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("expected the deletion to be blocked by the binding")
 	}
 
-	brokerActions := fakeClusterServiceBrokerClient.Actions()
-	assertNumberOfBrokerActions(t, brokerActions, 0)
-
-	// Verify no core kube actions occurred
-	kubeActions := fakeKubeClient.Actions()
-	assertNumberOfActions(t, kubeActions, 0)
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
 
-	// Verify no catalog client actions occurred
 	actions := fakeCatalogClient.Actions()
-	assertNumberOfActions(t, actions, 0)
-
-	events := getRecordedEvents(testController)
-	assertNumEvents(t, events, 0)
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyCondition(t, updatedServiceInstance, v1beta1.ConditionFalse, errorDeprovisionBlockedByCredentialsReason)
+	if e, a := []string{v1beta1.FinalizerServiceCatalog}, updatedServiceInstance.(*v1beta1.ServiceInstance).Finalizers; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected finalizers: expected %v, got %v", e, a)
+	}
 }
 
 // TestReconcileServiceInstanceDeleteFailedUpdate tests that an instance
@@ -2613,6 +3430,72 @@ func TestPollServiceInstanceFailureProvisioningWithOperation(t *testing.T) {
 	)
 }
 
+// TestPollServiceInstanceStatusGoneProvisioningWithOperation tests polling an
+// instance that has an async provision in progress when the broker responds
+// with Gone. Verify that the provision fails without orphan mitigation.
+func TestPollServiceInstanceStatusGoneProvisioningWithOperation(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		PollLastOperationReaction: fakeosb.DynamicPollLastOperationReaction(func(_ *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+			return nil, osb.HTTPStatusCodeError{
+				StatusCode: http.StatusGone,
+			}
+		}),
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceAsyncProvisioning(testOperation)
+	instanceKey := testNamespace + "/" + testServiceInstanceName
+
+	err := testController.pollServiceInstance(instance)
+	if err != nil {
+		t.Fatalf("pollServiceInstance failed: %s", err)
+	}
+
+	if testController.instancePollingQueue.NumRequeues(instanceKey) != 0 {
+		t.Fatalf("Expected polling queue to not have any record of test instance as polling should have completed")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	operationKey := osb.OperationKey(testOperation)
+	assertPollLastOperation(t, brokerActions[0], &osb.LastOperationRequest{
+		InstanceID:   testServiceInstanceGUID,
+		ServiceID:    strPtr(testClusterServiceClassGUID),
+		PlanID:       strPtr(testClusterServicePlanGUID),
+		OperationKey: &operationKey,
+	})
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceProvisionRequestFailingErrorNoOrphanMitigation(
+		t,
+		updatedServiceInstance,
+		v1beta1.ServiceInstanceOperationProvision,
+		errorProvisionCallFailedReason,
+		errorProvisionCallFailedReason,
+		instance,
+	)
+
+	events := getRecordedEvents(testController)
+
+	message := "Provision call failed: the broker reported the instance as gone while polling the last operation"
+	expectedEvents := []string{
+		warningEventBuilder(errorProvisionCallFailedReason).msg(message).String(),
+		warningEventBuilder(errorProvisionCallFailedReason).msg(message).String(),
+	}
+	if err := checkEvents(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestPollServiceInstanceInProgressDeprovisioningWithOperationNoFinalizer tests
 // polling an instance that was asynchronously being deprovisioned and is still
 // in progress.
@@ -3366,6 +4249,163 @@ func TestReconcileServiceInstanceFailureOnFinalRetry(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceFailureAfterProvisionTimeout verifies that the
+// provision timeout annotation of an instance overrides the retry duration of
+// the controller.
+func TestReconcileServiceInstanceFailureAfterProvisionTimeout(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: errors.New("fake creation failure"),
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationProvision
+	instance.Status.InProgressProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+	}
+	instance.Annotations = map[string]string{ProvisionTimeoutAnnotation: "1h"}
+	startTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	instance.Status.OperationStartTime = &startTime
+	instance.Status.ObservedGeneration = instance.Generation
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("Should have returned no error because the provision timeout has elapsed: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertProvision(t, brokerActions[0], &osb.ProvisionRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+		OrganizationGUID:  testClusterID,
+		SpaceGUID:         testNamespaceGUID,
+		Context:           testContext})
+
+	// verify no kube resources created
+	// One single action comes from getting namespace uid
+	kubeActions := fakeKubeClient.Actions()
+	if err := checkKubeClientActions(kubeActions, []kubeClientAction{
+		{verb: "get", resourceName: "namespaces", checkType: checkGetActionType},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceProvisionRequestFailingErrorNoOrphanMitigation(
+		t,
+		updatedServiceInstance,
+		v1beta1.ServiceInstanceOperationProvision,
+		errorErrorCallingProvisionReason,
+		errorReconciliationRetryTimeoutReason,
+		instance,
+	)
+
+	events := getRecordedEvents(testController)
+
+	expectedEventPrefixes := []string{
+		corev1.EventTypeWarning + " " + errorErrorCallingProvisionReason,
+		corev1.EventTypeWarning + " " + errorReconciliationRetryTimeoutReason,
+	}
+
+	if err := checkEventPrefixes(events, expectedEventPrefixes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestServiceInstanceRetryDurationExceeded verifies that the provision
+// timeout annotation of an instance only overrides the retry duration of the
+// controller for provisions.
+func TestServiceInstanceRetryDurationExceeded(t *testing.T) {
+	cases := []struct {
+		name             string
+		operation        v1beta1.ServiceInstanceOperation
+		mitigatingOrphan bool
+		timeout          string
+		elapsed          time.Duration
+		exceeded         bool
+	}{
+		{
+			name:      "provision within the retry duration",
+			operation: v1beta1.ServiceInstanceOperationProvision,
+			elapsed:   time.Hour,
+		},
+		{
+			name:      "provision after the retry duration",
+			operation: v1beta1.ServiceInstanceOperationProvision,
+			elapsed:   8 * 24 * time.Hour,
+			exceeded:  true,
+		},
+		{
+			name:      "provision within the provision timeout",
+			operation: v1beta1.ServiceInstanceOperationProvision,
+			timeout:   "2h",
+			elapsed:   time.Hour,
+		},
+		{
+			name:      "provision after the provision timeout",
+			operation: v1beta1.ServiceInstanceOperationProvision,
+			timeout:   "30m",
+			elapsed:   time.Hour,
+			exceeded:  true,
+		},
+		{
+			name:      "provision with a provision timeout longer than the retry duration",
+			operation: v1beta1.ServiceInstanceOperationProvision,
+			timeout:   "240h",
+			elapsed:   8 * 24 * time.Hour,
+		},
+		{
+			name:      "provision with an invalid provision timeout",
+			operation: v1beta1.ServiceInstanceOperationProvision,
+			timeout:   "soon",
+			elapsed:   time.Hour,
+		},
+		{
+			name:             "orphan mitigation ignores the provision timeout",
+			operation:        v1beta1.ServiceInstanceOperationProvision,
+			mitigatingOrphan: true,
+			timeout:          "30m",
+			elapsed:          time.Hour,
+		},
+		{
+			name:      "update ignores the provision timeout",
+			operation: v1beta1.ServiceInstanceOperationUpdate,
+			timeout:   "30m",
+			elapsed:   time.Hour,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, testController, _ := newTestController(t, noFakeActions())
+
+			instance := getTestServiceInstance()
+			if tc.timeout != "" {
+				instance.Annotations = map[string]string{ProvisionTimeoutAnnotation: tc.timeout}
+			}
+			instance.Status.CurrentOperation = tc.operation
+			instance.Status.OrphanMitigationInProgress = tc.mitigatingOrphan
+			startTime := metav1.NewTime(time.Now().Add(-tc.elapsed))
+			instance.Status.OperationStartTime = &startTime
+
+			if e, a := tc.exceeded, testController.serviceInstanceRetryDurationExceeded(instance); e != a {
+				t.Fatalf("unexpected result: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
+
 // TestPollServiceInstanceSuccessOnFinalRetry verifies that polling
 // can succeed on the last attempt before timing out of the retry loop
 func TestPollServiceInstanceSuccessOnFinalRetry(t *testing.T) {
@@ -4125,6 +5165,122 @@ func TestReconcileServiceInstanceTimeoutTriggersOrphanMitigation(t *testing.T) {
 	assertServiceInstanceOrphanMitigationInProgressTrue(t, updatedServiceInstance)
 }
 
+// TestReconcileServiceInstanceTimeoutWithOrphanMitigationDisabled tests that
+// a provision timeout does not start orphan mitigation for an instance that
+// has disabled it, and that the skip is recorded in an event.
+func TestReconcileServiceInstanceTimeoutWithOrphanMitigationDisabled(t *testing.T) {
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: &url.Error{
+				Err: getTestTimeoutError(),
+			},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Spec.DisableOrphanMitigation = true
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	getRecordedEvents(testController)
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("Reconciler should return error for timeout so that provision is retried")
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedObject := assertUpdateStatus(t, actions[0], instance)
+	updatedServiceInstance, ok := updatedObject.(*v1beta1.ServiceInstance)
+	if !ok {
+		fatalf(t, "Couldn't convert object %+v into a *v1beta1.ServiceInstance", updatedObject)
+	}
+
+	assertServiceInstanceReadyCondition(t, updatedServiceInstance, v1beta1.ConditionFalse, errorErrorCallingProvisionReason)
+	assertServiceInstanceOrphanMitigationMissing(t, updatedServiceInstance)
+	assertServiceInstanceOrphanMitigationInProgressFalse(t, updatedServiceInstance)
+	assertServiceInstanceDeprovisionStatus(t, updatedServiceInstance, v1beta1.ServiceInstanceDeprovisionStatusRequired)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(skippedInstanceOrphanMitigationReason).msg(skippedInstanceOrphanMitigationMessage)
+	if err := checkEvents(events[1:], expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceInstanceTimeoutWithOrphanMitigationDelayed tests that a
+// provision timeout of an instance whose orphan mitigation policy is Delayed
+// requires orphan mitigation, records the delay, and does not deprovision the
+// instance before the delay has elapsed.
+func TestReconcileServiceInstanceTimeoutWithOrphanMitigationDelayed(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Error: &url.Error{
+				Err: getTestTimeoutError(),
+			},
+		},
+	})
+	testController.orphanMitigationPolicy = OrphanMitigationPolicy{Delay: time.Hour}
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Annotations = map[string]string{OrphanMitigationPolicyAnnotation: string(OrphanMitigationDelayed)}
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	getRecordedEvents(testController)
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatal("Reconciler should return error for timeout so that instance is orphan mitigated")
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedObject := assertUpdateStatus(t, actions[0], instance)
+	updatedServiceInstance, ok := updatedObject.(*v1beta1.ServiceInstance)
+	if !ok {
+		fatalf(t, "Couldn't convert object %+v into a *v1beta1.ServiceInstance", updatedObject)
+	}
+
+	assertServiceInstanceReadyCondition(t, updatedServiceInstance, v1beta1.ConditionFalse, delayedInstanceOrphanMitigationReason)
+	assertServiceInstanceOrphanMitigationTrue(t, updatedServiceInstance, errorErrorCallingProvisionReason)
+	assertServiceInstanceOrphanMitigationInProgressTrue(t, updatedServiceInstance)
+	if updatedServiceInstance.Status.OrphanMitigationStartTime == nil {
+		t.Fatal("expected the start of orphan mitigation to be recorded")
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(delayedInstanceOrphanMitigationReason).msg(fmt.Sprintf(delayedInstanceOrphanMitigationMessage, time.Hour))
+	if err := checkEvents(events[1:], expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+
+	fakeCatalogClient.ClearActions()
+	brokerActions := len(fakeClusterServiceBrokerClient.Actions())
+	if err := reconcileServiceInstance(t, testController, updatedServiceInstance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	if e, a := brokerActions, len(fakeClusterServiceBrokerClient.Actions()); e != a {
+		t.Fatalf("expected no deprovision request before the delay has elapsed: %s", expectedGot(e, a))
+	}
+}
+
 func TestReconcileServiceInstanceOrphanMitigation(t *testing.T) {
 	key := osb.OperationKey(testOperation)
 	description := "description"
@@ -4474,6 +5630,7 @@ func TestReconcileServiceInstanceWithSecretParameters(t *testing.T) {
 
 	updatedServiceInstance = assertUpdateStatus(t, actions[0], instance)
 	assertServiceInstanceOperationSuccessWithParameters(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationProvision, testClusterServicePlanName, testClusterServicePlanGUID, expectedParameters, expectedParametersChecksum, instance)
+	assertServiceInstanceParametersChecksumAnnotation(t, updatedServiceInstance, expectedParametersChecksum)
 
 	updateObject, ok := updatedServiceInstance.(*v1beta1.ServiceInstance)
 	if !ok {
@@ -4682,6 +5839,7 @@ func TestReconcileServiceInstanceUpdateParameters(t *testing.T) {
 
 	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
 	assertServiceInstanceOperationSuccessWithParameters(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationUpdate, testClusterServicePlanName, testClusterServicePlanGUID, expectedParameters, expectedParametersChecksum, instance)
+	assertServiceInstanceParametersChecksumAnnotation(t, updatedServiceInstance, expectedParametersChecksum)
 
 	updateObject, ok := updatedServiceInstance.(*v1beta1.ServiceInstance)
 	if !ok {
@@ -4751,6 +5909,9 @@ func TestReconcileServiceInstanceDeleteParameters(t *testing.T) {
 		Parameters:                     oldParametersRaw,
 		ParameterChecksum:              generateChecksumOfParametersOrFail(t, oldParameters),
 	}
+	instance.Annotations = map[string]string{
+		ParametersChecksumAnnotation: generateChecksumOfParametersOrFail(t, oldParameters),
+	}
 
 	if err := reconcileServiceInstance(t, testController, instance); err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -4779,6 +5940,7 @@ func TestReconcileServiceInstanceDeleteParameters(t *testing.T) {
 
 	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
 	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationUpdate, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+	assertServiceInstanceParametersChecksumAnnotation(t, updatedServiceInstance, "")
 
 	updateObject, ok := updatedServiceInstance.(*v1beta1.ServiceInstance)
 	if !ok {
@@ -5370,6 +6532,44 @@ func TestResolveReferencesWorks(t *testing.T) {
 	assertNumEvents(t, events, 0)
 }
 
+// TestResolveReferencesRenamedClusterServiceClass tests that resolveReferences
+// ignores a class that was removed from the broker's catalog when another
+// class in the catalog has the same external name, which happens when the
+// broker renames a class and gives its former name to a new one.
+func TestResolveReferencesRenamedClusterServiceClass(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstance()
+
+	removed := getTestClusterServiceClass()
+	removed.Name = "old-cscguid"
+	removed.Spec.ExternalID = "old-cscguid"
+	removed.Status.RemovedFromBrokerCatalog = true
+	sc := getTestClusterServiceClass()
+	fakeCatalogClient.AddReactor("list", "clusterserviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ClusterServiceClassList{Items: []v1beta1.ClusterServiceClass{*removed, *sc}}, nil
+	})
+	sp := getTestClusterServicePlan()
+	fakeCatalogClient.AddReactor("list", "clusterserviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ClusterServicePlanList{Items: []v1beta1.ClusterServicePlan{*sp}}, nil
+	})
+
+	if _, err := testController.resolveReferences(instance); err != nil {
+		t.Fatalf("Should not have failed, but failed with: %q", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 3)
+	updatedServiceInstance := assertUpdateReference(t, actions[2], instance)
+	updateObject, ok := updatedServiceInstance.(*v1beta1.ServiceInstance)
+	if !ok {
+		t.Fatalf("couldn't convert to *v1beta1.ServiceInstance")
+	}
+	if updateObject.Spec.ClusterServiceClassRef == nil || updateObject.Spec.ClusterServiceClassRef.Name != testClusterServiceClassGUID {
+		t.Fatalf("ClusterServiceClassRef was not resolved to the class in the broker's catalog")
+	}
+}
+
 // TestResolveReferencesForPlanChange tests that resolveReferences updates the
 // ClusterServicePlanRef when the plan is changed.
 func TestResolveReferencesForPlanChange(t *testing.T) {
@@ -6056,6 +7256,65 @@ func TestReconcileServiceInstanceDeleteWithNonExistentPlan(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceDeleteWithRemovedClassAndPlan tests that an
+// instance can still be deprovisioned after its class and plan have been
+// removed from the catalog, using the external IDs recorded in its status at
+// provision time.
+func TestReconcileServiceInstanceDeleteWithRemovedClassAndPlan(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		DeprovisionReaction: &fakeosb.DeprovisionReaction{
+			Response: &osb.DeprovisionResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 1
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+		ClusterServiceClassExternalID:  testClusterServiceClassGUID,
+		ClusterServiceBrokerName:       testClusterServiceBrokerName,
+		ClusterServicePlanExternalName: testClusterServicePlanName,
+		ClusterServicePlanExternalID:   testClusterServicePlanGUID,
+	}
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+
+	fakeCatalogClient.AddReactor("get", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, instance, nil
+	})
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance = assertServiceInstanceDeprovisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfBrokerActions(t, brokerActions, 1)
+	assertDeprovision(t, brokerActions[0], &osb.DeprovisionRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceOperationSuccess(t, updatedServiceInstance, v1beta1.ServiceInstanceOperationDeprovision, testClusterServicePlanName, testClusterServicePlanGUID, instance)
+}
+
 // TestReconcileServiceInstanceUpdateMissingObservedGeneration tests reconciling a
 // ServiceInstance with ObservedGeneration missing (while Reconciled Generation set)
 // i.e. API version migration testing
@@ -6212,3 +7471,196 @@ func reconcileServiceInstance(t *testing.T, testController *controller, instance
 	}
 	return err
 }
+
+// TestReconcileServiceInstanceParametersStale tests that a ready instance gets
+// a ParametersStale condition when a secret referenced by its parametersFrom
+// changes, and that the condition is removed once the parameters match again.
+func TestReconcileServiceInstanceParametersStale(t *testing.T) {
+	cases := []struct {
+		name          string
+		secretValue   string
+		alreadyStale  bool
+		expectUpdate  bool
+		expectedStale bool
+	}{
+		{
+			name:        "unchanged secret",
+			secretValue: `{"b":"1"}`,
+		},
+		{
+			name:          "changed secret",
+			secretValue:   `{"b":"2"}`,
+			expectUpdate:  true,
+			expectedStale: true,
+		},
+		{
+			name:         "changed secret already reported",
+			secretValue:  `{"b":"2"}`,
+			alreadyStale: true,
+		},
+		{
+			name:         "secret restored",
+			secretValue:  `{"b":"1"}`,
+			alreadyStale: true,
+			expectUpdate: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+
+			addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+				Data: map[string][]byte{
+					"param-secret-key": []byte(tc.secretValue),
+				},
+			})
+
+			instance := getTestServiceInstanceWithStatus(v1beta1.ConditionTrue)
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties.ParameterChecksum = generateChecksumOfParametersOrFail(t, map[string]interface{}{
+				"b": "1",
+			})
+			instance.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+				{
+					SecretKeyRef: &v1beta1.SecretKeyReference{
+						Name: "param-secret-name",
+						Key:  "param-secret-key",
+					},
+				},
+			}
+			if tc.alreadyStale {
+				instance.Status.Conditions = append(instance.Status.Conditions, v1beta1.ServiceInstanceCondition{
+					Type:   v1beta1.ServiceInstanceConditionParametersStale,
+					Status: v1beta1.ConditionTrue,
+					Reason: staleParametersReason,
+				})
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectUpdate {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			if e, a := tc.expectedStale, isServiceInstanceParametersStale(updatedServiceInstance); e != a {
+				t.Fatalf("unexpected ParametersStale condition: %s", expectedGot(e, a))
+			}
+			if !isServiceInstanceReady(updatedServiceInstance) {
+				t.Fatal("expected the instance to remain ready")
+			}
+
+			if tc.expectedStale {
+				events := getRecordedEvents(testController)
+				expectedEvent := warningEventBuilder(staleParametersReason).msg(staleParametersMessage)
+				if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceWaitingForDependencies tests that an instance
+// is not provisioned until the instances it depends on are Ready.
+func TestReconcileServiceInstanceWaitingForDependencies(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	dependency := getTestServiceInstanceWithClusterRefs()
+	dependency.Name = "test-dependency"
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(dependency)
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	instance.Spec.DependsOn = []v1beta1.LocalObjectReference{{Name: dependency.Name}, {Name: "missing-dependency"}}
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyFalse(t, updatedInstance, waitingForDependenciesReason)
+	expectedMessage := fmt.Sprintf(waitingForDependenciesMessage, "test-dependency, missing-dependency")
+	if message := updatedInstance.(*v1beta1.ServiceInstance).Status.Conditions[0].Message; message != expectedMessage {
+		t.Fatalf("unexpected condition message: %v", expectedGot(expectedMessage, message))
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := normalEventBuilder(waitingForDependenciesReason).msg(expectedMessage)
+	if err := checkEvents(events, []string{expectedEvent.String()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reconciling the waiting instance again doesn't update it.
+	fakeCatalogClient.ClearActions()
+	if err := reconcileServiceInstance(t, testController, updatedInstance.(*v1beta1.ServiceInstance)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+
+	// Once the dependencies are Ready, the instance is provisioned.
+	dependency = dependency.DeepCopy()
+	setServiceInstanceCondition(dependency, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, successProvisionReason, successProvisionMessage)
+	sharedInformers.ServiceInstances().Informer().GetStore().Update(dependency)
+	missingDependency := dependency.DeepCopy()
+	missingDependency.Name = "missing-dependency"
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(missingDependency)
+
+	if err := reconcileServiceInstance(t, testController, updatedInstance.(*v1beta1.ServiceInstance)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+}
+
+// TestInstanceUpdateEnqueuesDependentInstances tests that the instances
+// depending on an instance are enqueued when the instance becomes Ready.
+func TestInstanceUpdateEnqueuesDependentInstances(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	dependent := getTestServiceInstanceWithClusterRefs()
+	dependent.Name = "test-dependent"
+	dependent.Spec.DependsOn = []v1beta1.LocalObjectReference{{Name: testServiceInstanceName}}
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(dependent)
+	unrelated := getTestServiceInstanceWithClusterRefs()
+	unrelated.Name = "test-unrelated"
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(unrelated)
+
+	oldInstance := getTestServiceInstanceWithClusterRefs()
+	newInstance := oldInstance.DeepCopy()
+	setServiceInstanceCondition(newInstance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, successProvisionReason, successProvisionMessage)
+
+	testController.instanceUpdate(oldInstance, newInstance)
+
+	if length := testController.instanceQueue.Len(); length != 2 {
+		t.Fatalf("expected the instance and its dependent to be enqueued, got %d instances", length)
+	}
+	queued := sets.NewString()
+	for i := 0; i < 2; i++ {
+		key, _ := testController.instanceQueue.Get()
+		queued.Insert(key.(string))
+	}
+	expected := sets.NewString(testNamespace+"/"+testServiceInstanceName, testNamespace+"/test-dependent")
+	if !queued.Equal(expected) {
+		t.Fatalf("unexpected enqueued instances: %v", expectedGot(expected.List(), queued.List()))
+	}
+}
@@ -62,7 +62,7 @@ func getTestServiceBrokerWithAuth(authInfo *v1beta1.ServiceBrokerAuthInfo) *v1be
 func getTestBrokerBasicAuthInfo() *v1beta1.ServiceBrokerAuthInfo {
 	return &v1beta1.ServiceBrokerAuthInfo{
 		Basic: &v1beta1.BasicAuthConfig{
-			SecretRef: &v1beta1.LocalObjectReference{Name: "auth-secret"},
+			SecretRef: &v1beta1.ObjectReference{Name: "auth-secret"},
 		},
 	}
 }
@@ -70,7 +70,7 @@ func getTestBrokerBasicAuthInfo() *v1beta1.ServiceBrokerAuthInfo {
 func getTestBrokerBearerAuthInfo() *v1beta1.ServiceBrokerAuthInfo {
 	return &v1beta1.ServiceBrokerAuthInfo{
 		Bearer: &v1beta1.BearerTokenAuthConfig{
-			SecretRef: &v1beta1.LocalObjectReference{Name: "auth-secret"},
+			SecretRef: &v1beta1.ObjectReference{Name: "auth-secret"},
 		},
 	}
 }
@@ -67,6 +67,11 @@ func (c *controller) serviceBrokerAdd(obj interface{}) {
 }
 
 func (c *controller) serviceBrokerUpdate(oldObj, newObj interface{}) {
+	oldBroker, oldOK := oldObj.(*v1beta1.ServiceBroker)
+	newBroker, newOK := newObj.(*v1beta1.ServiceBroker)
+	if oldOK && newOK && isServiceBrokerStatusUpdate(&oldBroker.ObjectMeta, &newBroker.ObjectMeta) {
+		return
+	}
 	c.serviceBrokerAdd(newObj)
 }
 
@@ -116,7 +121,7 @@ func (c *controller) reconcileServiceBrokerKey(key string) error {
 
 func (c *controller) updateServiceBrokerClient(broker *v1beta1.ServiceBroker) (osb.Client, error) {
 	pcb := pretty.NewServiceBrokerContextBuilder(broker)
-	authConfig, err := getAuthCredentialsFromServiceBroker(c.kubeClient, broker)
+	authConfig, err := getAuthCredentialsFromServiceBroker(c.kubeClient, broker, c.brokerAuthSecretNamespaces)
 	if err != nil {
 		s := fmt.Sprintf("Error getting broker auth credentials: %s", err)
 		klog.Info(pcb.Message(s))
@@ -128,8 +133,11 @@ func (c *controller) updateServiceBrokerClient(broker *v1beta1.ServiceBroker) (o
 	}
 
 	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, authConfig)
+	clientConfig.APIVersion = c.osbAPIVersionForBroker(&broker.Status.CommonServiceBrokerStatus)
+	transportConfig := NewTransportConfigurationForBroker(&broker.Spec.CommonServiceBrokerSpec)
+	transportConfig.UserAgent = c.getBrokerUserAgent()
 
-	brokerClient, err := c.brokerClientManager.UpdateBrokerClient(NewServiceBrokerKey(broker.Namespace, broker.Name), clientConfig)
+	brokerClient, err := c.brokerClientManager.UpdateBrokerClientWithTransport(NewServiceBrokerKey(broker.Namespace, broker.Name), clientConfig, transportConfig)
 	if err != nil {
 		s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
 		klog.Info(pcb.Message(s))
@@ -155,12 +163,37 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 	// * If the broker's ready condition is true and the relist interval has not
 	// elapsed, do not reconcile it.
 	if !shouldReconcileServiceBroker(broker, time.Now(), c.brokerRelistInterval) {
+		// Broker clients are only kept in memory, so a controller that has
+		// just started has none for brokers that are not due to be relisted
+		// yet. Create one, so that operations that were in progress on the
+		// broker's instances and bindings can resume.
+		if _, found := c.brokerClientManager.BrokerClient(NewServiceBrokerKey(broker.Namespace, broker.Name)); !found {
+			klog.V(4).Info(pcb.Message("Creating the broker client without relisting"))
+			_, err := c.updateServiceBrokerClient(broker)
+			return err
+		}
 		return nil
 	}
 
 	if broker.DeletionTimestamp == nil { // Add or update
 		klog.V(4).Info(pcb.Message("Processing adding/update event"))
 
+		if conditions, changed := setServiceBrokerInsecureCondition(broker.Status.Conditions, &broker.Spec.CommonServiceBrokerSpec); changed {
+			broker = broker.DeepCopy()
+			broker.Status.Conditions = conditions
+		}
+		if conditions, changed := setServiceBrokerQuiescedCondition(broker.Status.Conditions, &broker.Spec.CommonServiceBrokerSpec); changed {
+			broker = broker.DeepCopy()
+			broker.Status.Conditions = conditions
+		}
+		if isBrokerURLInsecure(&broker.Spec.CommonServiceBrokerSpec) && !broker.Spec.AllowInsecure && broker.Spec.AuthInfo != nil {
+			s := fmt.Sprintf(errorInsecureBrokerURLMessage, broker.Spec.URL)
+			klog.Warning(pcb.Message(s))
+			c.recorder.Event(broker, corev1.EventTypeWarning, errorInsecureBrokerURLReason, s)
+			c.brokerClientManager.RemoveBrokerClient(NewServiceBrokerKey(broker.Namespace, broker.Name))
+			return c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorInsecureBrokerURLReason, s)
+		}
+
 		brokerClient, err := c.updateServiceBrokerClient(broker)
 		if err != nil {
 			return err
@@ -168,12 +201,22 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 
 		// get the broker's catalog
 		now := metav1.Now()
-		brokerCatalog, err := brokerClient.GetCatalog()
+		delayBrokerCall := func(client osb.Client) bool { return c.delayServiceBrokerCall(broker, client) }
+		brokerCatalog, osbAPIVersion, err := c.getCatalogNegotiatingOSBAPIVersion(NewServiceBrokerKey(broker.Namespace, broker.Name), brokerClient, &broker.Status.CommonServiceBrokerStatus, delayBrokerCall)
+		if brokerCatalog == nil && err == nil {
+			// requeued to honor the rate limit of the broker
+			return nil
+		}
+		recordBrokerRelist(NewServiceBrokerKey(broker.Namespace, broker.Name), err)
 		if err != nil {
+			reason := errorFetchingCatalogReason
+			if _, ok := err.(*unsupportedOSBAPIVersionError); ok {
+				reason = errorUnsupportedOSBAPIVersionReason
+			}
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			klog.Warning(pcb.Message(s))
-			c.recorder.Eventf(broker, corev1.EventTypeWarning, errorFetchingCatalogReason, s)
-			if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
+			c.recorder.Eventf(broker, corev1.EventTypeWarning, reason, s)
+			if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, reason, errorFetchingCatalogMessage+s); err != nil {
 				return err
 			}
 			if broker.Status.OperationStartTime == nil {
@@ -201,6 +244,14 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 
 		klog.V(5).Info(pcb.Messagef("Successfully fetched %v catalog entries", len(brokerCatalog.Services)))
 
+		// record the version of the OSB API the broker accepted, so that
+		// the requests made for its instances and bindings use it too
+		if broker.Status.OSBAPIVersion != osbAPIVersion.HeaderValue() {
+			klog.V(4).Info(pcb.Messagef("Using version %s of the OSB API", osbAPIVersion.HeaderValue()))
+			broker = broker.DeepCopy()
+			broker.Status.OSBAPIVersion = osbAPIVersion.HeaderValue()
+		}
+
 		// set the operation start time if not already set
 		if broker.Status.OperationStartTime != nil {
 			toUpdate := broker.DeepCopy()
@@ -211,6 +262,14 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 		}
 
+		// skip reconciling the classes and plans when the catalog has not
+		// changed since the last successful relist
+		hash := catalogHash(brokerCatalog)
+		if isCatalogUnchanged(&broker.ObjectMeta, &broker.Status.CommonServiceBrokerStatus, hash) {
+			klog.V(4).Info(pcb.Message("Catalog is unchanged since the last relist; skipping reconciliation of classes and plans"))
+			return c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage)
+		}
+
 		// get the existing services and plans for this broker so that we can
 		// detect when services and plans are removed from the broker's
 		// catalog
@@ -344,9 +403,11 @@ func (c *controller) reconcileServiceBroker(broker *v1beta1.ServiceBroker) error
 			}
 		}
 
-		// everything worked correctly; update the broker's ready condition to
-		// status true
-		if err := c.updateServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
+		// everything worked correctly; record the catalog hash and update the
+		// broker's ready condition to status true
+		toUpdate := broker.DeepCopy()
+		toUpdate.Status.LastCatalogHash = hash
+		if err := c.updateServiceBrokerCondition(toUpdate, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue, successFetchedCatalogReason, successFetchedCatalogMessage); err != nil {
 			return err
 		}
 
@@ -484,6 +545,11 @@ func (c *controller) reconcileServiceClassFromServiceBrokerCatalog(broker *v1bet
 	}
 
 	klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ServiceClassName(serviceClass)))
+	if existingServiceClass.Spec.ExternalName != serviceClass.Spec.ExternalName {
+		// instances keep referring to the class by its K8S name, which is
+		// derived from its external ID and does not change
+		klog.V(4).Info(pcb.Messagef("%s was renamed by the broker from %q", pretty.ServiceClassName(serviceClass), existingServiceClass.Spec.ExternalName))
+	}
 
 	// There was an existing service class -- project the update onto it and
 	// update it.
@@ -569,6 +635,11 @@ func (c *controller) reconcileServicePlanFromServiceBrokerCatalog(broker *v1beta
 	}
 
 	klog.V(5).Info(pcb.Messagef("Found existing %s; updating", pretty.ServicePlanName(servicePlan)))
+	if existingServicePlan.Spec.ExternalName != servicePlan.Spec.ExternalName {
+		// instances keep referring to the plan by its K8S name, which is
+		// derived from its external ID and does not change
+		klog.V(4).Info(pcb.Messagef("%s was renamed by the broker from %q", pretty.ServicePlanName(servicePlan), existingServicePlan.Spec.ExternalName))
+	}
 
 	// There was an existing service plan -- project the update onto it and
 	// update it.
@@ -619,10 +690,10 @@ func updateCommonStatusCondition(pcb *pretty.ContextBuilder, meta metav1.ObjectM
 
 	t := time.Now()
 
-	if len(commonStatus.Conditions) == 0 {
+	if !hasServiceBrokerCondition(commonStatus.Conditions, conditionType) {
 		klog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
 		newCondition.LastTransitionTime = metav1.NewTime(t)
-		commonStatus.Conditions = []v1beta1.ServiceBrokerCondition{newCondition}
+		commonStatus.Conditions = append(commonStatus.Conditions, newCondition)
 	} else {
 		for i, cond := range commonStatus.Conditions {
 			if cond.Type == conditionType {
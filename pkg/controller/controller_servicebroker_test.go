@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	clientgofake "k8s.io/client-go/kubernetes/fake"
 	clientgotesting "k8s.io/client-go/testing"
 )
 
@@ -47,6 +48,35 @@ func TestShouldReconcileServiceBroker(t *testing.T) {
 	}
 }
 
+// TestServiceBrokerUpdate verifies that a broker is not queued again for
+// updates of its status, so that a failing broker is only retried with its
+// backoff.
+func TestServiceBrokerUpdate(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+
+	oldBroker := getTestServiceBroker()
+	oldBroker.ResourceVersion = "1"
+	oldBroker.Generation = 1
+	newBroker := oldBroker.DeepCopy()
+	newBroker.ResourceVersion = "2"
+	newBroker.Status.Conditions = []v1beta1.ServiceBrokerCondition{{
+		Type:   v1beta1.ServiceBrokerConditionReady,
+		Status: v1beta1.ConditionFalse,
+		Reason: errorFetchingCatalogReason,
+	}}
+
+	testController.serviceBrokerUpdate(oldBroker, newBroker)
+	if testController.serviceBrokerQueue.Len() != 0 {
+		t.Fatal("expected the broker not to be queued for a status update")
+	}
+
+	newBroker.Generation = 2
+	testController.serviceBrokerUpdate(oldBroker, newBroker)
+	if testController.serviceBrokerQueue.Len() != 1 {
+		t.Fatal("expected the broker to be queued for a spec update")
+	}
+}
+
 func TestReconcileServiceBrokerUpdatesBrokerClient(t *testing.T) {
 	broker := getTestServiceBroker()
 	broker.Name = broker.Name + "not-predefined"
@@ -133,7 +163,7 @@ func TestReconcileServiceBrokerDelete(t *testing.T) {
 			testController.brokerClientManager = NewBrokerClientManager(func(_ *osb.ClientConfiguration) (osb.Client, error) {
 				updateBrokerClientCalled = true
 				return nil, nil
-			})
+			}, 0, 0)
 
 			fakeCatalogClient.AddReactor(getServiceBrokerReactor(broker))
 			fakeCatalogClient.AddReactor(listServiceClassesReactor([]v1beta1.ServiceClass{*testServiceClass}))
@@ -400,3 +430,66 @@ func TestReconcileServicePlanFromServiceBrokerCatalog(t *testing.T) {
 		})
 	}
 }
+
+// TestGetAuthCredentialsFromServiceBrokerSecretNamespace tests that the auth
+// secret of a namespaced broker is read from the namespace of the broker, or
+// from another namespace only when that namespace is allowed.
+func TestGetAuthCredentialsFromServiceBrokerSecretNamespace(t *testing.T) {
+	cases := []struct {
+		name              string
+		secretNamespace   string
+		allowedNamespaces []string
+		expectedUsername  string
+		expectedError     bool
+	}{
+		{
+			name:             "namespace of the broker",
+			expectedUsername: "broker-user",
+		},
+		{
+			name:              "allowed namespace",
+			secretNamespace:   "secrets",
+			allowedNamespaces: []string{"other", "secrets"},
+			expectedUsername:  "central-user",
+		},
+		{
+			name:            "namespace not allowed",
+			secretNamespace: "secrets",
+			expectedError:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			broker := getTestServiceBrokerWithAuth(getTestBrokerBasicAuthInfo())
+			broker.Spec.AuthInfo.Basic.SecretRef.Namespace = tc.secretNamespace
+			client := clientgofake.NewSimpleClientset(
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: broker.Namespace, Name: "auth-secret"},
+					Data:       map[string][]byte{"username": []byte("broker-user"), "password": []byte("password")},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "secrets", Name: "auth-secret"},
+					Data:       map[string][]byte{"username": []byte("central-user"), "password": []byte("password")},
+				},
+			)
+
+			authConfig, err := getAuthCredentialsFromServiceBroker(client, broker, tc.allowedNamespaces)
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				if len(client.Actions()) != 0 {
+					t.Fatalf("expected the secret not to be read, got actions %v", client.Actions())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expectedUsername, authConfig.BasicAuthConfig.Username; e != a {
+				t.Fatalf("unexpected username: expected %q, got %q", e, a)
+			}
+		})
+	}
+}
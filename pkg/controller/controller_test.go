@@ -19,6 +19,8 @@ package controller
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"runtime/debug"
 	"testing"
@@ -315,6 +317,7 @@ const largeTestCatalog = `
     {
       "id": "41726368-6f6e-4569-8172-63686f6e6569",
       "name": "Archonei",
+      "tags": ["ship"],
       "description": "The contents of my chamber pot are more able than Ser Harys.",
       "requires": [
         "SeaBitch"
@@ -346,6 +349,7 @@ const largeTestCatalog = `
     {
       "id": "41727261-7841-4272-a178-417272617841",
       "name": "Arrax",
+      "tags": ["dragon", "young"],
       "description": "Darkness will be your cloak, your shield, your mother's milk. Darkness will make you strong.",
       "requires": [
         "Woe"
@@ -382,6 +386,7 @@ const largeTestCatalog = `
     {
       "id": "42616c65-7269-4f6e-8261-6c6572696f6e",
       "name": "Balerion",
+      "tags": ["dragon", "black"],
       "description": "A reader lives a thousand lives before he dies. The man who never reads lives only one. The a mind needs books as a sword needs a whetstone, if it is to keep its edge.",
       "bindable": true,
       "plans": [
@@ -1665,6 +1670,44 @@ func TestConvertAndFilterCatalog(t *testing.T) {
 			plans:   []string{"Goldengrove", "Queensgate"},
 			catalog: largeTestCatalog,
 		},
+		{
+			name: "allowed tags",
+			restrictions: &v1beta1.CatalogRestrictions{
+				AllowedTags: []string{"dragon"},
+			},
+			classes: []string{"Arrax", "Balerion"},
+			plans:   []string{"Eastwatch-by-the-Sea", "OldOak", "Ironrath", "Queensgate"},
+			catalog: largeTestCatalog,
+		},
+		{
+			name: "denied tags",
+			restrictions: &v1beta1.CatalogRestrictions{
+				DeniedTags: []string{"young"},
+			},
+			classes: []string{"Archonei", "Balerion"},
+			plans:   []string{"Goldengrove", "Ironrath", "Queensgate"},
+			catalog: largeTestCatalog,
+		},
+		{
+			name: "allowed and denied tags",
+			restrictions: &v1beta1.CatalogRestrictions{
+				AllowedTags: []string{"dragon", "ship"},
+				DeniedTags:  []string{"black"},
+			},
+			classes: []string{"Archonei", "Arrax"},
+			plans:   []string{"Goldengrove", "Eastwatch-by-the-Sea", "OldOak"},
+			catalog: largeTestCatalog,
+		},
+		{
+			name: "tags and class restrictions",
+			restrictions: &v1beta1.CatalogRestrictions{
+				ServiceClass: []string{"spec.externalName notin (Arrax)"},
+				AllowedTags:  []string{"dragon"},
+			},
+			classes: []string{"Balerion"},
+			plans:   []string{"Ironrath", "Queensgate"},
+			catalog: largeTestCatalog,
+		},
 		{
 			name: "filter free plans",
 			restrictions: &v1beta1.CatalogRestrictions{
@@ -2229,75 +2272,6 @@ func TestIsClusterServiceBrokerReady(t *testing.T) {
 	}
 }
 
-func TestIsPlanBindable(t *testing.T) {
-	serviceClass := func(bindable bool) *v1beta1.ClusterServiceClass {
-		serviceClass := getTestClusterServiceClass()
-		serviceClass.Spec.Bindable = bindable
-		return serviceClass
-	}
-
-	servicePlan := func(bindable *bool) *v1beta1.ClusterServicePlan {
-		return &v1beta1.ClusterServicePlan{
-			Spec: v1beta1.ClusterServicePlanSpec{
-				CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
-					Bindable: bindable,
-				},
-			},
-		}
-	}
-
-	cases := []struct {
-		name         string
-		serviceClass bool
-		servicePlan  *bool
-		bindable     bool
-	}{
-		{
-			name:         "service true, plan not set",
-			serviceClass: true,
-			bindable:     true,
-		},
-		{
-			name:         "service true, plan false",
-			serviceClass: true,
-			servicePlan:  falsePtr(),
-			bindable:     false,
-		},
-		{
-			name:         "service true, plan true",
-			serviceClass: true,
-			servicePlan:  truePtr(),
-			bindable:     true,
-		},
-		{
-			name:         "service false, plan not set",
-			serviceClass: false,
-			bindable:     false,
-		},
-		{
-			name:         "service false, plan false",
-			serviceClass: false,
-			servicePlan:  falsePtr(),
-			bindable:     false,
-		},
-		{
-			name:         "service false, plan true",
-			serviceClass: false,
-			servicePlan:  truePtr(),
-			bindable:     true,
-		},
-	}
-
-	for _, tc := range cases {
-		sc := serviceClass(tc.serviceClass)
-		plan := servicePlan(tc.servicePlan)
-
-		if e, a := tc.bindable, isClusterServicePlanBindable(sc, plan); e != a {
-			t.Errorf("%v: unexpected result; expected %v, got %v", tc.name, e, a)
-		}
-	}
-}
-
 // newTestController creates a new test controller injected with fake clients
 // and returns:
 //
@@ -2342,13 +2316,23 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
 		brokerClFunc,
+		0,
+		0,
 		24*time.Hour,
 		osb.LatestAPIVersion().HeaderValue(),
+		"",
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
 		DefaultClusterIDConfigMapName,
 		DefaultClusterIDConfigMapNamespace,
+		SecretKeyConvention{},
+		ForceDeletionPolicy{},
+		QuotaExceededPolicy{},
+		nil,
+		RequestContextPolicy{},
+		OrphanMitigationPolicy{},
+		SecretUpdateStrategyInPlace,
 	)
 
 	if err != nil {
@@ -2844,6 +2828,36 @@ func assertServiceInstanceDashboardURL(t *testing.T, obj runtime.Object, dashboa
 	}
 }
 
+func assertServiceInstanceParametersChecksumAnnotation(t *testing.T, obj runtime.Object, checksum string) {
+	instance, ok := obj.(*v1beta1.ServiceInstance)
+	if !ok {
+		fatalf(t, "Couldn't convert object %+v into a *v1beta1.ServiceInstance", obj)
+	}
+	a, ok := instance.Annotations[ParametersChecksumAnnotation]
+	if checksum == "" && ok {
+		fatalf(t, "Unexpected %v annotation %q", ParametersChecksumAnnotation, a)
+	}
+	if checksum != a {
+		fatalf(t, "Unexpected %v annotation: expected %q, got %q", ParametersChecksumAnnotation, checksum, a)
+	}
+}
+
+func assertServiceInstanceExternalPropertiesClusterServiceClass(t *testing.T, obj runtime.Object, classExternalID, brokerName string) {
+	instance, ok := obj.(*v1beta1.ServiceInstance)
+	if !ok {
+		fatalf(t, "Couldn't convert object %+v into a *v1beta1.ServiceInstance", obj)
+	}
+	if instance.Status.ExternalProperties == nil {
+		fatalf(t, "ExternalProperties was nil")
+	}
+	if e, a := classExternalID, instance.Status.ExternalProperties.ClusterServiceClassExternalID; e != a {
+		fatalf(t, "Unexpected ClusterServiceClassExternalID: expected %q, got %q", e, a)
+	}
+	if e, a := brokerName, instance.Status.ExternalProperties.ClusterServiceBrokerName; e != a {
+		fatalf(t, "Unexpected ClusterServiceBrokerName: expected %q, got %q", e, a)
+	}
+}
+
 func assertServiceInstanceDeprovisionStatus(t *testing.T, obj runtime.Object, deprovisionStatus v1beta1.ServiceInstanceDeprovisionStatus) {
 	instance, ok := obj.(*v1beta1.ServiceInstance)
 	if !ok {
@@ -3907,12 +3921,30 @@ func assertBind(t *testing.T, action fakeosb.Action, request *osb.BindRequest) {
 	request.OriginatingIdentity = nil
 	actualRequest.OriginatingIdentity = nil
 
+	// The operation key is generated when the bind operation starts, so
+	// only check that it is there when the request context has one.
+	actualContext := actualRequest.Context
+	if key, ok := actualContext[operationKeyContextKey]; ok {
+		if key == "" {
+			fatalf(t, "unexpected empty %v in bind request context", operationKeyContextKey)
+		}
+		if _, ok := request.Context[operationKeyContextKey]; !ok {
+			actualRequest.Context = make(map[string]interface{})
+			for k, v := range actualContext {
+				if k != operationKeyContextKey {
+					actualRequest.Context[k] = v
+				}
+			}
+		}
+	}
+
 	if e, a := request, action.Request; !reflect.DeepEqual(e, a) {
 		fatalf(t, "unexpected diff in bind request: %v\nexpected %+v\ngot      %+v", diff.ObjectReflectDiff(e, a), e, a)
 	}
 
 	request.OriginatingIdentity = expectedOriginatingIdentity
 	actualRequest.OriginatingIdentity = actualOriginatingIdentity
+	actualRequest.Context = actualContext
 
 	assertOriginatingIdentity(t, expectedOriginatingIdentity, actualOriginatingIdentity)
 }
@@ -3927,6 +3959,35 @@ func assertUnbind(t *testing.T, action fakeosb.Action, request *osb.UnbindReques
 	}
 }
 
+// serveGetInstance makes the client of the test ClusterServiceBroker fetch
+// instances from a test server that answers with the given status and body.
+// It returns the paths requested from the server and a func that closes it.
+func serveGetInstance(t *testing.T, testController *controller, fakeClient osb.Client, status int, body string) (*[]string, func()) {
+	paths := &[]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+
+	clientConfig := osb.DefaultClientConfiguration()
+	clientConfig.URL = server.URL
+	clientConfig.APIVersion = osb.LatestAPIVersion()
+	clientConfig.EnableAlphaFeatures = true
+	testController.brokerClientManager.clients[NewClusterServiceBrokerKey(getTestClusterServiceBroker().Name)] = clientWithConfig{
+		OSBClient:    fakeClient,
+		clientConfig: clientConfig,
+		httpClient:   server.Client(),
+	}
+	return paths, server.Close
+}
+
+func assertGetInstancePaths(t *testing.T, paths []string, instanceID string) {
+	if e, a := []string{"/v2/service_instances/" + instanceID}, paths; !reflect.DeepEqual(e, a) {
+		fatalf(t, "unexpected GET instance requests: %v", expectedGot(e, a))
+	}
+}
+
 func assertGetBinding(t *testing.T, action fakeosb.Action, request *osb.GetBindingRequest) {
 	if e, a := fakeosb.GetBinding, action.Type; e != a {
 		fatalf(t, "unexpected action type; expected %v, got %v", e, a)
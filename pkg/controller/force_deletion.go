@@ -0,0 +1,85 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// ForceDeletionPolicy describes when the controller gives up deprovisioning
+// an instance whose deletion was requested, for example because its broker
+// is permanently gone, and removes the finalizer of the instance so that it
+// can be deleted. The instance may then still exist at the broker.
+type ForceDeletionPolicy struct {
+	// FailedDeprovisionAttempts is the number of deprovision requests that
+	// must have failed before the finalizer of an instance is removed. Zero
+	// disables force deletion.
+	FailedDeprovisionAttempts int
+	// Window is the time that must have elapsed since the deletion of an
+	// instance was requested before its finalizer is removed.
+	Window time.Duration
+}
+
+// Validate checks that the policy has no negative values.
+func (p ForceDeletionPolicy) Validate() error {
+	if p.FailedDeprovisionAttempts < 0 {
+		return fmt.Errorf("invalid number of failed deprovision attempts %d, must not be negative", p.FailedDeprovisionAttempts)
+	}
+	if p.Window < 0 {
+		return fmt.Errorf("invalid force deletion window %v, must not be negative", p.Window)
+	}
+	return nil
+}
+
+// enabled returns whether instances may be force deleted.
+func (p ForceDeletionPolicy) enabled() bool {
+	return p.FailedDeprovisionAttempts > 0
+}
+
+// shouldForceDelete returns whether the finalizer of an instance should be
+// removed without deprovisioning it. When enough deprovision attempts have
+// failed but the window has not elapsed yet, it also returns how long is
+// left until it does.
+func (p ForceDeletionPolicy) shouldForceDelete(instance *v1beta1.ServiceInstance, now time.Time) (bool, time.Duration) {
+	if !p.enabled() || instance.DeletionTimestamp == nil {
+		return false, 0
+	}
+	switch instance.Status.DeprovisionStatus {
+	case v1beta1.ServiceInstanceDeprovisionStatusRequired, v1beta1.ServiceInstanceDeprovisionStatusFailed:
+	default:
+		return false, 0
+	}
+	if instance.Status.FailedDeprovisionAttempts < int64(p.FailedDeprovisionAttempts) {
+		return false, 0
+	}
+	if remaining := instance.DeletionTimestamp.Add(p.Window).Sub(now); remaining > 0 {
+		return false, remaining
+	}
+	return true, 0
+}
+
+// recordFailedDeprovisionAttempt counts a failed deprovision request for an
+// instance whose deletion was requested. Requests made to mitigate orphans
+// are not counted.
+func recordFailedDeprovisionAttempt(instance *v1beta1.ServiceInstance) {
+	if instance.DeletionTimestamp != nil && !instance.Status.OrphanMitigationInProgress {
+		instance.Status.FailedDeprovisionAttempts++
+	}
+}
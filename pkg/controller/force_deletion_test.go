@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestForceDeletionPolicyShouldForceDelete(t *testing.T) {
+	now := time.Now()
+	policy := ForceDeletionPolicy{FailedDeprovisionAttempts: 2, Window: time.Hour}
+
+	cases := []struct {
+		name              string
+		policy            ForceDeletionPolicy
+		deletedAt         *time.Time
+		deprovisionStatus v1beta1.ServiceInstanceDeprovisionStatus
+		attempts          int64
+		expectedForce     bool
+		expectedRemaining time.Duration
+	}{
+		{
+			name:              "disabled",
+			policy:            ForceDeletionPolicy{Window: time.Hour},
+			deletedAt:         timePtr(now.Add(-2 * time.Hour)),
+			deprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusFailed,
+			attempts:          5,
+		},
+		{
+			name:              "not deleted",
+			policy:            policy,
+			deprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusRequired,
+			attempts:          5,
+		},
+		{
+			name:              "deprovision not required",
+			policy:            policy,
+			deletedAt:         timePtr(now.Add(-2 * time.Hour)),
+			deprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusNotRequired,
+			attempts:          5,
+		},
+		{
+			name:              "not enough attempts",
+			policy:            policy,
+			deletedAt:         timePtr(now.Add(-2 * time.Hour)),
+			deprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusRequired,
+			attempts:          1,
+		},
+		{
+			name:              "window not elapsed",
+			policy:            policy,
+			deletedAt:         timePtr(now.Add(-15 * time.Minute)),
+			deprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusFailed,
+			attempts:          2,
+			expectedRemaining: 45 * time.Minute,
+		},
+		{
+			name:              "retrying deprovision",
+			policy:            policy,
+			deletedAt:         timePtr(now.Add(-2 * time.Hour)),
+			deprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusRequired,
+			attempts:          2,
+			expectedForce:     true,
+		},
+		{
+			name:              "failed deprovision",
+			policy:            policy,
+			deletedAt:         timePtr(now.Add(-time.Hour)),
+			deprovisionStatus: v1beta1.ServiceInstanceDeprovisionStatusFailed,
+			attempts:          3,
+			expectedForce:     true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &v1beta1.ServiceInstance{}
+			if tc.deletedAt != nil {
+				instance.DeletionTimestamp = &metav1.Time{Time: *tc.deletedAt}
+			}
+			instance.Status.DeprovisionStatus = tc.deprovisionStatus
+			instance.Status.FailedDeprovisionAttempts = tc.attempts
+
+			force, remaining := tc.policy.shouldForceDelete(instance, now)
+			if force != tc.expectedForce {
+				t.Fatalf("unexpected force deletion: expected %v, got %v", tc.expectedForce, force)
+			}
+			if remaining != tc.expectedRemaining {
+				t.Fatalf("unexpected remaining time: expected %v, got %v", tc.expectedRemaining, remaining)
+			}
+		})
+	}
+}
+
+func TestForceDeletionPolicyValidate(t *testing.T) {
+	valid := []ForceDeletionPolicy{
+		{},
+		{FailedDeprovisionAttempts: 3, Window: time.Hour},
+	}
+	for _, p := range valid {
+		if err := p.Validate(); err != nil {
+			t.Errorf("unexpected error for %+v: %v", p, err)
+		}
+	}
+
+	invalid := []ForceDeletionPolicy{
+		{FailedDeprovisionAttempts: -1},
+		{FailedDeprovisionAttempts: 3, Window: -time.Hour},
+	}
+	for _, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", p)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
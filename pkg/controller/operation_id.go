@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/pretty"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// operationIDs hands out the IDs that correlate the log lines of a single
+// operation on an instance or binding, from the reconcile that starts it
+// through every poll until it finishes. The IDs are only kept in memory, so
+// an operation that is still in progress when the controller restarts is
+// logged under a new ID afterwards. The zero value is ready to use.
+type operationIDs struct {
+	mutex sync.Mutex
+	ids   map[types.UID]operationIDEntry // Key is K8s metadata UID
+}
+
+type operationIDEntry struct {
+	startTime metav1.Time
+	id        string
+}
+
+// get returns the ID of the operation that started at startTime on the
+// resource with the given UID, generating one the first time the operation
+// is seen. A nil startTime means there is no operation in progress; the ID
+// of any previous operation is forgotten and an empty ID is returned.
+//
+// The start times are compared to the second, since the start time recorded
+// by the controller is truncated to the second once it is stored by the API
+// server.
+func (o *operationIDs) get(uid types.UID, startTime *metav1.Time) string {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if startTime == nil {
+		delete(o.ids, uid)
+		return ""
+	}
+	start := startTime.Rfc3339Copy()
+	if entry, ok := o.ids[uid]; ok && entry.startTime.Equal(&start) {
+		return entry.id
+	}
+	if o.ids == nil {
+		o.ids = make(map[types.UID]operationIDEntry)
+	}
+	id := string(uuid.NewUUID())
+	o.ids[uid] = operationIDEntry{startTime: start, id: id}
+	return id
+}
+
+// forget drops the ID held for the resource with the given UID.
+func (o *operationIDs) forget(uid types.UID) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	delete(o.ids, uid)
+}
+
+// newInstanceContextBuilder returns a pretty.ContextBuilder for the instance
+// that includes the ID of the operation in progress on it, if any.
+func (c *controller) newInstanceContextBuilder(instance *v1beta1.ServiceInstance) *pretty.ContextBuilder {
+	return pretty.NewInstanceContextBuilder(instance).
+		SetOperationID(c.operationIDs.get(instance.UID, instance.Status.OperationStartTime))
+}
+
+// newBindingContextBuilder returns a pretty.ContextBuilder for the binding
+// that includes the ID of the operation in progress on it, if any.
+func (c *controller) newBindingContextBuilder(binding *v1beta1.ServiceBinding) *pretty.ContextBuilder {
+	return pretty.NewBindingContextBuilder(binding).
+		SetOperationID(c.operationIDs.get(binding.UID, binding.Status.OperationStartTime))
+}
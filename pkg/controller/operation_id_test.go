@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestOperationIDs(t *testing.T) {
+	var ids operationIDs
+	uid := types.UID("uid")
+	start := metav1.NewTime(time.Unix(100, 0))
+
+	if id := ids.get(uid, nil); id != "" {
+		t.Fatalf("expected no ID without an operation in progress, got %q", id)
+	}
+
+	first := ids.get(uid, &start)
+	if first == "" {
+		t.Fatal("expected an ID for the operation in progress")
+	}
+	if id := ids.get(uid, &start); id != first {
+		t.Fatalf("expected the same ID for the same operation; %s", expectedGot(first, id))
+	}
+	if id := ids.get(types.UID("other"), &start); id == first {
+		t.Fatal("expected a different ID for an operation on another resource")
+	}
+
+	next := metav1.NewTime(time.Unix(200, 0))
+	second := ids.get(uid, &next)
+	if second == first {
+		t.Fatal("expected a new ID for a new operation")
+	}
+
+	ids.forget(uid)
+	if id := ids.get(uid, &next); id == second {
+		t.Fatal("expected a new ID after the resource was forgotten")
+	}
+}
+
+// TestOperationIDsSerializedStartTime tests that an operation keeps its ID
+// once its start time, recorded by the controller with nanoseconds, has been
+// stored by the API server, which truncates it to the second.
+func TestOperationIDsSerializedStartTime(t *testing.T) {
+	var ids operationIDs
+	uid := types.UID("uid")
+	start := metav1.NewTime(time.Unix(100, 123456789))
+
+	first := ids.get(uid, &start)
+
+	data, err := json.Marshal(&start)
+	if err != nil {
+		t.Fatalf("unexpected error serializing the start time: %v", err)
+	}
+	var stored metav1.Time
+	if err := json.Unmarshal(data, &stored); err != nil {
+		t.Fatalf("unexpected error deserializing the start time: %v", err)
+	}
+	if stored.Equal(&start) {
+		t.Fatal("expected the serialized start time to be truncated to the second")
+	}
+
+	if id := ids.get(uid, &stored); id != first {
+		t.Fatalf("expected the same ID for the stored start time; %s", expectedGot(first, id))
+	}
+	if id := ids.get(uid, &start); id != first {
+		t.Fatalf("expected the same ID for the recorded start time; %s", expectedGot(first, id))
+	}
+}
+
+func TestNewInstanceContextBuilderIncludesOperationID(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstance()
+	if msg := testController.newInstanceContextBuilder(instance).Message("msg"); strings.Contains(msg, "(operation ") {
+		t.Fatalf("expected no operation ID without an operation in progress, got %q", msg)
+	}
+
+	start := metav1.Now()
+	instance.Status.OperationStartTime = &start
+	first := testController.newInstanceContextBuilder(instance).Message("msg")
+	if !strings.Contains(first, "(operation ") {
+		t.Fatalf("expected an operation ID, got %q", first)
+	}
+	if second := testController.newInstanceContextBuilder(instance).Message("msg"); second != first {
+		t.Fatalf("expected the operation ID to be stable across reconciles; %s", expectedGot(first, second))
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// OrphanMitigationPolicyAnnotation is the annotation of a ServiceInstance that
+// overrides the orphan mitigation mode of the controller for the instance. Its
+// value is one of the OrphanMitigationMode values.
+const OrphanMitigationPolicyAnnotation = "servicecatalog.k8s.io/orphan-mitigation-policy"
+
+// OrphanMitigationMode tells what the controller does with an instance whose
+// provision failed in a way that may have left an orphaned resource on the
+// broker.
+type OrphanMitigationMode string
+
+const (
+	// OrphanMitigationAutomatic deprovisions the instance right away.
+	OrphanMitigationAutomatic OrphanMitigationMode = "Automatic"
+	// OrphanMitigationDelayed deprovisions the instance once the delay of
+	// the policy has elapsed, leaving time to inspect the failed instance.
+	OrphanMitigationDelayed OrphanMitigationMode = "Delayed"
+	// OrphanMitigationManual never deprovisions the instance. It is
+	// deprovisioned when it is deleted.
+	OrphanMitigationManual OrphanMitigationMode = "Manual"
+)
+
+// ParseOrphanMitigationMode parses an orphan mitigation mode, given by a flag
+// or by the OrphanMitigationPolicyAnnotation of an instance.
+func ParseOrphanMitigationMode(value string) (OrphanMitigationMode, error) {
+	switch mode := OrphanMitigationMode(value); mode {
+	case OrphanMitigationAutomatic, OrphanMitigationDelayed, OrphanMitigationManual:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid orphan mitigation policy %q, must be one of %s, %s or %s",
+		value, OrphanMitigationAutomatic, OrphanMitigationDelayed, OrphanMitigationManual)
+}
+
+// OrphanMitigationPolicy describes how the controller mitigates the orphaned
+// resources that failed provisions may leave on brokers.
+type OrphanMitigationPolicy struct {
+	// Mode is the mode of the instances that do not set their own. An
+	// empty mode is Automatic.
+	Mode OrphanMitigationMode
+	// Delay is the time waited after a failed provision before
+	// deprovisioning an instance whose mode is Delayed.
+	Delay time.Duration
+}
+
+// Validate checks that the mode is known and that the delay is not negative.
+func (p OrphanMitigationPolicy) Validate() error {
+	if p.Mode != "" {
+		if _, err := ParseOrphanMitigationMode(string(p.Mode)); err != nil {
+			return err
+		}
+	}
+	if p.Delay < 0 {
+		return fmt.Errorf("invalid orphan mitigation delay %v, must not be negative", p.Delay)
+	}
+	return nil
+}
+
+// modeFor returns the orphan mitigation mode of an instance. Instances that
+// disable orphan mitigation are Manual, and the annotation overrides the mode
+// of the policy. The API server rejects invalid annotations, so an invalid
+// one can only be left on an instance created before it was validated; the
+// mode of the policy is then used instead, and the error tells why the
+// annotation was ignored.
+func (p OrphanMitigationPolicy) modeFor(instance *v1beta1.ServiceInstance) (OrphanMitigationMode, error) {
+	mode := p.Mode
+	if mode == "" {
+		mode = OrphanMitigationAutomatic
+	}
+	if instance.Spec.DisableOrphanMitigation {
+		return OrphanMitigationManual, nil
+	}
+	value, ok := instance.Annotations[OrphanMitigationPolicyAnnotation]
+	if !ok {
+		return mode, nil
+	}
+	annotated, err := ParseOrphanMitigationMode(value)
+	if err != nil {
+		return mode, fmt.Errorf("ignoring the %s annotation: %v", OrphanMitigationPolicyAnnotation, err)
+	}
+	return annotated, nil
+}
+
+// remainingDelay returns how long is left before the orphan mitigation of an
+// instance may start. It is zero unless the mode of the instance is Delayed
+// and the delay has not elapsed since the recorded start of orphan
+// mitigation. An instance whose deletion was requested is not delayed.
+func (p OrphanMitigationPolicy) remainingDelay(instance *v1beta1.ServiceInstance, now time.Time) time.Duration {
+	if !instance.Status.OrphanMitigationInProgress || instance.DeletionTimestamp != nil {
+		return 0
+	}
+	if instance.Status.OrphanMitigationStartTime == nil {
+		return 0
+	}
+	if mode, _ := p.modeFor(instance); mode != OrphanMitigationDelayed {
+		return 0
+	}
+	if remaining := instance.Status.OrphanMitigationStartTime.Add(p.Delay).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOrphanMitigationPolicyValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy OrphanMitigationPolicy
+		valid  bool
+	}{
+		{name: "default", valid: true},
+		{name: "delayed", policy: OrphanMitigationPolicy{Mode: OrphanMitigationDelayed, Delay: time.Hour}, valid: true},
+		{name: "unknown mode", policy: OrphanMitigationPolicy{Mode: "Never"}},
+		{name: "negative delay", policy: OrphanMitigationPolicy{Delay: -time.Hour}},
+	}
+
+	for _, tc := range cases {
+		err := tc.policy.Validate()
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestOrphanMitigationPolicyModeFor(t *testing.T) {
+	cases := []struct {
+		name       string
+		policy     OrphanMitigationPolicy
+		annotation string
+		disabled   bool
+		expected   OrphanMitigationMode
+		ignored    bool
+	}{
+		{name: "default", expected: OrphanMitigationAutomatic},
+		{name: "policy", policy: OrphanMitigationPolicy{Mode: OrphanMitigationManual}, expected: OrphanMitigationManual},
+		{name: "annotation", policy: OrphanMitigationPolicy{Mode: OrphanMitigationManual}, annotation: "Delayed", expected: OrphanMitigationDelayed},
+		{name: "invalid annotation", annotation: "Never", expected: OrphanMitigationAutomatic, ignored: true},
+		{name: "disabled", annotation: "Automatic", disabled: true, expected: OrphanMitigationManual},
+	}
+
+	for _, tc := range cases {
+		instance := getTestServiceInstance()
+		if tc.annotation != "" {
+			instance.Annotations = map[string]string{OrphanMitigationPolicyAnnotation: tc.annotation}
+		}
+		instance.Spec.DisableOrphanMitigation = tc.disabled
+		mode, err := tc.policy.modeFor(instance)
+		if e, a := tc.expected, mode; e != a {
+			t.Errorf("%s: unexpected mode: %s", tc.name, expectedGot(e, a))
+		}
+		if e, a := tc.ignored, err != nil; e != a {
+			t.Errorf("%s: unexpected ignored annotation: %s (error %v)", tc.name, expectedGot(e, a), err)
+		}
+	}
+}
+
+func TestOrphanMitigationPolicyRemainingDelay(t *testing.T) {
+	now := time.Now()
+	policy := OrphanMitigationPolicy{Mode: OrphanMitigationDelayed, Delay: time.Hour}
+
+	instance := getTestServiceInstance()
+	instance.Status.OrphanMitigationInProgress = true
+	start := metav1.NewTime(now.Add(-20 * time.Minute))
+	instance.Status.OrphanMitigationStartTime = &start
+	// The condition may transition again after orphan mitigation started,
+	// for example when the reason of the failure changes.
+	instance.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+		Type:               v1beta1.ServiceInstanceConditionOrphanMitigation,
+		Status:             v1beta1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(now),
+	}}
+	if e, a := 40*time.Minute, policy.remainingDelay(instance, now); e != a {
+		t.Fatalf("unexpected remaining delay: %s", expectedGot(e, a))
+	}
+
+	if a := policy.remainingDelay(instance, now.Add(time.Hour)); a != 0 {
+		t.Fatalf("expected no remaining delay once it has elapsed, got %v", a)
+	}
+
+	automatic := OrphanMitigationPolicy{Delay: time.Hour}
+	if a := automatic.remainingDelay(instance, now); a != 0 {
+		t.Fatalf("expected no remaining delay for an automatic policy, got %v", a)
+	}
+
+	unrecorded := instance.DeepCopy()
+	unrecorded.Status.OrphanMitigationStartTime = nil
+	if a := policy.remainingDelay(unrecorded, now); a != 0 {
+		t.Fatalf("expected no remaining delay when the start of orphan mitigation was not recorded, got %v", a)
+	}
+
+	deleted := instance.DeepCopy()
+	deleted.DeletionTimestamp = &metav1.Time{Time: now}
+	if a := policy.remainingDelay(deleted, now); a != 0 {
+		t.Fatalf("expected no remaining delay for a deleted instance, got %v", a)
+	}
+}
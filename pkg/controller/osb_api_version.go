@@ -0,0 +1,147 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	"k8s.io/klog"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+// supportedOSBAPIVersions are the versions of the Open Service Broker API the
+// controller can use to talk to brokers, from the newest to the oldest.
+var supportedOSBAPIVersions = []osb.APIVersion{
+	osb.Version2_13(),
+	osb.Version2_12(),
+	osb.Version2_11(),
+}
+
+// osbAPIVersionsFrom returns the supported versions of the OSB API that are
+// not newer than the given version, from the newest to the oldest. All the
+// supported versions are returned when the given version is not one of them.
+func osbAPIVersionsFrom(preferred string) []osb.APIVersion {
+	for i, version := range supportedOSBAPIVersions {
+		if version.HeaderValue() == preferred {
+			return supportedOSBAPIVersions[i:]
+		}
+	}
+	return supportedOSBAPIVersions
+}
+
+// osbAPIVersionForBroker returns the version of the OSB API to use for the
+// requests made to a broker: the version negotiated on the last relist of
+// the broker, or the preferred version of the controller if none was.
+func (c *controller) osbAPIVersionForBroker(status *v1beta1.CommonServiceBrokerStatus) osb.APIVersion {
+	versions := osbAPIVersionsFrom(c.OSBAPIPreferredVersion)
+	for _, version := range versions {
+		if version.HeaderValue() == status.OSBAPIVersion {
+			return version
+		}
+	}
+	return versions[0]
+}
+
+// isUnsupportedOSBAPIVersionError returns whether the broker rejected a request
+// because it does not support the version of the OSB API sent in the
+// X-Broker-API-Version header, which brokers report with a 412 Precondition
+// Failed response.
+func isUnsupportedOSBAPIVersionError(err error) bool {
+	httpErr, ok := osb.IsHTTPError(err)
+	return ok && httpErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// unsupportedOSBAPIVersionError is returned when a broker rejects all the
+// versions of the OSB API the controller tried.
+type unsupportedOSBAPIVersionError struct {
+	versions []osb.APIVersion
+}
+
+func (e *unsupportedOSBAPIVersionError) Error() string {
+	labels := make([]string, 0, len(e.versions))
+	for _, version := range e.versions {
+		labels = append(labels, version.HeaderValue())
+	}
+	return fmt.Sprintf("the broker does not support any of the versions of the Open Service Broker API supported by the controller (%s); the broker may be too old", strings.Join(labels, ", "))
+}
+
+// getCatalogNegotiatingOSBAPIVersion gets the catalog of a broker with its
+// client, which uses the version of the OSB API negotiated on the last relist
+// of the broker. When the broker rejects that version, the other supported
+// versions are tried in turn, from the newest, on clients of their own, so
+// that the requests made meanwhile for the instances and bindings of the
+// broker keep using the client of the broker. The client of the broker is
+// updated once the broker accepts a version, which is returned along with the
+// catalog.
+//
+// Each request honors the rate limit of the broker: when one has to be
+// delayed, delayBrokerCall requeues the broker and a nil catalog and error
+// are returned. The next negotiation then resumes at the version it was
+// delayed at, rather than trying the versions the broker rejected again.
+func (c *controller) getCatalogNegotiatingOSBAPIVersion(brokerKey BrokerKey, brokerClient osb.Client, status *v1beta1.CommonServiceBrokerStatus, delayBrokerCall func(osb.Client) bool) (*osb.CatalogResponse, osb.APIVersion, error) {
+	current := c.osbAPIVersionForBroker(status)
+	versions := []osb.APIVersion{current}
+	for _, version := range osbAPIVersionsFrom(c.OSBAPIPreferredVersion) {
+		if version != current {
+			versions = append(versions, version)
+		}
+	}
+
+	start := 0
+	if delayedAt, found := c.brokerClientManager.takeDelayedOSBAPIVersionNegotiation(brokerKey); found {
+		for i, version := range versions {
+			if version == delayedAt {
+				start = i
+			}
+		}
+	}
+
+	for _, version := range versions[start:] {
+		client := brokerClient
+		if version != current {
+			var err error
+			client, err = c.brokerClientManager.newBrokerClientWithAPIVersion(brokerKey, version)
+			if err != nil {
+				return nil, version, err
+			}
+		}
+		if delayBrokerCall(client) {
+			c.brokerClientManager.recordDelayedOSBAPIVersionNegotiation(brokerKey, version)
+			return nil, version, nil
+		}
+
+		catalog, err := client.GetCatalog()
+		if isUnsupportedOSBAPIVersionError(err) {
+			klog.V(4).Infof("Broker %q does not support version %s of the OSB API", brokerKey.String(), version.HeaderValue())
+			continue
+		}
+		if err != nil {
+			return nil, version, err
+		}
+		if version != current {
+			if _, err := c.brokerClientManager.UpdateBrokerClientAPIVersion(brokerKey, version); err != nil {
+				return nil, version, err
+			}
+		}
+		return catalog, version, nil
+	}
+	return nil, versions[len(versions)-1], &unsupportedOSBAPIVersionError{versions: versions}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+func TestOSBAPIVersionForBroker(t *testing.T) {
+	cases := []struct {
+		name      string
+		preferred string
+		status    string
+		expected  osb.APIVersion
+	}{
+		{
+			name:      "no negotiated version",
+			preferred: "2.13",
+			expected:  osb.Version2_13(),
+		},
+		{
+			name:      "negotiated version",
+			preferred: "2.13",
+			status:    "2.11",
+			expected:  osb.Version2_11(),
+		},
+		{
+			name:      "negotiated version newer than the preferred one",
+			preferred: "2.12",
+			status:    "2.13",
+			expected:  osb.Version2_12(),
+		},
+		{
+			name:      "unknown negotiated version",
+			preferred: "2.12",
+			status:    "1.0",
+			expected:  osb.Version2_12(),
+		},
+		{
+			name:      "unknown preferred version",
+			preferred: "3.0",
+			expected:  osb.LatestAPIVersion(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &controller{OSBAPIPreferredVersion: tc.preferred}
+			status := &v1beta1.CommonServiceBrokerStatus{OSBAPIVersion: tc.status}
+			if e, a := tc.expected, c.osbAPIVersionForBroker(status); e != a {
+				t.Fatalf("unexpected version: expected %v, got %v", e.HeaderValue(), a.HeaderValue())
+			}
+		})
+	}
+}
+
+func TestIsUnsupportedOSBAPIVersionError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "precondition failed",
+			err:      osb.HTTPStatusCodeError{StatusCode: http.StatusPreconditionFailed},
+			expected: true,
+		},
+		{
+			name: "other http error",
+			err:  osb.HTTPStatusCodeError{StatusCode: http.StatusInternalServerError},
+		},
+		{
+			name: "other error",
+			err:  errors.New("oops"),
+		},
+		{
+			name: "no error",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if e, a := tc.expected, isUnsupportedOSBAPIVersionError(tc.err); e != a {
+				t.Fatalf("unexpected result: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
@@ -36,12 +36,14 @@ import (
 // The second return value is a map of parameters with secret values redacted,
 // replaced with "<redacted>".
 // The third return value is any error that caused the function to fail.
-func buildParameters(kubeClient kubernetes.Interface, namespace string, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension) (map[string]interface{}, map[string]interface{}, error) {
+// instance is the ServiceInstance that InstanceOutputRef sources are resolved
+// against; it is nil when building the parameters of an instance.
+func buildParameters(kubeClient kubernetes.Interface, namespace string, instance *v1beta1.ServiceInstance, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension) (map[string]interface{}, map[string]interface{}, error) {
 	params := make(map[string]interface{})
 	paramsWithSecretsRedacted := make(map[string]interface{})
 	if parametersFrom != nil {
 		for _, p := range parametersFrom {
-			fps, err := fetchParametersFromSource(kubeClient, namespace, &p)
+			fps, err := fetchParametersFromSource(kubeClient, namespace, instance, &p)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -50,7 +52,13 @@ func buildParameters(kubeClient kubernetes.Interface, namespace string, paramete
 					return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
 				}
 				params[k] = v
-				paramsWithSecretsRedacted[k] = "<redacted>"
+				if p.InstanceOutputRef != nil {
+					// Instance outputs are already visible in the
+					// instance status, so there is nothing to redact.
+					paramsWithSecretsRedacted[k] = v
+				} else {
+					paramsWithSecretsRedacted[k] = "<redacted>"
+				}
 			}
 		}
 	}
@@ -80,7 +88,7 @@ func buildParameters(kubeClient kubernetes.Interface, namespace string, paramete
 
 // fetchParametersFromSource fetches data from a specified external source and
 // represents it in the parameters map format
-func fetchParametersFromSource(kubeClient kubernetes.Interface, namespace string, parametersFrom *v1beta1.ParametersFromSource) (map[string]interface{}, error) {
+func fetchParametersFromSource(kubeClient kubernetes.Interface, namespace string, instance *v1beta1.ServiceInstance, parametersFrom *v1beta1.ParametersFromSource) (map[string]interface{}, error) {
 	var params map[string]interface{}
 	if parametersFrom.SecretKeyRef != nil {
 		data, err := fetchSecretKeyValue(kubeClient, namespace, parametersFrom.SecretKeyRef)
@@ -94,9 +102,81 @@ func fetchParametersFromSource(kubeClient kubernetes.Interface, namespace string
 		params = p
 
 	}
+	if parametersFrom.InstanceOutputRef != nil {
+		if instance == nil {
+			return nil, fmt.Errorf("instance output %q can only be referenced from a binding", parametersFrom.InstanceOutputRef.Key)
+		}
+		key := parametersFrom.InstanceOutputRef.Key
+		value, ok := instanceOutputs(instance)[key]
+		if !ok {
+			return nil, fmt.Errorf("instance output %q has not been recorded", key)
+		}
+		params = map[string]interface{}{key: value}
+	}
 	return params, nil
 }
 
+// parameterSources returns a description of where each top-level parameter
+// built by buildParameters comes from, keyed by parameter name: a secret key,
+// an instance output or the plain parameters.
+func parameterSources(kubeClient kubernetes.Interface, namespace string, instance *v1beta1.ServiceInstance, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension) (map[string]string, error) {
+	sources := make(map[string]string)
+	for _, p := range parametersFrom {
+		fps, err := fetchParametersFromSource(kubeClient, namespace, instance, &p)
+		if err != nil {
+			return nil, err
+		}
+		var source string
+		switch {
+		case p.SecretKeyRef != nil:
+			source = fmt.Sprintf("secret %q key %q", p.SecretKeyRef.Name, p.SecretKeyRef.Key)
+		case p.InstanceOutputRef != nil:
+			source = fmt.Sprintf("instance output %q", p.InstanceOutputRef.Key)
+		}
+		for k := range fps {
+			sources[k] = source
+		}
+	}
+	if parameters != nil {
+		pp, err := UnmarshalRawParameters(parameters.Raw)
+		if err != nil {
+			return nil, err
+		}
+		for k := range pp {
+			sources[k] = "parameters"
+		}
+	}
+	return sources, nil
+}
+
+// instanceOutputs returns the outputs currently recorded in the status of
+// the given instance, keyed by the name used to reference them from an
+// InstanceOutputRef.
+func instanceOutputs(instance *v1beta1.ServiceInstance) map[string]interface{} {
+	outputs := make(map[string]interface{})
+	if instance.Status.DashboardURL != nil {
+		outputs["dashboardURL"] = *instance.Status.DashboardURL
+	}
+	return outputs
+}
+
+// missingInstanceOutputs returns the keys of the instance outputs referenced
+// by parametersFrom that have not yet been recorded in the status of the
+// given instance.
+func missingInstanceOutputs(instance *v1beta1.ServiceInstance, parametersFrom []v1beta1.ParametersFromSource) []string {
+	outputs := instanceOutputs(instance)
+	var missing []string
+	for _, p := range parametersFrom {
+		if p.InstanceOutputRef == nil {
+			continue
+		}
+		if _, ok := outputs[p.InstanceOutputRef.Key]; !ok {
+			missing = append(missing, p.InstanceOutputRef.Key)
+		}
+	}
+	return missing
+}
+
 // UnmarshalRawParameters produces a map structure from a given raw YAML/JSON input
 func UnmarshalRawParameters(in []byte) (map[string]interface{}, error) {
 	parameters := make(map[string]interface{})
@@ -155,8 +235,8 @@ func generateChecksumOfParameters(params map[string]interface{}) (string, error)
 // 2 - a checksum for the map of parameters. This checksum is used to determine if parameters have changed.
 // 3 - the map of parameters marshaled into JSON as a RawExtension
 // 4 - any error that caused the function to fail.
-func prepareInProgressPropertyParameters(kubeClient kubernetes.Interface, namespace string, specParameters *runtime.RawExtension, specParametersFrom []v1beta1.ParametersFromSource) (map[string]interface{}, string, *runtime.RawExtension, error) {
-	parameters, parametersWithSecretsRedacted, err := buildParameters(kubeClient, namespace, specParametersFrom, specParameters)
+func prepareInProgressPropertyParameters(kubeClient kubernetes.Interface, namespace string, instance *v1beta1.ServiceInstance, specParameters *runtime.RawExtension, specParametersFrom []v1beta1.ParametersFromSource) (map[string]interface{}, string, *runtime.RawExtension, error) {
+	parameters, parametersWithSecretsRedacted, err := buildParameters(kubeClient, namespace, instance, specParametersFrom, specParameters)
 	if err != nil {
 		return nil, "", nil, fmt.Errorf(
 			"failed to prepare parameters %s: %s",
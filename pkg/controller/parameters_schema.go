@@ -0,0 +1,552 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// schemaViolation is a value of the parameters that does not match the
+// parameter schema of a plan.
+type schemaViolation struct {
+	// parameter is the top-level parameter holding the value, which tells
+	// where the value came from. It is empty for top-level parameters that
+	// are required but missing.
+	parameter string
+	// path is the path of the value, such as "db.replicas" or "args[0]".
+	path string
+	// problem describes what is wrong with the value.
+	problem string
+}
+
+// supportedSchemaKeywords are the keywords of a JSON schema that
+// validateParametersSchema either checks or may safely ignore because they
+// only annotate the schema.
+var supportedSchemaKeywords = sets.NewString(
+	"type", "enum", "const",
+	"properties", "patternProperties", "additionalProperties", "required", "minProperties", "maxProperties",
+	"items", "minItems", "maxItems", "uniqueItems",
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf",
+	"minLength", "maxLength", "pattern",
+	"allOf", "anyOf", "oneOf", "not",
+	"$ref", "definitions",
+	"$schema", "$id", "id", "$comment", "title", "description", "default", "examples", "readOnly", "writeOnly",
+)
+
+// validateParametersSchema validates parameters against the JSON schema of a
+// plan. References are only resolved within the schema. The keywords of the
+// schema that are not checked, such as format or dependencies, are returned
+// so that callers can report that the parameters were only partially
+// validated.
+func validateParametersSchema(rawSchema *runtime.RawExtension, parameters map[string]interface{}) ([]schemaViolation, []string, error) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal(rawSchema.Raw, &schema); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal parameter schema: %v", err)
+	}
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+
+	v := &schemaValidator{
+		root:      schema,
+		patterns:  make(map[string]*regexp.Regexp),
+		resolving: make(map[string]bool),
+	}
+	unsupported := sets.NewString()
+	if err := v.prepare(schema, unsupported); err != nil {
+		return nil, nil, err
+	}
+	v.validate(schema, parameters, "", "")
+	return v.violations, unsupported.List(), nil
+}
+
+// describeSchemaViolations describes the given violations in a single
+// message, pointing each at the source of its value. sources holds the
+// descriptions of the sources of the top-level parameters, as returned by
+// parameterSources.
+func describeSchemaViolations(violations []schemaViolation, sources map[string]string) string {
+	descriptions := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		if source, ok := sources[violation.parameter]; ok {
+			descriptions = append(descriptions, fmt.Sprintf("%s (from %s) %s", violation.path, source, violation.problem))
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%s %s", violation.path, violation.problem))
+		}
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+type schemaValidator struct {
+	violations []schemaViolation
+	// root is the schema that references are resolved in.
+	root map[string]interface{}
+	// patterns holds the compiled regular expressions of the schema.
+	patterns map[string]*regexp.Regexp
+	// resolving holds the references being resolved for a path, to stop
+	// references that loop back to themselves without going deeper into
+	// the value.
+	resolving map[string]bool
+}
+
+// prepare compiles the regular expressions of the schema and its
+// subschemas, checks that their references can be resolved, and adds the
+// keywords that are not checked to unsupported.
+func (v *schemaValidator) prepare(schema map[string]interface{}, unsupported sets.String) error {
+	for keyword, value := range schema {
+		if !supportedSchemaKeywords.Has(keyword) {
+			unsupported.Insert(keyword)
+			continue
+		}
+		switch keyword {
+		case "pattern":
+			if err := v.compilePattern(value); err != nil {
+				return err
+			}
+		case "$ref":
+			ref, _ := value.(string)
+			if !strings.HasPrefix(ref, "#") {
+				unsupported.Insert(fmt.Sprintf("$ref %q", ref))
+			} else if _, err := v.resolve(ref); err != nil {
+				return err
+			}
+		case "properties", "definitions":
+			subschemas, _ := value.(map[string]interface{})
+			for _, subschema := range subschemas {
+				if err := v.prepareSubschema(subschema, unsupported); err != nil {
+					return err
+				}
+			}
+		case "patternProperties":
+			subschemas, _ := value.(map[string]interface{})
+			for pattern, subschema := range subschemas {
+				if err := v.compilePattern(pattern); err != nil {
+					return err
+				}
+				if err := v.prepareSubschema(subschema, unsupported); err != nil {
+					return err
+				}
+			}
+		case "items", "allOf", "anyOf", "oneOf":
+			if subschemas, ok := value.([]interface{}); ok {
+				for _, subschema := range subschemas {
+					if err := v.prepareSubschema(subschema, unsupported); err != nil {
+						return err
+					}
+				}
+			} else if err := v.prepareSubschema(value, unsupported); err != nil {
+				return err
+			}
+		case "additionalProperties", "not":
+			if err := v.prepareSubschema(value, unsupported); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *schemaValidator) prepareSubschema(subschema interface{}, unsupported sets.String) error {
+	if subschema, ok := subschema.(map[string]interface{}); ok {
+		return v.prepare(subschema, unsupported)
+	}
+	return nil
+}
+
+func (v *schemaValidator) compilePattern(pattern interface{}) error {
+	s, ok := pattern.(string)
+	if !ok {
+		return fmt.Errorf("invalid pattern %v in parameter schema, must be a string", pattern)
+	}
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q in parameter schema: %v", s, err)
+	}
+	v.patterns[s] = re
+	return nil
+}
+
+// resolve returns the schema that a reference within the root schema, such
+// as "#/definitions/port", points at.
+func (v *schemaValidator) resolve(ref string) (map[string]interface{}, error) {
+	var current interface{} = v.root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#"), "/")[1:] {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		switch c := current.(type) {
+		case map[string]interface{}:
+			current = c[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(c) {
+				return nil, fmt.Errorf("unresolvable reference %q in parameter schema", ref)
+			}
+			current = c[i]
+		default:
+			current = nil
+		}
+	}
+	schema, ok := current.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unresolvable reference %q in parameter schema", ref)
+	}
+	return schema, nil
+}
+
+// matches returns whether value matches schema, without recording any
+// violation.
+func (v *schemaValidator) matches(schema map[string]interface{}, value interface{}, parameter, path string) bool {
+	sub := &schemaValidator{root: v.root, patterns: v.patterns, resolving: v.resolving}
+	sub.validate(schema, value, parameter, path)
+	return len(sub.violations) == 0
+}
+
+func (v *schemaValidator) addViolation(parameter, path, format string, args ...interface{}) {
+	v.violations = append(v.violations, schemaViolation{
+		parameter: parameter,
+		path:      path,
+		problem:   fmt.Sprintf(format, args...),
+	})
+}
+
+// validate validates value against schema. parameter is the top-level
+// parameter holding the value and path the path of the value, both empty for
+// the parameters as a whole.
+func (v *schemaValidator) validate(schema map[string]interface{}, value interface{}, parameter, path string) {
+	if ref, ok := schema["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+		key := ref + " " + path
+		if v.resolving[key] {
+			return
+		}
+		// References were checked when preparing the schema
+		resolved, _ := v.resolve(ref)
+		v.resolving[key] = true
+		v.validate(resolved, value, parameter, path)
+		delete(v.resolving, key)
+	}
+
+	v.validateSubschemas(schema, value, parameter, path)
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if matchesSchemaType(t, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.addViolation(parameter, pathOrParameters(path), "must be of type %s", strings.Join(types, " or "))
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range enum {
+			if reflect.DeepEqual(e, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			allowed := make([]string, 0, len(enum))
+			for _, e := range enum {
+				b, _ := json.Marshal(e)
+				allowed = append(allowed, string(b))
+			}
+			v.addViolation(parameter, pathOrParameters(path), "must be one of %s", strings.Join(allowed, ", "))
+		}
+	}
+
+	if c, ok := schema["const"]; ok && !reflect.DeepEqual(c, value) {
+		b, _ := json.Marshal(c)
+		v.addViolation(parameter, pathOrParameters(path), "must be %s", b)
+	}
+
+	switch value := value.(type) {
+	case string:
+		length := float64(utf8.RuneCountInString(value))
+		if min, ok := schema["minLength"].(float64); ok && length < min {
+			v.addViolation(parameter, pathOrParameters(path), "must be at least %v characters long", min)
+		}
+		if max, ok := schema["maxLength"].(float64); ok && length > max {
+			v.addViolation(parameter, pathOrParameters(path), "must be at most %v characters long", max)
+		}
+		if pattern, ok := schema["pattern"].(string); ok && !v.patterns[pattern].MatchString(value) {
+			v.addViolation(parameter, pathOrParameters(path), "must match the pattern %q", pattern)
+		}
+	case map[string]interface{}:
+		v.validateObject(schema, value, parameter, path)
+	case []interface{}:
+		v.validateArray(schema, value, parameter, path)
+	default:
+		if n, ok := toFloat64(value); ok {
+			v.validateNumber(schema, n, parameter, path)
+		}
+	}
+}
+
+// validateSubschemas validates value against the allOf, anyOf, oneOf and not
+// keywords of schema.
+func (v *schemaValidator) validateSubschemas(schema map[string]interface{}, value interface{}, parameter, path string) {
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, subschema := range allOf {
+			if subschema, ok := subschema.(map[string]interface{}); ok {
+				v.validate(subschema, value, parameter, path)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		matched := false
+		for _, subschema := range anyOf {
+			if subschema, ok := subschema.(map[string]interface{}); ok && v.matches(subschema, value, parameter, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.addViolation(parameter, pathOrParameters(path), "must match at least one of the schemas of anyOf")
+		}
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matched := 0
+		for _, subschema := range oneOf {
+			if subschema, ok := subschema.(map[string]interface{}); ok && v.matches(subschema, value, parameter, path) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.addViolation(parameter, pathOrParameters(path), "must match exactly one of the schemas of oneOf, but matches %d", matched)
+		}
+	}
+	if not, ok := schema["not"].(map[string]interface{}); ok && v.matches(not, value, parameter, path) {
+		v.addViolation(parameter, pathOrParameters(path), "must not match the schema of not")
+	}
+}
+
+func (v *schemaValidator) validateArray(schema map[string]interface{}, value []interface{}, parameter, path string) {
+	length := float64(len(value))
+	if min, ok := schema["minItems"].(float64); ok && length < min {
+		v.addViolation(parameter, pathOrParameters(path), "must have at least %v items", min)
+	}
+	if max, ok := schema["maxItems"].(float64); ok && length > max {
+		v.addViolation(parameter, pathOrParameters(path), "must have at most %v items", max)
+	}
+	if unique, ok := schema["uniqueItems"].(bool); ok && unique {
+	duplicates:
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					v.addViolation(parameter, pathOrParameters(path), "must not have duplicate items")
+					break duplicates
+				}
+			}
+		}
+	}
+
+	switch items := schema["items"].(type) {
+	case map[string]interface{}:
+		for i, item := range value {
+			v.validate(items, item, parameter, fmt.Sprintf("%s[%d]", pathOrParameters(path), i))
+		}
+	case []interface{}:
+		for i, item := range value {
+			if i >= len(items) {
+				break
+			}
+			if itemSchema, ok := items[i].(map[string]interface{}); ok {
+				v.validate(itemSchema, item, parameter, fmt.Sprintf("%s[%d]", pathOrParameters(path), i))
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(schema map[string]interface{}, n float64, parameter, path string) {
+	// exclusiveMinimum and exclusiveMaximum are booleans modifying minimum
+	// and maximum up to draft 4 of JSON schema, and bounds of their own
+	// since draft 6.
+	if min, ok := schema["minimum"].(float64); ok {
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive && n <= min {
+			v.addViolation(parameter, pathOrParameters(path), "must be greater than %v", min)
+		} else if n < min {
+			v.addViolation(parameter, pathOrParameters(path), "must be at least %v", min)
+		}
+	}
+	if min, ok := schema["exclusiveMinimum"].(float64); ok && n <= min {
+		v.addViolation(parameter, pathOrParameters(path), "must be greater than %v", min)
+	}
+	if max, ok := schema["maximum"].(float64); ok {
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive && n >= max {
+			v.addViolation(parameter, pathOrParameters(path), "must be less than %v", max)
+		} else if n > max {
+			v.addViolation(parameter, pathOrParameters(path), "must be at most %v", max)
+		}
+	}
+	if max, ok := schema["exclusiveMaximum"].(float64); ok && n >= max {
+		v.addViolation(parameter, pathOrParameters(path), "must be less than %v", max)
+	}
+	if multipleOf, ok := schema["multipleOf"].(float64); ok && multipleOf > 0 {
+		if q := n / multipleOf; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.addViolation(parameter, pathOrParameters(path), "must be a multiple of %v", multipleOf)
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(schema map[string]interface{}, value map[string]interface{}, parameter, path string) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	patternProperties, _ := schema["patternProperties"].(map[string]interface{})
+	patterns := make([]string, 0, len(patternProperties))
+	for pattern := range patternProperties {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	count := float64(len(value))
+	if min, ok := schema["minProperties"].(float64); ok && count < min {
+		v.addViolation(parameter, pathOrParameters(path), "must have at least %v properties", min)
+	}
+	if max, ok := schema["maxProperties"].(float64); ok && count > max {
+		v.addViolation(parameter, pathOrParameters(path), "must have at most %v properties", max)
+	}
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			name, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, ok := value[name]; !ok {
+				v.addViolation(parameter, joinSchemaPath(path, name), "is required")
+			}
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		p := parameter
+		if path == "" {
+			p = name
+		}
+		matched := false
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			v.validate(property, value[name], p, joinSchemaPath(path, name))
+			matched = true
+		}
+		for _, pattern := range patterns {
+			if property, ok := patternProperties[pattern].(map[string]interface{}); ok && v.patterns[pattern].MatchString(name) {
+				v.validate(property, value[name], p, joinSchemaPath(path, name))
+				matched = true
+			}
+		}
+		if matched {
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.addViolation(p, joinSchemaPath(path, name), "is not allowed")
+			}
+		case map[string]interface{}:
+			v.validate(additional, value[name], p, joinSchemaPath(path, name))
+		}
+	}
+}
+
+// schemaTypes returns the types allowed by the type keyword of a schema.
+func schemaTypes(t interface{}) []string {
+	switch t := t.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, e := range t {
+			if s, ok := e.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	}
+	return nil
+}
+
+func matchesSchemaType(t string, value interface{}) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := toFloat64(value)
+		return ok
+	case "integer":
+		n, ok := toFloat64(value)
+		return ok && n == math.Trunc(n)
+	case "null":
+		return value == nil
+	}
+	// Unknown types are not checked
+	return true
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func joinSchemaPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func pathOrParameters(path string) string {
+	if path == "" {
+		return "parameters"
+	}
+	return path
+}
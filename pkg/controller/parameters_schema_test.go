@@ -0,0 +1,308 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidateParametersSchema(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["name"],
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string", "minLength": 3, "maxLength": 8},
+			"size": {"enum": ["small", "large"]},
+			"replicas": {"type": "integer", "minimum": 1, "maximum": 5},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"db": {
+				"type": "object",
+				"required": ["engine"],
+				"properties": {
+					"engine": {"type": "string"},
+					"port": {"type": ["integer", "null"]}
+				}
+			}
+		}
+	}`
+
+	cases := []struct {
+		name       string
+		parameters string
+		expected   []schemaViolation
+	}{
+		{
+			name:       "valid",
+			parameters: `{"name":"test","size":"small","replicas":3,"tags":["a","b"],"db":{"engine":"pg","port":null}}`,
+		},
+		{
+			name:       "missing required parameter",
+			parameters: `{}`,
+			expected: []schemaViolation{
+				{path: "name", problem: "is required"},
+			},
+		},
+		{
+			name:       "wrong types",
+			parameters: `{"name":3,"replicas":1.5,"tags":["a",2],"db":{"engine":"pg","port":"80"}}`,
+			expected: []schemaViolation{
+				{parameter: "db", path: "db.port", problem: "must be of type integer or null"},
+				{parameter: "name", path: "name", problem: "must be of type string"},
+				{parameter: "replicas", path: "replicas", problem: "must be of type integer"},
+				{parameter: "tags", path: "tags[1]", problem: "must be of type string"},
+			},
+		},
+		{
+			name:       "out of range",
+			parameters: `{"name":"ab","size":"medium","replicas":6}`,
+			expected: []schemaViolation{
+				{parameter: "name", path: "name", problem: "must be at least 3 characters long"},
+				{parameter: "replicas", path: "replicas", problem: "must be at most 5"},
+				{parameter: "size", path: "size", problem: `must be one of "small", "large"`},
+			},
+		},
+		{
+			name:       "nested required and additional properties",
+			parameters: `{"name":"test","db":{},"color":"red"}`,
+			expected: []schemaViolation{
+				{parameter: "color", path: "color", problem: "is not allowed"},
+				{parameter: "db", path: "db.engine", problem: "is required"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var parameters map[string]interface{}
+			if err := json.Unmarshal([]byte(tc.parameters), &parameters); err != nil {
+				t.Fatalf("unexpected error unmarshaling parameters: %v", err)
+			}
+
+			violations, unsupported, err := validateParametersSchema(&runtime.RawExtension{Raw: []byte(schema)}, parameters)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(unsupported) != 0 {
+				t.Errorf("unexpected unsupported keywords: %v", unsupported)
+			}
+			if e, a := len(tc.expected), len(violations); e != a {
+				t.Fatalf("unexpected number of violations: expected %v, got %v: %+v", e, a, violations)
+			}
+			for i := range tc.expected {
+				if e, a := tc.expected[i], violations[i]; e != a {
+					t.Errorf("unexpected violation: expected %+v, got %+v", e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateParametersSchemaKeywords(t *testing.T) {
+	cases := []struct {
+		name       string
+		schema     string
+		parameters string
+		expected   []schemaViolation
+	}{
+		{
+			name:       "pattern",
+			schema:     `{"properties": {"name": {"type": "string", "pattern": "^[a-z]+$"}}}`,
+			parameters: `{"name":"Test"}`,
+			expected: []schemaViolation{
+				{parameter: "name", path: "name", problem: `must match the pattern "^[a-z]+$"`},
+			},
+		},
+		{
+			name:       "reference",
+			schema:     `{"definitions": {"port": {"type": "integer", "maximum": 65535}}, "properties": {"port": {"$ref": "#/definitions/port"}}}`,
+			parameters: `{"port":70000}`,
+			expected: []schemaViolation{
+				{parameter: "port", path: "port", problem: "must be at most 65535"},
+			},
+		},
+		{
+			name:       "recursive reference",
+			schema:     `{"properties": {"child": {"$ref": "#"}, "name": {"type": "string"}}}`,
+			parameters: `{"child":{"child":{"name":3}}}`,
+			expected: []schemaViolation{
+				{parameter: "child", path: "child.child.name", problem: "must be of type string"},
+			},
+		},
+		{
+			name:       "allOf",
+			schema:     `{"allOf": [{"required": ["name"]}, {"required": ["size"]}]}`,
+			parameters: `{"name":"test"}`,
+			expected: []schemaViolation{
+				{path: "size", problem: "is required"},
+			},
+		},
+		{
+			name:       "anyOf",
+			schema:     `{"properties": {"port": {"anyOf": [{"type": "integer"}, {"type": "string", "pattern": "^[0-9]+$"}]}}}`,
+			parameters: `{"port":"http"}`,
+			expected: []schemaViolation{
+				{parameter: "port", path: "port", problem: "must match at least one of the schemas of anyOf"},
+			},
+		},
+		{
+			name:       "oneOf",
+			schema:     `{"properties": {"size": {"oneOf": [{"type": "integer"}, {"type": "number"}]}}}`,
+			parameters: `{"size":3}`,
+			expected: []schemaViolation{
+				{parameter: "size", path: "size", problem: "must match exactly one of the schemas of oneOf, but matches 2"},
+			},
+		},
+		{
+			name:       "not",
+			schema:     `{"properties": {"name": {"not": {"enum": ["admin"]}}}}`,
+			parameters: `{"name":"admin"}`,
+			expected: []schemaViolation{
+				{parameter: "name", path: "name", problem: "must not match the schema of not"},
+			},
+		},
+		{
+			name:       "const",
+			schema:     `{"properties": {"tier": {"const": "free"}}}`,
+			parameters: `{"tier":"paid"}`,
+			expected: []schemaViolation{
+				{parameter: "tier", path: "tier", problem: `must be "free"`},
+			},
+		},
+		{
+			name:       "array bounds and unique items",
+			schema:     `{"properties": {"tags": {"minItems": 3, "uniqueItems": true}}}`,
+			parameters: `{"tags":["a","a"]}`,
+			expected: []schemaViolation{
+				{parameter: "tags", path: "tags", problem: "must have at least 3 items"},
+				{parameter: "tags", path: "tags", problem: "must not have duplicate items"},
+			},
+		},
+		{
+			name:       "exclusive bounds and multipleOf",
+			schema:     `{"properties": {"a": {"minimum": 1, "exclusiveMinimum": true}, "b": {"exclusiveMaximum": 10}, "c": {"multipleOf": 0.5}}}`,
+			parameters: `{"a":1,"b":10,"c":1.25}`,
+			expected: []schemaViolation{
+				{parameter: "a", path: "a", problem: "must be greater than 1"},
+				{parameter: "b", path: "b", problem: "must be less than 10"},
+				{parameter: "c", path: "c", problem: "must be a multiple of 0.5"},
+			},
+		},
+		{
+			name:       "pattern and additional properties",
+			schema:     `{"maxProperties": 2, "patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": {"type": "integer"}}`,
+			parameters: `{"x-name":3,"size":"large","count":1}`,
+			expected: []schemaViolation{
+				{path: "parameters", problem: "must have at most 2 properties"},
+				{parameter: "size", path: "size", problem: "must be of type integer"},
+				{parameter: "x-name", path: "x-name", problem: "must be of type string"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var parameters map[string]interface{}
+			if err := json.Unmarshal([]byte(tc.parameters), &parameters); err != nil {
+				t.Fatalf("unexpected error unmarshaling parameters: %v", err)
+			}
+
+			violations, unsupported, err := validateParametersSchema(&runtime.RawExtension{Raw: []byte(tc.schema)}, parameters)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(unsupported) != 0 {
+				t.Errorf("unexpected unsupported keywords: %v", unsupported)
+			}
+			if e, a := len(tc.expected), len(violations); e != a {
+				t.Fatalf("unexpected number of violations: expected %v, got %v: %+v", e, a, violations)
+			}
+			for i := range tc.expected {
+				if e, a := tc.expected[i], violations[i]; e != a {
+					t.Errorf("unexpected violation: expected %+v, got %+v", e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateParametersSchemaUnsupportedKeywords(t *testing.T) {
+	schema := `{
+		"properties": {
+			"email": {"type": "string", "format": "email"},
+			"db": {"$ref": "http://example.com/db.json"},
+			"tls": {"dependencies": {"cert": ["key"]}}
+		}
+	}`
+
+	violations, unsupported, err := validateParametersSchema(&runtime.RawExtension{Raw: []byte(schema)}, map[string]interface{}{"email": "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("unexpected violations: %+v", violations)
+	}
+	expected := []string{`$ref "http://example.com/db.json"`, "dependencies", "format"}
+	if !reflect.DeepEqual(expected, unsupported) {
+		t.Errorf("unexpected unsupported keywords: expected %v, got %v", expected, unsupported)
+	}
+}
+
+func TestValidateParametersSchemaInvalidSchema(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+	}{
+		{
+			name:   "invalid pattern",
+			schema: `{"properties": {"name": {"pattern": "[a-"}}}`,
+		},
+		{
+			name:   "unresolvable reference",
+			schema: `{"properties": {"port": {"$ref": "#/definitions/port"}}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, _, err := validateParametersSchema(&runtime.RawExtension{Raw: []byte(tc.schema)}, nil); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestDescribeSchemaViolations(t *testing.T) {
+	violations := []schemaViolation{
+		{path: "name", problem: "is required"},
+		{parameter: "size", path: "size", problem: "must be of type string"},
+		{parameter: "db", path: "db.port", problem: "must be at most 65535"},
+	}
+	sources := map[string]string{
+		"size": "parameters",
+		"db":   `secret "db-secret" key "params"`,
+	}
+
+	expected := `name is required; size (from parameters) must be of type string; db.port (from secret "db-secret" key "params") must be at most 65535`
+	if e, a := expected, describeSchemaViolations(violations, sources); e != a {
+		t.Fatalf("unexpected description: expected %q, got %q", e, a)
+	}
+}
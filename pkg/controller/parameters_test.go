@@ -35,8 +35,14 @@ func TestBuildParameters(t *testing.T) {
 		},
 	}
 
+	dashboardURL := "http://dashboard"
+	instanceWithOutputs := &v1beta1.ServiceInstance{
+		Status: v1beta1.ServiceInstanceStatus{DashboardURL: &dashboardURL},
+	}
+
 	cases := []struct {
 		name                                  string
+		instance                              *v1beta1.ServiceInstance
 		parametersFrom                        []v1beta1.ParametersFromSource
 		parameters                            *runtime.RawExtension
 		secret                                *corev1.Secret
@@ -138,16 +144,57 @@ func TestBuildParameters(t *testing.T) {
 			secret:        secret,
 			shouldSucceed: false,
 		},
+		{
+			name:     "parametersFrom: instance output",
+			instance: instanceWithOutputs,
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					InstanceOutputRef: &v1beta1.InstanceOutputReference{
+						Key: "dashboardURL",
+					},
+				},
+			},
+			expectedParameters: map[string]interface{}{
+				"dashboardURL": dashboardURL,
+			},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{
+				"dashboardURL": dashboardURL,
+			},
+			shouldSucceed: true,
+		},
+		{
+			name:     "parametersFrom: instance output not recorded",
+			instance: &v1beta1.ServiceInstance{},
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					InstanceOutputRef: &v1beta1.InstanceOutputReference{
+						Key: "dashboardURL",
+					},
+				},
+			},
+			shouldSucceed: false,
+		},
+		{
+			name: "parametersFrom: instance output without instance",
+			parametersFrom: []v1beta1.ParametersFromSource{
+				{
+					InstanceOutputRef: &v1beta1.InstanceOutputReference{
+						Key: "dashboardURL",
+					},
+				},
+			},
+			shouldSucceed: false,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			testBuildParameters(t, tc.parametersFrom, tc.parameters, tc.secret, tc.expectedParameters, tc.expectedParametersWithSecretsRedacted, tc.shouldSucceed)
+			testBuildParameters(t, tc.instance, tc.parametersFrom, tc.parameters, tc.secret, tc.expectedParameters, tc.expectedParametersWithSecretsRedacted, tc.shouldSucceed)
 		})
 	}
 }
 
-func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension, secret *corev1.Secret, expected map[string]interface{}, expectedWithSecretsRdacted map[string]interface{}, shouldSucceed bool) {
+func testBuildParameters(t *testing.T, instance *v1beta1.ServiceInstance, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension, secret *corev1.Secret, expected map[string]interface{}, expectedWithSecretsRdacted map[string]interface{}, shouldSucceed bool) {
 	// create a fake kube client
 	fakeKubeClient := &clientgofake.Clientset{}
 	if secret != nil {
@@ -156,7 +203,7 @@ func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSo
 		addGetSecretNotFoundReaction(fakeKubeClient)
 	}
 
-	actual, actualWithSecretsRedacted, err := buildParameters(fakeKubeClient, "test-ns", parametersFrom, parameters)
+	actual, actualWithSecretsRedacted, err := buildParameters(fakeKubeClient, "test-ns", instance, parametersFrom, parameters)
 	if shouldSucceed {
 		if err != nil {
 			t.Fatalf("Failed to build parameters: %v", err)
@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PlanDeprecatedMetadataKey is the key of the metadata of a plan in the
+	// broker's catalog that marks the plan as deprecated when it is true.
+	PlanDeprecatedMetadataKey = "deprecated"
+	// PlanDeprecationMessageMetadataKey is the key of the metadata of a plan
+	// that optionally tells users of a deprecated plan what to migrate to.
+	PlanDeprecationMessageMetadataKey = "deprecationMessage"
+)
+
+// planDeprecation returns whether the metadata of a plan marks it as
+// deprecated, and the deprecation message given by the broker, if any.
+// Metadata that cannot be parsed does not deprecate the plan.
+func planDeprecation(planSpec *v1beta1.CommonServicePlanSpec) (bool, string) {
+	if planSpec.ExternalMetadata == nil || len(planSpec.ExternalMetadata.Raw) == 0 {
+		return false, ""
+	}
+	var metadata struct {
+		Deprecated         bool   `json:"deprecated"`
+		DeprecationMessage string `json:"deprecationMessage"`
+	}
+	if err := json.Unmarshal(planSpec.ExternalMetadata.Raw, &metadata); err != nil {
+		return false, ""
+	}
+	return metadata.Deprecated, metadata.DeprecationMessage
+}
+
+// recordServiceInstancePlanDeprecation sets the PlanDeprecated condition of an
+// instance whose plan is deprecated, recording a warning event when the
+// condition is added, and removes the condition once the plan is no longer
+// deprecated. The condition is saved with the next status update of the
+// instance; it never blocks the operation in progress.
+func (c *controller) recordServiceInstancePlanDeprecation(instance *v1beta1.ServiceInstance, planSpec *v1beta1.CommonServicePlanSpec) {
+	deprecated, deprecationMessage := planDeprecation(planSpec)
+	if !deprecated {
+		if isServiceInstancePlanDeprecated(instance) {
+			removeServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionPlanDeprecated)
+		}
+		return
+	}
+
+	message := fmt.Sprintf("The plan %q of the instance is deprecated by the broker; consider moving the instance to another plan", planSpec.ExternalName)
+	if deprecationMessage != "" {
+		message = fmt.Sprintf("%s: %s", message, deprecationMessage)
+	}
+	if !isServiceInstancePlanDeprecated(instance) {
+		c.recorder.Event(instance, corev1.EventTypeWarning, planDeprecatedReason, message)
+	}
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionPlanDeprecated, v1beta1.ConditionTrue, planDeprecatedReason, message)
+}
+
+// isServiceInstancePlanDeprecated returns whether the instance has the
+// PlanDeprecated condition.
+func isServiceInstancePlanDeprecated(instance *v1beta1.ServiceInstance) bool {
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionPlanDeprecated {
+			return cond.Status == v1beta1.ConditionTrue
+		}
+	}
+	return false
+}
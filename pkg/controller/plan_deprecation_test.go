@@ -0,0 +1,91 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPlanDeprecation(t *testing.T) {
+	cases := []struct {
+		name       string
+		metadata   string
+		deprecated bool
+		message    string
+	}{
+		{
+			name: "no metadata",
+		},
+		{
+			name:     "not deprecated",
+			metadata: `{"deprecated": false, "displayName": "Default"}`,
+		},
+		{
+			name:       "deprecated",
+			metadata:   `{"deprecated": true}`,
+			deprecated: true,
+		},
+		{
+			name:       "deprecated with a message",
+			metadata:   `{"deprecated": true, "deprecationMessage": "use the premium plan"}`,
+			deprecated: true,
+			message:    "use the premium plan",
+		},
+		{
+			name:     "not a boolean",
+			metadata: `{"deprecated": "yes"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		planSpec := &v1beta1.CommonServicePlanSpec{}
+		if tc.metadata != "" {
+			planSpec.ExternalMetadata = &runtime.RawExtension{Raw: []byte(tc.metadata)}
+		}
+		deprecated, message := planDeprecation(planSpec)
+		if deprecated != tc.deprecated {
+			t.Errorf("%s: expected deprecated to be %v, got %v", tc.name, tc.deprecated, deprecated)
+		}
+		if message != tc.message {
+			t.Errorf("%s: expected message %q, got %q", tc.name, tc.message, message)
+		}
+	}
+}
+
+func TestRecordServiceInstancePlanDeprecationRemovesCondition(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceWithClusterRefs()
+	planSpec := &getTestClusterServicePlan().Spec.CommonServicePlanSpec
+	planSpec.ExternalMetadata = &runtime.RawExtension{Raw: []byte(`{"deprecated": true}`)}
+
+	testController.recordServiceInstancePlanDeprecation(instance, planSpec)
+	testController.recordServiceInstancePlanDeprecation(instance, planSpec)
+	if !isServiceInstancePlanDeprecated(instance) {
+		t.Fatal("expected the instance to have the PlanDeprecated condition")
+	}
+	if events := getRecordedEvents(testController); len(events) != 1 {
+		t.Fatalf("expected a single event, got %v", events)
+	}
+
+	planSpec.ExternalMetadata = nil
+	testController.recordServiceInstancePlanDeprecation(instance, planSpec)
+	assertServiceInstanceConditionMissing(t, instance, v1beta1.ServiceInstanceConditionPlanDeprecated)
+}
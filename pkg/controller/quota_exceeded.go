@@ -0,0 +1,71 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// QuotaExceededPolicy describes which errors returned by brokers for a
+// provision request mean that a quota was exceeded. Such provisions are
+// reported with the ErrorQuotaExceeded reason and retried after a longer
+// delay, since retrying them right away is unlikely to succeed.
+type QuotaExceededPolicy struct {
+	// BrokerErrors are the error codes, or fragments of the error
+	// descriptions, that brokers return when a quota was exceeded. An entry
+	// matches a response whose error code is equal to it, or whose
+	// description contains it ignoring case.
+	BrokerErrors []string
+	// RetryDelay is the minimum time waited before retrying a provision that
+	// failed because a quota was exceeded.
+	RetryDelay time.Duration
+}
+
+// Validate checks that the policy has no empty errors nor a negative delay.
+func (p QuotaExceededPolicy) Validate() error {
+	for _, brokerError := range p.BrokerErrors {
+		if brokerError == "" {
+			return fmt.Errorf("invalid quota exceeded broker error, must not be empty")
+		}
+	}
+	if p.RetryDelay < 0 {
+		return fmt.Errorf("invalid quota exceeded retry delay %v, must not be negative", p.RetryDelay)
+	}
+	return nil
+}
+
+// isQuotaExceeded returns whether the error returned by a broker means that
+// a quota was exceeded.
+func (p QuotaExceededPolicy) isQuotaExceeded(httpErr *osb.HTTPStatusCodeError) bool {
+	description := ""
+	if httpErr.Description != nil {
+		description = strings.ToLower(*httpErr.Description)
+	}
+	for _, brokerError := range p.BrokerErrors {
+		if httpErr.ErrorMessage != nil && *httpErr.ErrorMessage == brokerError {
+			return true
+		}
+		if strings.Contains(description, strings.ToLower(brokerError)) {
+			return true
+		}
+	}
+	return false
+}
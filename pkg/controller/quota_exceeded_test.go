@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+func TestQuotaExceededPolicyIsQuotaExceeded(t *testing.T) {
+	policy := QuotaExceededPolicy{BrokerErrors: []string{"QuotaExceeded", "quota reached"}}
+
+	cases := []struct {
+		name        string
+		policy      QuotaExceededPolicy
+		code        *string
+		description *string
+		expected    bool
+	}{
+		{name: "matching code", policy: policy, code: strPtr("QuotaExceeded"), expected: true},
+		{name: "code only matches exactly", policy: policy, code: strPtr("QuotaExceededSoon")},
+		{name: "matching description", policy: policy, description: strPtr("The Quota Reached its limit of 5 instances"), expected: true},
+		{name: "other error", policy: policy, code: strPtr("AsyncRequired"), description: strPtr("async please")},
+		{name: "no code nor description", policy: policy},
+		{name: "no broker errors", code: strPtr("QuotaExceeded"), description: strPtr("quota reached")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpErr := &osb.HTTPStatusCodeError{StatusCode: 403, ErrorMessage: tc.code, Description: tc.description}
+			if e, a := tc.expected, tc.policy.isQuotaExceeded(httpErr); e != a {
+				t.Fatalf("expected quota exceeded to be %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestQuotaExceededPolicyValidate(t *testing.T) {
+	valid := []QuotaExceededPolicy{
+		{},
+		{BrokerErrors: []string{"QuotaExceeded"}, RetryDelay: time.Minute},
+	}
+	for _, policy := range valid {
+		if err := policy.Validate(); err != nil {
+			t.Errorf("unexpected error for %+v: %v", policy, err)
+		}
+	}
+
+	invalid := []QuotaExceededPolicy{
+		{BrokerErrors: []string{""}},
+		{RetryDelay: -time.Minute},
+	}
+	for _, policy := range invalid {
+		if err := policy.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", policy)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// namespaceLabelsContextKey is the key of the labels of the namespace of
+	// an instance or binding in the context of the requests sent to brokers.
+	namespaceLabelsContextKey string = "namespace_labels"
+)
+
+// reservedContextKeys are the keys of the request context that are set by
+// the controller, and cannot be used by custom values.
+var reservedContextKeys = []string{
+	"platform",
+	"namespace",
+	clusterIdentifierKey,
+	operationKeyContextKey,
+	namespaceLabelsContextKey,
+}
+
+// RequestContextPolicy describes what the controller adds to the context
+// object of the provision, update and bind requests it sends to brokers,
+// besides the platform, the namespace and the cluster ID. Nothing else is
+// added unless it is configured, so that no data is sent to brokers that the
+// cluster administrator did not choose to send.
+type RequestContextPolicy struct {
+	// NamespaceLabels are the keys of the labels of the namespace of an
+	// instance or binding that are added to the context, in the
+	// namespace_labels object. The other labels of the namespace are not
+	// sent.
+	NamespaceLabels []string
+	// Values are added to the context as they are, and are sent to every
+	// broker.
+	Values map[string]string
+}
+
+// Validate checks that the label keys are valid and that the custom values
+// do not replace the keys set by the controller.
+func (p RequestContextPolicy) Validate() error {
+	for _, key := range p.NamespaceLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("invalid namespace label key %q: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	for key := range p.Values {
+		if key == "" {
+			return fmt.Errorf("invalid request context value with an empty key")
+		}
+		for _, reserved := range reservedContextKeys {
+			if key == reserved {
+				return fmt.Errorf("invalid request context value %q, the key is set by the controller", key)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseRequestContextValues parses custom values of the request context
+// given as KEY=VALUE entries.
+func ParseRequestContextValues(entries []string) (map[string]string, error) {
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid request context value %q, the format is KEY=VALUE", entry)
+		}
+		values[parts[0]] = parts[1]
+	}
+	return values, nil
+}
+
+// enrich adds the configured namespace labels and custom values to the
+// context of a request for a resource in the given namespace.
+func (p RequestContextPolicy) enrich(requestContext map[string]interface{}, ns *corev1.Namespace) {
+	for key, value := range p.Values {
+		requestContext[key] = value
+	}
+	if len(p.NamespaceLabels) == 0 || ns == nil {
+		return
+	}
+	labels := map[string]string{}
+	for _, key := range p.NamespaceLabels {
+		if value, ok := ns.Labels[key]; ok {
+			labels[key] = value
+		}
+	}
+	if len(labels) != 0 {
+		requestContext[namespaceLabelsContextKey] = labels
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequestContextPolicyValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy RequestContextPolicy
+		valid  bool
+	}{
+		{
+			name:  "empty",
+			valid: true,
+		},
+		{
+			name: "labels and values",
+			policy: RequestContextPolicy{
+				NamespaceLabels: []string{"team", "example.com/cost-center"},
+				Values:          map[string]string{"region": "eu-west"},
+			},
+			valid: true,
+		},
+		{
+			name:   "invalid label key",
+			policy: RequestContextPolicy{NamespaceLabels: []string{"not a key"}},
+		},
+		{
+			name:   "empty value key",
+			policy: RequestContextPolicy{Values: map[string]string{"": "value"}},
+		},
+		{
+			name:   "reserved value key",
+			policy: RequestContextPolicy{Values: map[string]string{"namespace": "other"}},
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.policy.Validate()
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestParseRequestContextValues(t *testing.T) {
+	values, err := ParseRequestContextValues([]string{"region=eu-west", "selector=tier=gold", "empty="})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]string{"region": "eu-west", "selector": "tier=gold", "empty": ""}
+	if !reflect.DeepEqual(expected, values) {
+		t.Fatalf("unexpected values: %s", expectedGot(expected, values))
+	}
+
+	if _, err := ParseRequestContextValues([]string{"region"}); err == nil {
+		t.Fatal("expected an error for a value without a key")
+	}
+}
+
+func TestRequestContextPolicyEnrich(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   testNamespace,
+			Labels: map[string]string{"team": "payments", "secret-project": "x"},
+		},
+	}
+	policy := RequestContextPolicy{
+		NamespaceLabels: []string{"team", "missing"},
+		Values:          map[string]string{"region": "eu-west"},
+	}
+
+	requestContext := map[string]interface{}{"platform": ContextProfilePlatformKubernetes}
+	policy.enrich(requestContext, ns)
+
+	expected := map[string]interface{}{
+		"platform":                ContextProfilePlatformKubernetes,
+		"region":                  "eu-west",
+		namespaceLabelsContextKey: map[string]string{"team": "payments"},
+	}
+	if !reflect.DeepEqual(expected, requestContext) {
+		t.Fatalf("unexpected context: %s", expectedGot(expected, requestContext))
+	}
+
+	requestContext = map[string]interface{}{}
+	RequestContextPolicy{}.enrich(requestContext, ns)
+	if len(requestContext) != 0 {
+		t.Fatalf("expected nothing to be added without a policy, got %v", requestContext)
+	}
+}
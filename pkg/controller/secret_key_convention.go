@@ -0,0 +1,164 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// SecretKeyCaseUnchanged leaves the case of credential keys as returned
+	// by the broker.
+	SecretKeyCaseUnchanged = ""
+	// SecretKeyCaseUpperSnake converts credential keys to UPPER_SNAKE_CASE,
+	// e.g. "apiKey" and "api-key" both become "API_KEY".
+	SecretKeyCaseUpperSnake = "upper-snake-case"
+
+	// OriginalSecretKeysAnnotation is the annotation of a binding's secret
+	// that maps each sanitized key of the secret to the key it was
+	// sanitized from, as a JSON object.
+	OriginalSecretKeysAnnotation = "servicecatalog.k8s.io/original-secret-keys"
+)
+
+// SecretKeyConvention describes how the keys of the credentials returned by
+// a broker are renamed before they are written to a binding's secret. It is
+// applied after the binding's SecretTransforms.
+type SecretKeyConvention struct {
+	// Case is the case keys are converted to; one of the SecretKeyCase
+	// constants.
+	Case string
+	// Prefix is prepended to every key after its case has been converted.
+	Prefix string
+	// Sanitize replaces the characters that are not valid in secret keys
+	// with underscores. Otherwise, credentials with such keys fail the
+	// binding.
+	Sanitize bool
+}
+
+// invalidSecretKeysError is returned when credential keys are not valid
+// secret keys and the convention does not sanitize them.
+type invalidSecretKeysError struct {
+	keys []string
+}
+
+func (e *invalidSecretKeysError) Error() string {
+	return fmt.Sprintf("credential keys %s are not valid secret keys, which may only contain alphanumeric characters, '-', '_' or '.' and be at most 253 characters long", strings.Join(e.keys, ", "))
+}
+
+// Validate checks that the convention is known and produces valid secret
+// keys.
+func (c SecretKeyConvention) Validate() error {
+	switch c.Case {
+	case SecretKeyCaseUnchanged, SecretKeyCaseUpperSnake:
+	default:
+		return fmt.Errorf("invalid secret key case %q, allowed values are: %q and %q", c.Case, SecretKeyCaseUnchanged, SecretKeyCaseUpperSnake)
+	}
+	if c.Prefix != "" {
+		if errs := validation.IsConfigMapKey(c.Prefix); len(errs) > 0 {
+			return fmt.Errorf("invalid secret key prefix %q: %s", c.Prefix, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// apply renames the keys of credentials according to the convention, then
+// checks that the keys are valid secret keys, sanitizing them if the
+// convention says so. It also returns a map from each sanitized key to the
+// key it was sanitized from. It fails if two keys are renamed to the same
+// key.
+func (c SecretKeyConvention) apply(credentials map[string]interface{}) (map[string]interface{}, map[string]string, error) {
+	renamed := make(map[string]interface{}, len(credentials))
+	originals := make(map[string]string, len(credentials))
+	sanitized := make(map[string]string)
+	var invalid []string
+	for k, v := range credentials {
+		key := k
+		if c.Case == SecretKeyCaseUpperSnake {
+			key = toUpperSnakeCase(key)
+		}
+		key = c.Prefix + key
+		if len(validation.IsConfigMapKey(key)) > 0 {
+			if c.Sanitize {
+				unsanitized := key
+				key = sanitizeSecretKey(key)
+				sanitized[key] = unsanitized
+			}
+			// Keys that are too long cannot be sanitized
+			if len(validation.IsConfigMapKey(key)) > 0 {
+				invalid = append(invalid, fmt.Sprintf("%q", key))
+				continue
+			}
+		}
+		if original, ok := originals[key]; ok {
+			return nil, nil, fmt.Errorf("credential keys %q and %q are both renamed to %q", original, k, key)
+		}
+		originals[key] = k
+		renamed[key] = v
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, nil, &invalidSecretKeysError{keys: invalid}
+	}
+	return renamed, sanitized, nil
+}
+
+// sanitizeSecretKey replaces the characters of key that are not valid in
+// secret keys with underscores. The keys ".", ".." and "", which are invalid
+// whatever their characters, are prefixed with an underscore.
+func sanitizeSecretKey(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' || r == '.') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	sanitized := b.String()
+	switch sanitized {
+	case "", ".", "..":
+		sanitized = "_" + sanitized
+	}
+	return sanitized
+}
+
+// toUpperSnakeCase converts camelCase, kebab-case and dotted keys to
+// UPPER_SNAKE_CASE.
+func toUpperSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			b.WriteRune('_')
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
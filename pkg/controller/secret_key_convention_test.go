@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToUpperSnakeCase(t *testing.T) {
+	cases := map[string]string{
+		"password":     "PASSWORD",
+		"apiKey":       "API_KEY",
+		"api-key":      "API_KEY",
+		"database.url": "DATABASE_URL",
+		"HTTPServer":   "HTTP_SERVER",
+		"port2":        "PORT2",
+		"ALREADY_DONE": "ALREADY_DONE",
+	}
+	for in, e := range cases {
+		if a := toUpperSnakeCase(in); e != a {
+			t.Errorf("unexpected result for %q; %s", in, expectedGot(e, a))
+		}
+	}
+}
+
+func TestSecretKeyConventionApply(t *testing.T) {
+	cases := []struct {
+		name        string
+		convention  SecretKeyConvention
+		credentials map[string]interface{}
+		expected    map[string]interface{}
+		// expectedSanitized maps sanitized keys to their unsanitized keys
+		expectedSanitized map[string]string
+		expectedErr       bool
+	}{
+		{
+			name:        "unchanged",
+			credentials: map[string]interface{}{"apiKey": "k"},
+			expected:    map[string]interface{}{"apiKey": "k"},
+		},
+		{
+			name:        "prefix only",
+			convention:  SecretKeyConvention{Prefix: "svc-"},
+			credentials: map[string]interface{}{"apiKey": "k"},
+			expected:    map[string]interface{}{"svc-apiKey": "k"},
+		},
+		{
+			name:        "upper snake case with prefix",
+			convention:  SecretKeyConvention{Case: SecretKeyCaseUpperSnake, Prefix: "SVC_"},
+			credentials: map[string]interface{}{"apiKey": "k", "host": "h"},
+			expected:    map[string]interface{}{"SVC_API_KEY": "k", "SVC_HOST": "h"},
+		},
+		{
+			name:        "keys renamed to the same key",
+			convention:  SecretKeyConvention{Case: SecretKeyCaseUpperSnake},
+			credentials: map[string]interface{}{"apiKey": "k", "api-key": "k"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid keys",
+			credentials: map[string]interface{}{"db/url": "u", "host": "h"},
+			expectedErr: true,
+		},
+		{
+			name:              "invalid keys sanitized",
+			convention:        SecretKeyConvention{Sanitize: true},
+			credentials:       map[string]interface{}{"db/url": "u", "user name": "n", "..": "d", "host.name": "h"},
+			expected:          map[string]interface{}{"db_url": "u", "user_name": "n", "_..": "d", "host.name": "h"},
+			expectedSanitized: map[string]string{"db_url": "db/url", "user_name": "user name", "_..": ".."},
+		},
+		{
+			name:        "sanitized keys collide",
+			convention:  SecretKeyConvention{Sanitize: true},
+			credentials: map[string]interface{}{"db/url": "u", "db_url": "u"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, sanitized, err := tc.convention.apply(tc.credentials)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Fatalf("unexpected credentials; %s", expectedGot(tc.expected, actual))
+			}
+			if tc.expectedSanitized == nil {
+				tc.expectedSanitized = map[string]string{}
+			}
+			if !reflect.DeepEqual(tc.expectedSanitized, sanitized) {
+				t.Fatalf("unexpected sanitized keys; %s", expectedGot(tc.expectedSanitized, sanitized))
+			}
+		})
+	}
+}
+
+func TestSecretKeyConventionValidate(t *testing.T) {
+	valid := []SecretKeyConvention{
+		{},
+		{Case: SecretKeyCaseUpperSnake},
+		{Prefix: "SVC_"},
+	}
+	for _, c := range valid {
+		if err := c.Validate(); err != nil {
+			t.Errorf("unexpected error for %+v: %v", c, err)
+		}
+	}
+
+	invalid := []SecretKeyConvention{
+		{Case: "lower"},
+		{Prefix: "not a key"},
+	}
+	for _, c := range invalid {
+		if err := c.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", c)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+)
+
+// SecretUpdateStrategy tells how the controller writes new credentials to the
+// secret of a binding that already exists.
+type SecretUpdateStrategy string
+
+const (
+	// SecretUpdateStrategyInPlace updates the existing secret, keeping its
+	// UID, labels and annotations. Applications watching the secret see a
+	// single modification.
+	SecretUpdateStrategyInPlace SecretUpdateStrategy = "InPlace"
+	// SecretUpdateStrategyRecreate deletes the existing secret and creates it
+	// again. Applications watching the secret see it deleted and added, and
+	// anything added to the secret by others is dropped.
+	SecretUpdateStrategyRecreate SecretUpdateStrategy = "Recreate"
+)
+
+// ParseSecretUpdateStrategy parses a secret update strategy given by a flag.
+func ParseSecretUpdateStrategy(value string) (SecretUpdateStrategy, error) {
+	switch strategy := SecretUpdateStrategy(value); strategy {
+	case SecretUpdateStrategyInPlace, SecretUpdateStrategyRecreate:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("invalid secret update strategy %q, must be %s or %s",
+		value, SecretUpdateStrategyInPlace, SecretUpdateStrategyRecreate)
+}
+
+// Validate checks that the strategy is known. An empty strategy is InPlace.
+func (s SecretUpdateStrategy) Validate() error {
+	if s == "" {
+		return nil
+	}
+	_, err := ParseSecretUpdateStrategy(string(s))
+	return err
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+)
+
+func TestParseSecretUpdateStrategy(t *testing.T) {
+	for _, value := range []string{"InPlace", "Recreate"} {
+		strategy, err := ParseSecretUpdateStrategy(value)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", value, err)
+		}
+		if string(strategy) != value {
+			t.Errorf("unexpected strategy: %s", expectedGot(value, strategy))
+		}
+	}
+
+	for _, value := range []string{"", "inplace", "Patch"} {
+		if _, err := ParseSecretUpdateStrategy(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+
+	if err := SecretUpdateStrategy("").Validate(); err != nil {
+		t.Errorf("unexpected error for the empty strategy: %v", err)
+	}
+}
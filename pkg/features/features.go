@@ -77,6 +77,20 @@ const (
 	// owner: @carolynvs
 	// alpha: v0.1.32
 	ServicePlanDefaults utilfeature.Feature = "ServicePlanDefaults"
+
+	// BindingSecretDriftDetection enables checking that the secrets of
+	// bindings still hold the credentials written by the controller, and
+	// restoring the secrets that were changed or deleted.
+	// owner: @poy
+	// alpha: v0.1.43
+	BindingSecretDriftDetection utilfeature.Feature = "BindingSecretDriftDetection"
+
+	// ParametersSchemaValidation enables validating the parameters of
+	// bindings, including those read from secrets, against the binding
+	// parameter schema of their plan before sending them to the broker.
+	// owner: @poy
+	// alpha: v0.1.44
+	ParametersSchemaValidation utilfeature.Feature = "ParametersSchemaValidation"
 )
 
 func init() {
@@ -87,12 +101,14 @@ func init() {
 // To add a new feature, define a key for it above and add it here. The features will be
 // available throughout service catalog binaries.
 var defaultServiceCatalogFeatureGates = map[utilfeature.Feature]utilfeature.FeatureSpec{
-	PodPreset:                  {Default: false, PreRelease: utilfeature.Alpha},
-	OriginatingIdentity:        {Default: true, PreRelease: utilfeature.GA},
-	AsyncBindingOperations:     {Default: false, PreRelease: utilfeature.Alpha},
-	NamespacedServiceBroker:    {Default: true, PreRelease: utilfeature.Alpha},
-	ResponseSchema:             {Default: false, PreRelease: utilfeature.Alpha},
-	UpdateDashboardURL:         {Default: false, PreRelease: utilfeature.Alpha},
-	OriginatingIdentityLocking: {Default: true, PreRelease: utilfeature.Alpha},
-	ServicePlanDefaults:        {Default: false, PreRelease: utilfeature.Alpha},
+	PodPreset:                   {Default: false, PreRelease: utilfeature.Alpha},
+	OriginatingIdentity:         {Default: true, PreRelease: utilfeature.GA},
+	AsyncBindingOperations:      {Default: false, PreRelease: utilfeature.Alpha},
+	NamespacedServiceBroker:     {Default: true, PreRelease: utilfeature.Alpha},
+	ResponseSchema:              {Default: false, PreRelease: utilfeature.Alpha},
+	UpdateDashboardURL:          {Default: false, PreRelease: utilfeature.Alpha},
+	OriginatingIdentityLocking:  {Default: true, PreRelease: utilfeature.Alpha},
+	ServicePlanDefaults:         {Default: false, PreRelease: utilfeature.Alpha},
+	BindingSecretDriftDetection: {Default: false, PreRelease: utilfeature.Alpha},
+	ParametersSchemaValidation:  {Default: false, PreRelease: utilfeature.Alpha},
 }
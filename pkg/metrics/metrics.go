@@ -72,6 +72,35 @@ var (
 		},
 		[]string{"broker", "method", "status"},
 	)
+
+	// BrokerRelistCount exposes the number of times the catalog of a Service
+	// Broker was fetched.  The metric is broken out by broker scope
+	// (cluster/namespace), namespace, broker name and result
+	// (success/failure), so that a failing broker can be told apart from the
+	// brokers of the same name in other namespaces.
+	BrokerRelistCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Name:      "broker_relist_count",
+			Help:      "Cumulative number of catalog fetches from Service Brokers grouped by broker scope, namespace, broker name, and result.",
+		},
+		[]string{"scope", "namespace", "broker", "result"},
+	)
+
+	// BrokerOperationLatency exposes the latency of the calls made to Open
+	// Service Brokers.  The metric is broken out by OSB operation
+	// (provision/bind/poll/...) and broker scope (cluster/namespace).  Broker
+	// names are left out to keep the number of series bounded;
+	// OSBRequestCount can be used to tell brokers apart.
+	BrokerOperationLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: catalogNamespace,
+			Name:      "broker_operation_duration_seconds",
+			Help:      "How long in seconds the calls to Service Brokers take, grouped by OSB operation and broker scope.",
+			Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14),
+		},
+		[]string{"operation", "scope"},
+	)
 )
 
 func register(registry *prometheus.Registry) {
@@ -79,6 +108,15 @@ func register(registry *prometheus.Registry) {
 		registry.MustRegister(BrokerServiceClassCount)
 		registry.MustRegister(BrokerServicePlanCount)
 		registry.MustRegister(OSBRequestCount)
+		registry.MustRegister(BrokerRelistCount)
+		registry.MustRegister(BrokerOperationLatency)
+		registry.MustRegister(WorkqueueDepth)
+		registry.MustRegister(WorkqueueAdds)
+		registry.MustRegister(WorkqueueLatency)
+		registry.MustRegister(WorkqueueWorkDuration)
+		registry.MustRegister(WorkqueueUnfinishedWork)
+		registry.MustRegister(WorkqueueLongestRunningProcessor)
+		registry.MustRegister(WorkqueueRetries)
 	})
 }
 
@@ -47,6 +47,11 @@ func NewClient(config *osb.ClientConfiguration) (osb.Client, error) {
 
 var _ osb.CreateFunc = NewClient
 
+// Unwrap returns the client whose calls are proxied.
+func (pc proxyclient) Unwrap() osb.Client {
+	return pc.realOSBClient
+}
+
 const (
 	getCatalog               = "GetCatalog"
 	provisionInstance        = "ProvisionInstance"
@@ -0,0 +1,147 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const workqueueSubsystem = "workqueue"
+
+var (
+	// WorkqueueDepth exposes the current depth of each controller workqueue.
+	WorkqueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Subsystem: workqueueSubsystem,
+			Name:      "depth",
+			Help:      "Current depth of the workqueue.",
+		},
+		[]string{"name"},
+	)
+
+	// WorkqueueAdds exposes the total number of adds handled by each
+	// controller workqueue.
+	WorkqueueAdds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Subsystem: workqueueSubsystem,
+			Name:      "adds_total",
+			Help:      "Total number of adds handled by the workqueue.",
+		},
+		[]string{"name"},
+	)
+
+	// WorkqueueLatency exposes how long items stay in each controller
+	// workqueue before being requested.
+	WorkqueueLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace: catalogNamespace,
+			Subsystem: workqueueSubsystem,
+			Name:      "queue_latency_microseconds",
+			Help:      "How long in microseconds an item stays in the workqueue before being requested.",
+		},
+		[]string{"name"},
+	)
+
+	// WorkqueueWorkDuration exposes how long processing an item from each
+	// controller workqueue takes.
+	WorkqueueWorkDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace: catalogNamespace,
+			Subsystem: workqueueSubsystem,
+			Name:      "work_duration_microseconds",
+			Help:      "How long in microseconds processing an item from the workqueue takes.",
+		},
+		[]string{"name"},
+	)
+
+	// WorkqueueUnfinishedWork exposes how long the items of each controller
+	// workqueue currently being processed have been in progress.
+	WorkqueueUnfinishedWork = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Subsystem: workqueueSubsystem,
+			Name:      "unfinished_work_seconds",
+			Help:      "How many seconds of work has been done that is in progress and hasn't been observed by work_duration.",
+		},
+		[]string{"name"},
+	)
+
+	// WorkqueueLongestRunningProcessor exposes how long the longest running
+	// processor of each controller workqueue has been running.
+	WorkqueueLongestRunningProcessor = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Subsystem: workqueueSubsystem,
+			Name:      "longest_running_processor_microseconds",
+			Help:      "How many microseconds the longest running processor of the workqueue has been running.",
+		},
+		[]string{"name"},
+	)
+
+	// WorkqueueRetries exposes the total number of retries handled by each
+	// controller workqueue.
+	WorkqueueRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Subsystem: workqueueSubsystem,
+			Name:      "retries_total",
+			Help:      "Total number of retries handled by the workqueue.",
+		},
+		[]string{"name"},
+	)
+)
+
+// workqueueMetricsProvider implements workqueue.MetricsProvider by handing
+// out the per queue children of the workqueue metrics above.
+type workqueueMetricsProvider struct{}
+
+// NewWorkqueueMetricsProvider returns a workqueue.MetricsProvider that
+// exposes the metrics of every named workqueue through Prometheus.
+func NewWorkqueueMetricsProvider() workqueue.MetricsProvider {
+	return workqueueMetricsProvider{}
+}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return WorkqueueDepth.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return WorkqueueAdds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.SummaryMetric {
+	return WorkqueueLatency.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.SummaryMetric {
+	return WorkqueueWorkDuration.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return WorkqueueUnfinishedWork.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLongestRunningProcessorMicrosecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return WorkqueueLongestRunningProcessor.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return WorkqueueRetries.WithLabelValues(name)
+}
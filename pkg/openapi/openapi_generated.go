@@ -58,6 +58,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassStatus":       schema_pkg_apis_servicecatalog_v1beta1_CommonServiceClassStatus(ref),
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanSpec":          schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanSpec(ref),
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanStatus":        schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref),
+		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.InstanceOutputReference":        schema_pkg_apis_servicecatalog_v1beta1_InstanceOutputReference(ref),
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference":           schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref),
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference":                schema_pkg_apis_servicecatalog_v1beta1_ObjectReference(ref),
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource":           schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref),
@@ -92,6 +93,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanList":                schema_pkg_apis_servicecatalog_v1beta1_ServicePlanList(ref),
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanSpec":                schema_pkg_apis_servicecatalog_v1beta1_ServicePlanSpec(ref),
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanStatus":              schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref),
+		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateKeyTransform":           schema_pkg_apis_servicecatalog_v1beta1_TemplateKeyTransform(ref),
 		"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo":                       schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref),
 		"github.com/poy/service-catalog/pkg/apis/settings/v1alpha1.PodPreset":                           schema_pkg_apis_settings_v1alpha1_PodPreset(ref),
 		"github.com/poy/service-catalog/pkg/apis/settings/v1alpha1.PodPresetList":                       schema_pkg_apis_settings_v1alpha1_PodPresetList(ref),
@@ -409,15 +411,15 @@ func schema_pkg_apis_servicecatalog_v1beta1_BasicAuthConfig(ref common.Reference
 				Properties: map[string]spec.Schema{
 					"secretRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecretRef is a reference to a Secret containing information the catalog should use to authenticate to this ServiceBroker.\n\nRequired at least one of the fields: - Secret.Data[\"username\"] - username used for authentication - Secret.Data[\"password\"] - password or token needed for authentication",
-							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+							Description: "SecretRef is a reference to a Secret containing information the catalog should use to authenticate to this ServiceBroker.\n\nRequired at least one of the fields: - Secret.Data[\"username\"] - username used for authentication - Secret.Data[\"password\"] - password or token needed for authentication\n\nThe Secret is in the namespace of the ServiceBroker unless a namespace is given, which the controller must be configured to allow.",
+							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"},
+			"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
 	}
 }
 
@@ -429,15 +431,15 @@ func schema_pkg_apis_servicecatalog_v1beta1_BearerTokenAuthConfig(ref common.Ref
 				Properties: map[string]spec.Schema{
 					"secretRef": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecretRef is a reference to a Secret containing information the catalog should use to authenticate to this ServiceBroker.\n\nRequired field: - Secret.Data[\"token\"] - bearer token for authentication",
-							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+							Description: "SecretRef is a reference to a Secret containing information the catalog should use to authenticate to this ServiceBroker.\n\nRequired field: - Secret.Data[\"token\"] - bearer token for authentication\n\nThe Secret is in the namespace of the ServiceBroker unless a namespace is given, which the controller must be configured to allow.",
+							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"),
 						},
 					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"},
+			"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
 	}
 }
 
@@ -445,7 +447,7 @@ func schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref common.Refer
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "CatalogRestrictions is a set of restrictions on which of a broker's services and plans have resources created for them.\n\nSome examples of this object are as follows:\n\nThis is an example of a whitelist on service externalName. Goal: Only list Services with the externalName of FooService and BarService, Solution: restrictions := ServiceCatalogRestrictions{\n\t\tServiceClass: [\"spec.externalName in (FooService, BarService)\"]\n}\n\nThis is an example of a blacklist on service externalName. Goal: Allow all services except the ones with the externalName of FooService and BarService, Solution: restrictions := ServiceCatalogRestrictions{\n\t\tServiceClass: [\"spec.externalName notin (FooService, BarService)\"]\n}\n\nThis whitelists plans called \"Demo\", and blacklists (but only a single element in the list) a service and a plan. Goal: Allow all plans with the externalName demo, but not AABBCC, and not a specific service by name, Solution: restrictions := ServiceCatalogRestrictions{\n\t\tServiceClass: [\"name!=AABBB-CCDD-EEGG-HIJK\"]\n\t\tServicePlan: [\"spec.externalName in (Demo)\", \"name!=AABBCC\"]\n}\n\nCatalogRestrictions strings have a special format similar to Label Selectors, except the catalog supports only a very specific property set.\n\nThe predicate format is expected to be `<property><conditional><requirement>` Check the *Requirements type definition for which <property> strings will be allowed. <conditional> is allowed to be one of the following: ==, !=, in, notin <requirement> will be a string value if `==` or `!=` are used. <requirement> will be a set of string values if `in` or `notin` are used. Multiple predicates are allowed to be chained with a comma (,)\n\nServiceClass allowed property names:\n  name - the value set to [Cluster]ServiceClass.Name\n  spec.externalName - the value set to [Cluster]ServiceClass.Spec.ExternalName\n  spec.externalID - the value set to [Cluster]ServiceClass.Spec.ExternalID\nServicePlan allowed property names:\n  name - the value set to [Cluster]ServicePlan.Name\n  spec.externalName - the value set to [Cluster]ServicePlan.Spec.ExternalName\n  spec.externalID - the value set to [Cluster]ServicePlan.Spec.ExternalID\n  spec.free - the value set to [Cluster]ServicePlan.Spec.Free\n  spec.serviceClass.name - the value set to ServicePlan.Spec.ServiceClassRef.Name\n  spec.clusterServiceClass.name - the value set to ClusterServicePlan.Spec.ClusterServiceClassRef.Name",
+				Description: "CatalogRestrictions is a set of restrictions on which of a broker's services and plans have resources created for them.\n\nSome examples of this object are as follows:\n\nThis is an example of a whitelist on service externalName. Goal: Only list Services with the externalName of FooService and BarService, Solution: restrictions := ServiceCatalogRestrictions{\n\t\tServiceClass: [\"spec.externalName in (FooService, BarService)\"]\n}\n\nThis is an example of a blacklist on service externalName. Goal: Allow all services except the ones with the externalName of FooService and BarService, Solution: restrictions := ServiceCatalogRestrictions{\n\t\tServiceClass: [\"spec.externalName notin (FooService, BarService)\"]\n}\n\nThis whitelists plans called \"Demo\", and blacklists (but only a single element in the list) a service and a plan. Goal: Allow all plans with the externalName demo, but not AABBCC, and not a specific service by name, Solution: restrictions := ServiceCatalogRestrictions{\n\t\tServiceClass: [\"name!=AABBB-CCDD-EEGG-HIJK\"]\n\t\tServicePlan: [\"spec.externalName in (Demo)\", \"name!=AABBCC\"]\n}\n\nCatalogRestrictions strings have a special format similar to Label Selectors, except the catalog supports only a very specific property set.\n\nThe predicate format is expected to be `<property><conditional><requirement>` Check the *Requirements type definition for which <property> strings will be allowed. <conditional> is allowed to be one of the following: ==, !=, in, notin <requirement> will be a string value if `==` or `!=` are used. <requirement> will be a set of string values if `in` or `notin` are used. Multiple predicates are allowed to be chained with a comma (,)\n\nServiceClass allowed property names:\n  name - the value set to [Cluster]ServiceClass.Name\n  spec.externalName - the value set to [Cluster]ServiceClass.Spec.ExternalName\n  spec.externalID - the value set to [Cluster]ServiceClass.Spec.ExternalID\nServicePlan allowed property names:\n  name - the value set to [Cluster]ServicePlan.Name\n  spec.externalName - the value set to [Cluster]ServicePlan.Spec.ExternalName\n  spec.externalID - the value set to [Cluster]ServicePlan.Spec.ExternalID\n  spec.free - the value set to [Cluster]ServicePlan.Spec.Free\n  spec.serviceClass.name - the value set to ServicePlan.Spec.ServiceClassRef.Name\n  spec.clusterServiceClass.name - the value set to ClusterServicePlan.Spec.ClusterServiceClassRef.Name\n\nAllowedTags and DeniedTags restrict the classes by the tags the broker reports for them. A class is only accepted when it has at least one of the allowed tags, if any are given, and none of the denied tags. Plans of a class that is not accepted are not accepted either.",
 				Properties: map[string]spec.Schema{
 					"serviceClass": {
 						SchemaProps: spec.SchemaProps{
@@ -475,6 +477,34 @@ func schema_pkg_apis_servicecatalog_v1beta1_CatalogRestrictions(ref common.Refer
 							},
 						},
 					},
+					"allowedTags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowedTags are the tags of which a class must have at least one to be accepted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"deniedTags": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeniedTags are the tags of which a class must have none to be accepted.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -685,6 +715,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 							Format:      "",
 						},
 					},
+					"allowInsecure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowInsecure allows the Broker URL to use a scheme other than https. Without it, the controller will not send the Broker's credentials over an insecure connection. This is intended for local and development Brokers only.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"caBundle": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CABundle is a PEM encoded CA bundle which will be used to validate a Broker's serving certificate.",
@@ -718,6 +755,20 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerSpec(ref common.
 							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"quiesced": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quiesced stops the creation of new ServiceInstances of the broker's classes, while existing ServiceInstances and ServiceBindings keep being updated and deprovisioned as usual.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"proxyURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProxyURL is the URL of the HTTP proxy that the controller uses to contact the broker, overriding the proxy configured by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the controller. The environment variables are used when it is not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"authInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ClusterServiceBroker.",
@@ -770,6 +821,20 @@ func schema_pkg_apis_servicecatalog_v1beta1_ClusterServiceBrokerStatus(ref commo
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"lastCatalogHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastCatalogHash is a hash of the catalog returned by the Service Broker on the last successful relist. When a relist returns a catalog with the same hash, the controller skips reconciling its classes and plans.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"osbAPIVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OSBAPIVersion is the version of the Open Service Broker API negotiated with the Service Broker on the last successful relist. It is the version used for all the requests made to the Service Broker.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"conditions", "reconciledGeneration"},
 			},
@@ -1238,6 +1303,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref common.R
 							Format:      "",
 						},
 					},
+					"allowInsecure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowInsecure allows the Broker URL to use a scheme other than https. Without it, the controller will not send the Broker's credentials over an insecure connection. This is intended for local and development Brokers only.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"caBundle": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CABundle is a PEM encoded CA bundle which will be used to validate a Broker's serving certificate.",
@@ -1271,6 +1343,20 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerSpec(ref common.R
 							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"quiesced": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quiesced stops the creation of new ServiceInstances of the broker's classes, while existing ServiceInstances and ServiceBindings keep being updated and deprovisioned as usual.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"proxyURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProxyURL is the URL of the HTTP proxy that the controller uses to contact the broker, overriding the proxy configured by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the controller. The environment variables are used when it is not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
@@ -1317,6 +1403,20 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServiceBrokerStatus(ref common
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"lastCatalogHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastCatalogHash is a hash of the catalog returned by the Service Broker on the last successful relist. When a relist returns a catalog with the same hash, the controller skips reconciling its classes and plans.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"osbAPIVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OSBAPIVersion is the version of the Open Service Broker API negotiated with the Service Broker on the last successful relist. It is the version used for all the requests made to the Service Broker.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"conditions", "reconciledGeneration"},
 			},
@@ -1551,6 +1651,27 @@ func schema_pkg_apis_servicecatalog_v1beta1_CommonServicePlanStatus(ref common.R
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_InstanceOutputReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InstanceOutputReference references an output recorded in the status of a ServiceInstance.",
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the output to select. The value is passed to the broker as a parameter of the same name. Currently the only supported output is \"dashboardURL\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_LocalObjectReference(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1610,11 +1731,17 @@ func schema_pkg_apis_servicecatalog_v1beta1_ParametersFromSource(ref common.Refe
 							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"),
 						},
 					},
+					"instanceOutputRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "An output recorded in the status of the ServiceInstance the ServiceBinding refers to. Only valid for ServiceBindings.",
+							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.InstanceOutputReference"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"},
+			"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.InstanceOutputReference", "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"},
 	}
 }
 
@@ -1822,11 +1949,17 @@ func schema_pkg_apis_servicecatalog_v1beta1_SecretTransform(ref common.Reference
 							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform"),
 						},
 					},
+					"templateKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TemplateKey represents a transform that adds a key to the credentials Secret whose value is composed from the other credentials entries",
+							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateKeyTransform"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform", "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform", "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform", "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform"},
+			"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform", "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform", "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform", "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform", "github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateKeyTransform"},
 	}
 }
 
@@ -2047,6 +2180,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 							Format:      "",
 						},
 					},
+					"secretType": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretType is the type of the secret that holds the credentials, such as kubernetes.io/tls for integrations that expect a TLS secret. The credentials are written to the secret regardless of its type, so they must have the keys required by the type, which SecretTransforms can add. Defaults to Opaque.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"secretTransforms": {
 						SchemaProps: spec.SchemaProps{
 							Description: "List of transformations that should be applied to the credentials associated with the ServiceBinding before they are inserted into the Secret.",
@@ -2060,6 +2200,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingSpec(ref common.Refere
 							},
 						},
 					},
+					"rotationRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nRotationRequests is a strictly increasing, non-negative integer counter that can be manually incremented by a user to rotate the credentials of the ServiceBinding. The controller unbinds and binds again at the broker, and replaces the data of the secret with the new credentials.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"externalID": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable.",
@@ -2160,6 +2307,33 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBindingStatus(ref common.Refe
 							Format:      "",
 						},
 					},
+					"operationKey": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nOperationKey identifies the bind operation in progress. It is generated when the operation starts and sent to the broker in the context of every bind request made for the operation, so that a broker can recognize a retried request as one it has already received.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretChecksum": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nSecretChecksum is the checksum of the data the controller last wrote to the secret of the ServiceBinding. It is only set when the BindingSecretDriftDetection feature is enabled, and is used to detect when the secret has been changed or deleted by someone else.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"rotationRequests": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nRotationRequests is the value of Spec.RotationRequests the credentials of the ServiceBinding were last rotated for.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastRotationTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nLastRotationTime is the time at which the credentials of the ServiceBinding were last rotated.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 				Required: []string{"conditions", "asyncOpInProgress", "reconciledGeneration", "orphanMitigationInProgress", "unbindStatus"},
 			},
@@ -2361,6 +2535,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 							Format:      "",
 						},
 					},
+					"allowInsecure": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowInsecure allows the Broker URL to use a scheme other than https. Without it, the controller will not send the Broker's credentials over an insecure connection. This is intended for local and development Brokers only.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
 					"caBundle": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CABundle is a PEM encoded CA bundle which will be used to validate a Broker's serving certificate.",
@@ -2394,6 +2575,20 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerSpec(ref common.Referen
 							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 						},
 					},
+					"quiesced": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Quiesced stops the creation of new ServiceInstances of the broker's classes, while existing ServiceInstances and ServiceBindings keep being updated and deprovisioned as usual.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"proxyURL": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ProxyURL is the URL of the HTTP proxy that the controller uses to contact the broker, overriding the proxy configured by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the controller. The environment variables are used when it is not set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"authInfo": {
 						SchemaProps: spec.SchemaProps{
 							Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ServiceBroker.",
@@ -2446,6 +2641,20 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceBrokerStatus(ref common.Refer
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"lastCatalogHash": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastCatalogHash is a hash of the catalog returned by the Service Broker on the last successful relist. When a relist returns a catalog with the same hash, the controller skips reconciling its classes and plans.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"osbAPIVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "OSBAPIVersion is the version of the Open Service Broker API negotiated with the Service Broker on the last successful relist. It is the version used for all the requests made to the Service Broker.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"conditions", "reconciledGeneration"},
 			},
@@ -2852,6 +3061,34 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstancePropertiesState(ref c
 							Format:      "",
 						},
 					},
+					"clusterServiceClassExternalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceClassExternalID is the external ID of the class that the broker knows this ServiceInstance to be of.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"clusterServiceBrokerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClusterServiceBrokerName is the name of the ClusterServiceBroker that this ServiceInstance was provisioned against.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceClassExternalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceClassExternalID is the external ID of the class that the broker knows this ServiceInstance to be of.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"serviceBrokerName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceBrokerName is the name of the ServiceBroker that this ServiceInstance was provisioned against.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"parameters": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Parameters is a blob of the parameters and their values that the broker knows about for this ServiceInstance.  If a parameter was sourced from a secret, its value will be \"<redacted>\" in this blob.",
@@ -3033,6 +3270,26 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceSpec(ref common.Refer
 							Format:      "int64",
 						},
 					},
+					"disableOrphanMitigation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DisableOrphanMitigation prevents the controller from deprovisioning the instance after a failed provision request that may have left an orphaned resource on the broker. Use it for brokers that clean up failed provisions themselves.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"dependsOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DependsOn lists the instances in the same namespace that must be Ready before the controller provisions this instance. Until then, the instance has a Ready condition with the WaitingForDependencies reason. Instances cannot depend on each other in a cycle.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+									},
+								},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -3074,6 +3331,12 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							Format:      "",
 						},
 					},
+					"orphanMitigationStartTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nOrphanMitigationStartTime is the time at which the orphan mitigation of the ServiceInstance was required. The orphan mitigation of an instance whose policy is Delayed starts once the delay has elapsed since then.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 					"lastOperation": {
 						SchemaProps: spec.SchemaProps{
 							Description: "LastOperation is the string that the broker may have returned when an async operation started, it should be sent back to the broker on poll requests as a query param.",
@@ -3127,6 +3390,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							Ref:         ref("github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState"),
 						},
 					},
+					"externalID": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalID is the ID the ServiceInstance was provisioned with at the broker. It is recorded when the provision request is first sent, and the same ID is sent on every retry of the request, so that the broker can recognize retries of a provision that partially succeeded.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"provisionStatus": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ProvisionStatus describes whether the instance is in the provisioned state.",
@@ -3141,6 +3411,13 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServiceInstanceStatus(ref common.Ref
 							Format:      "",
 						},
 					},
+					"failedDeprovisionAttempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailedDeprovisionAttempts is the number of requests to deprovision the ServiceInstance that failed since its deletion was requested.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 					"defaultProvisionParameters": {
 						SchemaProps: spec.SchemaProps{
 							Description: "DefaultProvisionParameters are the default parameters applied to this instance.",
@@ -3373,6 +3650,41 @@ func schema_pkg_apis_servicecatalog_v1beta1_ServicePlanStatus(ref common.Referen
 	}
 }
 
+func schema_pkg_apis_servicecatalog_v1beta1_TemplateKeyTransform(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TemplateKeyTransform specifies that Service Catalog should add an additional entry to the Secret whose value is rendered from a Go text/template referencing the other credentials entries by key. For example, given the following credentials entries:\n    \"user\": \"johndoe\", \"host\": \"db.example.com\"\nand the following TemplateKeyTransform:\n    {\"key\": \"DSN\", \"template\": \"postgres://{{.user}}@{{.host}}/app\"}\nthe following entry will appear in the Secret:\n    \"DSN\": \"postgres://johndoe@db.example.com/app\"\nIf the template references a key that is not present in the credentials, the binding fails unless a default value is provided.",
+				Properties: map[string]spec.Schema{
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The name of the key to add",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The Go text/template used to render the value. Credentials entries are available to the template by key, for example {{.username}}.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"default": {
+						SchemaProps: spec.SchemaProps{
+							Description: "The value stored under the key when the template references a key that is not present in the credentials.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"key", "template"},
+			},
+		},
+		Dependencies: []string{},
+	}
+}
+
 func schema_pkg_apis_servicecatalog_v1beta1_UserInfo(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
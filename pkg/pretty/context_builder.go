@@ -26,11 +26,14 @@ import (
 // that is important for debugging and tracing. This class helps create log
 // line formatting consistency. Pretty lines should be in the form:
 // <Kind> "<Namespace>/<Name>" v<ResourceVersion>: <message>
+// or, when the resource has an operation in progress:
+// <Kind> "<Namespace>/<Name>" v<ResourceVersion> (operation <OperationID>): <message>
 type ContextBuilder struct {
 	Kind            Kind
 	Namespace       string
 	Name            string
 	ResourceVersion string
+	OperationID     string
 }
 
 // NewInstanceContextBuilder returns a new ContextBuilder that can be used to format messages in the
@@ -91,6 +94,13 @@ func (pcb *ContextBuilder) SetName(n string) *ContextBuilder {
 	return pcb
 }
 
+// SetOperationID sets the ID of the operation in progress to use in the
+// source context for messages.
+func (pcb *ContextBuilder) SetOperationID(id string) *ContextBuilder {
+	pcb.OperationID = id
+	return pcb
+}
+
 // Message returns a string with message prepended with the current source context.
 func (pcb *ContextBuilder) Message(msg string) string {
 	if pcb.Kind > 0 || pcb.Namespace != "" || pcb.Name != "" {
@@ -125,5 +135,8 @@ func (pcb ContextBuilder) String() string {
 	if pcb.ResourceVersion != "" {
 		s += " v" + pcb.ResourceVersion
 	}
+	if pcb.OperationID != "" {
+		s += " (operation " + pcb.OperationID + ")"
+	}
 	return s
 }
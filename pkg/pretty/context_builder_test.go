@@ -166,6 +166,16 @@ func TestPrettyContextBuilderNamespaceNameAndResourceVersion(t *testing.T) {
 	}
 }
 
+func TestPrettyContextBuilderOperationID(t *testing.T) {
+	pcb := NewContextBuilder(ServiceInstance, "Namespace", "Name", "877").SetOperationID("1a2b3c4d")
+	e := `ServiceInstance "Namespace/Name" v877 (operation 1a2b3c4d): msg`
+	g := pcb.Message("msg")
+
+	if g != e {
+		t.Fatalf("Unexpected value of ContextBuilder Message; expected %v, got %v", e, g)
+	}
+}
+
 var bResult string
 
 func BenchmarkPCB(b *testing.B) {
@@ -140,18 +140,20 @@ func (bindingRESTStrategy) PrepareForUpdate(ctx context.Context, new, old runtim
 	}
 	newServiceBinding.Status = oldServiceBinding.Status
 
-	// TODO: We currently don't handle any changes to the spec in the
-	// reconciler. Once we do that, this check needs to be removed and
-	// proper validation of allowed changes needs to be implemented in
-	// ValidateUpdate. Also, the check for whether the generation needs
-	// to be updated needs to be un-commented.
+	// TODO: The reconciler only handles changes to the secret transforms,
+	// which rewrite the secret of the binding, and to the rotation
+	// requests, which rotate its credentials. Once it handles other
+	// changes to the spec, this check needs to be removed and proper
+	// validation of allowed changes needs to be implemented in
+	// ValidateUpdate.
+	secretTransforms := newServiceBinding.Spec.SecretTransforms
+	rotationRequests := newServiceBinding.Spec.RotationRequests
 	newServiceBinding.Spec = oldServiceBinding.Spec
+	newServiceBinding.Spec.SecretTransforms = secretTransforms
+	newServiceBinding.Spec.RotationRequests = rotationRequests
 
 	// Spec updates bump the generation so that we can distinguish between
 	// spec changes and other changes to the object.
-	//
-	// Note that since we do not currently handle any changes to the spec,
-	// the generation will never be incremented
 	if !apiequality.Semantic.DeepEqual(oldServiceBinding.Spec, newServiceBinding.Spec) {
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
 			setServiceBindingUserInfo(ctx, newServiceBinding)
@@ -49,9 +49,6 @@ func getTestInstanceCredential() *servicecatalog.ServiceBinding {
 	}
 }
 
-// TODO: Un-comment "spec-change" test case when there is a field
-// in the spec to which the reconciler allows a change.
-
 // TestInstanceCredentialUpdate tests that generation is incremented correctly when the
 // spec of a ServiceBinding is updated.
 func TestInstanceCredentialUpdate(t *testing.T) {
@@ -66,18 +63,39 @@ func TestInstanceCredentialUpdate(t *testing.T) {
 			older: getTestInstanceCredential(),
 			newer: getTestInstanceCredential(),
 		},
-		//		{
-		//			name:  "spec change",
-		//			older: getTestInstanceCredential(),
-		//			newer: func() *v1beta1.ServiceBinding {
-		//				ic := getTestInstanceCredential()
-		//				ic.Spec.InstanceRef = servicecatalog.LocalObjectReference{
-		//					Name: "new-string",
-		//				}
-		//				return ic
-		//			},
-		//			shouldGenerationIncrement: true,
-		//		},
+		{
+			name:  "secret transforms change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+					{RemoveKey: &servicecatalog.RemoveKeyTransform{Key: "password"}},
+				}
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
+		{
+			name:  "rotation requested",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.RotationRequests = 1
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
+		{
+			name:  "unsupported spec change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.InstanceRef = servicecatalog.LocalObjectReference{
+					Name: "new-string",
+				}
+				return ic
+			}(),
+		},
 	}
 	creatorUserName := "creator"
 	createContext := sctestutil.ContextWithUserName(creatorUserName)
@@ -110,17 +128,17 @@ func TestInstanceCredentialUserInfo(t *testing.T) {
 		t.Errorf("unexpected user info in created spec: expected %q, got %q", e, a)
 	}
 
-	// TODO: Un-comment the following portion of this test when there is a field
-	// in the spec to which the reconciler allows a change.
-
-	//  updaterUserName := "updater"
-	//	updatedInstanceCredential := getTestInstanceCredential()
-	//	updateContext := sctestutil.ContextWithUserName(updaterUserName)
-	//	bindingRESTStrategies.PrepareForUpdate(updateContext, updatedInstanceCredential, createdInstanceCredential)
+	updaterUserName := "updater"
+	updatedInstanceCredential := getTestInstanceCredential()
+	updatedInstanceCredential.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+		{RemoveKey: &servicecatalog.RemoveKeyTransform{Key: "password"}},
+	}
+	updateContext := sctestutil.ContextWithUserName(updaterUserName)
+	bindingRESTStrategies.PrepareForUpdate(updateContext, updatedInstanceCredential, createdInstanceCredential)
 
-	//	if e, a := updaterUserName, updatedInstanceCredential.Spec.UserInfo.Username; e != a {
-	//		t.Errorf("unexpected user info in updated spec: expected %q, got %q", e, a)
-	//	}
+	if e, a := updaterUserName, updatedInstanceCredential.Spec.UserInfo.Username; e != a {
+		t.Errorf("unexpected user info in updated spec: expected %q, got %q", e, a)
+	}
 
 	deleterUserName := "deleter"
 	deletedInstanceCredential := getTestInstanceCredential()
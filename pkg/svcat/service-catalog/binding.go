@@ -26,21 +26,75 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
-// RetrieveBindings lists all bindings in a namespace.
+// RetrieveBindings lists all bindings in a namespace. Use
+// RetrieveBindingsPage to filter them.
 func (sdk *SDK) RetrieveBindings(ns string) (*v1beta1.ServiceBindingList, error) {
-	bindings, err := sdk.ServiceCatalog().ServiceBindings(ns).List(v1.ListOptions{})
+	return sdk.RetrieveBindingsPage(ScopeOptions{Namespace: ns})
+}
+
+// RetrieveBindingsPage lists a page of at most opts.Limit bindings in
+// opts.Namespace, starting from opts.Continue, requesting them in chunks of
+// opts.ChunkSize when it is set. The continue token of the next page is set
+// on the returned list. Bindings are filtered by instance and age after the
+// page is retrieved, so a page may hold fewer bindings than the limit.
+func (sdk *SDK) RetrieveBindingsPage(opts ScopeOptions) (*v1beta1.ServiceBindingList, error) {
+	var bindings *v1beta1.ServiceBindingList
+	_, _, err := listChunks(opts, opts.Continue, func(lopts v1.ListOptions) (int, string, error) {
+		chunk, err := sdk.ServiceCatalog().ServiceBindings(opts.Namespace).List(lopts)
+		if err != nil {
+			return 0, "", err
+		}
+		if bindings == nil {
+			bindings = chunk
+		} else {
+			bindings.Items = append(bindings.Items, chunk.Items...)
+			bindings.ListMeta = chunk.ListMeta
+		}
+		return len(chunk.Items), chunk.Continue, nil
+	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "unable to list bindings in %s", ns)
+		return nil, errors.Wrapf(describeListError(err, opts.FieldSelector), "unable to list bindings in %s", opts.Namespace)
+	}
+
+	if opts.InstanceFilter != "" || opts.filtersByAge() {
+		now := time.Now()
+		filtered := bindings.Items[:0]
+		for _, binding := range bindings.Items {
+			if opts.InstanceFilter != "" && binding.Spec.InstanceRef.Name != opts.InstanceFilter {
+				continue
+			}
+			if opts.matchesAge(binding.CreationTimestamp, now) {
+				filtered = append(filtered, binding)
+			}
+		}
+		bindings.Items = filtered
 	}
 
 	return bindings, nil
 }
 
+// WatchBindings watches the bindings in opts.Namespace, optionally filtered
+// by label selector and field selector, for changes made after
+// opts.ResourceVersion.
+func (sdk *SDK) WatchBindings(opts ScopeOptions) (watch.Interface, error) {
+	w, err := sdk.ServiceCatalog().ServiceBindings(opts.Namespace).Watch(v1.ListOptions{
+		LabelSelector:   opts.LabelSelector,
+		FieldSelector:   opts.FieldSelector,
+		ResourceVersion: opts.ResourceVersion,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(describeListError(err, opts.FieldSelector), "unable to watch bindings in %s", opts.Namespace)
+	}
+	return w, nil
+}
+
 // RetrieveBinding gets a binding by its name.
 func (sdk *SDK) RetrieveBinding(ns, name string) (*v1beta1.ServiceBinding, error) {
 	binding, err := sdk.ServiceCatalog().ServiceBindings(ns).Get(name, v1.GetOptions{})
@@ -213,6 +267,74 @@ func GetBindingStatusCondition(status v1beta1.ServiceBindingStatus) v1beta1.Serv
 	return v1beta1.ServiceBindingCondition{}
 }
 
+// BindingStatus is the overall status of a binding.
+type BindingStatus string
+
+const (
+	// BindingStatusReady means that the binding is ready to be used.
+	BindingStatusReady BindingStatus = "Ready"
+
+	// BindingStatusNotReady means that the binding is not ready yet, for
+	// example because the bind is still in progress or is being retried.
+	BindingStatusNotReady BindingStatus = "NotReady"
+
+	// BindingStatusFailed means that the bind or the unbind of the binding
+	// has failed and will not be retried.
+	BindingStatusFailed BindingStatus = "Failed"
+)
+
+// GetBindingStatus returns the overall status of a binding, derived from its
+// conditions, along with the reason of the condition it was derived from.
+func GetBindingStatus(status v1beta1.ServiceBindingStatus) (BindingStatus, string) {
+	for _, cond := range status.Conditions {
+		if cond.Type == v1beta1.ServiceBindingConditionFailed && cond.Status == v1beta1.ConditionTrue {
+			return BindingStatusFailed, cond.Reason
+		}
+	}
+	if status.UnbindStatus == v1beta1.ServiceBindingUnbindStatusFailed {
+		return BindingStatusFailed, GetBindingStatusCondition(status).Reason
+	}
+	for _, cond := range status.Conditions {
+		if cond.Type == v1beta1.ServiceBindingConditionReady && cond.Status == v1beta1.ConditionTrue {
+			return BindingStatusReady, cond.Reason
+		}
+	}
+	return BindingStatusNotReady, GetBindingStatusCondition(status).Reason
+}
+
+// TouchBindingAnnotation is the annotation that TouchBinding sets to the
+// current time to make the controller process a binding again.
+const TouchBindingAnnotation = "svcat.servicecatalog.k8s.io/touched-at"
+
+// TouchBinding updates an annotation on a binding to make service catalog
+// process it again, so that a bind that failed with an error it retries does
+// not have to wait for the next retry. It returns the updated binding.
+func (sdk *SDK) TouchBinding(ns, name string, retries int) (*v1beta1.ServiceBinding, error) {
+	for j := 0; j < retries; j++ {
+		binding, err := sdk.RetrieveBinding(ns, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if binding.Annotations == nil {
+			binding.Annotations = map[string]string{}
+		}
+		binding.Annotations[TouchBindingAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+
+		updated, err := sdk.ServiceCatalog().ServiceBindings(ns).Update(binding)
+		if err == nil {
+			return updated, nil
+		}
+		// if we didn't get a conflict, no idea what happened
+		if !apierrors.IsConflict(err) {
+			return nil, fmt.Errorf("could not touch binding (%s)", err)
+		}
+	}
+
+	// conflict after `retries` tries
+	return nil, fmt.Errorf("could not touch binding after %d tries", retries)
+}
+
 // WaitForBinding waits for the instance to complete the current operation (or fail).
 func (sdk *SDK) WaitForBinding(ns, name string, interval time.Duration, timeout *time.Duration) (binding *v1beta1.ServiceBinding, err error) {
 	if timeout == nil {
@@ -239,6 +361,41 @@ func (sdk *SDK) WaitForBinding(ns, name string, interval time.Duration, timeout
 	return binding, err
 }
 
+// WaitForBindingToNotExist waits for the specified binding to no longer
+// exist, across asynchronous unbind operations and the retries of failed
+// unbind requests. When the unbind failed and will not be retried, the
+// binding is returned. onPoll, when not nil, is called with the binding each
+// time it is polled.
+func (sdk *SDK) WaitForBindingToNotExist(ns, name string, interval time.Duration, timeout *time.Duration, onPoll func(*v1beta1.ServiceBinding)) (binding *v1beta1.ServiceBinding, err error) {
+	if timeout == nil {
+		notimeout := time.Duration(math.MaxInt64)
+		timeout = &notimeout
+	}
+
+	err = wait.PollImmediate(interval, *timeout,
+		func() (bool, error) {
+			binding, err = sdk.ServiceCatalog().ServiceBindings(ns).Get(name, v1.GetOptions{})
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					binding = nil
+					err = nil
+				}
+				return true, err
+			}
+			if onPoll != nil {
+				onPoll(binding)
+			}
+			return IsBindingUnbindFailed(binding), nil
+		})
+	return binding, err
+}
+
+// IsBindingUnbindFailed returns if the unbind of the binding has failed and
+// will not be retried.
+func IsBindingUnbindFailed(binding *v1beta1.ServiceBinding) bool {
+	return binding.Status.UnbindStatus == v1beta1.ServiceBindingUnbindStatusFailed
+}
+
 // IsBindingReady returns true if the instance is in the Ready status.
 func (sdk *SDK) IsBindingReady(binding *v1beta1.ServiceBinding) bool {
 	return sdk.bindingHasStatus(binding, v1beta1.ServiceBindingConditionReady)
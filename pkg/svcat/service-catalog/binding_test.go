@@ -18,9 +18,11 @@ package servicecatalog_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -49,6 +51,25 @@ var _ = Describe("Binding", func() {
 		}
 	})
 
+	Describe("WatchBindings", func() {
+		It("Calls the generated v1beta1 Watch method with the specified options", func() {
+			w, err := sdk.WatchBindings(ScopeOptions{
+				Namespace:       sb.Namespace,
+				LabelSelector:   "app=wordpress",
+				ResourceVersion: "42",
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			defer w.Stop()
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("watch", "servicebindings")).To(BeTrue())
+			restrictions := actions[0].(testing.WatchActionImpl).GetWatchRestrictions()
+			Expect(actions[0].GetNamespace()).To(Equal(sb.Namespace))
+			Expect(restrictions.Labels.String()).To(Equal("app=wordpress"))
+			Expect(restrictions.ResourceVersion).To(Equal("42"))
+		})
+	})
+
 	Describe("RetrieveBinding", func() {
 		It("Calls the generated v1beta1 Get method with the passed in binding and namespace", func() {
 			binding, err := sdk.RetrieveBinding(sb.Namespace, sb.Name)
@@ -96,6 +117,30 @@ var _ = Describe("Binding", func() {
 			Expect(err.Error()).Should(ContainSubstring(errorMessage))
 			Expect(badClient.Actions()[0].Matches("list", "servicebindings")).To(BeTrue())
 		})
+		It("Filters the bindings by instance", func() {
+			sb.Spec.InstanceRef.Name = "mysql"
+			sb2.Spec.InstanceRef.Name = "redis"
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(sb, sb2)
+
+			bindings, err := sdk.RetrieveBindingsPage(ScopeOptions{Namespace: sb.Namespace, InstanceFilter: "redis"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bindings.Items).Should(ConsistOf(*sb2))
+		})
+		It("Filters the bindings by age", func() {
+			sb.CreationTimestamp = metav1.NewTime(time.Now().Add(-60 * 24 * time.Hour))
+			sb2.CreationTimestamp = metav1.NewTime(time.Now().Add(-24 * time.Hour))
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(sb, sb2)
+
+			bindings, err := sdk.RetrieveBindingsPage(ScopeOptions{
+				Namespace: sb.Namespace,
+				OlderThan: 7 * 24 * time.Hour,
+				NewerThan: 90 * 24 * time.Hour,
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bindings.Items).Should(ConsistOf(*sb))
+		})
 	})
 
 	Describe("RetrieveBindingsByInstance", func() {
@@ -173,6 +218,45 @@ var _ = Describe("Binding", func() {
 		})
 	})
 
+	Describe("TouchBinding", func() {
+		It("Sets the touch annotation on the binding", func() {
+			binding, err := sdk.TouchBinding(sb.Namespace, sb.Name, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Name).To(Equal(sb.Name))
+			Expect(binding.Annotations).To(HaveKey(TouchBindingAnnotation))
+			actions := svcCatClient.Actions()
+			Expect(actions).To(HaveLen(2))
+			Expect(actions[0].Matches("get", "servicebindings")).To(BeTrue())
+			Expect(actions[1].Matches("update", "servicebindings")).To(BeTrue())
+		})
+
+		It("Retries on conflicts", func() {
+			conflicts := 1
+			svcCatClient.PrependReactor("update", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				if conflicts > 0 {
+					conflicts--
+					return true, nil, apierrors.NewConflict(v1beta1.Resource("servicebindings"), sb.Name, fmt.Errorf("conflict"))
+				}
+				return false, nil, nil
+			})
+
+			binding, err := sdk.TouchBinding(sb.Namespace, sb.Name, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding.Annotations).To(HaveKey(TouchBindingAnnotation))
+			Expect(svcCatClient.Actions()).To(HaveLen(4))
+		})
+
+		It("Bubbles up errors", func() {
+			binding, err := sdk.TouchBinding(sb.Namespace, "missing", 3)
+
+			Expect(binding).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("not found"))
+		})
+	})
+
 	Describe("Unbind", func() {
 		It("Calls the generated v1beta1 method to delete a binding", func() {
 			instanceNamespace := sb.Namespace
@@ -312,4 +396,126 @@ var _ = Describe("Binding", func() {
 			Expect(deletedBindings[sb2.Name]).To(Equal(sb2.Namespace))
 		})
 	})
+	Describe("WaitForBindingToNotExist", func() {
+		var (
+			counter    int
+			interval   time.Duration
+			timeout    time.Duration
+			waitClient *fake.Clientset
+		)
+		BeforeEach(func() {
+			counter = 0
+			interval = 100 * time.Millisecond
+			timeout = 1 * time.Second
+			waitClient = &fake.Clientset{}
+			waitClient.AddReactor("get", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				counter++
+				return true, sb, nil
+			})
+			sdk.ServiceCatalogClient = waitClient
+		})
+		It("Polls the binding through an asynchronous unbind until it no longer exists", func() {
+			unbinding := sb.DeepCopy()
+			unbinding.Status.AsyncOpInProgress = true
+			unbinding.Status.CurrentOperation = v1beta1.ServiceBindingOperationUnbind
+			waitClient.PrependReactor("get", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				counter++
+				if counter > 3 {
+					return true, nil, apierrors.NewNotFound(v1beta1.Resource("servicebinding"), sb.Name)
+				}
+				return true, unbinding, nil
+			})
+			polled := 0
+			binding, err := sdk.WaitForBindingToNotExist(sb.Namespace, sb.Name, interval, &timeout, func(b *v1beta1.ServiceBinding) {
+				Expect(b.Name).To(Equal(sb.Name))
+				polled++
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding).To(BeNil())
+			Expect(polled).To(Equal(3))
+			for _, v := range waitClient.Actions() {
+				Expect(v.Matches("get", "servicebindings")).To(BeTrue())
+				Expect(v.(testing.GetActionImpl).Name).To(Equal(sb.Name))
+				Expect(v.(testing.GetActionImpl).Namespace).To(Equal(sb.Namespace))
+			}
+		})
+		It("Keeps waiting while a failed unbind is retried", func() {
+			retrying := sb.DeepCopy()
+			retrying.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusRequired
+			retrying.Status.Conditions = []v1beta1.ServiceBindingCondition{
+				{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionUnknown, Reason: "UnbindCallFailed"},
+			}
+			waitClient.PrependReactor("get", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				counter++
+				if counter > 2 {
+					return true, nil, apierrors.NewNotFound(v1beta1.Resource("servicebinding"), sb.Name)
+				}
+				return true, retrying, nil
+			})
+			binding, err := sdk.WaitForBindingToNotExist(sb.Namespace, sb.Name, interval, &timeout, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding).To(BeNil())
+			Expect(waitClient.Actions()).To(HaveLen(3))
+		})
+		It("Stops waiting when the unbind has failed", func() {
+			failed := sb.DeepCopy()
+			failed.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusFailed
+			waitClient.PrependReactor("get", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, failed, nil
+			})
+			binding, err := sdk.WaitForBindingToNotExist(sb.Namespace, sb.Name, interval, &timeout, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(binding).To(Equal(failed))
+			Expect(waitClient.Actions()).To(HaveLen(1))
+		})
+		It("Times out if the binding never goes away", func() {
+			binding, err := sdk.WaitForBindingToNotExist(sb.Namespace, sb.Name, interval, &timeout, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("timed out"))
+			Expect(binding).ToNot(BeNil())
+		})
+	})
+	Describe("GetBindingStatus", func() {
+		It("Reports a binding with a true ready condition as ready", func() {
+			status, reason := GetBindingStatus(v1beta1.ServiceBindingStatus{
+				Conditions: []v1beta1.ServiceBindingCondition{
+					{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue, Reason: "InjectedBindResult"},
+				},
+			})
+			Expect(status).To(Equal(BindingStatusReady))
+			Expect(reason).To(Equal("InjectedBindResult"))
+		})
+		It("Reports a binding with a true failed condition as failed", func() {
+			status, reason := GetBindingStatus(v1beta1.ServiceBindingStatus{
+				Conditions: []v1beta1.ServiceBindingCondition{
+					{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionFalse, Reason: "BindCallFailed"},
+					{Type: v1beta1.ServiceBindingConditionFailed, Status: v1beta1.ConditionTrue, Reason: "ReconciliationRetryTimeout"},
+				},
+			})
+			Expect(status).To(Equal(BindingStatusFailed))
+			Expect(reason).To(Equal("ReconciliationRetryTimeout"))
+		})
+		It("Reports a binding whose unbind failed as failed", func() {
+			status, _ := GetBindingStatus(v1beta1.ServiceBindingStatus{
+				UnbindStatus: v1beta1.ServiceBindingUnbindStatusFailed,
+				Conditions: []v1beta1.ServiceBindingCondition{
+					{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue, Reason: "InjectedBindResult"},
+				},
+			})
+			Expect(status).To(Equal(BindingStatusFailed))
+		})
+		It("Reports any other binding as not ready", func() {
+			status, reason := GetBindingStatus(v1beta1.ServiceBindingStatus{
+				Conditions: []v1beta1.ServiceBindingCondition{
+					{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionFalse, Reason: "ErrorNonexistentServiceInstance"},
+				},
+			})
+			Expect(status).To(Equal(BindingStatusNotReady))
+			Expect(reason).To(Equal("ErrorNonexistentServiceInstance"))
+
+			status, reason = GetBindingStatus(v1beta1.ServiceBindingStatus{})
+			Expect(status).To(Equal(BindingStatusNotReady))
+			Expect(reason).To(BeEmpty())
+		})
+	})
 })
@@ -24,6 +24,8 @@ import (
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/pkg/errors"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -38,6 +40,9 @@ type Broker interface {
 	// GetNamespace returns the broker's namespace, or "" if it's cluster-scoped.
 	GetNamespace() string
 
+	// GetLabels returns the broker's labels.
+	GetLabels() map[string]string
+
 	// GetURL returns the broker's URL.
 	GetURL() string
 
@@ -71,11 +76,11 @@ func (sdk *SDK) RetrieveBrokers(opts ScopeOptions) ([]Broker, error) {
 	var brokers []Broker
 
 	if opts.Scope.Matches(ClusterScope) {
-		csb, err := sdk.ServiceCatalog().ClusterServiceBrokers().List(v1.ListOptions{})
+		csb, err := sdk.listClusterServiceBrokers()
 		if err != nil {
 			return nil, fmt.Errorf("unable to list cluster-scoped brokers (%s)", err)
 		}
-		for _, b := range csb.Items {
+		for _, b := range csb {
 			broker := b
 			brokers = append(brokers, &broker)
 		}
@@ -101,7 +106,7 @@ func (sdk *SDK) RetrieveBrokers(opts ScopeOptions) ([]Broker, error) {
 
 // RetrieveBroker gets a broker by its name.
 func (sdk *SDK) RetrieveBroker(name string) (*v1beta1.ClusterServiceBroker, error) {
-	broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
+	broker, err := sdk.getClusterServiceBroker(name)
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to get broker '%s'", name)
 	}
@@ -109,11 +114,54 @@ func (sdk *SDK) RetrieveBroker(name string) (*v1beta1.ClusterServiceBroker, erro
 	return broker, nil
 }
 
+// RetrieveBrokerByName gets a broker by its name, looking for it at the
+// scopes given by opts. An ambiguous error is returned when both a cluster
+// and a namespaced broker have the name.
+func (sdk *SDK) RetrieveBrokerByName(name string, opts ScopeOptions) (Broker, error) {
+	var searchResults []Broker
+
+	if opts.Scope.Matches(ClusterScope) {
+		csb, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, v1.GetOptions{})
+		if err == nil {
+			searchResults = append(searchResults, csb)
+		} else if !apierrors.IsNotFound(err) {
+			return nil, wrapError(err, "unable to get broker '%s'", name)
+		}
+	}
+
+	if opts.Scope.Matches(NamespaceScope) && opts.Namespace != "" {
+		sb, err := sdk.ServiceCatalog().ServiceBrokers(opts.Namespace).Get(name, v1.GetOptions{})
+		if err == nil {
+			searchResults = append(searchResults, sb)
+		} else if !apierrors.IsNotFound(err) {
+			return nil, wrapError(err, "unable to get broker '%s'", name)
+		}
+	}
+
+	if len(searchResults) > 1 {
+		candidates := make([]string, 0, len(searchResults))
+		for _, broker := range searchResults {
+			if broker.GetNamespace() == "" {
+				candidates = append(candidates, broker.GetName())
+			} else {
+				candidates = append(candidates, broker.GetNamespace()+"/"+broker.GetName())
+			}
+		}
+		return nil, newAmbiguousError(candidates, "more than one matching broker found for '%s'", name)
+	}
+
+	if len(searchResults) == 0 {
+		return nil, newNotFoundError("broker '%s' not found", name)
+	}
+
+	return searchResults[0], nil
+}
+
 // RetrieveNamespacedBroker gets a broker by its name & namespace.
 func (sdk *SDK) RetrieveNamespacedBroker(namespace string, name string) (*v1beta1.ServiceBroker, error) {
 	broker, err := sdk.ServiceCatalog().ServiceBrokers(namespace).Get(name, v1.GetOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("unable to get broker '%s' (%s)", name, err)
+		return nil, wrapError(err, "unable to get broker '%s'", name)
 	}
 
 	return broker, nil
@@ -123,7 +171,7 @@ func (sdk *SDK) RetrieveNamespacedBroker(namespace string, name string) (*v1beta
 func (sdk *SDK) RetrieveBrokerByClass(class *v1beta1.ClusterServiceClass,
 ) (*v1beta1.ClusterServiceBroker, error) {
 	brokerName := class.Spec.ClusterServiceBrokerName
-	broker, err := sdk.ServiceCatalog().ClusterServiceBrokers().Get(brokerName, v1.GetOptions{})
+	broker, err := sdk.getClusterServiceBroker(brokerName)
 	if err != nil {
 		return nil, err
 	}
@@ -141,8 +189,32 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, s
 		}
 
 	}
+	if opts.Username == "" {
+		return sdk.createBroker(brokerName, url, caBytes, opts, scopeOpts)
+	}
+
+	if err := sdk.createBasicAuthSecret(opts); err != nil {
+		return nil, err
+	}
+	broker, err := sdk.createBroker(brokerName, url, caBytes, opts, scopeOpts)
+	if err != nil {
+		// Remove the secret again so that registering the broker a second
+		// time does not fail because the secret already exists.
+		delErr := sdk.Core().Secrets(opts.Namespace).Delete(opts.BasicSecret, &v1.DeleteOptions{})
+		if delErr != nil && !apierrors.IsNotFound(delErr) {
+			return nil, fmt.Errorf("%s, and the secret %s/%s could not be deleted (%s)", err, opts.Namespace, opts.BasicSecret, delErr)
+		}
+		return nil, err
+	}
+	return broker, nil
+}
+
+// createBroker creates the cluster or namespaced broker of the Register
+// request.
+func (sdk *SDK) createBroker(brokerName string, url string, caBytes []byte, opts *RegisterOptions, scopeOpts *ScopeOptions) (Broker, error) {
 	objectMeta := v1.ObjectMeta{Name: brokerName}
 	commonServiceBrokerSpec := v1beta1.CommonServiceBrokerSpec{
+		AllowInsecure:         opts.AllowInsecure,
 		CABundle:              caBytes,
 		InsecureSkipTLSVerify: opts.SkipTLS,
 		RelistBehavior:        opts.RelistBehavior,
@@ -180,7 +252,7 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, s
 
 		result, err := sdk.ServiceCatalog().ClusterServiceBrokers().Create(request)
 		if err != nil {
-			return nil, fmt.Errorf("register request failed (%s)", err)
+			return nil, registerError(err, opts, opts.Namespace)
 		}
 
 		return result, nil
@@ -194,7 +266,7 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, s
 	if opts.BasicSecret != "" {
 		request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
 			Basic: &v1beta1.BasicAuthConfig{
-				SecretRef: &v1beta1.LocalObjectReference{
+				SecretRef: &v1beta1.ObjectReference{
 					Name: opts.BasicSecret,
 				},
 			},
@@ -202,7 +274,7 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, s
 	} else if opts.BearerSecret != "" {
 		request.Spec.AuthInfo = &v1beta1.ServiceBrokerAuthInfo{
 			Bearer: &v1beta1.BearerTokenAuthConfig{
-				SecretRef: &v1beta1.LocalObjectReference{
+				SecretRef: &v1beta1.ObjectReference{
 					Name: opts.BearerSecret,
 				},
 			},
@@ -211,11 +283,125 @@ func (sdk *SDK) Register(brokerName string, url string, opts *RegisterOptions, s
 
 	result, err := sdk.ServiceCatalog().ServiceBrokers(scopeOpts.Namespace).Create(request)
 	if err != nil {
-		return nil, fmt.Errorf("register request failed (%s)", err)
+		return nil, registerError(err, opts, scopeOpts.Namespace)
 	}
 	return result, nil
 }
 
+// createBasicAuthSecret creates the secret referenced by --basic-secret from
+// the username and password provided in the register options.
+func (sdk *SDK) createBasicAuthSecret(opts *RegisterOptions) error {
+	if opts.BasicSecret == "" {
+		return fmt.Errorf("a secret name is required to store the broker credentials")
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      opts.BasicSecret,
+			Namespace: opts.Namespace,
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		StringData: map[string]string{
+			corev1.BasicAuthUsernameKey: opts.Username,
+			corev1.BasicAuthPasswordKey: opts.Password,
+		},
+	}
+	_, err := sdk.Core().Secrets(opts.Namespace).Create(secret)
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("the secret %s/%s already exists, reference it as the basic auth secret instead of passing a username and password, or choose another secret name", opts.Namespace, opts.BasicSecret)
+		}
+		if apierrors.IsForbidden(err) {
+			return fmt.Errorf("you do not have permission to create the secret %s/%s (%s)", opts.Namespace, opts.BasicSecret, err)
+		}
+		return fmt.Errorf("unable to create the secret %s/%s (%s)", opts.Namespace, opts.BasicSecret, err)
+	}
+	return nil
+}
+
+// verifyBrokerTimeoutSeconds is how long VerifyBroker waits for the catalog of
+// a broker.
+const verifyBrokerTimeoutSeconds = 10
+
+// VerifyBroker checks that the broker at the given URL answers a request for
+// its catalog, with the credentials, CA and TLS settings that a broker
+// registered with the same options would use. The credentials are taken from
+// the username and password of the options or, when they are not set, from
+// the basic or bearer secret in the namespace of the options.
+func (sdk *SDK) VerifyBroker(url string, opts *RegisterOptions) error {
+	config := osb.DefaultClientConfiguration()
+	config.URL = url
+	config.Insecure = opts.SkipTLS
+	config.TimeoutSeconds = verifyBrokerTimeoutSeconds
+	if opts.CAFile != "" {
+		caBytes, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return fmt.Errorf("Error opening CA file: %v", err.Error())
+		}
+		config.CAData = caBytes
+	}
+	authConfig, err := sdk.verifyBrokerAuthConfig(opts)
+	if err != nil {
+		return err
+	}
+	config.AuthConfig = authConfig
+
+	client, err := osb.NewClient(config)
+	if err != nil {
+		return fmt.Errorf("unable to create a client for the broker at %s (%s)", url, err)
+	}
+	if _, err := client.GetCatalog(); err != nil {
+		return fmt.Errorf("unable to get the catalog of the broker at %s (%s)", url, err)
+	}
+	return nil
+}
+
+// verifyBrokerAuthConfig returns the credentials VerifyBroker sends to the
+// broker, if any.
+func (sdk *SDK) verifyBrokerAuthConfig(opts *RegisterOptions) (*osb.AuthConfig, error) {
+	if opts.Username != "" {
+		return &osb.AuthConfig{
+			BasicAuthConfig: &osb.BasicAuthConfig{Username: opts.Username, Password: opts.Password},
+		}, nil
+	}
+	secretName := opts.BasicSecret
+	if secretName == "" {
+		secretName = opts.BearerSecret
+	}
+	if secretName == "" {
+		return nil, nil
+	}
+	secret, err := sdk.Core().Secrets(opts.Namespace).Get(secretName, v1.GetOptions{})
+	if err != nil {
+		return nil, wrapError(err, "unable to get the secret %s/%s", opts.Namespace, secretName)
+	}
+	if opts.BasicSecret != "" {
+		return &osb.AuthConfig{
+			BasicAuthConfig: &osb.BasicAuthConfig{
+				Username: string(secret.Data[corev1.BasicAuthUsernameKey]),
+				Password: string(secret.Data[corev1.BasicAuthPasswordKey]),
+			},
+		}, nil
+	}
+	// The controller reads bearer tokens from the token key of the secret.
+	return &osb.AuthConfig{
+		BearerConfig: &osb.BearerConfig{Token: string(secret.Data["token"])},
+	}, nil
+}
+
+// registerError explains a failed register request. The broker auth admission
+// check rejects brokers whose auth secret the user cannot access, so a
+// forbidden error is reported against the referenced secret.
+func registerError(err error, opts *RegisterOptions, namespace string) error {
+	secretName := opts.BasicSecret
+	if secretName == "" {
+		secretName = opts.BearerSecret
+	}
+	if apierrors.IsForbidden(err) && secretName != "" {
+		return fmt.Errorf("register request failed, you may not have access to the secret %s/%s referenced by the broker (%s)", namespace, secretName, err)
+	}
+	return fmt.Errorf("register request failed (%s)", err)
+}
+
 // Sync or relist a broker to refresh its broker metadata.
 func (sdk *SDK) Sync(name string, scopeOpts ScopeOptions, retries int) error {
 	success := false
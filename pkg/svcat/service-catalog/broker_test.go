@@ -19,12 +19,18 @@ package servicecatalog_test
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 
 	. "github.com/poy/service-catalog/pkg/svcat/service-catalog"
@@ -243,6 +249,54 @@ var _ = Describe("Broker", func() {
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(brokerName))
 		})
 	})
+	Describe("RetrieveBrokerByName", func() {
+		It("Gets a cluster-scoped broker", func() {
+			broker, err := sdk.RetrieveBrokerByName(csb2.Name, ScopeOptions{Scope: ClusterScope, Namespace: "ns2"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(csb2))
+			actions := svcCatClient.Actions()
+			Expect(actions).To(HaveLen(1))
+			Expect(actions[0].Matches("get", "clusterservicebrokers")).To(BeTrue())
+		})
+		It("Gets a namespaced broker", func() {
+			broker, err := sdk.RetrieveBrokerByName(sb2.Name, ScopeOptions{Scope: NamespaceScope, Namespace: "ns2"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).To(Equal(sb2))
+			actions := svcCatClient.Actions()
+			Expect(actions).To(HaveLen(1))
+			Expect(actions[0].Matches("get", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("ns2"))
+		})
+		It("Reports brokers with the same name at both scopes as ambiguous", func() {
+			_, err := sdk.RetrieveBrokerByName(csb.Name, ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(IsAmbiguous(err)).To(BeTrue())
+			Expect(AmbiguousCandidates(err)).To(ConsistOf("foobar", "default/foobar"))
+		})
+		It("Reports a missing broker as not found", func() {
+			_, err := sdk.RetrieveBrokerByName("banana", ScopeOptions{Scope: AllScope, Namespace: "default"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(IsNotFound(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("broker 'banana' not found"))
+		})
+		It("Bubbles up errors", func() {
+			badClient := &fake.Clientset{}
+			errorMessage := "error retrieving broker"
+			badClient.AddReactor("get", "servicebrokers", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			_, err := sdk.RetrieveBrokerByName("foobar", ScopeOptions{Scope: NamespaceScope, Namespace: "default"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(errorMessage))
+		})
+	})
 	Describe("RetrieveBrokerByClass", func() {
 		It("Calls the generated v1beta1 List method with the passed in class's parent broker", func() {
 			sc := &v1beta1.ClusterServiceClass{Spec: v1beta1.ClusterServiceClassSpec{ClusterServiceBrokerName: csb.Name}}
@@ -345,6 +399,124 @@ var _ = Describe("Broker", func() {
 			Expect(objectFromRequest.Spec.URL).To(Equal(url))
 			Expect(objectFromRequest.Spec.AuthInfo.Bearer.SecretRef.Name).To(Equal(bearerSecret))
 		})
+		It("creates the basic auth secret when a username and password are provided", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			basicSecret := "potatobasicsecret"
+			k8sClient := k8sfake.NewSimpleClientset()
+			sdk.K8sClient = k8sClient
+			opts := &RegisterOptions{
+				BasicSecret: basicSecret,
+				Namespace:   namespace,
+				Username:    "potatouser",
+				Password:    "potatopassword",
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     NamespaceScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker).NotTo(BeNil())
+			k8sActions := k8sClient.Actions()
+			Expect(k8sActions[0].Matches("create", "secrets")).To(BeTrue())
+			secret := k8sActions[0].(testing.CreateActionImpl).Object.(*corev1.Secret)
+			Expect(secret.Name).To(Equal(basicSecret))
+			Expect(secret.Namespace).To(Equal(namespace))
+			Expect(secret.StringData).To(Equal(map[string]string{
+				"username": "potatouser",
+				"password": "potatopassword",
+			}))
+			actions := svcCatClient.Actions()
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ServiceBroker)
+			Expect(objectFromRequest.Spec.AuthInfo.Basic.SecretRef.Name).To(Equal(basicSecret))
+		})
+		It("deletes the basic auth secret when the broker cannot be created", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			basicSecret := "potatobasicsecret"
+			k8sClient := k8sfake.NewSimpleClientset()
+			sdk.K8sClient = k8sClient
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("create", "servicebrokers", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("error creating broker")
+			})
+			sdk.ServiceCatalogClient = badClient
+			opts := &RegisterOptions{
+				BasicSecret: basicSecret,
+				Namespace:   namespace,
+				Username:    "potatouser",
+				Password:    "potatopassword",
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     NamespaceScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(broker).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("error creating broker"))
+			k8sActions := k8sClient.Actions()
+			Expect(k8sActions).To(HaveLen(2))
+			Expect(k8sActions[0].Matches("create", "secrets")).To(BeTrue())
+			Expect(k8sActions[1].Matches("delete", "secrets")).To(BeTrue())
+			Expect(k8sActions[1].(testing.DeleteActionImpl).Name).To(Equal(basicSecret))
+		})
+		It("Explains that the basic auth secret already exists", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			namespace := "potatonamespace"
+			basicSecret := "potatobasicsecret"
+			k8sClient := k8sfake.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: basicSecret, Namespace: namespace},
+			})
+			sdk.K8sClient = k8sClient
+			opts := &RegisterOptions{
+				BasicSecret: basicSecret,
+				Namespace:   namespace,
+				Username:    "potatouser",
+				Password:    "potatopassword",
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: namespace,
+				Scope:     NamespaceScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(broker).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the secret potatonamespace/potatobasicsecret already exists"))
+			Expect(svcCatClient.Actions()).To(BeEmpty())
+		})
+		It("Explains forbidden errors caused by the auth secret", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("create", "servicebrokers", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "servicebrokers"}, brokerName, errors.New("broker forbidden access to auth secret"))
+			})
+			sdk.ServiceCatalogClient = badClient
+			opts := &RegisterOptions{
+				BasicSecret: "potatobasicsecret",
+			}
+			scopeOpts := &ScopeOptions{
+				Namespace: "default",
+				Scope:     NamespaceScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(broker).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("you may not have access to the secret default/potatobasicsecret"))
+		})
 		It("Bubbles up namespace service broker errors", func() {
 			errorMessage := "error provisioning broker"
 			brokerName := "potato_broker"
@@ -413,6 +585,24 @@ var _ = Describe("Broker", func() {
 			Expect(objectFromRequest.Spec.RelistDuration).To(Equal(relistDuration))
 			Expect(objectFromRequest.Spec.CatalogRestrictions.ServicePlan).To(Equal(planRestrictions))
 		})
+		It("creates a cluster service broker that allows an insecure URL", func() {
+			brokerName := "potato_broker"
+			url := "http://potato.com"
+			opts := &RegisterOptions{
+				AllowInsecure: true,
+			}
+			scopeOpts := &ScopeOptions{
+				Scope: ClusterScope,
+			}
+
+			broker, err := sdk.Register(brokerName, url, opts, scopeOpts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker.GetSpec().AllowInsecure).To(BeTrue())
+			actions := svcCatClient.Actions()
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ClusterServiceBroker)
+			Expect(objectFromRequest.Spec.AllowInsecure).To(BeTrue())
+		})
 		It("creates a cluster service broker with a bearer secret", func() {
 			brokerName := "potato_broker"
 			url := "http://potato.com"
@@ -510,6 +700,71 @@ var _ = Describe("Broker", func() {
 			Expect(actions[0].(testing.GetActionImpl).Name).To(Equal(csb.Name))
 		})
 	})
+	Describe("VerifyBroker", func() {
+		var (
+			server *httptest.Server
+			path   string
+			auth   string
+		)
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				auth = r.Header.Get("Authorization")
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(`{"services": []}`))
+			}))
+		})
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("gets the catalog of the broker with the credentials of the basic secret", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "broker-auth", Namespace: "potatonamespace"},
+				Data: map[string][]byte{
+					corev1.BasicAuthUsernameKey: []byte("admin"),
+					corev1.BasicAuthPasswordKey: []byte("s3cr3t"),
+				},
+			}
+			sdk.K8sClient = k8sfake.NewSimpleClientset(secret)
+
+			err := sdk.VerifyBroker(server.URL, &RegisterOptions{BasicSecret: "broker-auth", Namespace: "potatonamespace"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("/v2/catalog"))
+			Expect(auth).To(Equal("Basic YWRtaW46czNjcjN0"))
+		})
+		It("sends the bearer token of the bearer secret", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "broker-token", Namespace: "potatonamespace"},
+				Data:       map[string][]byte{"token": []byte("potato")},
+			}
+			sdk.K8sClient = k8sfake.NewSimpleClientset(secret)
+
+			err := sdk.VerifyBroker(server.URL, &RegisterOptions{BearerSecret: "broker-token", Namespace: "potatonamespace"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(auth).To(Equal("Bearer potato"))
+		})
+		It("fails when the broker does not return its catalog", func() {
+			server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			})
+
+			err := sdk.VerifyBroker(server.URL, &RegisterOptions{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to get the catalog of the broker at " + server.URL))
+		})
+		It("fails when the secret does not exist", func() {
+			sdk.K8sClient = k8sfake.NewSimpleClientset()
+
+			err := sdk.VerifyBroker(server.URL, &RegisterOptions{BasicSecret: "broker-auth", Namespace: "potatonamespace"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to get the secret potatonamespace/broker-auth"))
+		})
+	})
 	Describe("WaitForBroker", func() {
 		var (
 			counter        int
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/client/informers_generated/externalversions"
+	listers "github.com/poy/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// catalogCache holds informer-backed listers for the cluster-scoped catalog
+// resources, which are read far more often than they change.
+type catalogCache struct {
+	brokers listers.ClusterServiceBrokerLister
+	classes listers.ClusterServiceClassLister
+	plans   listers.ClusterServicePlanLister
+}
+
+// EnableCache starts informers for cluster-scoped brokers, classes and plans
+// and serves subsequent reads of those resources from the informer caches
+// instead of the API server. It blocks until the caches have synced. The
+// informers run until stopCh is closed. Reads of all other resources, and
+// all writes, still go directly to the API server.
+func (sdk *SDK) EnableCache(resync time.Duration, stopCh <-chan struct{}) error {
+	factory := externalversions.NewSharedInformerFactory(sdk.ServiceCatalogClient, resync)
+	informers := factory.Servicecatalog().V1beta1()
+	brokers := informers.ClusterServiceBrokers()
+	classes := informers.ClusterServiceClasses()
+	plans := informers.ClusterServicePlans()
+
+	// Informer() registers the informers with the factory, so it must be
+	// called before the factory is started.
+	synced := []cache.InformerSynced{
+		brokers.Informer().HasSynced,
+		classes.Informer().HasSynced,
+		plans.Informer().HasSynced,
+	}
+	factory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, synced...) {
+		return fmt.Errorf("unable to sync the service catalog cache")
+	}
+
+	sdk.cache = &catalogCache{
+		brokers: brokers.Lister(),
+		classes: classes.Lister(),
+		plans:   plans.Lister(),
+	}
+	return nil
+}
+
+func (sdk *SDK) listClusterServiceBrokers() ([]v1beta1.ClusterServiceBroker, error) {
+	if sdk.cache == nil {
+		list, err := sdk.ServiceCatalog().ClusterServiceBrokers().List(metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+
+	cached, err := sdk.cache.brokers.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	brokers := make([]v1beta1.ClusterServiceBroker, 0, len(cached))
+	for _, b := range cached {
+		brokers = append(brokers, *b.DeepCopy())
+	}
+	return brokers, nil
+}
+
+func (sdk *SDK) getClusterServiceBroker(name string) (*v1beta1.ClusterServiceBroker, error) {
+	if sdk.cache == nil {
+		return sdk.ServiceCatalog().ClusterServiceBrokers().Get(name, metav1.GetOptions{})
+	}
+
+	broker, err := sdk.cache.brokers.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return broker.DeepCopy(), nil
+}
+
+// listClusterServiceClasses lists the cluster-scoped classes from the cache,
+// when there is one. The cache cannot filter by field, so classes are listed
+// from the server when a field selector is given.
+func (sdk *SDK) listClusterServiceClasses(opts metav1.ListOptions) ([]v1beta1.ClusterServiceClass, string, error) {
+	// The cache can neither filter on fields nor hand out pages.
+	if sdk.cache == nil || opts.FieldSelector != "" || opts.Limit > 0 || opts.Continue != "" {
+		list, err := sdk.ServiceCatalog().ClusterServiceClasses().List(opts)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, list.Continue, nil
+	}
+
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, "", err
+	}
+	cached, err := sdk.cache.classes.List(selector)
+	if err != nil {
+		return nil, "", err
+	}
+	classes := make([]v1beta1.ClusterServiceClass, 0, len(cached))
+	for _, c := range cached {
+		classes = append(classes, *c.DeepCopy())
+	}
+	return classes, "", nil
+}
+
+func (sdk *SDK) getClusterServiceClass(name string) (*v1beta1.ClusterServiceClass, error) {
+	if sdk.cache == nil {
+		return sdk.ServiceCatalog().ClusterServiceClasses().Get(name, metav1.GetOptions{})
+	}
+
+	class, err := sdk.cache.classes.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return class.DeepCopy(), nil
+}
+
+func (sdk *SDK) getClusterServicePlan(name string) (*v1beta1.ClusterServicePlan, error) {
+	if sdk.cache == nil {
+		return sdk.ServiceCatalog().ClusterServicePlans().Get(name, metav1.GetOptions{})
+	}
+
+	plan, err := sdk.cache.plans.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return plan.DeepCopy(), nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/testing"
+
+	. "github.com/poy/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+	var (
+		sdk          *SDK
+		svcCatClient *fake.Clientset
+		csb          *v1beta1.ClusterServiceBroker
+		csc          *v1beta1.ClusterServiceClass
+		csp          *v1beta1.ClusterServicePlan
+		stopCh       chan struct{}
+	)
+
+	BeforeEach(func() {
+		csb = &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}}
+		csc = &v1beta1.ClusterServiceClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobarclass"},
+			Spec:       v1beta1.ClusterServiceClassSpec{ClusterServiceBrokerName: csb.Name},
+		}
+		csp = &v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "foobarplan"}}
+		svcCatClient = fake.NewSimpleClientset(csb, csc, csp)
+		sdk = &SDK{
+			ServiceCatalogClient: svcCatClient,
+		}
+		stopCh = make(chan struct{})
+	})
+
+	AfterEach(func() {
+		close(stopCh)
+	})
+
+	Describe("EnableCache", func() {
+		It("serves cluster-scoped catalog reads from the cache", func() {
+			err := sdk.EnableCache(0, stopCh)
+			Expect(err).NotTo(HaveOccurred())
+			svcCatClient.ClearActions()
+
+			broker, err := sdk.RetrieveBroker(csb.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker.Name).To(Equal(csb.Name))
+
+			brokers, err := sdk.RetrieveBrokers(ScopeOptions{Scope: ClusterScope})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(brokers).To(HaveLen(1))
+
+			class, err := sdk.RetrieveClassByID(csc.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(class.Name).To(Equal(csc.Name))
+
+			broker, err = sdk.RetrieveBrokerByClass(class)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(broker.Name).To(Equal(csb.Name))
+
+			plan, err := sdk.RetrievePlanByID(csp.Name, ScopeOptions{Scope: ClusterScope})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.GetName()).To(Equal(csp.Name))
+
+			Expect(svcCatClient.Actions()).To(BeEmpty())
+		})
+		It("returns copies that do not modify the cache", func() {
+			err := sdk.EnableCache(0, stopCh)
+			Expect(err).NotTo(HaveOccurred())
+
+			class, err := sdk.RetrieveClassByID(csc.Name)
+			Expect(err).NotTo(HaveOccurred())
+			class.Spec.ClusterServiceBrokerName = "modified"
+
+			class, err = sdk.RetrieveClassByID(csc.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(class.Spec.ClusterServiceBrokerName).To(Equal(csb.Name))
+		})
+		It("continues to call the API server for other resources", func() {
+			err := sdk.EnableCache(0, stopCh)
+			Expect(err).NotTo(HaveOccurred())
+			svcCatClient.ClearActions()
+
+			_, err = sdk.RetrieveBrokers(ScopeOptions{Namespace: "default", Scope: NamespaceScope})
+			Expect(err).NotTo(HaveOccurred())
+
+			actions := svcCatClient.Actions()
+			Expect(actions).To(HaveLen(1))
+			Expect(actions[0].Matches("list", "servicebrokers")).To(BeTrue())
+			Expect(actions[0].(testing.ListActionImpl).Namespace).To(Equal("default"))
+		})
+	})
+})
@@ -19,6 +19,7 @@ package servicecatalog
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -48,6 +49,9 @@ type Class interface {
 	// GetNamespace returns the class's namespace, or "" if it's cluster-scoped.
 	GetNamespace() string
 
+	// GetLabels returns the class's labels.
+	GetLabels() map[string]string
+
 	// GetExternalName returns the class's external name.
 	GetExternalName() string
 
@@ -67,37 +71,104 @@ type Class interface {
 
 // RetrieveClasses lists all classes defined in the cluster.
 func (sdk *SDK) RetrieveClasses(opts ScopeOptions) ([]Class, error) {
+	classes, _, err := sdk.RetrieveClassesPage(opts)
+	return classes, err
+}
+
+// RetrieveClassesPage lists a page of at most opts.Limit classes, starting
+// from opts.Continue. The returned continue token is empty once all classes
+// were listed. Classes are filtered by opts.Tags after the page is retrieved,
+// so a page may hold fewer classes than the limit.
+func (sdk *SDK) RetrieveClassesPage(opts ScopeOptions) ([]Class, string, error) {
 	var classes []Class
-	if opts.Scope.Matches(ClusterScope) {
-		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(metav1.ListOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("unable to list cluster-scoped classes (%s)", err)
-		}
-		for _, c := range csc.Items {
-			class := c
-			classes = append(classes, &class)
+	next, err := listScopePages(opts, func(scope Scope, lopts metav1.ListOptions) (int, string, error) {
+		if scope == ClusterScope {
+			csc, next, err := sdk.listClusterServiceClasses(lopts)
+			if err != nil {
+				return 0, "", fmt.Errorf("unable to list cluster-scoped classes (%s)", describeListError(err, opts.FieldSelector))
+			}
+			for _, c := range csc {
+				class := c
+				if opts.Broker != "" && class.GetServiceBrokerName() != opts.Broker {
+					continue
+				}
+				if opts.ExcludeRemoved && IsClassRemoved(&class) {
+					continue
+				}
+				classes = append(classes, &class)
+			}
+			return len(csc), next, nil
 		}
-	}
 
-	if opts.Scope.Matches(NamespaceScope) {
-		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(metav1.ListOptions{})
+		sc, err := sdk.ServiceCatalog().ServiceClasses(opts.Namespace).List(lopts)
 		if err != nil {
 			// Gracefully handle when the feature-flag for namespaced broker resources isn't enabled on the server.
 			if apierrors.IsNotFound(err) {
-				return classes, nil
+				return 0, "", nil
 			}
-			return nil, fmt.Errorf("unable to list classes in %q (%s)", opts.Namespace, err)
+			return 0, "", fmt.Errorf("unable to list classes in %q (%s)", opts.Namespace, describeListError(err, opts.FieldSelector))
 		}
 		for _, c := range sc.Items {
 			class := c
+			if opts.Broker != "" && class.GetServiceBrokerName() != opts.Broker {
+				continue
+			}
+			if opts.ExcludeRemoved && IsClassRemoved(&class) {
+				continue
+			}
 			classes = append(classes, &class)
 		}
+		return len(sc.Items), sc.Continue, nil
+	})
+	if err != nil {
+		return nil, "", err
 	}
 
-	return classes, nil
+	return filterClassesByTags(classes, opts.Tags), next, nil
 }
 
-// RetrieveClassByName gets a class by its external name.
+// IsClassRemoved returns if the broker of the class removed it from its
+// catalog. Such classes are kept while instances still use them.
+func IsClassRemoved(class Class) bool {
+	switch c := class.(type) {
+	case *v1beta1.ClusterServiceClass:
+		return c.Status.RemovedFromBrokerCatalog
+	case *v1beta1.ServiceClass:
+		return c.Status.RemovedFromBrokerCatalog
+	}
+	return false
+}
+
+// filterClassesByTags returns the classes that have all of the tags, ignoring
+// case.
+func filterClassesByTags(classes []Class, tags []string) []Class {
+	if len(tags) == 0 {
+		return classes
+	}
+
+	var filtered []Class
+	for _, class := range classes {
+		classTags := map[string]bool{}
+		for _, tag := range class.GetSpec().Tags {
+			classTags[strings.ToLower(tag)] = true
+		}
+		matches := true
+		for _, tag := range tags {
+			if !classTags[strings.ToLower(tag)] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, class)
+		}
+	}
+	return filtered
+}
+
+// RetrieveClassByName gets a class by its external name. When more than one
+// broker offers a class with the name, an ambiguous error is returned unless
+// opts.Broker selects one of them.
 func (sdk *SDK) RetrieveClassByName(name string, opts ScopeOptions) (Class, error) {
 	var searchResults []Class
 
@@ -108,11 +179,14 @@ func (sdk *SDK) RetrieveClassByName(name string, opts ScopeOptions) (Class, erro
 	if opts.Scope.Matches(ClusterScope) {
 		csc, err := sdk.ServiceCatalog().ClusterServiceClasses().List(lopts)
 		if err != nil {
-			return nil, fmt.Errorf("unable to search classes by name (%s)", err)
+			return nil, wrapError(err, "unable to search classes by name")
 		}
 
 		for _, c := range csc.Items {
 			class := c
+			if opts.Broker != "" && class.GetServiceBrokerName() != opts.Broker {
+				continue
+			}
 			searchResults = append(searchResults, &class)
 		}
 	}
@@ -124,30 +198,40 @@ func (sdk *SDK) RetrieveClassByName(name string, opts ScopeOptions) (Class, erro
 			if apierrors.IsNotFound(err) {
 				sc = &v1beta1.ServiceClassList{}
 			} else {
-				return nil, fmt.Errorf("unable to search classes by name (%s)", err)
+				return nil, wrapError(err, "unable to search classes by name")
 			}
 		}
 
 		for _, c := range sc.Items {
 			class := c
+			if opts.Broker != "" && class.GetServiceBrokerName() != opts.Broker {
+				continue
+			}
 			searchResults = append(searchResults, &class)
 		}
 	}
 
 	if len(searchResults) > 1 {
-		return nil, fmt.Errorf("more than one matching class found for '%s' %d", name, len(searchResults))
+		candidates := make([]string, 0, len(searchResults))
+		for _, class := range searchResults {
+			candidates = append(candidates, class.GetName())
+		}
+		return nil, newAmbiguousError(candidates, "more than one matching class found for '%s'", name)
 	}
 
 	if len(searchResults) == 0 {
+		if opts.Broker != "" {
+			return nil, newNotFoundError("class '%s' not found in broker %s", name, opts.Broker)
+		}
 		if opts.Scope.Matches(ClusterScope) {
-			return nil, fmt.Errorf("class '%s' not found in cluster scope", name)
+			return nil, newNotFoundError("class '%s' not found in cluster scope", name)
 		} else if opts.Scope.Matches(NamespaceScope) {
 			if opts.Namespace == "" {
-				return nil, fmt.Errorf("class '%s' not found in any namespace", name)
+				return nil, newNotFoundError("class '%s' not found in any namespace", name)
 			}
-			return nil, fmt.Errorf("class '%s' not found in namespace %s", name, opts.Namespace)
+			return nil, newNotFoundError("class '%s' not found in namespace %s", name, opts.Namespace)
 		}
-		return nil, fmt.Errorf("class '%s' not found", name)
+		return nil, newNotFoundError("class '%s' not found", name)
 	}
 
 	return searchResults[0], nil
@@ -155,9 +239,9 @@ func (sdk *SDK) RetrieveClassByName(name string, opts ScopeOptions) (Class, erro
 
 // RetrieveClassByID gets a class by its Kubernetes name.
 func (sdk *SDK) RetrieveClassByID(kubeName string) (*v1beta1.ClusterServiceClass, error) {
-	class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(kubeName, metav1.GetOptions{})
+	class, err := sdk.getClusterServiceClass(kubeName)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get class (%s)", err)
+		return nil, wrapError(err, "unable to get class")
 	}
 	return class, nil
 }
@@ -165,9 +249,9 @@ func (sdk *SDK) RetrieveClassByID(kubeName string) (*v1beta1.ClusterServiceClass
 // RetrieveClassByPlan gets the class associated to a plan.
 func (sdk *SDK) RetrieveClassByPlan(plan Plan) (*v1beta1.ClusterServiceClass, error) {
 	// Retrieve the class as well because plans don't have the external class name
-	class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(plan.GetClassID(), metav1.GetOptions{})
+	class, err := sdk.getClusterServiceClass(plan.GetClassID())
 	if err != nil {
-		return nil, fmt.Errorf("unable to get class (%s)", err)
+		return nil, wrapError(err, "unable to get class")
 	}
 
 	return class, nil
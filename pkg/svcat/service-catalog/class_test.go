@@ -71,6 +71,43 @@ var _ = Describe("Class", func() {
 			Expect(svcCatClient.Actions()[0].Matches("list", "serviceclasses")).To(BeTrue())
 
 		})
+		It("Filters by tags", func() {
+			csc.Spec.Tags = []string{"database", "MySQL"}
+			csc2.Spec.Tags = []string{"database"}
+			sc.Spec.Tags = []string{"mysql"}
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(csc, csc2, sc, sc2)
+
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope, Tags: []string{"mysql"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc, sc))
+
+			classes, err = sdk.RetrieveClasses(ScopeOptions{Scope: AllScope, Tags: []string{"database", "mysql"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc))
+		})
+		It("Filters by broker", func() {
+			csc.Spec.ClusterServiceBrokerName = "sql-broker"
+			csc2.Spec.ClusterServiceBrokerName = "other-broker"
+			sc.Spec.ServiceBrokerName = "sql-broker"
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(csc, csc2, sc, sc2)
+
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope, Broker: "sql-broker"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc, sc))
+		})
+		It("Filters out removed classes", func() {
+			csc2.Status.RemovedFromBrokerCatalog = true
+			sc.Status.RemovedFromBrokerCatalog = true
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(csc, csc2, sc, sc2)
+
+			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: AllScope, ExcludeRemoved: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc, sc2))
+
+			classes, err = sdk.RetrieveClasses(ScopeOptions{Scope: AllScope})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc, csc2, sc, sc2))
+		})
 		It("Filters by cluster scope", func() {
 			classes, err := sdk.RetrieveClasses(ScopeOptions{Scope: ClusterScope, Namespace: "default"})
 
@@ -97,6 +134,100 @@ var _ = Describe("Class", func() {
 			Expect(badClient.Actions()[0].Matches("list", "clusterserviceclasses")).To(BeTrue())
 		})
 	})
+	Describe("RetrieveClassesPage", func() {
+		It("Returns the continue token of a truncated cluster-scoped page", func() {
+			svcCatClient.PrependReactor("list", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ClusterServiceClassList{
+					ListMeta: metav1.ListMeta{Continue: "abc"},
+					Items:    []v1beta1.ClusterServiceClass{*csc},
+				}, nil
+			})
+
+			classes, next, err := sdk.RetrieveClassesPage(ScopeOptions{Scope: AllScope, Limit: 1})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc))
+			Expect(next).To(Equal("cluster:abc"))
+			Expect(len(svcCatClient.Actions())).Should(Equal(1))
+		})
+		It("Fills the rest of the page with namespaced classes", func() {
+			svcCatClient.PrependReactor("list", "serviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ServiceClassList{
+					ListMeta: metav1.ListMeta{Continue: "def"},
+					Items:    []v1beta1.ServiceClass{*sc},
+				}, nil
+			})
+
+			classes, next, err := sdk.RetrieveClassesPage(ScopeOptions{Scope: AllScope, Namespace: "default", Limit: 3})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc, csc2, sc))
+			Expect(next).To(Equal("namespace:def"))
+			Expect(svcCatClient.Actions()[0].Matches("list", "clusterserviceclasses")).To(BeTrue())
+			Expect(svcCatClient.Actions()[1].Matches("list", "serviceclasses")).To(BeTrue())
+		})
+		It("Resumes with the namespaced classes once the cluster-scoped classes fill a page", func() {
+			classes, next, err := sdk.RetrieveClassesPage(ScopeOptions{Scope: AllScope, Namespace: "default", Limit: 2})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc, csc2))
+			Expect(next).To(Equal("namespace:"))
+			Expect(len(svcCatClient.Actions())).Should(Equal(1))
+
+			classes, next, err = sdk.RetrieveClassesPage(ScopeOptions{Scope: AllScope, Namespace: "default", Limit: 2, Continue: next})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(sc))
+			Expect(next).To(BeEmpty())
+			Expect(len(svcCatClient.Actions())).Should(Equal(2))
+			Expect(svcCatClient.Actions()[1].Matches("list", "serviceclasses")).To(BeTrue())
+		})
+		It("Stops requesting chunks once the page is full", func() {
+			chunks := []*v1beta1.ClusterServiceClassList{
+				{ListMeta: metav1.ListMeta{Continue: "abc"}, Items: []v1beta1.ClusterServiceClass{*csc}},
+				{ListMeta: metav1.ListMeta{Continue: "def"}, Items: []v1beta1.ClusterServiceClass{*csc2}},
+			}
+			svcCatClient.PrependReactor("list", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				chunk := chunks[0]
+				chunks = chunks[1:]
+				return true, chunk, nil
+			})
+
+			classes, next, err := sdk.RetrieveClassesPage(ScopeOptions{Scope: AllScope, Namespace: "default", Limit: 2, ChunkSize: 1})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc, csc2))
+			Expect(next).To(Equal("cluster:def"))
+			Expect(len(svcCatClient.Actions())).Should(Equal(2))
+		})
+		It("Retrieves all the classes of every scope in chunks", func() {
+			chunks := []*v1beta1.ClusterServiceClassList{
+				{ListMeta: metav1.ListMeta{Continue: "abc"}, Items: []v1beta1.ClusterServiceClass{*csc}},
+				{Items: []v1beta1.ClusterServiceClass{*csc2}},
+			}
+			svcCatClient.PrependReactor("list", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				chunk := chunks[0]
+				chunks = chunks[1:]
+				return true, chunk, nil
+			})
+
+			classes, next, err := sdk.RetrieveClassesPage(ScopeOptions{Scope: AllScope, Namespace: "default", ChunkSize: 1})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(classes).Should(ConsistOf(csc, csc2, sc))
+			Expect(next).To(BeEmpty())
+			Expect(svcCatClient.Actions()[0].Matches("list", "clusterserviceclasses")).To(BeTrue())
+			Expect(svcCatClient.Actions()[1].Matches("list", "clusterserviceclasses")).To(BeTrue())
+			Expect(svcCatClient.Actions()[2].Matches("list", "serviceclasses")).To(BeTrue())
+		})
+		It("Rejects a continue token for another scope", func() {
+			_, _, err := sdk.RetrieveClassesPage(ScopeOptions{Scope: ClusterScope, Limit: 2, Continue: "namespace:abc"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("invalid continue token"))
+			Expect(svcCatClient.Actions()).To(BeEmpty())
+		})
+	})
 	Describe("RetrieveClassByName", func() {
 		It("Calls the generated v1beta1 List method with the passed in class name", func() {
 			className := csc.Name
@@ -126,6 +257,31 @@ var _ = Describe("Class", func() {
 			Expect(requirements[0].Field).To(Equal("spec.externalName"))
 			Expect(requirements[0].Value).To(Equal(className))
 		})
+		It("Reports a name offered by more than one broker as ambiguous", func() {
+			csc.Spec.ExternalName = "mysqldb"
+			csc.Spec.ClusterServiceBrokerName = "sql-broker"
+			csc2.Spec.ExternalName = "mysqldb"
+			csc2.Spec.ClusterServiceBrokerName = "other-broker"
+			realClient := &fake.Clientset{}
+			realClient.AddReactor("list", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ClusterServiceClassList{Items: []v1beta1.ClusterServiceClass{*csc, *csc2}}, nil
+			})
+			sdk = &SDK{
+				ServiceCatalogClient: realClient,
+			}
+
+			_, err := sdk.RetrieveClassByName("mysqldb", ScopeOptions{Scope: ClusterScope})
+			Expect(err).To(HaveOccurred())
+			Expect(IsAmbiguous(err)).To(BeTrue())
+
+			class, err := sdk.RetrieveClassByName("mysqldb", ScopeOptions{Scope: ClusterScope, Broker: "other-broker"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(class).To(Equal(csc2))
+
+			_, err = sdk.RetrieveClassByName("mysqldb", ScopeOptions{Scope: ClusterScope, Broker: "missing-broker"})
+			Expect(IsNotFound(err)).To(BeTrue())
+			Expect(err.Error()).To(Equal("class 'mysqldb' not found in broker missing-broker"))
+		})
 		It("Bubbles up errors", func() {
 			className := "notreal_class"
 			emptyClient := &fake.Clientset{}
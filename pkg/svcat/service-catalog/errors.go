@@ -0,0 +1,159 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrorReason is the reason why a request of the SDK failed.
+type ErrorReason string
+
+const (
+	// ReasonNotFound means that the requested resource does not exist.
+	ReasonNotFound ErrorReason = "NotFound"
+
+	// ReasonForbidden means that the user is not allowed to access the
+	// requested resource.
+	ReasonForbidden ErrorReason = "Forbidden"
+
+	// ReasonAmbiguous means that more than one resource matched the request.
+	ReasonAmbiguous ErrorReason = "Ambiguous"
+)
+
+// Error is an error returned by the SDK with a reason that callers can check
+// with IsNotFound, IsForbidden and IsAmbiguous.
+type Error struct {
+	// Reason is why the request failed.
+	Reason ErrorReason
+
+	// Candidates are the names of the resources that matched an ambiguous
+	// request.
+	Candidates []string
+
+	message string
+	cause   error
+}
+
+// Error returns the message of the error.
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Cause returns the error this error was caused by, if any.
+func (e *Error) Cause() error {
+	return e.cause
+}
+
+// causer is implemented by errors that wrap the error they were caused by.
+type causer interface {
+	Cause() error
+}
+
+// newNotFoundError returns an error with the NotFound reason.
+func newNotFoundError(format string, args ...interface{}) *Error {
+	return &Error{
+		Reason:  ReasonNotFound,
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// newAmbiguousError returns an error with the Ambiguous reason, listing the
+// candidates that matched after its message.
+func newAmbiguousError(candidates []string, format string, args ...interface{}) *Error {
+	return &Error{
+		Reason:     ReasonAmbiguous,
+		Candidates: candidates,
+		message:    fmt.Sprintf(format, args...) + ": " + strings.Join(candidates, ", "),
+	}
+}
+
+// wrapError adds a message to an error returned by the API server, keeping
+// the reason of the error when it is one the SDK reports.
+func wrapError(err error, format string, args ...interface{}) error {
+	message := fmt.Sprintf("%s (%s)", fmt.Sprintf(format, args...), err)
+	reason := reasonForError(err)
+	if reason == "" {
+		return fmt.Errorf("%s", message)
+	}
+	return &Error{
+		Reason:  reason,
+		message: message,
+		cause:   err,
+	}
+}
+
+// reasonForError returns the reason of an error, looking through the errors
+// it was caused by. It returns an empty reason when the error is not one the
+// SDK reports.
+func reasonForError(err error) ErrorReason {
+	for err != nil {
+		switch {
+		case apierrors.IsNotFound(err):
+			return ReasonNotFound
+		case apierrors.IsForbidden(err):
+			return ReasonForbidden
+		}
+		if e, ok := err.(*Error); ok {
+			return e.Reason
+		}
+		cause, ok := err.(causer)
+		if !ok {
+			return ""
+		}
+		err = cause.Cause()
+	}
+	return ""
+}
+
+// IsNotFound returns true if the error means that the requested resource
+// does not exist.
+func IsNotFound(err error) bool {
+	return reasonForError(err) == ReasonNotFound
+}
+
+// IsForbidden returns true if the error means that the user is not allowed to
+// access the requested resource.
+func IsForbidden(err error) bool {
+	return reasonForError(err) == ReasonForbidden
+}
+
+// IsAmbiguous returns true if the error means that more than one resource
+// matched the request. Use AmbiguousCandidates to get the resources that
+// matched.
+func IsAmbiguous(err error) bool {
+	return reasonForError(err) == ReasonAmbiguous
+}
+
+// AmbiguousCandidates returns the names of the resources that matched an
+// ambiguous request, or nil if the error is not ambiguous.
+func AmbiguousCandidates(err error) []string {
+	for err != nil {
+		if e, ok := err.(*Error); ok && e.Reason == ReasonAmbiguous {
+			return e.Candidates
+		}
+		cause, ok := err.(causer)
+		if !ok {
+			return nil
+		}
+		err = cause.Cause()
+	}
+	return nil
+}
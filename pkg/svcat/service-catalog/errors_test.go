@@ -0,0 +1,104 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/testing"
+
+	. "github.com/poy/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Errors", func() {
+	var sdk *SDK
+
+	BeforeEach(func() {
+		sdk = &SDK{}
+	})
+
+	It("Reports a missing class as not found", func() {
+		sdk.ServiceCatalogClient = fake.NewSimpleClientset()
+
+		_, err := sdk.RetrieveClassByName("foo", ScopeOptions{Scope: ClusterScope})
+
+		Expect(err).To(HaveOccurred())
+		Expect(IsNotFound(err)).To(BeTrue())
+		Expect(IsForbidden(err)).To(BeFalse())
+		Expect(IsAmbiguous(err)).To(BeFalse())
+	})
+	It("Reports a forbidden get of a class as forbidden", func() {
+		client := &fake.Clientset{}
+		client.AddReactor("get", "clusterserviceclasses", func(action testing.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "clusterserviceclasses"}, "foo", errors.New("denied"))
+		})
+		sdk.ServiceCatalogClient = client
+
+		_, err := sdk.RetrieveClassByID("foo")
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to get class"))
+		Expect(IsForbidden(err)).To(BeTrue())
+		Expect(IsNotFound(err)).To(BeFalse())
+	})
+	It("Reports a plan matching in several classes as ambiguous", func() {
+		sdk.ServiceCatalogClient = fake.NewSimpleClientset(
+			&v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "plan-a"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec:  v1beta1.CommonServicePlanSpec{ExternalName: "default"},
+					ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: "class-a"},
+				},
+			},
+			&v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "plan-b"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec:  v1beta1.CommonServicePlanSpec{ExternalName: "default"},
+					ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: "class-b"},
+				},
+			},
+		)
+
+		_, err := sdk.RetrievePlanByName("default", ScopeOptions{Scope: ClusterScope})
+
+		Expect(err).To(HaveOccurred())
+		Expect(IsAmbiguous(err)).To(BeTrue())
+		Expect(AmbiguousCandidates(err)).To(ConsistOf("class-a/default", "class-b/default"))
+		Expect(err.Error()).To(ContainSubstring("more than one matching plan found for 'default'"))
+	})
+	It("Looks through wrapped errors", func() {
+		err := errors.Wrap(apierrors.NewNotFound(schema.GroupResource{Resource: "servicebindings"}, "foo"), "unable to get binding")
+
+		Expect(IsNotFound(err)).To(BeTrue())
+		Expect(AmbiguousCandidates(err)).To(BeNil())
+	})
+	It("Does not report a reason for other errors", func() {
+		err := errors.New("boom")
+
+		Expect(IsNotFound(err)).To(BeFalse())
+		Expect(IsForbidden(err)).To(BeFalse())
+		Expect(IsAmbiguous(err)).To(BeFalse())
+	})
+})
@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// RetrieveEvents lists the events involving the object of the given kind,
+// namespace and name, such as a ServiceInstance, oldest first. Events of
+// cluster-scoped objects, which have an empty namespace, are recorded in the
+// default namespace.
+func (sdk *SDK) RetrieveEvents(kind, namespace, name string) ([]corev1.Event, error) {
+	eventNamespace := namespace
+	if eventNamespace == "" {
+		eventNamespace = metav1.NamespaceDefault
+	}
+
+	// The terms are listed in a fixed order, so the selector is always
+	// written the same way.
+	selector := fields.AndSelectors(
+		fields.OneTermEqualSelector("involvedObject.kind", kind),
+		fields.OneTermEqualSelector("involvedObject.name", name),
+	)
+	if namespace != "" {
+		selector = fields.AndSelectors(selector, fields.OneTermEqualSelector("involvedObject.namespace", namespace))
+	}
+
+	events, err := sdk.Core().Events(eventNamespace).List(metav1.ListOptions{
+		FieldSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, wrapError(err, "unable to list the events of %s %s", kind, name)
+	}
+
+	items := events.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return EventTime(items[i]).Time.Before(EventTime(items[j]).Time)
+	})
+	return items, nil
+}
+
+// EventTime returns when an event was last seen, from the first of its
+// timestamps that is set.
+func EventTime(event corev1.Event) metav1.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp
+	case !event.EventTime.IsZero():
+		return metav1.NewTime(event.EventTime.Time)
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp
+	}
+	return event.CreationTimestamp
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	. "github.com/poy/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Event", func() {
+	var (
+		sdk       *SDK
+		k8sClient *k8sfake.Clientset
+		start     time.Time
+	)
+
+	newEvent := func(name string, lastSeen time.Time) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:    metav1.ObjectMeta{Name: name, Namespace: "foobar_namespace"},
+			LastTimestamp: metav1.NewTime(lastSeen),
+		}
+	}
+
+	BeforeEach(func() {
+		start = time.Date(2019, 3, 4, 12, 0, 0, 0, time.UTC)
+		k8sClient = &k8sfake.Clientset{}
+		k8sClient.AddReactor("list", "events", func(action testing.Action) (bool, runtime.Object, error) {
+			return true, &corev1.EventList{
+				Items: []corev1.Event{
+					newEvent("second", start.Add(time.Minute)),
+					newEvent("third", start.Add(time.Hour)),
+					newEvent("first", start),
+				},
+			}, nil
+		})
+		sdk = &SDK{
+			K8sClient:            k8sClient,
+			ServiceCatalogClient: fake.NewSimpleClientset(),
+		}
+	})
+
+	Describe("RetrieveEvents", func() {
+		It("Lists the events of the object, oldest first", func() {
+			events, err := sdk.RetrieveEvents("ServiceInstance", "foobar_namespace", "foobar")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].Name).To(Equal("first"))
+			Expect(events[1].Name).To(Equal("second"))
+			Expect(events[2].Name).To(Equal("third"))
+
+			actions := k8sClient.Actions()
+			Expect(actions).To(HaveLen(1))
+			Expect(actions[0].Matches("list", "events")).To(BeTrue())
+			Expect(actions[0].GetNamespace()).To(Equal("foobar_namespace"))
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.String()).To(Equal(
+				"involvedObject.kind=ServiceInstance,involvedObject.name=foobar,involvedObject.namespace=foobar_namespace"))
+		})
+		It("Looks up the events of cluster-scoped objects in the default namespace", func() {
+			_, err := sdk.RetrieveEvents("ClusterServiceBroker", "", "foobar")
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := k8sClient.Actions()
+			Expect(actions[0].GetNamespace()).To(Equal(metav1.NamespaceDefault))
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.String()).To(Equal(
+				"involvedObject.kind=ClusterServiceBroker,involvedObject.name=foobar"))
+		})
+		It("Bubbles up errors", func() {
+			badClient := &k8sfake.Clientset{}
+			badClient.AddReactor("list", "events", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, fmt.Errorf("oops")
+			})
+			sdk.K8sClient = badClient
+
+			events, err := sdk.RetrieveEvents("ServiceInstance", "foobar_namespace", "foobar")
+
+			Expect(err).To(HaveOccurred())
+			Expect(events).To(BeNil())
+			Expect(err.Error()).To(ContainSubstring("oops"))
+		})
+	})
+})
@@ -27,34 +27,79 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 const (
 	// FieldServicePlanRef is the jsonpath to an instance's plan name (Kubernetes name).
 	FieldServicePlanRef = "spec.clusterServicePlanRef.name"
+
+	// DeprovisionBlockedByBindingsReason is the reason set by the controller on
+	// the Ready condition of an instance whose deletion is waiting for its
+	// bindings to be deleted.
+	DeprovisionBlockedByBindingsReason = "DeprovisionBlockedByExistingCredentials"
 )
 
-// RetrieveInstances lists all instances in a namespace.
+// RetrieveInstances lists all instances in a namespace, optionally filtered
+// by class and plan. Use RetrieveInstancesPage for the other filters.
 func (sdk *SDK) RetrieveInstances(ns, classFilter, planFilter string) (*v1beta1.ServiceInstanceList, error) {
-	instances, err := sdk.ServiceCatalog().ServiceInstances(ns).List(v1.ListOptions{})
+	return sdk.RetrieveInstancesPage(ScopeOptions{
+		Namespace:   ns,
+		ClassFilter: classFilter,
+		PlanFilter:  planFilter,
+	})
+}
+
+// RetrieveInstancesPage lists a page of at most opts.Limit instances in
+// opts.Namespace, starting from opts.Continue, requesting them in chunks of
+// opts.ChunkSize when it is set. The continue token of the next page is set
+// on the returned list. Instances are filtered by class, plan, resolution of
+// their references and age after the page is retrieved, so a page may hold
+// fewer instances than the limit.
+func (sdk *SDK) RetrieveInstancesPage(opts ScopeOptions) (*v1beta1.ServiceInstanceList, error) {
+	ns := opts.Namespace
+	var instances *v1beta1.ServiceInstanceList
+	_, _, err := listChunks(opts, opts.Continue, func(lopts v1.ListOptions) (int, string, error) {
+		chunk, err := sdk.ServiceCatalog().ServiceInstances(ns).List(lopts)
+		if err != nil {
+			return 0, "", err
+		}
+		if instances == nil {
+			instances = chunk
+		} else {
+			instances.Items = append(instances.Items, chunk.Items...)
+			instances.ListMeta = chunk.ListMeta
+		}
+		return len(chunk.Items), chunk.Continue, nil
+	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "unable to list instances in %s", ns)
+		return nil, errors.Wrapf(describeListError(err, opts.FieldSelector), "unable to list instances in %s", ns)
 	}
 
-	if classFilter == "" && planFilter == "" {
+	if opts.ClassFilter == "" && opts.PlanFilter == "" && opts.PlanRefResolved == nil && !opts.filtersByAge() {
 		return instances, nil
 	}
 
 	filtered := v1beta1.ServiceInstanceList{
-		Items: []v1beta1.ServiceInstance{},
+		ListMeta: instances.ListMeta,
+		Items:    []v1beta1.ServiceInstance{},
 	}
 
+	now := time.Now()
 	for _, instance := range instances.Items {
-		if classFilter != "" && instance.Spec.GetSpecifiedClusterServiceClass() != classFilter {
+		if !opts.matchesAge(instance.CreationTimestamp, now) {
+			continue
+		}
+
+		if opts.ClassFilter != "" && instance.Spec.GetSpecifiedClusterServiceClass() != opts.ClassFilter {
 			continue
 		}
 
-		if planFilter != "" && instance.Spec.GetSpecifiedClusterServicePlan() != planFilter {
+		if opts.PlanFilter != "" && instance.Spec.GetSpecifiedClusterServicePlan() != opts.PlanFilter {
+			continue
+		}
+
+		if opts.PlanRefResolved != nil && sdk.IsInstanceRefResolved(&instance) != *opts.PlanRefResolved {
 			continue
 		}
 
@@ -64,11 +109,26 @@ func (sdk *SDK) RetrieveInstances(ns, classFilter, planFilter string) (*v1beta1.
 	return &filtered, nil
 }
 
+// WatchInstances watches the instances in opts.Namespace, optionally
+// filtered by label selector and field selector, for changes made after
+// opts.ResourceVersion.
+func (sdk *SDK) WatchInstances(opts ScopeOptions) (watch.Interface, error) {
+	w, err := sdk.ServiceCatalog().ServiceInstances(opts.Namespace).Watch(v1.ListOptions{
+		LabelSelector:   opts.LabelSelector,
+		FieldSelector:   opts.FieldSelector,
+		ResourceVersion: opts.ResourceVersion,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(describeListError(err, opts.FieldSelector), "unable to watch instances in %s", opts.Namespace)
+	}
+	return w, nil
+}
+
 // RetrieveInstance gets an instance by its name.
 func (sdk *SDK) RetrieveInstance(ns, name string) (*v1beta1.ServiceInstance, error) {
 	instance, err := sdk.ServiceCatalog().ServiceInstances(ns).Get(name, v1.GetOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("unable to get instance '%s.%s' (%s)", ns, name, err)
+		return nil, wrapError(err, "unable to get instance '%s.%s'", ns, name)
 	}
 	return instance, nil
 }
@@ -121,7 +181,7 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 	classCh := make(chan *v1beta1.ClusterServiceClass)
 	classErrCh := make(chan error)
 	go func() {
-		class, err := sdk.ServiceCatalog().ClusterServiceClasses().Get(classID, v1.GetOptions{})
+		class, err := sdk.getClusterServiceClass(classID)
 		if err != nil {
 			classErrCh <- err
 			return
@@ -133,7 +193,7 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 	planCh := make(chan *v1beta1.ClusterServicePlan)
 	planErrCh := make(chan error)
 	go func() {
-		plan, err := sdk.ServiceCatalog().ClusterServicePlans().Get(planID, v1.GetOptions{})
+		plan, err := sdk.getClusterServicePlan(planID)
 		if err != nil {
 			planErrCh <- err
 			return
@@ -189,6 +249,49 @@ func (sdk *SDK) Provision(instanceName, className, planName string, opts *Provis
 	return result, nil
 }
 
+// ProvisionFromInstance creates an instance of the same class and plan as an
+// existing instance in the same namespace, with its parameters and references
+// to the same secret parameters, which are not resolved. The parameters and
+// secrets of opts are added to the ones of the existing instance, and replace
+// its top-level parameters with the same names.
+func (sdk *SDK) ProvisionFromInstance(instanceName, fromInstanceName string, opts *ProvisionOptions) (*v1beta1.ServiceInstance, error) {
+	from, err := sdk.RetrieveInstance(opts.Namespace, fromInstanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := mergeParameters(from.Spec.Parameters, opts.Params)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the parameters of instance '%s.%s' (%s)", opts.Namespace, fromInstanceName, err)
+	}
+
+	parametersFrom := append([]v1beta1.ParametersFromSource{}, from.Spec.ParametersFrom...)
+	for _, param := range BuildParametersFrom(opts.Secrets) {
+		if !hasParametersFromSource(parametersFrom, param) {
+			parametersFrom = append(parametersFrom, param)
+		}
+	}
+
+	request := &v1beta1.ServiceInstance{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      instanceName,
+			Namespace: opts.Namespace,
+		},
+		Spec: v1beta1.ServiceInstanceSpec{
+			ExternalID:     opts.ExternalID,
+			PlanReference:  from.Spec.PlanReference,
+			Parameters:     params,
+			ParametersFrom: parametersFrom,
+		},
+	}
+
+	result, err := sdk.ServiceCatalog().ServiceInstances(opts.Namespace).Create(request)
+	if err != nil {
+		return nil, fmt.Errorf("provision request failed (%s)", err)
+	}
+	return result, nil
+}
+
 // Deprovision deletes an instance.
 func (sdk *SDK) Deprovision(namespace, instanceName string) error {
 	err := sdk.ServiceCatalog().ServiceInstances(namespace).Delete(instanceName, &v1.DeleteOptions{})
@@ -223,8 +326,10 @@ func (sdk *SDK) TouchInstance(ns, name string, retries int) error {
 	return fmt.Errorf("could not sync service broker after %d tries", retries)
 }
 
-// WaitForInstanceToNotExist waits for the specified instance to no longer exist.
-func (sdk *SDK) WaitForInstanceToNotExist(ns, name string, interval time.Duration, timeout *time.Duration) (instance *v1beta1.ServiceInstance, err error) {
+// WaitForInstanceToNotExist waits for the specified instance to no longer
+// exist, or for its deprovision to fail. When onPoll is not nil, it is called
+// with the instance each time the instance is found to still exist.
+func (sdk *SDK) WaitForInstanceToNotExist(ns, name string, interval time.Duration, timeout *time.Duration, onPoll func(*v1beta1.ServiceInstance)) (instance *v1beta1.ServiceInstance, err error) {
 	if timeout == nil {
 		notimeout := time.Duration(math.MaxInt64)
 		timeout = &notimeout
@@ -235,15 +340,37 @@ func (sdk *SDK) WaitForInstanceToNotExist(ns, name string, interval time.Duratio
 			instance, err = sdk.ServiceCatalog().ServiceInstances(ns).Get(name, v1.GetOptions{})
 			if err != nil {
 				if apierrors.IsNotFound(err) {
+					instance = nil
 					err = nil
 				}
 				return true, err
 			}
-			return false, err
+			if onPoll != nil {
+				onPoll(instance)
+			}
+			return IsInstanceDeprovisionFailed(instance), nil
 		})
 	return instance, err
 }
 
+// IsInstanceDeprovisionFailed returns if the deprovision of the instance has
+// failed and will not be retried.
+func IsInstanceDeprovisionFailed(instance *v1beta1.ServiceInstance) bool {
+	return instance.Status.DeprovisionStatus == v1beta1.ServiceInstanceDeprovisionStatusFailed
+}
+
+// IsInstanceDeprovisionBlockedByBindings returns if the deletion of the
+// instance is waiting for its bindings to be deleted first.
+func IsInstanceDeprovisionBlockedByBindings(instance *v1beta1.ServiceInstance) bool {
+	for _, cond := range instance.Status.Conditions {
+		if cond.Type == v1beta1.ServiceInstanceConditionReady &&
+			cond.Reason == DeprovisionBlockedByBindingsReason {
+			return true
+		}
+	}
+	return false
+}
+
 // WaitForInstance waits for the instance to complete the current operation (or fail).
 func (sdk *SDK) WaitForInstance(ns, name string, interval time.Duration, timeout *time.Duration) (instance *v1beta1.ServiceInstance, err error) {
 	if timeout == nil {
@@ -280,6 +407,17 @@ func (sdk *SDK) IsInstanceFailed(instance *v1beta1.ServiceInstance) bool {
 	return sdk.InstanceHasStatus(instance, v1beta1.ServiceInstanceConditionFailed)
 }
 
+// IsInstanceRefResolved returns if the class and plan of the instance have
+// been resolved by the controller. Until they are, the controller cannot
+// provision or update the instance, which happens for example when the broker
+// has not relisted its catalog yet.
+func (sdk *SDK) IsInstanceRefResolved(instance *v1beta1.ServiceInstance) bool {
+	if instance.Spec.ClusterServiceClassSpecified() {
+		return instance.Spec.ClusterServiceClassRef != nil && instance.Spec.ClusterServicePlanRef != nil
+	}
+	return instance.Spec.ServiceClassRef != nil && instance.Spec.ServicePlanRef != nil
+}
+
 // InstanceHasStatus returns if the instance is in the specified status.
 func (sdk *SDK) InstanceHasStatus(instance *v1beta1.ServiceInstance, status v1beta1.ServiceInstanceConditionType) bool {
 	for _, cond := range instance.Status.Conditions {
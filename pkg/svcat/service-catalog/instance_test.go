@@ -24,11 +24,14 @@ import (
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/testing"
 
 	. "github.com/poy/service-catalog/pkg/svcat/service-catalog"
@@ -83,6 +86,34 @@ var _ = Describe("Instances", func() {
 			Expect(status).To(BeFalse())
 		})
 	})
+	Describe("IsInstanceRefResolved", func() {
+		It("returns true if the cluster class and plan refs are set", func() {
+			si.Spec.ClusterServiceClassExternalName = "my-class"
+			si.Spec.ClusterServicePlanExternalName = "my-plan"
+			si.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{Name: "class-id"}
+			si.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: "plan-id"}
+			Expect(sdk.IsInstanceRefResolved(si)).To(BeTrue())
+		})
+		It("returns false if the cluster plan ref is not set", func() {
+			si.Spec.ClusterServiceClassExternalName = "my-class"
+			si.Spec.ClusterServicePlanExternalName = "my-plan"
+			si.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{Name: "class-id"}
+			Expect(sdk.IsInstanceRefResolved(si)).To(BeFalse())
+		})
+		It("returns true if the namespaced class and plan refs are set", func() {
+			si.Spec.ServiceClassExternalName = "my-class"
+			si.Spec.ServicePlanExternalName = "my-plan"
+			si.Spec.ServiceClassRef = &v1beta1.LocalObjectReference{Name: "class-id"}
+			si.Spec.ServicePlanRef = &v1beta1.LocalObjectReference{Name: "plan-id"}
+			Expect(sdk.IsInstanceRefResolved(si)).To(BeTrue())
+		})
+		It("returns false if the namespaced class ref is not set", func() {
+			si.Spec.ServiceClassExternalName = "my-class"
+			si.Spec.ServicePlanExternalName = "my-plan"
+			si.Spec.ServicePlanRef = &v1beta1.LocalObjectReference{Name: "plan-id"}
+			Expect(sdk.IsInstanceRefResolved(si)).To(BeFalse())
+		})
+	})
 	Describe("RetrieveInstancees", func() {
 		It("Calls the generated v1beta1 List method with the specified namespace", func() {
 			namespace := si.Namespace
@@ -95,6 +126,70 @@ var _ = Describe("Instances", func() {
 			Expect(actions[0].Matches("list", "serviceinstances")).To(BeTrue())
 			Expect(actions[0].(testing.ListActionImpl).Namespace).To(Equal(namespace))
 		})
+		It("Filters the instances by class and plan", func() {
+			si.Spec.ClusterServiceClassExternalName = "mysql"
+			si.Spec.ClusterServicePlanExternalName = "small"
+			si2.Spec.ClusterServiceClassExternalName = "mysql"
+			si2.Spec.ClusterServicePlanExternalName = "large"
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(si, si2)
+
+			instances, err := sdk.RetrieveInstances(si.Namespace, "mysql", "large")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances.Items).Should(ConsistOf(*si2))
+		})
+		It("Filters the instances by whether their references are resolved", func() {
+			si.Spec.ClusterServiceClassExternalName = "mysql"
+			si.Spec.ClusterServicePlanExternalName = "small"
+			si.Spec.ClusterServiceClassRef = &v1beta1.ClusterObjectReference{Name: "class-id"}
+			si.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: "plan-id"}
+			si2.Spec.ClusterServiceClassExternalName = "mysql"
+			si2.Spec.ClusterServicePlanExternalName = "large"
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(si, si2)
+
+			resolved := true
+			instances, err := sdk.RetrieveInstancesPage(ScopeOptions{Namespace: si.Namespace, PlanRefResolved: &resolved})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances.Items).Should(ConsistOf(*si))
+
+			resolved = false
+			instances, err = sdk.RetrieveInstancesPage(ScopeOptions{Namespace: si.Namespace, PlanRefResolved: &resolved})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances.Items).Should(ConsistOf(*si2))
+		})
+		It("Passes the label selector to the List method", func() {
+			namespace := si.Namespace
+
+			_, err := sdk.RetrieveInstancesPage(ScopeOptions{Namespace: namespace, LabelSelector: "app=wordpress"})
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Labels.String()).To(Equal("app=wordpress"))
+		})
+		It("Passes the field selector to the List method", func() {
+			namespace := si.Namespace
+
+			_, err := sdk.RetrieveInstancesPage(ScopeOptions{Namespace: namespace, FieldSelector: "spec.externalID=abc123"})
+
+			Expect(err).NotTo(HaveOccurred())
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("list", "serviceinstances")).To(BeTrue())
+			Expect(actions[0].(testing.ListActionImpl).GetListRestrictions().Fields.String()).To(Equal("spec.externalID=abc123"))
+		})
+		It("Explains when the server rejects the field selector", func() {
+			namespace := si.Namespace
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("list", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewBadRequest("field label not supported: spec.foo")
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			_, err := sdk.RetrieveInstancesPage(ScopeOptions{Namespace: namespace, FieldSelector: "spec.foo=bar"})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(`unsupported field selector "spec.foo=bar": field label not supported: spec.foo`))
+		})
 		It("Bubbles up errors", func() {
 			namespace := si.Namespace
 			badClient := &fake.Clientset{}
@@ -110,6 +205,70 @@ var _ = Describe("Instances", func() {
 			Expect(err.Error()).Should(ContainSubstring(errorMessage))
 			Expect(badClient.Actions()[0].Matches("list", "serviceinstances")).To(BeTrue())
 		})
+		It("Retrieves all the instances in chunks", func() {
+			chunks := []*v1beta1.ServiceInstanceList{
+				{ListMeta: metav1.ListMeta{Continue: "abc"}, Items: []v1beta1.ServiceInstance{*si}},
+				{Items: []v1beta1.ServiceInstance{*si2}},
+			}
+			svcCatClient.PrependReactor("list", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				chunk := chunks[0]
+				chunks = chunks[1:]
+				return true, chunk, nil
+			})
+
+			instances, err := sdk.RetrieveInstancesPage(ScopeOptions{Namespace: si.Namespace, ChunkSize: 1})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances.Items).Should(ConsistOf(*si, *si2))
+			Expect(instances.Continue).To(BeEmpty())
+			Expect(len(svcCatClient.Actions())).Should(Equal(2))
+		})
+		It("Filters the instances by age", func() {
+			si.CreationTimestamp = metav1.NewTime(time.Now().Add(-60 * 24 * time.Hour))
+			si2.CreationTimestamp = metav1.NewTime(time.Now().Add(-24 * time.Hour))
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(si, si2)
+
+			older, err := sdk.RetrieveInstancesPage(ScopeOptions{Namespace: si.Namespace, OlderThan: 30 * 24 * time.Hour})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(older.Items).Should(ConsistOf(*si))
+
+			newer, err := sdk.RetrieveInstancesPage(ScopeOptions{Namespace: si.Namespace, NewerThan: 30 * 24 * time.Hour})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(newer.Items).Should(ConsistOf(*si2))
+		})
+	})
+	Describe("WatchInstances", func() {
+		It("Calls the generated v1beta1 Watch method with the specified options", func() {
+			w, err := sdk.WatchInstances(ScopeOptions{
+				Namespace:       si.Namespace,
+				LabelSelector:   "app=wordpress",
+				FieldSelector:   "metadata.name=foobar",
+				ResourceVersion: "42",
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			defer w.Stop()
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("watch", "serviceinstances")).To(BeTrue())
+			restrictions := actions[0].(testing.WatchActionImpl).GetWatchRestrictions()
+			Expect(actions[0].GetNamespace()).To(Equal(si.Namespace))
+			Expect(restrictions.Labels.String()).To(Equal("app=wordpress"))
+			Expect(restrictions.Fields.String()).To(Equal("metadata.name=foobar"))
+			Expect(restrictions.ResourceVersion).To(Equal("42"))
+		})
+		It("Bubbles up errors", func() {
+			badClient := &fake.Clientset{}
+			errorMessage := "error watching"
+			badClient.AddWatchReactor("serviceinstances", func(action testing.Action) (bool, watch.Interface, error) {
+				return true, nil, fmt.Errorf(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			_, err := sdk.WatchInstances(ScopeOptions{Namespace: si.Namespace})
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(errorMessage))
+		})
 	})
 	Describe("RetrieveInstance", func() {
 		It("Calls the generated v1beta1 Get method with the passed in instance", func() {
@@ -174,6 +333,118 @@ var _ = Describe("Instances", func() {
 			Expect(actions[0].(testing.GetActionImpl).Namespace).To(Equal(namespace))
 		})
 	})
+	Describe("RetrieveInstanceParameters", func() {
+		BeforeEach(func() {
+			si.Spec.Parameters = BuildParameters(map[string]interface{}{"region": "eu-west"})
+			si.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "creds", Key: "params"}},
+			}
+		})
+		It("Redacts the values of the parameters from secrets", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: si.Namespace},
+				Data:       map[string][]byte{"params": []byte(`{"password": "s3cr3t"}`)},
+			}
+			sdk.K8sClient = k8sfake.NewSimpleClientset(secret)
+
+			params, err := sdk.RetrieveInstanceParameters(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(params).To(Equal(map[string]interface{}{"region": "eu-west", "password": "<redacted>"}))
+		})
+		It("Bubbles up errors", func() {
+			sdk.K8sClient = k8sfake.NewSimpleClientset()
+
+			params, err := sdk.RetrieveInstanceParameters(si)
+
+			Expect(params).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unable to get secret foobar_namespace/creds"))
+		})
+	})
+	Describe("DiffInstanceParameters", func() {
+		const checksum = "bd1376632e24acda47d398167e1454204cc692386d41820b9a2ea54216d9d8e6"
+		BeforeEach(func() {
+			si.Generation = 1
+			si.Spec.Parameters = BuildParameters(map[string]interface{}{"region": "eu-west"})
+			si.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "creds", Key: "params"}},
+			}
+			si.Status.ReconciledGeneration = 1
+			si.Annotations = map[string]string{ParametersChecksumAnnotation: checksum}
+			si.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+				Parameters:        BuildParameters(map[string]interface{}{"region": "eu-west", "password": "<redacted>"}),
+				ParameterChecksum: checksum,
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: si.Namespace},
+				Data:       map[string][]byte{"params": []byte(`{"password": "s3cr3t"}`)},
+			}
+			sdk.K8sClient = k8sfake.NewSimpleClientset(secret)
+		})
+		It("Reports unchanged parameters", func() {
+			diff, err := sdk.DiffInstanceParameters(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Parameters).To(Equal(map[string]interface{}{"region": "eu-west", "password": "<redacted>"}))
+			Expect(diff.Checksum).To(Equal(checksum))
+			Expect(diff.ParametersChanged).To(BeFalse())
+			Expect(diff.SpecChangePending).To(BeFalse())
+		})
+		It("Detects parameters changed in secrets", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: si.Namespace},
+				Data:       map[string][]byte{"params": []byte(`{"password": "n3w"}`)},
+			}
+			sdk.K8sClient = k8sfake.NewSimpleClientset(secret)
+
+			diff, err := sdk.DiffInstanceParameters(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Parameters).To(Equal(diff.LastSentParameters))
+			Expect(diff.ParametersChanged).To(BeTrue())
+			Expect(diff.SpecChangePending).To(BeFalse())
+		})
+		It("Detects spec changes that were not sent yet", func() {
+			si.Generation = 2
+			si.Spec.Parameters = BuildParameters(map[string]interface{}{"region": "us-east"})
+
+			diff, err := sdk.DiffInstanceParameters(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.Parameters["region"]).To(Equal("us-east"))
+			Expect(diff.LastSentParameters["region"]).To(Equal("eu-west"))
+			Expect(diff.ParametersChanged).To(BeTrue())
+			Expect(diff.SpecChangePending).To(BeTrue())
+		})
+		It("Compares to the checksum of the annotation", func() {
+			si.Status.ExternalProperties.ParameterChecksum = ""
+
+			diff, err := sdk.DiffInstanceParameters(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.LastSentChecksum).To(Equal(checksum))
+			Expect(diff.ParametersChanged).To(BeFalse())
+		})
+		It("Falls back to the checksum of the status without the annotation", func() {
+			si.Annotations = nil
+
+			diff, err := sdk.DiffInstanceParameters(si)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.LastSentChecksum).To(Equal(checksum))
+			Expect(diff.ParametersChanged).To(BeFalse())
+		})
+		It("Fails for instances that were not provisioned", func() {
+			si.Status.ExternalProperties = nil
+
+			diff, err := sdk.DiffInstanceParameters(si)
+
+			Expect(diff).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has not been provisioned yet"))
+		})
+	})
 	Describe("RetrieveInstancesByPlan", func() {
 		It("Calls the generated v1beta1 List method with a ListOption containing the passed in plan", func() {
 			plan := &v1beta1.ClusterServicePlan{
@@ -566,6 +837,50 @@ var _ = Describe("Instances", func() {
 			Expect(err.Error()).To(ContainSubstring(errorMessage))
 		})
 	})
+	Describe("ProvisionFromInstance", func() {
+		It("Creates an instance with the class, plan and parameters of the existing instance", func() {
+			existing := &v1beta1.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "cherry", Namespace: "cherry_namespace"},
+				Spec: v1beta1.ServiceInstanceSpec{
+					PlanReference: v1beta1.PlanReference{
+						ClusterServiceClassExternalName: "cherry_class",
+						ClusterServicePlanExternalName:  "cherry_plan",
+					},
+					Parameters: &runtime.RawExtension{Raw: []byte(`{"foo":"bar","size":"small"}`)},
+					ParametersFrom: []v1beta1.ParametersFromSource{
+						{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "creds", Key: "db"}},
+					},
+				},
+			}
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(existing)
+			opts := &ProvisionOptions{
+				Namespace: "cherry_namespace",
+				Params:    map[string]interface{}{"size": "large"},
+				Secrets:   map[string]string{"creds": "db", "other": "key"},
+			}
+
+			instance, err := sdk.ProvisionFromInstance("cherry-copy", "cherry", opts)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Name).To(Equal("cherry-copy"))
+			Expect(instance.Namespace).To(Equal("cherry_namespace"))
+			Expect(instance.Spec.PlanReference).To(Equal(existing.Spec.PlanReference))
+			Expect(instance.Spec.Parameters.Raw).To(MatchJSON(`{"foo":"bar","size":"large"}`))
+			Expect(instance.Spec.ParametersFrom).To(ConsistOf(
+				v1beta1.ParametersFromSource{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "creds", Key: "db"}},
+				v1beta1.ParametersFromSource{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "other", Key: "key"}},
+			))
+		})
+		It("Bubbles up errors getting the existing instance", func() {
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset()
+
+			instance, err := sdk.ProvisionFromInstance("cherry-copy", "cherry", &ProvisionOptions{Namespace: "cherry_namespace"})
+
+			Expect(instance).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(IsNotFound(err)).To(BeTrue())
+		})
+	})
 	Describe("Deprovision", func() {
 		It("Calls the v1beta1 Delete method with the passed in service instance name", func() {
 			err := sdk.Deprovision(si.Namespace, si.Name)
@@ -700,7 +1015,7 @@ var _ = Describe("Instances", func() {
 				}
 				return false, nil, nil
 			})
-			instance, err := sdk.WaitForInstanceToNotExist(si.Namespace, si.Name, interval, &timeout)
+			instance, err := sdk.WaitForInstanceToNotExist(si.Namespace, si.Name, interval, &timeout, nil)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(instance).To(BeNil())
 			actions := waitClient.Actions()
@@ -711,8 +1026,35 @@ var _ = Describe("Instances", func() {
 				Expect(v.(testing.GetActionImpl).Namespace).To(Equal(si.Namespace))
 			}
 		})
+		It("Reports the instance each time it is found to still exist", func() {
+			waitClient.PrependReactor("get", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				if counter >= 3 {
+					return true, nil, apierrors.NewNotFound(v1beta1.Resource("serviceinstance"), "instance not found")
+				}
+				return false, nil, nil
+			})
+			polled := 0
+			instance, err := sdk.WaitForInstanceToNotExist(si.Namespace, si.Name, interval, &timeout, func(i *v1beta1.ServiceInstance) {
+				Expect(i.Name).To(Equal(si.Name))
+				polled++
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(BeNil())
+			Expect(polled).To(Equal(3))
+		})
+		It("Stops waiting when the deprovision has failed", func() {
+			failed := si.DeepCopy()
+			failed.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusFailed
+			waitClient.PrependReactor("get", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, failed, nil
+			})
+			instance, err := sdk.WaitForInstanceToNotExist(si.Namespace, si.Name, interval, &timeout, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance).To(Equal(failed))
+			Expect(waitClient.Actions()).To(HaveLen(1))
+		})
 		It("Times out if the instance never goes away", func() {
-			instance, err := sdk.WaitForInstanceToNotExist(si.Namespace, si.Name, interval, &timeout)
+			instance, err := sdk.WaitForInstanceToNotExist(si.Namespace, si.Name, interval, &timeout, nil)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("timed out"))
 			Expect(instance).ToNot(BeNil())
@@ -732,7 +1074,7 @@ var _ = Describe("Instances", func() {
 				return false, nil, nil
 			})
 			timeout := 1 * time.Second
-			instance, err := sdk.WaitForInstanceToNotExist(si.Namespace, si.Name, 1*time.Second, &timeout)
+			instance, err := sdk.WaitForInstanceToNotExist(si.Namespace, si.Name, 1*time.Second, &timeout, nil)
 			Expect(err).To(HaveOccurred())
 			Expect(strings.Contains(err.Error(), "timed out waiting for the condition"))
 			Expect(strings.Contains(err.Error(), errorMessage))
@@ -28,6 +28,7 @@ type FilterOptions struct {
 
 // RegisterOptions allows for passing of optional fields to the broker Register method.
 type RegisterOptions struct {
+	AllowInsecure     bool
 	BasicSecret       string
 	BearerSecret      string
 	CAFile            string
@@ -37,6 +38,9 @@ type RegisterOptions struct {
 	RelistBehavior    v1beta1.ServiceBrokerRelistBehavior
 	RelistDuration    *metav1.Duration
 	SkipTLS           bool
+	// Username and Password are stored in a new BasicSecret when set.
+	Username string
+	Password string
 }
 
 // ProvisionOptions allows for the passing of optional fields to the instance Provision method.
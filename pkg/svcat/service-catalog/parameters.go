@@ -17,13 +17,24 @@ limitations under the License.
 package servicecatalog
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// ParametersChecksumAnnotation is the annotation the controller sets on
+// instances with the checksum of the parameters last sent to the broker.
+const ParametersChecksumAnnotation = "servicecatalog.k8s.io/parameters-checksum"
+
+// redactedParameterValue replaces the values of the parameters sourced from
+// secrets, like the controller does in the status of instances.
+const redactedParameterValue = "<redacted>"
+
 // BuildParameters converts a map of variable assignments to a byte encoded json document,
 // which is what the ServiceCatalog API consumes.
 func BuildParameters(params interface{}) *runtime.RawExtension {
@@ -37,6 +48,158 @@ func BuildParameters(params interface{}) *runtime.RawExtension {
 	return &runtime.RawExtension{Raw: paramsJSON}
 }
 
+// mergeParameters returns the parameters of an existing resource with the
+// given top-level parameters added, replacing the ones with the same names.
+func mergeParameters(existing *runtime.RawExtension, params interface{}) (*runtime.RawExtension, error) {
+	merged := map[string]interface{}{}
+	if existing != nil && len(existing.Raw) > 0 {
+		if err := json.Unmarshal(existing.Raw, &merged); err != nil {
+			return nil, err
+		}
+	}
+	if params, ok := params.(map[string]interface{}); ok {
+		for name, value := range params {
+			merged[name] = value
+		}
+	}
+	return BuildParameters(merged), nil
+}
+
+// RetrieveInstanceParameters returns the parameters that are sent to the
+// broker for an instance: its parameters, and the ones from the secrets of its
+// parametersFrom, whose values are redacted. The secrets are read to find the
+// names of their parameters.
+func (sdk *SDK) RetrieveInstanceParameters(instance *v1beta1.ServiceInstance) (map[string]interface{}, error) {
+	_, redacted, err := sdk.buildInstanceParameters(instance)
+	return redacted, err
+}
+
+// buildInstanceParameters returns the parameters that are sent to the broker
+// for an instance, and the same parameters with the values from secrets
+// redacted.
+func (sdk *SDK) buildInstanceParameters(instance *v1beta1.ServiceInstance) (map[string]interface{}, map[string]interface{}, error) {
+	params := map[string]interface{}{}
+	if instance.Spec.Parameters != nil && len(instance.Spec.Parameters.Raw) > 0 {
+		if err := json.Unmarshal(instance.Spec.Parameters.Raw, &params); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse the parameters of instance %s/%s (%s)", instance.Namespace, instance.Name, err)
+		}
+	}
+	redacted := make(map[string]interface{}, len(params))
+	for name, value := range params {
+		redacted[name] = value
+	}
+	for _, source := range instance.Spec.ParametersFrom {
+		if source.SecretKeyRef == nil {
+			continue
+		}
+		ref := source.SecretKeyRef
+		secret, err := sdk.Core().Secrets(instance.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, wrapError(err, "unable to get secret %s/%s", instance.Namespace, ref.Name)
+		}
+		var secretParams map[string]interface{}
+		if err := json.Unmarshal(secret.Data[ref.Key], &secretParams); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse the parameters in key %q of secret %s/%s (%s)", ref.Key, instance.Namespace, ref.Name, err)
+		}
+		for name, value := range secretParams {
+			params[name] = value
+			redacted[name] = redactedParameterValue
+		}
+	}
+	return params, redacted, nil
+}
+
+// InstanceParametersDiff compares the parameters of an instance with the ones
+// last sent to its broker. The values of the parameters from secrets are
+// redacted.
+type InstanceParametersDiff struct {
+	// Parameters are the parameters that the spec of the instance asks for.
+	Parameters map[string]interface{} `json:"parameters"`
+	// LastSentParameters are the parameters last sent to the broker.
+	LastSentParameters map[string]interface{} `json:"lastSentParameters"`
+	// Checksum is the checksum of the parameters, computed like the
+	// controller does.
+	Checksum string `json:"checksum"`
+	// LastSentChecksum is the checksum of the parameters last sent to the
+	// broker.
+	LastSentChecksum string `json:"lastSentChecksum"`
+	// ParametersChanged tells whether the parameters differ from the ones
+	// last sent to the broker. Parameters from secrets are compared too,
+	// through the checksums.
+	ParametersChanged bool `json:"parametersChanged"`
+	// SpecChangePending tells whether the spec of the instance changed since
+	// it was last sent to the broker, so that the controller is going to send
+	// an update. Parameters that only changed in their secrets are not sent
+	// until the instance is touched.
+	SpecChangePending bool `json:"specChangePending"`
+}
+
+// DiffInstanceParameters compares the parameters of an instance, including
+// the current values of the secrets of its parametersFrom, with the ones last
+// sent to its broker, whose checksum is read from the
+// ParametersChecksumAnnotation of the instance. Nothing is changed.
+func (sdk *SDK) DiffInstanceParameters(instance *v1beta1.ServiceInstance) (*InstanceParametersDiff, error) {
+	if instance.Status.ExternalProperties == nil {
+		return nil, fmt.Errorf("instance %s/%s has not been provisioned yet, there are no parameters to compare to", instance.Namespace, instance.Name)
+	}
+
+	params, redacted, err := sdk.buildInstanceParameters(instance)
+	if err != nil {
+		return nil, err
+	}
+	checksum, err := parametersChecksum(params)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSent := map[string]interface{}{}
+	if raw := instance.Status.ExternalProperties.Parameters; raw != nil && len(raw.Raw) > 0 {
+		if err := json.Unmarshal(raw.Raw, &lastSent); err != nil {
+			return nil, fmt.Errorf("unable to parse the last sent parameters of instance %s/%s (%s)", instance.Namespace, instance.Name, err)
+		}
+	}
+
+	lastSentChecksum, ok := instance.Annotations[ParametersChecksumAnnotation]
+	if !ok {
+		// The controller removes the annotation when no parameters were
+		// sent, and instances it has not updated since it started setting
+		// the annotation lack it, so fall back to the status.
+		lastSentChecksum = instance.Status.ExternalProperties.ParameterChecksum
+	}
+	return &InstanceParametersDiff{
+		Parameters:         redacted,
+		LastSentParameters: lastSent,
+		Checksum:           checksum,
+		LastSentChecksum:   lastSentChecksum,
+		ParametersChanged:  checksum != lastSentChecksum,
+		SpecChangePending:  instance.Generation > instance.Status.ReconciledGeneration,
+	}, nil
+}
+
+// parametersChecksum returns the checksum of parameters the way the
+// controller records it, which is empty when there are no parameters.
+func parametersChecksum(params map[string]interface{}) (string, error) {
+	if len(params) == 0 {
+		return "", nil
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(paramsJSON)), nil
+}
+
+// hasParametersFromSource returns whether a source of parameters is already
+// in a list of sources.
+func hasParametersFromSource(sources []v1beta1.ParametersFromSource, source v1beta1.ParametersFromSource) bool {
+	for _, s := range sources {
+		if reflect.DeepEqual(s, source) {
+			return true
+		}
+	}
+	return false
+}
+
 // BuildParametersFrom converts a map of secrets names to secret keys to the
 // type consumed by the ServiceCatalog API.
 func BuildParametersFrom(secrets map[string]string) []v1beta1.ParametersFromSource {
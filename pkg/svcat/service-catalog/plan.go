@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -51,6 +52,9 @@ type Plan interface {
 	// GetNamespace returns the plan's namespace, or "" if it's cluster-scoped.
 	GetNamespace() string
 
+	// GetLabels returns the plan's labels.
+	GetLabels() map[string]string
+
 	// GetExternalName returns the plan's external name.
 	GetExternalName() string
 
@@ -63,6 +67,10 @@ type Plan interface {
 	// GetClassID returns the plan's class name.
 	GetClassID() string
 
+	// GetServiceBrokerName returns the name of the service
+	// broker for the plan.
+	GetServiceBrokerName() string
+
 	// GetInstanceCreateSchema returns the instance create schema from plan.
 	GetInstanceCreateSchema() *runtime.RawExtension
 
@@ -78,13 +86,22 @@ type Plan interface {
 
 // RetrievePlans lists all plans defined in the cluster.
 func (sdk *SDK) RetrievePlans(classID string, opts ScopeOptions) ([]Plan, error) {
-	plans, err := sdk.retrievePlansByListOptions(opts, metav1.ListOptions{})
+	plans, _, err := sdk.RetrievePlansPage(classID, opts)
+	return plans, err
+}
+
+// RetrievePlansPage lists a page of at most opts.Limit plans, starting from
+// opts.Continue. The returned continue token is empty once all plans were
+// listed. Plans are filtered by class after the page is retrieved, so a
+// page may hold fewer plans than the limit.
+func (sdk *SDK) RetrievePlansPage(classID string, opts ScopeOptions) ([]Plan, string, error) {
+	plans, next, err := sdk.retrievePlansByScopeOptions(opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if classID == "" {
-		return plans, nil
+		return plans, next, nil
 	}
 
 	var filtered []Plan
@@ -94,41 +111,78 @@ func (sdk *SDK) RetrievePlans(classID string, opts ScopeOptions) ([]Plan, error)
 		}
 	}
 
-	return filtered, nil
+	return filtered, next, nil
 }
 
-func (sdk *SDK) retrievePlansByListOptions(scopeOpts ScopeOptions, listOpts metav1.ListOptions) ([]Plan, error) {
-	var plans []Plan
-
-	if scopeOpts.Scope.Matches(ClusterScope) {
-		csp, err := sdk.ServiceCatalog().ClusterServicePlans().List(listOpts)
-		if err != nil {
-			return nil, fmt.Errorf("unable to list cluster-scoped plans (%s)", err)
-		}
+// IsPlanRemoved returns if the broker of the plan removed it from its
+// catalog.
+func IsPlanRemoved(plan Plan) bool {
+	switch p := plan.(type) {
+	case *v1beta1.ClusterServicePlan:
+		return p.Status.RemovedFromBrokerCatalog
+	case *v1beta1.ServicePlan:
+		return p.Status.RemovedFromBrokerCatalog
+	}
+	return false
+}
 
-		for _, p := range csp.Items {
-			plan := p
-			plans = append(plans, &plan)
+func (sdk *SDK) retrievePlansByScopeOptions(opts ScopeOptions) ([]Plan, string, error) {
+	var plans []Plan
+	next, err := listScopePages(opts, func(scope Scope, lopts metav1.ListOptions) (int, string, error) {
+		if scope == ClusterScope {
+			csp, err := sdk.ServiceCatalog().ClusterServicePlans().List(lopts)
+			if err != nil {
+				return 0, "", fmt.Errorf("unable to list cluster-scoped plans (%s)", describeListError(err, lopts.FieldSelector))
+			}
+			for _, p := range csp.Items {
+				plan := p
+				if opts.Broker != "" && plan.GetServiceBrokerName() != opts.Broker {
+					continue
+				}
+				if opts.ExcludeRemoved && IsPlanRemoved(&plan) {
+					continue
+				}
+				plans = append(plans, &plan)
+			}
+			return len(csp.Items), csp.Continue, nil
 		}
-	}
 
-	if scopeOpts.Scope.Matches(NamespaceScope) {
-		sp, err := sdk.ServiceCatalog().ServicePlans(scopeOpts.Namespace).List(listOpts)
+		sp, err := sdk.ServiceCatalog().ServicePlans(opts.Namespace).List(lopts)
 		if err != nil {
 			// Gracefully handle when the feature-flag for namespaced broker resources isn't enabled on the server.
 			if apierrors.IsNotFound(err) {
-				return plans, nil
+				return 0, "", nil
 			}
-			return nil, fmt.Errorf("unable to list plans in %q (%s)", scopeOpts.Namespace, err)
+			return 0, "", fmt.Errorf("unable to list plans in %q (%s)", opts.Namespace, describeListError(err, lopts.FieldSelector))
 		}
-
 		for _, p := range sp.Items {
 			plan := p
+			if opts.Broker != "" && plan.GetServiceBrokerName() != opts.Broker {
+				continue
+			}
+			if opts.ExcludeRemoved && IsPlanRemoved(&plan) {
+				continue
+			}
 			plans = append(plans, &plan)
 		}
+		return len(sp.Items), sp.Continue, nil
+	})
+	if err != nil {
+		return nil, "", err
 	}
 
-	return plans, nil
+	return plans, next, nil
+}
+
+func (sdk *SDK) retrievePlansByListOptions(scopeOpts ScopeOptions, listOpts metav1.ListOptions) ([]Plan, error) {
+	plans, _, err := sdk.retrievePlansByScopeOptions(ScopeOptions{
+		Namespace:     scopeOpts.Namespace,
+		Scope:         scopeOpts.Scope,
+		LabelSelector: listOpts.LabelSelector,
+		FieldSelector: listOpts.FieldSelector,
+		Broker:        scopeOpts.Broker,
+	})
+	return plans, err
 }
 
 // RetrievePlanByName gets a plan by its external name.
@@ -217,7 +271,12 @@ func (sdk *SDK) RetrievePlanByClassIDAndName(classKubeName, planName string, sco
 			return plan, nil
 		}
 	}
-	return nil, fmt.Errorf("plan '%s' not found:%s", planName, findError.Error())
+	for _, err := range findError.Errors {
+		if IsAmbiguous(err) {
+			return nil, err
+		}
+	}
+	return nil, newNotFoundError("plan '%s' not found:%s", planName, findError.Error())
 }
 
 func (sdk *SDK) retrieveSinglePlanByListOptions(name string, scopeOpts ScopeOptions, listOpts metav1.ListOptions) (Plan, error) {
@@ -226,10 +285,14 @@ func (sdk *SDK) retrieveSinglePlanByListOptions(name string, scopeOpts ScopeOpti
 		return nil, err
 	}
 	if len(plans) == 0 {
-		return nil, fmt.Errorf("plan not found '%s'", name)
+		return nil, newNotFoundError("plan not found '%s'", name)
 	}
 	if len(plans) > 1 {
-		return nil, fmt.Errorf("more than one matching plan found for '%s'", name)
+		candidates := make([]string, 0, len(plans))
+		for _, plan := range plans {
+			candidates = append(candidates, plan.GetClassID()+"/"+plan.GetExternalName())
+		}
+		return nil, newAmbiguousError(candidates, "more than one matching plan found for '%s'", name)
 	}
 	return plans[0], nil
 }
@@ -241,9 +304,9 @@ func (sdk *SDK) RetrievePlanByID(kubeName string, opts ScopeOptions) (Plan, erro
 	}
 
 	if opts.Scope.Matches(ClusterScope) {
-		p, err := sdk.ServiceCatalog().ClusterServicePlans().Get(kubeName, metav1.GetOptions{})
+		p, err := sdk.getClusterServicePlan(kubeName)
 		if err != nil {
-			return nil, fmt.Errorf("unable to get cluster-scoped plan by Kubernetes name'%s' (%s)", kubeName, err)
+			return nil, wrapError(err, "unable to get cluster-scoped plan by Kubernetes name'%s'", kubeName)
 		}
 		return p, nil
 	}
@@ -251,7 +314,7 @@ func (sdk *SDK) RetrievePlanByID(kubeName string, opts ScopeOptions) (Plan, erro
 	if opts.Scope.Matches(NamespaceScope) {
 		p, err := sdk.ServiceCatalog().ServicePlans(opts.Namespace).Get(kubeName, metav1.GetOptions{})
 		if err != nil {
-			return nil, fmt.Errorf("unable to get plan by Kubernetes name'%s' (%s)", kubeName, err)
+			return nil, wrapError(err, "unable to get plan by Kubernetes name'%s'", kubeName)
 		}
 		return p, nil
 	}
@@ -101,6 +101,19 @@ var _ = Describe("Plan", func() {
 			Expect(svcCatClient.Actions()[0].Matches("list", "clusterserviceplans")).To(BeTrue())
 			Expect(svcCatClient.Actions()[1].Matches("list", "serviceplans")).To(BeTrue())
 		})
+		It("Filters out removed plans", func() {
+			csp2.Status.RemovedFromBrokerCatalog = true
+			sp.Status.RemovedFromBrokerCatalog = true
+			sdk.ServiceCatalogClient = fake.NewSimpleClientset(csc, csp, csp2, sc, sp, sp2)
+
+			plans, err := sdk.RetrievePlans("", ScopeOptions{Scope: AllScope, ExcludeRemoved: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plans).Should(ConsistOf(csp, sp2))
+
+			plans, err = sdk.RetrievePlans("", ScopeOptions{Scope: AllScope})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plans).Should(ConsistOf(csp, csp2, sp, sp2))
+		})
 		It("Bubbles up errors", func() {
 			errorMessage := "error retrieving list"
 			badClient := &fake.Clientset{}
@@ -115,6 +128,20 @@ var _ = Describe("Plan", func() {
 			Expect(badClient.Actions()[0].Matches("list", "clusterserviceplans")).To(BeTrue())
 		})
 	})
+	Describe("IsPlanRemoved", func() {
+		It("Returns false for plans offered by the broker", func() {
+			Expect(IsPlanRemoved(csp)).To(BeFalse())
+			Expect(IsPlanRemoved(sp)).To(BeFalse())
+		})
+		It("Returns true for plans removed from the broker catalog", func() {
+			csp.Status.RemovedFromBrokerCatalog = true
+			sp.Status.RemovedFromBrokerCatalog = true
+
+			Expect(IsPlanRemoved(csp)).To(BeTrue())
+			Expect(IsPlanRemoved(sp)).To(BeTrue())
+		})
+	})
+
 	Describe("RetrievePlanByName", func() {
 		It("Calls the generated v1beta1 List method with the passed in plan name for cluster-scoped plans", func() {
 			planName := csp.Name
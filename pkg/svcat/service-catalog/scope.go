@@ -16,6 +16,16 @@ limitations under the License.
 
 package servicecatalog
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // Scope is an enum that represents filtering resources by their scope (cluster vs. namespace).
 type Scope string
 
@@ -30,6 +40,11 @@ const (
 	AllScope = "all"
 )
 
+// AllNamespaces may be passed as the namespace to the list methods, such as
+// RetrieveInstances and RetrieveBindings, to retrieve resources across all
+// namespaces.
+const AllNamespaces = ""
+
 // Matches determines if a particular value is included in the scope.
 func (s Scope) Matches(value Scope) bool {
 	if s == AllScope {
@@ -43,4 +58,199 @@ func (s Scope) Matches(value Scope) bool {
 type ScopeOptions struct {
 	Namespace string
 	Scope     Scope
+	// LabelSelector, when set, limits list results to the resources matching
+	// the label selector, for example "tier=gold,env!=prod".
+	LabelSelector string
+	// FieldSelector, when set, limits list results to the resources matching
+	// the field selector, for example "spec.externalName=mysql".
+	FieldSelector string
+	// Limit, when set, is the maximum number of resources returned by the
+	// list methods that page their results, such as RetrieveClassesPage.
+	Limit int64
+	// Continue is the token returned with a previous page of results, to
+	// retrieve the next page.
+	Continue string
+	// ChunkSize, when set, is the maximum number of resources the list
+	// methods request from the server at once. They keep requesting chunks
+	// until all the resources, or Limit of them, were retrieved, which
+	// bounds the size of each response when listing many resources.
+	ChunkSize int64
+	// ResourceVersion, when set, is the resource version that the watch
+	// methods, such as WatchInstances, start watching from.
+	ResourceVersion string
+	// Tags, when set, limits the classes returned by RetrieveClasses and
+	// RetrieveClassesPage to the ones that have all of the tags, ignoring
+	// case.
+	Tags []string
+	// Broker, when set, limits the classes and plans to the ones offered by
+	// the broker with this name.
+	Broker string
+	// ExcludeRemoved, when set, leaves out of the classes and plans the ones
+	// that their broker removed from its catalog, see IsClassRemoved and
+	// IsPlanRemoved.
+	ExcludeRemoved bool
+	// ClassFilter, when set, limits the instances returned by
+	// RetrieveInstancesPage to the ones that specify this class, by external
+	// name or by Kubernetes name, as returned by
+	// GetSpecifiedClusterServiceClass.
+	ClassFilter string
+	// PlanFilter, when set, limits the instances returned by
+	// RetrieveInstancesPage to the ones that specify this plan, by external
+	// name or by Kubernetes name, as returned by
+	// GetSpecifiedClusterServicePlan.
+	PlanFilter string
+	// PlanRefResolved, when set, limits the instances returned by
+	// RetrieveInstancesPage to the ones whose class and plan references have
+	// (true) or have not (false) been resolved, see IsInstanceRefResolved.
+	PlanRefResolved *bool
+	// InstanceFilter, when set, limits the bindings returned by
+	// RetrieveBindingsPage to the ones of the instance with this name.
+	InstanceFilter string
+	// OlderThan, when set, limits the instances and bindings returned by
+	// RetrieveInstancesPage and RetrieveBindingsPage to the ones created
+	// longer ago than this.
+	OlderThan time.Duration
+	// NewerThan, when set, limits the instances and bindings returned by
+	// RetrieveInstancesPage and RetrieveBindingsPage to the ones created
+	// more recently than this.
+	NewerThan time.Duration
+}
+
+// filtersByAge returns whether opts limits resources by their age.
+func (opts ScopeOptions) filtersByAge() bool {
+	return opts.OlderThan > 0 || opts.NewerThan > 0
+}
+
+// matchesAge returns whether a resource created at the given time is within
+// the ages of opts at the time now.
+func (opts ScopeOptions) matchesAge(created metav1.Time, now time.Time) bool {
+	age := now.Sub(created.Time)
+	if opts.OlderThan > 0 && age <= opts.OlderThan {
+		return false
+	}
+	if opts.NewerThan > 0 && age >= opts.NewerThan {
+		return false
+	}
+	return true
+}
+
+// ParseAge parses the age of resources, for ScopeOptions.OlderThan and
+// ScopeOptions.NewerThan. Besides durations such as "90m" or "12h", it
+// accepts whole days such as "30d". The age must be positive.
+func ParseAge(age string) (time.Duration, error) {
+	var d time.Duration
+	if days := strings.TrimSuffix(age, "d"); days != age {
+		n, err := strconv.ParseInt(days, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid age %q, days must be a whole number such as 30d", age)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(age); err != nil {
+			return 0, fmt.Errorf("invalid age %q, must be a number of days such as 30d or a duration such as 12h", age)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid age %q, must be positive", age)
+	}
+	return d, nil
+}
+
+// describeListError explains err when the server rejected a list call
+// because its field selector uses a field that is not supported for the
+// resource.
+func describeListError(err error, fieldSelector string) error {
+	if fieldSelector != "" && apierrors.IsBadRequest(err) {
+		return fmt.Errorf("unsupported field selector %q: %s", fieldSelector, err)
+	}
+	return err
+}
+
+// listScopePages lists one page of the resources within the scopes of opts,
+// cluster-scoped resources first. The list function is called with the list
+// options for each scope, and returns how many resources it retrieved along
+// with the continue token of the server. The returned token, when not empty,
+// is passed as opts.Continue to retrieve the next page, and records which
+// scope to resume listing from.
+func listScopePages(opts ScopeOptions, list func(scope Scope, lopts metav1.ListOptions) (int, string, error)) (string, error) {
+	var scopes []Scope
+	token := ""
+	if opts.Continue == "" {
+		for _, scope := range []Scope{ClusterScope, NamespaceScope} {
+			if opts.Scope.Matches(scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+	} else {
+		parts := strings.SplitN(opts.Continue, ":", 2)
+		if len(parts) != 2 || (parts[0] != ClusterScope && parts[0] != NamespaceScope) || !opts.Scope.Matches(Scope(parts[0])) {
+			return "", fmt.Errorf("invalid continue token %q", opts.Continue)
+		}
+		scopes = append(scopes, Scope(parts[0]))
+		if parts[0] == ClusterScope && opts.Scope.Matches(NamespaceScope) {
+			scopes = append(scopes, NamespaceScope)
+		}
+		token = parts[1]
+	}
+
+	remaining := opts.Limit
+	for i, scope := range scopes {
+		scopeOpts := opts
+		scopeOpts.Limit = remaining
+		n, next, err := listChunks(scopeOpts, token, func(lopts metav1.ListOptions) (int, string, error) {
+			return list(scope, lopts)
+		})
+		if err != nil {
+			return "", err
+		}
+		if next != "" {
+			return string(scope) + ":" + next, nil
+		}
+		token = ""
+
+		if opts.Limit > 0 {
+			remaining -= int64(n)
+			if remaining <= 0 {
+				if i+1 < len(scopes) {
+					return string(scopes[i+1]) + ":", nil
+				}
+				return "", nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// listChunks lists the resources matching the selectors of opts, starting
+// from the continue token. When opts.ChunkSize is set, the resources are
+// requested in chunks of that size until all of them, or opts.Limit of them,
+// were listed. The list function returns how many resources it retrieved
+// along with the continue token of the server. listChunks returns how many
+// resources were listed and the continue token of the remaining ones.
+func listChunks(opts ScopeOptions, token string, list func(lopts metav1.ListOptions) (int, string, error)) (int, string, error) {
+	total := int64(0)
+	for {
+		limit := opts.Limit
+		if limit > 0 {
+			limit -= total
+		}
+		if opts.ChunkSize > 0 && (limit == 0 || opts.ChunkSize < limit) {
+			limit = opts.ChunkSize
+		}
+		n, next, err := list(metav1.ListOptions{
+			LabelSelector: opts.LabelSelector,
+			FieldSelector: opts.FieldSelector,
+			Limit:         limit,
+			Continue:      token,
+		})
+		if err != nil {
+			return 0, "", err
+		}
+		total += int64(n)
+		if next == "" || opts.ChunkSize <= 0 || (opts.Limit > 0 && total >= opts.Limit) {
+			return int(total), next, nil
+		}
+		token = next
+	}
 }
@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"time"
+
+	. "github.com/poy/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scope", func() {
+	Describe("ParseAge", func() {
+		It("Parses days", func() {
+			age, err := ParseAge("30d")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(age).To(Equal(30 * 24 * time.Hour))
+		})
+		It("Parses durations", func() {
+			age, err := ParseAge("1h30m")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(age).To(Equal(90 * time.Minute))
+		})
+		It("Rejects invalid ages", func() {
+			for _, age := range []string{"", "30days", "1.5d", "-1d", "0s"} {
+				_, err := ParseAge(age)
+
+				Expect(err).To(HaveOccurred(), "age %q", age)
+			}
+		})
+	})
+})
@@ -25,6 +25,7 @@ import (
 	apicorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
@@ -40,19 +41,26 @@ type SvcatClient interface {
 	IsBindingReady(*apiv1beta1.ServiceBinding) bool
 	RetrieveBinding(string, string) (*apiv1beta1.ServiceBinding, error)
 	RetrieveBindings(string) (*apiv1beta1.ServiceBindingList, error)
+	RetrieveBindingsPage(ScopeOptions) (*apiv1beta1.ServiceBindingList, error)
+	WatchBindings(ScopeOptions) (watch.Interface, error)
 	RetrieveBindingsByInstance(*apiv1beta1.ServiceInstance) ([]apiv1beta1.ServiceBinding, error)
+	TouchBinding(string, string, int) (*apiv1beta1.ServiceBinding, error)
 	Unbind(string, string) ([]types.NamespacedName, error)
 	WaitForBinding(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceBinding, error)
+	WaitForBindingToNotExist(string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceBinding)) (*apiv1beta1.ServiceBinding, error)
 
 	Deregister(string, *ScopeOptions) error
 	RetrieveBrokers(opts ScopeOptions) ([]Broker, error)
 	RetrieveBroker(string) (*apiv1beta1.ClusterServiceBroker, error)
 	RetrieveBrokerByClass(*apiv1beta1.ClusterServiceClass) (*apiv1beta1.ClusterServiceBroker, error)
+	RetrieveBrokerByName(string, ScopeOptions) (Broker, error)
 	Register(string, string, *RegisterOptions, *ScopeOptions) (Broker, error)
 	Sync(string, ScopeOptions, int) error
+	VerifyBroker(string, *RegisterOptions) error
 	WaitForBroker(string, time.Duration, *time.Duration) (Broker, error)
 
 	RetrieveClasses(ScopeOptions) ([]Class, error)
+	RetrieveClassesPage(ScopeOptions) ([]Class, string, error)
 	RetrieveClassByName(string, ScopeOptions) (Class, error)
 	RetrieveClassByID(string) (*apiv1beta1.ClusterServiceClass, error)
 	RetrieveClassByPlan(Plan) (*apiv1beta1.ClusterServiceClass, error)
@@ -63,16 +71,23 @@ type SvcatClient interface {
 	InstanceToServiceClassAndPlan(*apiv1beta1.ServiceInstance) (*apiv1beta1.ClusterServiceClass, *apiv1beta1.ClusterServicePlan, error)
 	IsInstanceFailed(*apiv1beta1.ServiceInstance) bool
 	IsInstanceReady(*apiv1beta1.ServiceInstance) bool
+	IsInstanceRefResolved(*apiv1beta1.ServiceInstance) bool
 	Provision(string, string, string, *ProvisionOptions) (*apiv1beta1.ServiceInstance, error)
+	ProvisionFromInstance(string, string, *ProvisionOptions) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstance(string, string) (*apiv1beta1.ServiceInstance, error)
 	RetrieveInstanceByBinding(*apiv1beta1.ServiceBinding) (*apiv1beta1.ServiceInstance, error)
+	RetrieveInstanceParameters(*apiv1beta1.ServiceInstance) (map[string]interface{}, error)
+	DiffInstanceParameters(*apiv1beta1.ServiceInstance) (*InstanceParametersDiff, error)
 	RetrieveInstances(string, string, string) (*apiv1beta1.ServiceInstanceList, error)
+	RetrieveInstancesPage(ScopeOptions) (*apiv1beta1.ServiceInstanceList, error)
+	WatchInstances(ScopeOptions) (watch.Interface, error)
 	RetrieveInstancesByPlan(Plan) ([]apiv1beta1.ServiceInstance, error)
 	TouchInstance(string, string, int) error
 	WaitForInstance(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
-	WaitForInstanceToNotExist(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
+	WaitForInstanceToNotExist(string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceInstance)) (*apiv1beta1.ServiceInstance, error)
 
 	RetrievePlans(string, ScopeOptions) ([]Plan, error)
+	RetrievePlansPage(string, ScopeOptions) ([]Plan, string, error)
 	RetrievePlanByName(string, ScopeOptions) (Plan, error)
 	RetrievePlanByClassAndName(string, string, ScopeOptions) (Plan, error)
 	RetrievePlanByClassIDAndName(string, string, ScopeOptions) (Plan, error)
@@ -80,6 +95,8 @@ type SvcatClient interface {
 
 	RetrieveSecretByBinding(*apiv1beta1.ServiceBinding) (*apicorev1.Secret, error)
 
+	RetrieveEvents(string, string, string) ([]apicorev1.Event, error)
+
 	ServerVersion() (*version.Info, error)
 }
 
@@ -87,6 +104,9 @@ type SvcatClient interface {
 type SDK struct {
 	K8sClient            kubernetes.Interface
 	ServiceCatalogClient clientset.Interface
+
+	// cache serves reads of cluster-scoped catalog resources when enabled.
+	cache *catalogCache
 }
 
 // ServiceCatalog is the underlying generated Service Catalog versioned interface
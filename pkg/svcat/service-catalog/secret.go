@@ -17,8 +17,6 @@ limitations under the License.
 package servicecatalog
 
 import (
-	"fmt"
-
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -36,7 +34,7 @@ func (sdk *SDK) RetrieveSecretByBinding(binding *v1beta1.ServiceBinding) (*corev
 			return nil, nil
 		}
 
-		return nil, fmt.Errorf("unable to get secret %s/%s (%s)", binding.Namespace, binding.Spec.SecretName, err)
+		return nil, wrapError(err, "unable to get secret %s/%s", binding.Namespace, binding.Spec.SecretName)
 	}
 
 	return secret, nil
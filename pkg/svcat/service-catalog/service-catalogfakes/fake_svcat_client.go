@@ -26,6 +26,7 @@ import (
 	apicorev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 type FakeSvcatClient struct {
@@ -141,6 +142,32 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceBindingList
 		result2 error
 	}
+	RetrieveBindingsPageStub        func(servicecatalog.ScopeOptions) (*apiv1beta1.ServiceBindingList, error)
+	retrieveBindingsPageMutex       sync.RWMutex
+	retrieveBindingsPageArgsForCall []struct {
+		arg1 servicecatalog.ScopeOptions
+	}
+	retrieveBindingsPageReturns struct {
+		result1 *apiv1beta1.ServiceBindingList
+		result2 error
+	}
+	retrieveBindingsPageReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceBindingList
+		result2 error
+	}
+	WatchBindingsStub        func(servicecatalog.ScopeOptions) (watch.Interface, error)
+	watchBindingsMutex       sync.RWMutex
+	watchBindingsArgsForCall []struct {
+		arg1 servicecatalog.ScopeOptions
+	}
+	watchBindingsReturns struct {
+		result1 watch.Interface
+		result2 error
+	}
+	watchBindingsReturnsOnCall map[int]struct {
+		result1 watch.Interface
+		result2 error
+	}
 	RetrieveBindingsByInstanceStub        func(*apiv1beta1.ServiceInstance) ([]apiv1beta1.ServiceBinding, error)
 	retrieveBindingsByInstanceMutex       sync.RWMutex
 	retrieveBindingsByInstanceArgsForCall []struct {
@@ -184,6 +211,23 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceBinding
 		result2 error
 	}
+	WaitForBindingToNotExistStub        func(string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceBinding)) (*apiv1beta1.ServiceBinding, error)
+	waitForBindingToNotExistMutex       sync.RWMutex
+	waitForBindingToNotExistArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 time.Duration
+		arg4 *time.Duration
+		arg5 func(*apiv1beta1.ServiceBinding)
+	}
+	waitForBindingToNotExistReturns struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}
+	waitForBindingToNotExistReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}
 	DeregisterStub        func(string, *servicecatalog.ScopeOptions) error
 	deregisterMutex       sync.RWMutex
 	deregisterArgsForCall []struct {
@@ -196,6 +240,18 @@ type FakeSvcatClient struct {
 	deregisterReturnsOnCall map[int]struct {
 		result1 error
 	}
+	VerifyBrokerStub        func(string, *servicecatalog.RegisterOptions) error
+	verifyBrokerMutex       sync.RWMutex
+	verifyBrokerArgsForCall []struct {
+		arg1 string
+		arg2 *servicecatalog.RegisterOptions
+	}
+	verifyBrokerReturns struct {
+		result1 error
+	}
+	verifyBrokerReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RetrieveBrokersStub        func(opts servicecatalog.ScopeOptions) ([]servicecatalog.Broker, error)
 	retrieveBrokersMutex       sync.RWMutex
 	retrieveBrokersArgsForCall []struct {
@@ -235,6 +291,20 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ClusterServiceBroker
 		result2 error
 	}
+	RetrieveBrokerByNameStub        func(string, servicecatalog.ScopeOptions) (servicecatalog.Broker, error)
+	retrieveBrokerByNameMutex       sync.RWMutex
+	retrieveBrokerByNameArgsForCall []struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}
+	retrieveBrokerByNameReturns struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
+	retrieveBrokerByNameReturnsOnCall map[int]struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}
 	RegisterStub        func(string, string, *servicecatalog.RegisterOptions, *servicecatalog.ScopeOptions) (servicecatalog.Broker, error)
 	registerMutex       sync.RWMutex
 	registerArgsForCall []struct {
@@ -292,6 +362,21 @@ type FakeSvcatClient struct {
 		result1 []servicecatalog.Class
 		result2 error
 	}
+	RetrieveClassesPageStub        func(servicecatalog.ScopeOptions) ([]servicecatalog.Class, string, error)
+	retrieveClassesPageMutex       sync.RWMutex
+	retrieveClassesPageArgsForCall []struct {
+		arg1 servicecatalog.ScopeOptions
+	}
+	retrieveClassesPageReturns struct {
+		result1 []servicecatalog.Class
+		result2 string
+		result3 error
+	}
+	retrieveClassesPageReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Class
+		result2 string
+		result3 error
+	}
 	RetrieveClassByNameStub        func(string, servicecatalog.ScopeOptions) (servicecatalog.Class, error)
 	retrieveClassByNameMutex       sync.RWMutex
 	retrieveClassByNameArgsForCall []struct {
@@ -411,6 +496,17 @@ type FakeSvcatClient struct {
 	isInstanceReadyReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsInstanceRefResolvedStub        func(*apiv1beta1.ServiceInstance) bool
+	isInstanceRefResolvedMutex       sync.RWMutex
+	isInstanceRefResolvedArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}
+	isInstanceRefResolvedReturns struct {
+		result1 bool
+	}
+	isInstanceRefResolvedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	ProvisionStub        func(string, string, string, *servicecatalog.ProvisionOptions) (*apiv1beta1.ServiceInstance, error)
 	provisionMutex       sync.RWMutex
 	provisionArgsForCall []struct {
@@ -427,6 +523,21 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstance
 		result2 error
 	}
+	ProvisionFromInstanceStub        func(string, string, *servicecatalog.ProvisionOptions) (*apiv1beta1.ServiceInstance, error)
+	provisionFromInstanceMutex       sync.RWMutex
+	provisionFromInstanceArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 *servicecatalog.ProvisionOptions
+	}
+	provisionFromInstanceReturns struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
+	provisionFromInstanceReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}
 	RetrieveInstanceStub        func(string, string) (*apiv1beta1.ServiceInstance, error)
 	retrieveInstanceMutex       sync.RWMutex
 	retrieveInstanceArgsForCall []struct {
@@ -454,6 +565,32 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstance
 		result2 error
 	}
+	RetrieveInstanceParametersStub        func(*apiv1beta1.ServiceInstance) (map[string]interface{}, error)
+	retrieveInstanceParametersMutex       sync.RWMutex
+	retrieveInstanceParametersArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}
+	retrieveInstanceParametersReturns struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	retrieveInstanceParametersReturnsOnCall map[int]struct {
+		result1 map[string]interface{}
+		result2 error
+	}
+	DiffInstanceParametersStub        func(*apiv1beta1.ServiceInstance) (*servicecatalog.InstanceParametersDiff, error)
+	diffInstanceParametersMutex       sync.RWMutex
+	diffInstanceParametersArgsForCall []struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}
+	diffInstanceParametersReturns struct {
+		result1 *servicecatalog.InstanceParametersDiff
+		result2 error
+	}
+	diffInstanceParametersReturnsOnCall map[int]struct {
+		result1 *servicecatalog.InstanceParametersDiff
+		result2 error
+	}
 	RetrieveInstancesStub        func(string, string, string) (*apiv1beta1.ServiceInstanceList, error)
 	retrieveInstancesMutex       sync.RWMutex
 	retrieveInstancesArgsForCall []struct {
@@ -469,6 +606,32 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstanceList
 		result2 error
 	}
+	RetrieveInstancesPageStub        func(servicecatalog.ScopeOptions) (*apiv1beta1.ServiceInstanceList, error)
+	retrieveInstancesPageMutex       sync.RWMutex
+	retrieveInstancesPageArgsForCall []struct {
+		arg1 servicecatalog.ScopeOptions
+	}
+	retrieveInstancesPageReturns struct {
+		result1 *apiv1beta1.ServiceInstanceList
+		result2 error
+	}
+	retrieveInstancesPageReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceInstanceList
+		result2 error
+	}
+	WatchInstancesStub        func(servicecatalog.ScopeOptions) (watch.Interface, error)
+	watchInstancesMutex       sync.RWMutex
+	watchInstancesArgsForCall []struct {
+		arg1 servicecatalog.ScopeOptions
+	}
+	watchInstancesReturns struct {
+		result1 watch.Interface
+		result2 error
+	}
+	watchInstancesReturnsOnCall map[int]struct {
+		result1 watch.Interface
+		result2 error
+	}
 	RetrieveInstancesByPlanStub        func(servicecatalog.Plan) ([]apiv1beta1.ServiceInstance, error)
 	retrieveInstancesByPlanMutex       sync.RWMutex
 	retrieveInstancesByPlanArgsForCall []struct {
@@ -495,6 +658,21 @@ type FakeSvcatClient struct {
 	touchInstanceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	TouchBindingStub        func(string, string, int) (*apiv1beta1.ServiceBinding, error)
+	touchBindingMutex       sync.RWMutex
+	touchBindingArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}
+	touchBindingReturns struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}
+	touchBindingReturnsOnCall map[int]struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}
 	WaitForInstanceStub        func(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
 	waitForInstanceMutex       sync.RWMutex
 	waitForInstanceArgsForCall []struct {
@@ -511,13 +689,14 @@ type FakeSvcatClient struct {
 		result1 *apiv1beta1.ServiceInstance
 		result2 error
 	}
-	WaitForInstanceToNotExistStub        func(string, string, time.Duration, *time.Duration) (*apiv1beta1.ServiceInstance, error)
+	WaitForInstanceToNotExistStub        func(string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceInstance)) (*apiv1beta1.ServiceInstance, error)
 	waitForInstanceToNotExistMutex       sync.RWMutex
 	waitForInstanceToNotExistArgsForCall []struct {
 		arg1 string
 		arg2 string
 		arg3 time.Duration
 		arg4 *time.Duration
+		arg5 func(*apiv1beta1.ServiceInstance)
 	}
 	waitForInstanceToNotExistReturns struct {
 		result1 *apiv1beta1.ServiceInstance
@@ -541,6 +720,22 @@ type FakeSvcatClient struct {
 		result1 []servicecatalog.Plan
 		result2 error
 	}
+	RetrievePlansPageStub        func(string, servicecatalog.ScopeOptions) ([]servicecatalog.Plan, string, error)
+	retrievePlansPageMutex       sync.RWMutex
+	retrievePlansPageArgsForCall []struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}
+	retrievePlansPageReturns struct {
+		result1 []servicecatalog.Plan
+		result2 string
+		result3 error
+	}
+	retrievePlansPageReturnsOnCall map[int]struct {
+		result1 []servicecatalog.Plan
+		result2 string
+		result3 error
+	}
 	RetrievePlanByNameStub        func(string, servicecatalog.ScopeOptions) (servicecatalog.Plan, error)
 	retrievePlanByNameMutex       sync.RWMutex
 	retrievePlanByNameArgsForCall []struct {
@@ -612,6 +807,21 @@ type FakeSvcatClient struct {
 		result1 *apicorev1.Secret
 		result2 error
 	}
+	RetrieveEventsStub        func(string, string, string) ([]apicorev1.Event, error)
+	retrieveEventsMutex       sync.RWMutex
+	retrieveEventsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	retrieveEventsReturns struct {
+		result1 []apicorev1.Event
+		result2 error
+	}
+	retrieveEventsReturnsOnCall map[int]struct {
+		result1 []apicorev1.Event
+		result2 error
+	}
 	ServerVersionStub        func() (*version.Info, error)
 	serverVersionMutex       sync.RWMutex
 	serverVersionArgsForCall []struct{}
@@ -1048,6 +1258,108 @@ func (fake *FakeSvcatClient) RetrieveBindingsReturnsOnCall(i int, result1 *apiv1
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveBindingsPage(arg1 servicecatalog.ScopeOptions) (*apiv1beta1.ServiceBindingList, error) {
+	fake.retrieveBindingsPageMutex.Lock()
+	ret, specificReturn := fake.retrieveBindingsPageReturnsOnCall[len(fake.retrieveBindingsPageArgsForCall)]
+	fake.retrieveBindingsPageArgsForCall = append(fake.retrieveBindingsPageArgsForCall, struct {
+		arg1 servicecatalog.ScopeOptions
+	}{arg1})
+	fake.recordInvocation("RetrieveBindingsPage", []interface{}{arg1})
+	fake.retrieveBindingsPageMutex.Unlock()
+	if fake.RetrieveBindingsPageStub != nil {
+		return fake.RetrieveBindingsPageStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveBindingsPageReturns.result1, fake.retrieveBindingsPageReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveBindingsPageCallCount() int {
+	fake.retrieveBindingsPageMutex.RLock()
+	defer fake.retrieveBindingsPageMutex.RUnlock()
+	return len(fake.retrieveBindingsPageArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveBindingsPageArgsForCall(i int) servicecatalog.ScopeOptions {
+	fake.retrieveBindingsPageMutex.RLock()
+	defer fake.retrieveBindingsPageMutex.RUnlock()
+	return fake.retrieveBindingsPageArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveBindingsPageReturns(result1 *apiv1beta1.ServiceBindingList, result2 error) {
+	fake.RetrieveBindingsPageStub = nil
+	fake.retrieveBindingsPageReturns = struct {
+		result1 *apiv1beta1.ServiceBindingList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveBindingsPageReturnsOnCall(i int, result1 *apiv1beta1.ServiceBindingList, result2 error) {
+	fake.RetrieveBindingsPageStub = nil
+	if fake.retrieveBindingsPageReturnsOnCall == nil {
+		fake.retrieveBindingsPageReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceBindingList
+			result2 error
+		})
+	}
+	fake.retrieveBindingsPageReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceBindingList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WatchBindings(arg1 servicecatalog.ScopeOptions) (watch.Interface, error) {
+	fake.watchBindingsMutex.Lock()
+	ret, specificReturn := fake.watchBindingsReturnsOnCall[len(fake.watchBindingsArgsForCall)]
+	fake.watchBindingsArgsForCall = append(fake.watchBindingsArgsForCall, struct {
+		arg1 servicecatalog.ScopeOptions
+	}{arg1})
+	fake.recordInvocation("WatchBindings", []interface{}{arg1})
+	fake.watchBindingsMutex.Unlock()
+	if fake.WatchBindingsStub != nil {
+		return fake.WatchBindingsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.watchBindingsReturns.result1, fake.watchBindingsReturns.result2
+}
+
+func (fake *FakeSvcatClient) WatchBindingsCallCount() int {
+	fake.watchBindingsMutex.RLock()
+	defer fake.watchBindingsMutex.RUnlock()
+	return len(fake.watchBindingsArgsForCall)
+}
+
+func (fake *FakeSvcatClient) WatchBindingsArgsForCall(i int) servicecatalog.ScopeOptions {
+	fake.watchBindingsMutex.RLock()
+	defer fake.watchBindingsMutex.RUnlock()
+	return fake.watchBindingsArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) WatchBindingsReturns(result1 watch.Interface, result2 error) {
+	fake.WatchBindingsStub = nil
+	fake.watchBindingsReturns = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WatchBindingsReturnsOnCall(i int, result1 watch.Interface, result2 error) {
+	fake.WatchBindingsStub = nil
+	if fake.watchBindingsReturnsOnCall == nil {
+		fake.watchBindingsReturnsOnCall = make(map[int]struct {
+			result1 watch.Interface
+			result2 error
+		})
+	}
+	fake.watchBindingsReturnsOnCall[i] = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveBindingsByInstance(arg1 *apiv1beta1.ServiceInstance) ([]apiv1beta1.ServiceBinding, error) {
 	fake.retrieveBindingsByInstanceMutex.Lock()
 	ret, specificReturn := fake.retrieveBindingsByInstanceReturnsOnCall[len(fake.retrieveBindingsByInstanceArgsForCall)]
@@ -1205,6 +1517,61 @@ func (fake *FakeSvcatClient) WaitForBindingReturnsOnCall(i int, result1 *apiv1be
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) WaitForBindingToNotExist(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration, arg5 func(*apiv1beta1.ServiceBinding)) (*apiv1beta1.ServiceBinding, error) {
+	fake.waitForBindingToNotExistMutex.Lock()
+	ret, specificReturn := fake.waitForBindingToNotExistReturnsOnCall[len(fake.waitForBindingToNotExistArgsForCall)]
+	fake.waitForBindingToNotExistArgsForCall = append(fake.waitForBindingToNotExistArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 time.Duration
+		arg4 *time.Duration
+		arg5 func(*apiv1beta1.ServiceBinding)
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("WaitForBindingToNotExist", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.waitForBindingToNotExistMutex.Unlock()
+	if fake.WaitForBindingToNotExistStub != nil {
+		return fake.WaitForBindingToNotExistStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.waitForBindingToNotExistReturns.result1, fake.waitForBindingToNotExistReturns.result2
+}
+
+func (fake *FakeSvcatClient) WaitForBindingToNotExistCallCount() int {
+	fake.waitForBindingToNotExistMutex.RLock()
+	defer fake.waitForBindingToNotExistMutex.RUnlock()
+	return len(fake.waitForBindingToNotExistArgsForCall)
+}
+
+func (fake *FakeSvcatClient) WaitForBindingToNotExistArgsForCall(i int) (string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceBinding)) {
+	fake.waitForBindingToNotExistMutex.RLock()
+	defer fake.waitForBindingToNotExistMutex.RUnlock()
+	return fake.waitForBindingToNotExistArgsForCall[i].arg1, fake.waitForBindingToNotExistArgsForCall[i].arg2, fake.waitForBindingToNotExistArgsForCall[i].arg3, fake.waitForBindingToNotExistArgsForCall[i].arg4, fake.waitForBindingToNotExistArgsForCall[i].arg5
+}
+
+func (fake *FakeSvcatClient) WaitForBindingToNotExistReturns(result1 *apiv1beta1.ServiceBinding, result2 error) {
+	fake.WaitForBindingToNotExistStub = nil
+	fake.waitForBindingToNotExistReturns = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WaitForBindingToNotExistReturnsOnCall(i int, result1 *apiv1beta1.ServiceBinding, result2 error) {
+	fake.WaitForBindingToNotExistStub = nil
+	if fake.waitForBindingToNotExistReturnsOnCall == nil {
+		fake.waitForBindingToNotExistReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceBinding
+			result2 error
+		})
+	}
+	fake.waitForBindingToNotExistReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Deregister(arg1 string, arg2 *servicecatalog.ScopeOptions) error {
 	fake.deregisterMutex.Lock()
 	ret, specificReturn := fake.deregisterReturnsOnCall[len(fake.deregisterArgsForCall)]
@@ -1254,6 +1621,55 @@ func (fake *FakeSvcatClient) DeregisterReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) VerifyBroker(arg1 string, arg2 *servicecatalog.RegisterOptions) error {
+	fake.verifyBrokerMutex.Lock()
+	ret, specificReturn := fake.verifyBrokerReturnsOnCall[len(fake.verifyBrokerArgsForCall)]
+	fake.verifyBrokerArgsForCall = append(fake.verifyBrokerArgsForCall, struct {
+		arg1 string
+		arg2 *servicecatalog.RegisterOptions
+	}{arg1, arg2})
+	fake.recordInvocation("VerifyBroker", []interface{}{arg1, arg2})
+	fake.verifyBrokerMutex.Unlock()
+	if fake.VerifyBrokerStub != nil {
+		return fake.VerifyBrokerStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.verifyBrokerReturns.result1
+}
+
+func (fake *FakeSvcatClient) VerifyBrokerCallCount() int {
+	fake.verifyBrokerMutex.RLock()
+	defer fake.verifyBrokerMutex.RUnlock()
+	return len(fake.verifyBrokerArgsForCall)
+}
+
+func (fake *FakeSvcatClient) VerifyBrokerArgsForCall(i int) (string, *servicecatalog.RegisterOptions) {
+	fake.verifyBrokerMutex.RLock()
+	defer fake.verifyBrokerMutex.RUnlock()
+	return fake.verifyBrokerArgsForCall[i].arg1, fake.verifyBrokerArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) VerifyBrokerReturns(result1 error) {
+	fake.VerifyBrokerStub = nil
+	fake.verifyBrokerReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSvcatClient) VerifyBrokerReturnsOnCall(i int, result1 error) {
+	fake.VerifyBrokerStub = nil
+	if fake.verifyBrokerReturnsOnCall == nil {
+		fake.verifyBrokerReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.verifyBrokerReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSvcatClient) RetrieveBrokers(opts servicecatalog.ScopeOptions) ([]servicecatalog.Broker, error) {
 	fake.retrieveBrokersMutex.Lock()
 	ret, specificReturn := fake.retrieveBrokersReturnsOnCall[len(fake.retrieveBrokersArgsForCall)]
@@ -1407,6 +1823,58 @@ func (fake *FakeSvcatClient) RetrieveBrokerByClassReturnsOnCall(i int, result1 *
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveBrokerByName(arg1 string, arg2 servicecatalog.ScopeOptions) (servicecatalog.Broker, error) {
+	fake.retrieveBrokerByNameMutex.Lock()
+	ret, specificReturn := fake.retrieveBrokerByNameReturnsOnCall[len(fake.retrieveBrokerByNameArgsForCall)]
+	fake.retrieveBrokerByNameArgsForCall = append(fake.retrieveBrokerByNameArgsForCall, struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}{arg1, arg2})
+	fake.recordInvocation("RetrieveBrokerByName", []interface{}{arg1, arg2})
+	fake.retrieveBrokerByNameMutex.Unlock()
+	if fake.RetrieveBrokerByNameStub != nil {
+		return fake.RetrieveBrokerByNameStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveBrokerByNameReturns.result1, fake.retrieveBrokerByNameReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerByNameCallCount() int {
+	fake.retrieveBrokerByNameMutex.RLock()
+	defer fake.retrieveBrokerByNameMutex.RUnlock()
+	return len(fake.retrieveBrokerByNameArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerByNameArgsForCall(i int) (string, servicecatalog.ScopeOptions) {
+	fake.retrieveBrokerByNameMutex.RLock()
+	defer fake.retrieveBrokerByNameMutex.RUnlock()
+	return fake.retrieveBrokerByNameArgsForCall[i].arg1, fake.retrieveBrokerByNameArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerByNameReturns(result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveBrokerByNameStub = nil
+	fake.retrieveBrokerByNameReturns = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveBrokerByNameReturnsOnCall(i int, result1 servicecatalog.Broker, result2 error) {
+	fake.RetrieveBrokerByNameStub = nil
+	if fake.retrieveBrokerByNameReturnsOnCall == nil {
+		fake.retrieveBrokerByNameReturnsOnCall = make(map[int]struct {
+			result1 servicecatalog.Broker
+			result2 error
+		})
+	}
+	fake.retrieveBrokerByNameReturnsOnCall[i] = struct {
+		result1 servicecatalog.Broker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) Register(arg1 string, arg2 string, arg3 *servicecatalog.RegisterOptions, arg4 *servicecatalog.ScopeOptions) (servicecatalog.Broker, error) {
 	fake.registerMutex.Lock()
 	ret, specificReturn := fake.registerReturnsOnCall[len(fake.registerArgsForCall)]
@@ -1615,6 +2083,60 @@ func (fake *FakeSvcatClient) RetrieveClassesReturnsOnCall(i int, result1 []servi
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveClassesPage(arg1 servicecatalog.ScopeOptions) ([]servicecatalog.Class, string, error) {
+	fake.retrieveClassesPageMutex.Lock()
+	ret, specificReturn := fake.retrieveClassesPageReturnsOnCall[len(fake.retrieveClassesPageArgsForCall)]
+	fake.retrieveClassesPageArgsForCall = append(fake.retrieveClassesPageArgsForCall, struct {
+		arg1 servicecatalog.ScopeOptions
+	}{arg1})
+	fake.recordInvocation("RetrieveClassesPage", []interface{}{arg1})
+	fake.retrieveClassesPageMutex.Unlock()
+	if fake.RetrieveClassesPageStub != nil {
+		return fake.RetrieveClassesPageStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.retrieveClassesPageReturns.result1, fake.retrieveClassesPageReturns.result2, fake.retrieveClassesPageReturns.result3
+}
+
+func (fake *FakeSvcatClient) RetrieveClassesPageCallCount() int {
+	fake.retrieveClassesPageMutex.RLock()
+	defer fake.retrieveClassesPageMutex.RUnlock()
+	return len(fake.retrieveClassesPageArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveClassesPageArgsForCall(i int) servicecatalog.ScopeOptions {
+	fake.retrieveClassesPageMutex.RLock()
+	defer fake.retrieveClassesPageMutex.RUnlock()
+	return fake.retrieveClassesPageArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveClassesPageReturns(result1 []servicecatalog.Class, result2 string, result3 error) {
+	fake.RetrieveClassesPageStub = nil
+	fake.retrieveClassesPageReturns = struct {
+		result1 []servicecatalog.Class
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSvcatClient) RetrieveClassesPageReturnsOnCall(i int, result1 []servicecatalog.Class, result2 string, result3 error) {
+	fake.RetrieveClassesPageStub = nil
+	if fake.retrieveClassesPageReturnsOnCall == nil {
+		fake.retrieveClassesPageReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Class
+			result2 string
+			result3 error
+		})
+	}
+	fake.retrieveClassesPageReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Class
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeSvcatClient) RetrieveClassByName(arg1 string, arg2 servicecatalog.ScopeOptions) (servicecatalog.Class, error) {
 	fake.retrieveClassByNameMutex.Lock()
 	ret, specificReturn := fake.retrieveClassByNameReturnsOnCall[len(fake.retrieveClassByNameArgsForCall)]
@@ -2076,6 +2598,54 @@ func (fake *FakeSvcatClient) IsInstanceReadyReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) IsInstanceRefResolved(arg1 *apiv1beta1.ServiceInstance) bool {
+	fake.isInstanceRefResolvedMutex.Lock()
+	ret, specificReturn := fake.isInstanceRefResolvedReturnsOnCall[len(fake.isInstanceRefResolvedArgsForCall)]
+	fake.isInstanceRefResolvedArgsForCall = append(fake.isInstanceRefResolvedArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}{arg1})
+	fake.recordInvocation("IsInstanceRefResolved", []interface{}{arg1})
+	fake.isInstanceRefResolvedMutex.Unlock()
+	if fake.IsInstanceRefResolvedStub != nil {
+		return fake.IsInstanceRefResolvedStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.isInstanceRefResolvedReturns.result1
+}
+
+func (fake *FakeSvcatClient) IsInstanceRefResolvedCallCount() int {
+	fake.isInstanceRefResolvedMutex.RLock()
+	defer fake.isInstanceRefResolvedMutex.RUnlock()
+	return len(fake.isInstanceRefResolvedArgsForCall)
+}
+
+func (fake *FakeSvcatClient) IsInstanceRefResolvedArgsForCall(i int) *apiv1beta1.ServiceInstance {
+	fake.isInstanceRefResolvedMutex.RLock()
+	defer fake.isInstanceRefResolvedMutex.RUnlock()
+	return fake.isInstanceRefResolvedArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) IsInstanceRefResolvedReturns(result1 bool) {
+	fake.IsInstanceRefResolvedStub = nil
+	fake.isInstanceRefResolvedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeSvcatClient) IsInstanceRefResolvedReturnsOnCall(i int, result1 bool) {
+	fake.IsInstanceRefResolvedStub = nil
+	if fake.isInstanceRefResolvedReturnsOnCall == nil {
+		fake.isInstanceRefResolvedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isInstanceRefResolvedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeSvcatClient) Provision(arg1 string, arg2 string, arg3 string, arg4 *servicecatalog.ProvisionOptions) (*apiv1beta1.ServiceInstance, error) {
 	fake.provisionMutex.Lock()
 	ret, specificReturn := fake.provisionReturnsOnCall[len(fake.provisionArgsForCall)]
@@ -2130,6 +2700,59 @@ func (fake *FakeSvcatClient) ProvisionReturnsOnCall(i int, result1 *apiv1beta1.S
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) ProvisionFromInstance(arg1 string, arg2 string, arg3 *servicecatalog.ProvisionOptions) (*apiv1beta1.ServiceInstance, error) {
+	fake.provisionFromInstanceMutex.Lock()
+	ret, specificReturn := fake.provisionFromInstanceReturnsOnCall[len(fake.provisionFromInstanceArgsForCall)]
+	fake.provisionFromInstanceArgsForCall = append(fake.provisionFromInstanceArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 *servicecatalog.ProvisionOptions
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ProvisionFromInstance", []interface{}{arg1, arg2, arg3})
+	fake.provisionFromInstanceMutex.Unlock()
+	if fake.ProvisionFromInstanceStub != nil {
+		return fake.ProvisionFromInstanceStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.provisionFromInstanceReturns.result1, fake.provisionFromInstanceReturns.result2
+}
+
+func (fake *FakeSvcatClient) ProvisionFromInstanceCallCount() int {
+	fake.provisionFromInstanceMutex.RLock()
+	defer fake.provisionFromInstanceMutex.RUnlock()
+	return len(fake.provisionFromInstanceArgsForCall)
+}
+
+func (fake *FakeSvcatClient) ProvisionFromInstanceArgsForCall(i int) (string, string, *servicecatalog.ProvisionOptions) {
+	fake.provisionFromInstanceMutex.RLock()
+	defer fake.provisionFromInstanceMutex.RUnlock()
+	return fake.provisionFromInstanceArgsForCall[i].arg1, fake.provisionFromInstanceArgsForCall[i].arg2, fake.provisionFromInstanceArgsForCall[i].arg3
+}
+
+func (fake *FakeSvcatClient) ProvisionFromInstanceReturns(result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.ProvisionFromInstanceStub = nil
+	fake.provisionFromInstanceReturns = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) ProvisionFromInstanceReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstance, result2 error) {
+	fake.ProvisionFromInstanceStub = nil
+	if fake.provisionFromInstanceReturnsOnCall == nil {
+		fake.provisionFromInstanceReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceInstance
+			result2 error
+		})
+	}
+	fake.provisionFromInstanceReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveInstance(arg1 string, arg2 string) (*apiv1beta1.ServiceInstance, error) {
 	fake.retrieveInstanceMutex.Lock()
 	ret, specificReturn := fake.retrieveInstanceReturnsOnCall[len(fake.retrieveInstanceArgsForCall)]
@@ -2233,6 +2856,108 @@ func (fake *FakeSvcatClient) RetrieveInstanceByBindingReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveInstanceParameters(arg1 *apiv1beta1.ServiceInstance) (map[string]interface{}, error) {
+	fake.retrieveInstanceParametersMutex.Lock()
+	ret, specificReturn := fake.retrieveInstanceParametersReturnsOnCall[len(fake.retrieveInstanceParametersArgsForCall)]
+	fake.retrieveInstanceParametersArgsForCall = append(fake.retrieveInstanceParametersArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}{arg1})
+	fake.recordInvocation("RetrieveInstanceParameters", []interface{}{arg1})
+	fake.retrieveInstanceParametersMutex.Unlock()
+	if fake.RetrieveInstanceParametersStub != nil {
+		return fake.RetrieveInstanceParametersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveInstanceParametersReturns.result1, fake.retrieveInstanceParametersReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceParametersCallCount() int {
+	fake.retrieveInstanceParametersMutex.RLock()
+	defer fake.retrieveInstanceParametersMutex.RUnlock()
+	return len(fake.retrieveInstanceParametersArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceParametersArgsForCall(i int) *apiv1beta1.ServiceInstance {
+	fake.retrieveInstanceParametersMutex.RLock()
+	defer fake.retrieveInstanceParametersMutex.RUnlock()
+	return fake.retrieveInstanceParametersArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceParametersReturns(result1 map[string]interface{}, result2 error) {
+	fake.RetrieveInstanceParametersStub = nil
+	fake.retrieveInstanceParametersReturns = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveInstanceParametersReturnsOnCall(i int, result1 map[string]interface{}, result2 error) {
+	fake.RetrieveInstanceParametersStub = nil
+	if fake.retrieveInstanceParametersReturnsOnCall == nil {
+		fake.retrieveInstanceParametersReturnsOnCall = make(map[int]struct {
+			result1 map[string]interface{}
+			result2 error
+		})
+	}
+	fake.retrieveInstanceParametersReturnsOnCall[i] = struct {
+		result1 map[string]interface{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DiffInstanceParameters(arg1 *apiv1beta1.ServiceInstance) (*servicecatalog.InstanceParametersDiff, error) {
+	fake.diffInstanceParametersMutex.Lock()
+	ret, specificReturn := fake.diffInstanceParametersReturnsOnCall[len(fake.diffInstanceParametersArgsForCall)]
+	fake.diffInstanceParametersArgsForCall = append(fake.diffInstanceParametersArgsForCall, struct {
+		arg1 *apiv1beta1.ServiceInstance
+	}{arg1})
+	fake.recordInvocation("DiffInstanceParameters", []interface{}{arg1})
+	fake.diffInstanceParametersMutex.Unlock()
+	if fake.DiffInstanceParametersStub != nil {
+		return fake.DiffInstanceParametersStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.diffInstanceParametersReturns.result1, fake.diffInstanceParametersReturns.result2
+}
+
+func (fake *FakeSvcatClient) DiffInstanceParametersCallCount() int {
+	fake.diffInstanceParametersMutex.RLock()
+	defer fake.diffInstanceParametersMutex.RUnlock()
+	return len(fake.diffInstanceParametersArgsForCall)
+}
+
+func (fake *FakeSvcatClient) DiffInstanceParametersArgsForCall(i int) *apiv1beta1.ServiceInstance {
+	fake.diffInstanceParametersMutex.RLock()
+	defer fake.diffInstanceParametersMutex.RUnlock()
+	return fake.diffInstanceParametersArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) DiffInstanceParametersReturns(result1 *servicecatalog.InstanceParametersDiff, result2 error) {
+	fake.DiffInstanceParametersStub = nil
+	fake.diffInstanceParametersReturns = struct {
+		result1 *servicecatalog.InstanceParametersDiff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) DiffInstanceParametersReturnsOnCall(i int, result1 *servicecatalog.InstanceParametersDiff, result2 error) {
+	fake.DiffInstanceParametersStub = nil
+	if fake.diffInstanceParametersReturnsOnCall == nil {
+		fake.diffInstanceParametersReturnsOnCall = make(map[int]struct {
+			result1 *servicecatalog.InstanceParametersDiff
+			result2 error
+		})
+	}
+	fake.diffInstanceParametersReturnsOnCall[i] = struct {
+		result1 *servicecatalog.InstanceParametersDiff
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveInstances(arg1 string, arg2 string, arg3 string) (*apiv1beta1.ServiceInstanceList, error) {
 	fake.retrieveInstancesMutex.Lock()
 	ret, specificReturn := fake.retrieveInstancesReturnsOnCall[len(fake.retrieveInstancesArgsForCall)]
@@ -2286,6 +3011,108 @@ func (fake *FakeSvcatClient) RetrieveInstancesReturnsOnCall(i int, result1 *apiv
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveInstancesPage(arg1 servicecatalog.ScopeOptions) (*apiv1beta1.ServiceInstanceList, error) {
+	fake.retrieveInstancesPageMutex.Lock()
+	ret, specificReturn := fake.retrieveInstancesPageReturnsOnCall[len(fake.retrieveInstancesPageArgsForCall)]
+	fake.retrieveInstancesPageArgsForCall = append(fake.retrieveInstancesPageArgsForCall, struct {
+		arg1 servicecatalog.ScopeOptions
+	}{arg1})
+	fake.recordInvocation("RetrieveInstancesPage", []interface{}{arg1})
+	fake.retrieveInstancesPageMutex.Unlock()
+	if fake.RetrieveInstancesPageStub != nil {
+		return fake.RetrieveInstancesPageStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveInstancesPageReturns.result1, fake.retrieveInstancesPageReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveInstancesPageCallCount() int {
+	fake.retrieveInstancesPageMutex.RLock()
+	defer fake.retrieveInstancesPageMutex.RUnlock()
+	return len(fake.retrieveInstancesPageArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveInstancesPageArgsForCall(i int) servicecatalog.ScopeOptions {
+	fake.retrieveInstancesPageMutex.RLock()
+	defer fake.retrieveInstancesPageMutex.RUnlock()
+	return fake.retrieveInstancesPageArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) RetrieveInstancesPageReturns(result1 *apiv1beta1.ServiceInstanceList, result2 error) {
+	fake.RetrieveInstancesPageStub = nil
+	fake.retrieveInstancesPageReturns = struct {
+		result1 *apiv1beta1.ServiceInstanceList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveInstancesPageReturnsOnCall(i int, result1 *apiv1beta1.ServiceInstanceList, result2 error) {
+	fake.RetrieveInstancesPageStub = nil
+	if fake.retrieveInstancesPageReturnsOnCall == nil {
+		fake.retrieveInstancesPageReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceInstanceList
+			result2 error
+		})
+	}
+	fake.retrieveInstancesPageReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceInstanceList
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WatchInstances(arg1 servicecatalog.ScopeOptions) (watch.Interface, error) {
+	fake.watchInstancesMutex.Lock()
+	ret, specificReturn := fake.watchInstancesReturnsOnCall[len(fake.watchInstancesArgsForCall)]
+	fake.watchInstancesArgsForCall = append(fake.watchInstancesArgsForCall, struct {
+		arg1 servicecatalog.ScopeOptions
+	}{arg1})
+	fake.recordInvocation("WatchInstances", []interface{}{arg1})
+	fake.watchInstancesMutex.Unlock()
+	if fake.WatchInstancesStub != nil {
+		return fake.WatchInstancesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.watchInstancesReturns.result1, fake.watchInstancesReturns.result2
+}
+
+func (fake *FakeSvcatClient) WatchInstancesCallCount() int {
+	fake.watchInstancesMutex.RLock()
+	defer fake.watchInstancesMutex.RUnlock()
+	return len(fake.watchInstancesArgsForCall)
+}
+
+func (fake *FakeSvcatClient) WatchInstancesArgsForCall(i int) servicecatalog.ScopeOptions {
+	fake.watchInstancesMutex.RLock()
+	defer fake.watchInstancesMutex.RUnlock()
+	return fake.watchInstancesArgsForCall[i].arg1
+}
+
+func (fake *FakeSvcatClient) WatchInstancesReturns(result1 watch.Interface, result2 error) {
+	fake.WatchInstancesStub = nil
+	fake.watchInstancesReturns = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) WatchInstancesReturnsOnCall(i int, result1 watch.Interface, result2 error) {
+	fake.WatchInstancesStub = nil
+	if fake.watchInstancesReturnsOnCall == nil {
+		fake.watchInstancesReturnsOnCall = make(map[int]struct {
+			result1 watch.Interface
+			result2 error
+		})
+	}
+	fake.watchInstancesReturnsOnCall[i] = struct {
+		result1 watch.Interface
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) RetrieveInstancesByPlan(arg1 servicecatalog.Plan) ([]apiv1beta1.ServiceInstance, error) {
 	fake.retrieveInstancesByPlanMutex.Lock()
 	ret, specificReturn := fake.retrieveInstancesByPlanReturnsOnCall[len(fake.retrieveInstancesByPlanArgsForCall)]
@@ -2387,6 +3214,59 @@ func (fake *FakeSvcatClient) TouchInstanceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSvcatClient) TouchBinding(arg1 string, arg2 string, arg3 int) (*apiv1beta1.ServiceBinding, error) {
+	fake.touchBindingMutex.Lock()
+	ret, specificReturn := fake.touchBindingReturnsOnCall[len(fake.touchBindingArgsForCall)]
+	fake.touchBindingArgsForCall = append(fake.touchBindingArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("TouchBinding", []interface{}{arg1, arg2, arg3})
+	fake.touchBindingMutex.Unlock()
+	if fake.TouchBindingStub != nil {
+		return fake.TouchBindingStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.touchBindingReturns.result1, fake.touchBindingReturns.result2
+}
+
+func (fake *FakeSvcatClient) TouchBindingCallCount() int {
+	fake.touchBindingMutex.RLock()
+	defer fake.touchBindingMutex.RUnlock()
+	return len(fake.touchBindingArgsForCall)
+}
+
+func (fake *FakeSvcatClient) TouchBindingArgsForCall(i int) (string, string, int) {
+	fake.touchBindingMutex.RLock()
+	defer fake.touchBindingMutex.RUnlock()
+	return fake.touchBindingArgsForCall[i].arg1, fake.touchBindingArgsForCall[i].arg2, fake.touchBindingArgsForCall[i].arg3
+}
+
+func (fake *FakeSvcatClient) TouchBindingReturns(result1 *apiv1beta1.ServiceBinding, result2 error) {
+	fake.TouchBindingStub = nil
+	fake.touchBindingReturns = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) TouchBindingReturnsOnCall(i int, result1 *apiv1beta1.ServiceBinding, result2 error) {
+	fake.TouchBindingStub = nil
+	if fake.touchBindingReturnsOnCall == nil {
+		fake.touchBindingReturnsOnCall = make(map[int]struct {
+			result1 *apiv1beta1.ServiceBinding
+			result2 error
+		})
+	}
+	fake.touchBindingReturnsOnCall[i] = struct {
+		result1 *apiv1beta1.ServiceBinding
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) WaitForInstance(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration) (*apiv1beta1.ServiceInstance, error) {
 	fake.waitForInstanceMutex.Lock()
 	ret, specificReturn := fake.waitForInstanceReturnsOnCall[len(fake.waitForInstanceArgsForCall)]
@@ -2441,7 +3321,7 @@ func (fake *FakeSvcatClient) WaitForInstanceReturnsOnCall(i int, result1 *apiv1b
 	}{result1, result2}
 }
 
-func (fake *FakeSvcatClient) WaitForInstanceToNotExist(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration) (*apiv1beta1.ServiceInstance, error) {
+func (fake *FakeSvcatClient) WaitForInstanceToNotExist(arg1 string, arg2 string, arg3 time.Duration, arg4 *time.Duration, arg5 func(*apiv1beta1.ServiceInstance)) (*apiv1beta1.ServiceInstance, error) {
 	fake.waitForInstanceToNotExistMutex.Lock()
 	ret, specificReturn := fake.waitForInstanceToNotExistReturnsOnCall[len(fake.waitForInstanceToNotExistArgsForCall)]
 	fake.waitForInstanceToNotExistArgsForCall = append(fake.waitForInstanceToNotExistArgsForCall, struct {
@@ -2449,11 +3329,12 @@ func (fake *FakeSvcatClient) WaitForInstanceToNotExist(arg1 string, arg2 string,
 		arg2 string
 		arg3 time.Duration
 		arg4 *time.Duration
-	}{arg1, arg2, arg3, arg4})
-	fake.recordInvocation("WaitForInstanceToNotExist", []interface{}{arg1, arg2, arg3, arg4})
+		arg5 func(*apiv1beta1.ServiceInstance)
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("WaitForInstanceToNotExist", []interface{}{arg1, arg2, arg3, arg4, arg5})
 	fake.waitForInstanceToNotExistMutex.Unlock()
 	if fake.WaitForInstanceToNotExistStub != nil {
-		return fake.WaitForInstanceToNotExistStub(arg1, arg2, arg3, arg4)
+		return fake.WaitForInstanceToNotExistStub(arg1, arg2, arg3, arg4, arg5)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2467,10 +3348,10 @@ func (fake *FakeSvcatClient) WaitForInstanceToNotExistCallCount() int {
 	return len(fake.waitForInstanceToNotExistArgsForCall)
 }
 
-func (fake *FakeSvcatClient) WaitForInstanceToNotExistArgsForCall(i int) (string, string, time.Duration, *time.Duration) {
+func (fake *FakeSvcatClient) WaitForInstanceToNotExistArgsForCall(i int) (string, string, time.Duration, *time.Duration, func(*apiv1beta1.ServiceInstance)) {
 	fake.waitForInstanceToNotExistMutex.RLock()
 	defer fake.waitForInstanceToNotExistMutex.RUnlock()
-	return fake.waitForInstanceToNotExistArgsForCall[i].arg1, fake.waitForInstanceToNotExistArgsForCall[i].arg2, fake.waitForInstanceToNotExistArgsForCall[i].arg3, fake.waitForInstanceToNotExistArgsForCall[i].arg4
+	return fake.waitForInstanceToNotExistArgsForCall[i].arg1, fake.waitForInstanceToNotExistArgsForCall[i].arg2, fake.waitForInstanceToNotExistArgsForCall[i].arg3, fake.waitForInstanceToNotExistArgsForCall[i].arg4, fake.waitForInstanceToNotExistArgsForCall[i].arg5
 }
 
 func (fake *FakeSvcatClient) WaitForInstanceToNotExistReturns(result1 *apiv1beta1.ServiceInstance, result2 error) {
@@ -2547,6 +3428,61 @@ func (fake *FakeSvcatClient) RetrievePlansReturnsOnCall(i int, result1 []service
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrievePlansPage(arg1 string, arg2 servicecatalog.ScopeOptions) ([]servicecatalog.Plan, string, error) {
+	fake.retrievePlansPageMutex.Lock()
+	ret, specificReturn := fake.retrievePlansPageReturnsOnCall[len(fake.retrievePlansPageArgsForCall)]
+	fake.retrievePlansPageArgsForCall = append(fake.retrievePlansPageArgsForCall, struct {
+		arg1 string
+		arg2 servicecatalog.ScopeOptions
+	}{arg1, arg2})
+	fake.recordInvocation("RetrievePlansPage", []interface{}{arg1, arg2})
+	fake.retrievePlansPageMutex.Unlock()
+	if fake.RetrievePlansPageStub != nil {
+		return fake.RetrievePlansPageStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.retrievePlansPageReturns.result1, fake.retrievePlansPageReturns.result2, fake.retrievePlansPageReturns.result3
+}
+
+func (fake *FakeSvcatClient) RetrievePlansPageCallCount() int {
+	fake.retrievePlansPageMutex.RLock()
+	defer fake.retrievePlansPageMutex.RUnlock()
+	return len(fake.retrievePlansPageArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrievePlansPageArgsForCall(i int) (string, servicecatalog.ScopeOptions) {
+	fake.retrievePlansPageMutex.RLock()
+	defer fake.retrievePlansPageMutex.RUnlock()
+	return fake.retrievePlansPageArgsForCall[i].arg1, fake.retrievePlansPageArgsForCall[i].arg2
+}
+
+func (fake *FakeSvcatClient) RetrievePlansPageReturns(result1 []servicecatalog.Plan, result2 string, result3 error) {
+	fake.RetrievePlansPageStub = nil
+	fake.retrievePlansPageReturns = struct {
+		result1 []servicecatalog.Plan
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeSvcatClient) RetrievePlansPageReturnsOnCall(i int, result1 []servicecatalog.Plan, result2 string, result3 error) {
+	fake.RetrievePlansPageStub = nil
+	if fake.retrievePlansPageReturnsOnCall == nil {
+		fake.retrievePlansPageReturnsOnCall = make(map[int]struct {
+			result1 []servicecatalog.Plan
+			result2 string
+			result3 error
+		})
+	}
+	fake.retrievePlansPageReturnsOnCall[i] = struct {
+		result1 []servicecatalog.Plan
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeSvcatClient) RetrievePlanByName(arg1 string, arg2 servicecatalog.ScopeOptions) (servicecatalog.Plan, error) {
 	fake.retrievePlanByNameMutex.Lock()
 	ret, specificReturn := fake.retrievePlanByNameReturnsOnCall[len(fake.retrievePlanByNameArgsForCall)]
@@ -2808,6 +3744,59 @@ func (fake *FakeSvcatClient) RetrieveSecretByBindingReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *FakeSvcatClient) RetrieveEvents(arg1 string, arg2 string, arg3 string) ([]apicorev1.Event, error) {
+	fake.retrieveEventsMutex.Lock()
+	ret, specificReturn := fake.retrieveEventsReturnsOnCall[len(fake.retrieveEventsArgsForCall)]
+	fake.retrieveEventsArgsForCall = append(fake.retrieveEventsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RetrieveEvents", []interface{}{arg1, arg2, arg3})
+	fake.retrieveEventsMutex.Unlock()
+	if fake.RetrieveEventsStub != nil {
+		return fake.RetrieveEventsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.retrieveEventsReturns.result1, fake.retrieveEventsReturns.result2
+}
+
+func (fake *FakeSvcatClient) RetrieveEventsCallCount() int {
+	fake.retrieveEventsMutex.RLock()
+	defer fake.retrieveEventsMutex.RUnlock()
+	return len(fake.retrieveEventsArgsForCall)
+}
+
+func (fake *FakeSvcatClient) RetrieveEventsArgsForCall(i int) (string, string, string) {
+	fake.retrieveEventsMutex.RLock()
+	defer fake.retrieveEventsMutex.RUnlock()
+	return fake.retrieveEventsArgsForCall[i].arg1, fake.retrieveEventsArgsForCall[i].arg2, fake.retrieveEventsArgsForCall[i].arg3
+}
+
+func (fake *FakeSvcatClient) RetrieveEventsReturns(result1 []apicorev1.Event, result2 error) {
+	fake.RetrieveEventsStub = nil
+	fake.retrieveEventsReturns = struct {
+		result1 []apicorev1.Event
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSvcatClient) RetrieveEventsReturnsOnCall(i int, result1 []apicorev1.Event, result2 error) {
+	fake.RetrieveEventsStub = nil
+	if fake.retrieveEventsReturnsOnCall == nil {
+		fake.retrieveEventsReturnsOnCall = make(map[int]struct {
+			result1 []apicorev1.Event
+			result2 error
+		})
+	}
+	fake.retrieveEventsReturnsOnCall[i] = struct {
+		result1 []apicorev1.Event
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeSvcatClient) ServerVersion() (*version.Info, error) {
 	fake.serverVersionMutex.Lock()
 	ret, specificReturn := fake.serverVersionReturnsOnCall[len(fake.serverVersionArgsForCall)]
@@ -2870,20 +3859,30 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrieveBindingMutex.RUnlock()
 	fake.retrieveBindingsMutex.RLock()
 	defer fake.retrieveBindingsMutex.RUnlock()
+	fake.retrieveBindingsPageMutex.RLock()
+	defer fake.retrieveBindingsPageMutex.RUnlock()
+	fake.watchBindingsMutex.RLock()
+	defer fake.watchBindingsMutex.RUnlock()
 	fake.retrieveBindingsByInstanceMutex.RLock()
 	defer fake.retrieveBindingsByInstanceMutex.RUnlock()
 	fake.unbindMutex.RLock()
 	defer fake.unbindMutex.RUnlock()
 	fake.waitForBindingMutex.RLock()
 	defer fake.waitForBindingMutex.RUnlock()
+	fake.waitForBindingToNotExistMutex.RLock()
+	defer fake.waitForBindingToNotExistMutex.RUnlock()
 	fake.deregisterMutex.RLock()
 	defer fake.deregisterMutex.RUnlock()
+	fake.verifyBrokerMutex.RLock()
+	defer fake.verifyBrokerMutex.RUnlock()
 	fake.retrieveBrokersMutex.RLock()
 	defer fake.retrieveBrokersMutex.RUnlock()
 	fake.retrieveBrokerMutex.RLock()
 	defer fake.retrieveBrokerMutex.RUnlock()
 	fake.retrieveBrokerByClassMutex.RLock()
 	defer fake.retrieveBrokerByClassMutex.RUnlock()
+	fake.retrieveBrokerByNameMutex.RLock()
+	defer fake.retrieveBrokerByNameMutex.RUnlock()
 	fake.registerMutex.RLock()
 	defer fake.registerMutex.RUnlock()
 	fake.syncMutex.RLock()
@@ -2892,6 +3891,8 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.waitForBrokerMutex.RUnlock()
 	fake.retrieveClassesMutex.RLock()
 	defer fake.retrieveClassesMutex.RUnlock()
+	fake.retrieveClassesPageMutex.RLock()
+	defer fake.retrieveClassesPageMutex.RUnlock()
 	fake.retrieveClassByNameMutex.RLock()
 	defer fake.retrieveClassByNameMutex.RUnlock()
 	fake.retrieveClassByIDMutex.RLock()
@@ -2910,24 +3911,40 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.isInstanceFailedMutex.RUnlock()
 	fake.isInstanceReadyMutex.RLock()
 	defer fake.isInstanceReadyMutex.RUnlock()
+	fake.isInstanceRefResolvedMutex.RLock()
+	defer fake.isInstanceRefResolvedMutex.RUnlock()
 	fake.provisionMutex.RLock()
 	defer fake.provisionMutex.RUnlock()
+	fake.provisionFromInstanceMutex.RLock()
+	defer fake.provisionFromInstanceMutex.RUnlock()
 	fake.retrieveInstanceMutex.RLock()
 	defer fake.retrieveInstanceMutex.RUnlock()
 	fake.retrieveInstanceByBindingMutex.RLock()
 	defer fake.retrieveInstanceByBindingMutex.RUnlock()
+	fake.retrieveInstanceParametersMutex.RLock()
+	defer fake.retrieveInstanceParametersMutex.RUnlock()
+	fake.diffInstanceParametersMutex.RLock()
+	defer fake.diffInstanceParametersMutex.RUnlock()
 	fake.retrieveInstancesMutex.RLock()
 	defer fake.retrieveInstancesMutex.RUnlock()
+	fake.retrieveInstancesPageMutex.RLock()
+	defer fake.retrieveInstancesPageMutex.RUnlock()
+	fake.watchInstancesMutex.RLock()
+	defer fake.watchInstancesMutex.RUnlock()
 	fake.retrieveInstancesByPlanMutex.RLock()
 	defer fake.retrieveInstancesByPlanMutex.RUnlock()
 	fake.touchInstanceMutex.RLock()
 	defer fake.touchInstanceMutex.RUnlock()
+	fake.touchBindingMutex.RLock()
+	defer fake.touchBindingMutex.RUnlock()
 	fake.waitForInstanceMutex.RLock()
 	defer fake.waitForInstanceMutex.RUnlock()
 	fake.waitForInstanceToNotExistMutex.RLock()
 	defer fake.waitForInstanceToNotExistMutex.RUnlock()
 	fake.retrievePlansMutex.RLock()
 	defer fake.retrievePlansMutex.RUnlock()
+	fake.retrievePlansPageMutex.RLock()
+	defer fake.retrievePlansPageMutex.RUnlock()
 	fake.retrievePlanByNameMutex.RLock()
 	defer fake.retrievePlanByNameMutex.RUnlock()
 	fake.retrievePlanByClassAndNameMutex.RLock()
@@ -2938,6 +3955,8 @@ func (fake *FakeSvcatClient) Invocations() map[string][][]interface{} {
 	defer fake.retrievePlanByIDMutex.RUnlock()
 	fake.retrieveSecretByBindingMutex.RLock()
 	defer fake.retrieveSecretByBindingMutex.RUnlock()
+	fake.retrieveEventsMutex.RLock()
+	defer fake.retrieveEventsMutex.RUnlock()
 	fake.serverVersionMutex.RLock()
 	defer fake.serverVersionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
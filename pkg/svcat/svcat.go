@@ -17,6 +17,9 @@ limitations under the License.
 package svcat
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/poy/service-catalog/pkg/client/clientset_generated/clientset"
 	"github.com/poy/service-catalog/pkg/svcat/service-catalog"
 	k8sclient "k8s.io/client-go/kubernetes"
@@ -42,3 +45,14 @@ func NewApp(k8sClient k8sclient.Interface, serviceCatalogClient clientset.Interf
 
 	return app, nil
 }
+
+// EnableCache serves repeated reads of cluster-scoped brokers, classes and
+// plans from informer-backed listers, for long-lived processes that issue many
+// calls. The informers run until stopCh is closed.
+func (a *App) EnableCache(resync time.Duration, stopCh <-chan struct{}) error {
+	sdk, ok := a.SvcatClient.(*servicecatalog.SDK)
+	if !ok {
+		return fmt.Errorf("caching is not supported by %T", a.SvcatClient)
+	}
+	return sdk.EnableCache(resync, stopCh)
+}
@@ -114,7 +114,7 @@ func (s *sarcheck) Admit(a admission.Attributes) error {
 			return nil
 		}
 
-		var secretRef *servicecatalog.LocalObjectReference
+		var secretRef *servicecatalog.ObjectReference
 		if serviceBroker.Spec.AuthInfo.Basic != nil {
 			secretRef = serviceBroker.Spec.AuthInfo.Basic.SecretRef
 		} else if serviceBroker.Spec.AuthInfo.Bearer != nil {
@@ -125,7 +125,12 @@ func (s *sarcheck) Admit(a admission.Attributes) error {
 			return nil
 		}
 		klog.V(5).Infof("ServiceBroker %+v: evaluating auth secret ref, with authInfo %q", serviceBroker, secretRef)
-		namespace = serviceBroker.Namespace
+		// the secret is in the namespace of the broker unless another
+		// namespace is referenced
+		namespace = secretRef.Namespace
+		if namespace == "" {
+			namespace = serviceBroker.Namespace
+		}
 		secretName = secretRef.Name
 	}
 	// if we didn't get a namespace and name, it wasn't a clusterservicebroker or broker
@@ -287,7 +287,7 @@ func TestAdmissionBroker(t *testing.T) {
 				Spec: servicecatalog.ServiceBrokerSpec{
 					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
 						Basic: &servicecatalog.BasicAuthConfig{
-							SecretRef: &servicecatalog.LocalObjectReference{
+							SecretRef: &servicecatalog.ObjectReference{
 								Name: "test-secret",
 							},
 						},
@@ -314,7 +314,7 @@ func TestAdmissionBroker(t *testing.T) {
 				Spec: servicecatalog.ServiceBrokerSpec{
 					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
 						Bearer: &servicecatalog.BearerTokenAuthConfig{
-							SecretRef: &servicecatalog.LocalObjectReference{
+							SecretRef: &servicecatalog.ObjectReference{
 								Name: "test-secret",
 							},
 						},
@@ -341,7 +341,7 @@ func TestAdmissionBroker(t *testing.T) {
 				Spec: servicecatalog.ServiceBrokerSpec{
 					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
 						Bearer: &servicecatalog.BearerTokenAuthConfig{
-							SecretRef: &servicecatalog.LocalObjectReference{
+							SecretRef: &servicecatalog.ObjectReference{
 								Name: "test-secret",
 							},
 						},
@@ -388,7 +388,7 @@ func TestAdmissionBroker(t *testing.T) {
 				Spec: servicecatalog.ServiceBrokerSpec{
 					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
 						Bearer: &servicecatalog.BearerTokenAuthConfig{
-							SecretRef: &servicecatalog.LocalObjectReference{
+							SecretRef: &servicecatalog.ObjectReference{
 								Name: "",
 							},
 						},
@@ -421,3 +421,71 @@ func TestAdmissionBroker(t *testing.T) {
 		}
 	}
 }
+
+// TestAdmissionServiceBrokerSecretNamespace tests that the SAR check for the
+// auth secret of a ServiceBroker is made in the namespace of the secret.
+func TestAdmissionServiceBrokerSecretNamespace(t *testing.T) {
+	cases := []struct {
+		name              string
+		secretNamespace   string
+		expectedNamespace string
+	}{
+		{
+			name:              "secret in the namespace of the broker",
+			expectedNamespace: "test-ns",
+		},
+		{
+			name:              "secret in another namespace",
+			secretNamespace:   "secrets",
+			expectedNamespace: "secrets",
+		},
+	}
+
+	for _, tc := range cases {
+		userInfo := &user.DefaultInfo{
+			Name:   "system:serviceaccount:test-ns:catalog",
+			Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+		}
+		broker := &servicecatalog.ServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "test-ns",
+				Name:      "test-broker",
+			},
+			Spec: servicecatalog.ServiceBrokerSpec{
+				AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+					Basic: &servicecatalog.BasicAuthConfig{
+						SecretRef: &servicecatalog.ObjectReference{
+							Namespace: tc.secretNamespace,
+							Name:      "test-secret",
+						},
+					},
+				},
+				CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+					URL:            "http://example.com",
+					RelistBehavior: "Manual",
+				},
+			},
+		}
+
+		var namespace string
+		mockKubeClient := newMockKubeClientForTest(userInfo)
+		mockKubeClient.PrependReactor("create", "subjectaccessreviews", func(action core.Action) (bool, runtime.Object, error) {
+			sar := action.(core.CreateAction).GetObject().(*authorizationapi.SubjectAccessReview)
+			namespace = sar.Spec.ResourceAttributes.Namespace
+			return false, nil, nil
+		})
+		handler, kubeInformerFactory, err := newHandlerForTest(mockKubeClient)
+		if err != nil {
+			t.Errorf("unexpected error initializing handler: %v", err)
+		}
+		kubeInformerFactory.Start(wait.NeverStop)
+
+		err = handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(broker, nil, servicecatalog.Kind("ServiceBroker").WithVersion("version"), broker.Namespace, broker.Name, servicecatalog.Resource("servicebrokers").WithVersion("version"), "", admission.Create, false, userInfo))
+		if err != nil {
+			t.Errorf("%s: unexpected error returned from admission handler: %v", tc.name, err)
+		}
+		if e, a := tc.expectedNamespace, namespace; e != a {
+			t.Errorf("%s: unexpected namespace in the SAR check: expected %q, got %q", tc.name, e, a)
+		}
+	}
+}
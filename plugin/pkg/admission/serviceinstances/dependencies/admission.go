@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependencies
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/klog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/internalclientset"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/poy/service-catalog/pkg/apiserver/admission"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceInstanceDependencies"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewDenyDependencyCycles()
+	})
+}
+
+// denyDependencyCycles is an implementation of admission.Interface.
+// It rejects the creation and updates of Service Instances whose dependsOn
+// list makes the instances of the namespace depend on each other in a cycle,
+// which would keep all of them from being provisioned.
+type denyDependencyCycles struct {
+	*admission.Handler
+	internalClientSet internalclientset.Interface
+}
+
+var _ = scadmission.WantsInternalServiceCatalogClientSet(&denyDependencyCycles{})
+
+func (d *denyDependencyCycles) Admit(a admission.Attributes) error {
+	// We only care about service Instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") {
+		return nil
+	}
+	if a.GetSubresource() != "" {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+	if len(instance.Spec.DependsOn) == 0 {
+		return nil
+	}
+
+	instances, err := d.internalClientSet.Servicecatalog().ServiceInstances(instance.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	dependsOn := make(map[string][]servicecatalog.LocalObjectReference, len(instances.Items)+1)
+	for _, i := range instances.Items {
+		dependsOn[i.Name] = i.Spec.DependsOn
+	}
+	// The stored instance, if any, is replaced by the one being admitted
+	dependsOn[instance.Name] = instance.Spec.DependsOn
+
+	cycle := findDependencyCycle(instance.Name, dependsOn)
+	if cycle == nil {
+		return nil
+	}
+
+	msg := fmt.Sprintf("dependsOn makes instances depend on each other in a cycle: %s", strings.Join(cycle, " -> "))
+	klog.V(4).Infof(`ServiceInstance "%s/%s": %s`, instance.Namespace, instance.Name, msg)
+	return admission.NewForbidden(a, errors.New(msg))
+}
+
+// findDependencyCycle returns the names of the instances of a cycle going
+// through the named instance, starting and ending with it, or nil if its
+// dependencies don't lead back to it. Instances missing from dependsOn have
+// no dependencies.
+func findDependencyCycle(name string, dependsOn map[string][]servicecatalog.LocalObjectReference) []string {
+	visited := make(map[string]bool)
+	var visit func(path []string) []string
+	visit = func(path []string) []string {
+		for _, dependency := range dependsOn[path[len(path)-1]] {
+			if dependency.Name == name {
+				return append(path, name)
+			}
+			if visited[dependency.Name] {
+				continue
+			}
+			visited[dependency.Name] = true
+			if cycle := visit(append(path, dependency.Name)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return visit([]string{name})
+}
+
+// NewDenyDependencyCycles creates a new admission control handler that
+// rejects Service Instances whose dependencies form a cycle.
+func NewDenyDependencyCycles() (admission.Interface, error) {
+	return &denyDependencyCycles{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
+
+func (d *denyDependencyCycles) SetInternalServiceCatalogClientSet(i internalclientset.Interface) {
+	d.internalClientSet = i
+}
+
+func (d *denyDependencyCycles) ValidateInitialization() error {
+	if d.internalClientSet == nil {
+		return errors.New("missing service catalog clientset")
+	}
+	return nil
+}
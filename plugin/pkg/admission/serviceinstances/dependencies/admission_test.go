@@ -0,0 +1,159 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependencies
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	core "k8s.io/client-go/testing"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/poy/service-catalog/pkg/apiserver/admission"
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/internalclientset"
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/poy/service-catalog/pkg/client/informers_generated/internalversion"
+)
+
+// newHandlerForTest returns a configured handler for testing.
+func newHandlerForTest(internalClient internalclientset.Interface) (admission.Interface, error) {
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	handler, err := NewDenyDependencyCycles()
+	if err != nil {
+		return nil, err
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, nil, nil)
+	pluginInitializer.Initialize(handler)
+	err = admission.ValidateInitialization(handler)
+	return handler, err
+}
+
+// newFakeServiceCatalogClientForTest creates a fake clientset that returns
+// the given instances on lists.
+func newFakeServiceCatalogClientForTest(instances ...servicecatalog.ServiceInstance) *fake.Clientset {
+	fakeClient := &fake.Clientset{}
+	fakeClient.AddReactor("list", "serviceinstances", func(action core.Action) (bool, runtime.Object, error) {
+		return true, &servicecatalog.ServiceInstanceList{Items: instances}, nil
+	})
+	return fakeClient
+}
+
+func newServiceInstance(name string, dependsOn ...string) servicecatalog.ServiceInstance {
+	instance := servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "dummy"},
+	}
+	for _, dependency := range dependsOn {
+		instance.Spec.DependsOn = append(instance.Spec.DependsOn, servicecatalog.LocalObjectReference{Name: dependency})
+	}
+	return instance
+}
+
+func TestDenyDependencyCycles(t *testing.T) {
+	cases := []struct {
+		name      string
+		existing  []servicecatalog.ServiceInstance
+		instance  servicecatalog.ServiceInstance
+		operation admission.Operation
+		expected  string
+	}{
+		{
+			name:      "no dependencies",
+			existing:  []servicecatalog.ServiceInstance{newServiceInstance("a", "b")},
+			instance:  newServiceInstance("b"),
+			operation: admission.Create,
+		},
+		{
+			name:      "missing dependency",
+			instance:  newServiceInstance("a", "b"),
+			operation: admission.Create,
+		},
+		{
+			name: "dependency chain",
+			existing: []servicecatalog.ServiceInstance{
+				newServiceInstance("b", "c"),
+				newServiceInstance("c"),
+			},
+			instance:  newServiceInstance("a", "b"),
+			operation: admission.Create,
+		},
+		{
+			name: "shared dependency",
+			existing: []servicecatalog.ServiceInstance{
+				newServiceInstance("b", "d"),
+				newServiceInstance("c", "d"),
+				newServiceInstance("d"),
+			},
+			instance:  newServiceInstance("a", "b", "c"),
+			operation: admission.Create,
+		},
+		{
+			name:      "cycle on create",
+			existing:  []servicecatalog.ServiceInstance{newServiceInstance("b", "a")},
+			instance:  newServiceInstance("a", "b"),
+			operation: admission.Create,
+			expected:  "a -> b -> a",
+		},
+		{
+			name: "longer cycle on update",
+			existing: []servicecatalog.ServiceInstance{
+				newServiceInstance("a"),
+				newServiceInstance("b", "c"),
+				newServiceInstance("c", "a"),
+			},
+			instance:  newServiceInstance("a", "b"),
+			operation: admission.Update,
+			expected:  "a -> b -> c -> a",
+		},
+		{
+			name: "update without a cycle",
+			existing: []servicecatalog.ServiceInstance{
+				newServiceInstance("a", "b"),
+				newServiceInstance("b", "c"),
+			},
+			instance:  newServiceInstance("a", "c"),
+			operation: admission.Update,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, err := newHandlerForTest(newFakeServiceCatalogClientForTest(tc.existing...))
+			if err != nil {
+				t.Fatalf("unexpected error initializing handler: %v", err)
+			}
+
+			instance := tc.instance
+			err = handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(&instance, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", tc.operation, false, nil))
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("expected error containing %q, got %q", tc.expected, err)
+			}
+		})
+	}
+}
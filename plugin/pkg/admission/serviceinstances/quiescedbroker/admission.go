@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quiescedbroker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/klog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/internalclientset"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/poy/service-catalog/pkg/apiserver/admission"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceInstanceQuiescedBroker"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewDenyQuiescedBroker()
+	})
+}
+
+// denyQuiescedBroker is an implementation of admission.Interface.
+// It rejects the creation of Service Instances of a Service Class whose
+// broker is quiesced. Updates and deletes of existing Service Instances are
+// not handled, so that they keep working while the broker is quiesced.
+type denyQuiescedBroker struct {
+	*admission.Handler
+	internalClientSet internalclientset.Interface
+}
+
+var _ = scadmission.WantsInternalServiceCatalogClientSet(&denyQuiescedBroker{})
+
+func (d *denyQuiescedBroker) Admit(a admission.Attributes) error {
+	// We only care about service Instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+
+	var kind, brokerName string
+	var quiesced bool
+	var err error
+	if instance.Spec.ClusterServiceClassSpecified() {
+		kind = "ClusterServiceBroker"
+		brokerName, quiesced, err = d.isClusterServiceBrokerQuiesced(a, &instance.Spec.PlanReference)
+	} else if instance.Spec.ServiceClassSpecified() {
+		kind = "ServiceBroker"
+		brokerName, quiesced, err = d.isServiceBrokerQuiesced(a, instance.Namespace, &instance.Spec.PlanReference)
+	} else {
+		return nil
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Let the controller report the missing class or broker on the
+			// instance
+			return nil
+		}
+		return admission.NewForbidden(a, err)
+	}
+	if !quiesced {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s %q is quiesced, new instances of its classes cannot be created", kind, brokerName)
+	klog.V(4).Infof(`ServiceInstance "%s/%s": %s`, instance.Namespace, instance.Name, msg)
+	return admission.NewForbidden(a, errors.New(msg))
+}
+
+// isClusterServiceBrokerQuiesced returns the name of the ClusterServiceBroker
+// offering the ClusterServiceClass the plan reference points to and whether
+// it is quiesced.
+func (d *denyQuiescedBroker) isClusterServiceBrokerQuiesced(a admission.Attributes, ref *servicecatalog.PlanReference) (string, bool, error) {
+	client := d.internalClientSet.Servicecatalog().ClusterServiceClasses()
+	var brokerName string
+	if ref.ClusterServiceClassName != "" {
+		sc, err := client.Get(ref.ClusterServiceClassName, metav1.GetOptions{})
+		if err != nil {
+			return "", false, err
+		}
+		brokerName = sc.Spec.ClusterServiceBrokerName
+	} else {
+		listOpts := metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(ref.GetClusterServiceClassFilterFieldName(), ref.GetSpecifiedClusterServiceClass()).String(),
+		}
+		serviceClasses, err := client.List(listOpts)
+		if err != nil {
+			return "", false, err
+		}
+		if len(serviceClasses.Items) != 1 {
+			return "", false, admission.NewNotFound(a)
+		}
+		brokerName = serviceClasses.Items[0].Spec.ClusterServiceBrokerName
+	}
+
+	broker, err := d.internalClientSet.Servicecatalog().ClusterServiceBrokers().Get(brokerName, metav1.GetOptions{})
+	if err != nil {
+		return "", false, err
+	}
+	return broker.Name, broker.Spec.Quiesced, nil
+}
+
+// isServiceBrokerQuiesced returns the name of the ServiceBroker offering the
+// ServiceClass the plan reference points to and whether it is quiesced.
+func (d *denyQuiescedBroker) isServiceBrokerQuiesced(a admission.Attributes, namespace string, ref *servicecatalog.PlanReference) (string, bool, error) {
+	client := d.internalClientSet.Servicecatalog().ServiceClasses(namespace)
+	var brokerName string
+	if ref.ServiceClassName != "" {
+		sc, err := client.Get(ref.ServiceClassName, metav1.GetOptions{})
+		if err != nil {
+			return "", false, err
+		}
+		brokerName = sc.Spec.ServiceBrokerName
+	} else {
+		listOpts := metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(ref.GetServiceClassFilterFieldName(), ref.GetSpecifiedServiceClass()).String(),
+		}
+		serviceClasses, err := client.List(listOpts)
+		if err != nil {
+			return "", false, err
+		}
+		if len(serviceClasses.Items) != 1 {
+			return "", false, admission.NewNotFound(a)
+		}
+		brokerName = serviceClasses.Items[0].Spec.ServiceBrokerName
+	}
+
+	broker, err := d.internalClientSet.Servicecatalog().ServiceBrokers(namespace).Get(brokerName, metav1.GetOptions{})
+	if err != nil {
+		return "", false, err
+	}
+	return broker.Name, broker.Spec.Quiesced, nil
+}
+
+// NewDenyQuiescedBroker creates a new admission control handler that rejects
+// the creation of Service Instances of a Service Class whose broker is
+// quiesced.
+func NewDenyQuiescedBroker() (admission.Interface, error) {
+	return &denyQuiescedBroker{
+		Handler: admission.NewHandler(admission.Create),
+	}, nil
+}
+
+func (d *denyQuiescedBroker) SetInternalServiceCatalogClientSet(i internalclientset.Interface) {
+	d.internalClientSet = i
+}
+
+func (d *denyQuiescedBroker) ValidateInitialization() error {
+	if d.internalClientSet == nil {
+		return errors.New("missing service catalog clientset")
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quiescedbroker
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	core "k8s.io/client-go/testing"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/poy/service-catalog/pkg/apiserver/admission"
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/internalclientset"
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/poy/service-catalog/pkg/client/informers_generated/internalversion"
+)
+
+// newHandlerForTest returns a configured handler for testing.
+func newHandlerForTest(internalClient internalclientset.Interface) (admission.Interface, error) {
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	handler, err := NewDenyQuiescedBroker()
+	if err != nil {
+		return nil, err
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, nil, nil)
+	pluginInitializer.Initialize(handler)
+	err = admission.ValidateInitialization(handler)
+	return handler, err
+}
+
+// newFakeServiceCatalogClientForTest creates a fake clientset that returns the
+// given classes and brokers, if any, on gets and lists.
+func newFakeServiceCatalogClientForTest(csc *servicecatalog.ClusterServiceClass, csb *servicecatalog.ClusterServiceBroker, sc *servicecatalog.ServiceClass, sb *servicecatalog.ServiceBroker) *fake.Clientset {
+	fakeClient := &fake.Clientset{}
+
+	fakeClient.AddReactor("get", "clusterserviceclasses", func(action core.Action) (bool, runtime.Object, error) {
+		if csc != nil {
+			return true, csc, nil
+		}
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{}, "")
+	})
+	fakeClient.AddReactor("list", "clusterserviceclasses", func(action core.Action) (bool, runtime.Object, error) {
+		list := &servicecatalog.ClusterServiceClassList{}
+		if csc != nil {
+			list.Items = append(list.Items, *csc)
+		}
+		return true, list, nil
+	})
+	fakeClient.AddReactor("get", "clusterservicebrokers", func(action core.Action) (bool, runtime.Object, error) {
+		if csb != nil {
+			return true, csb, nil
+		}
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{}, "")
+	})
+	fakeClient.AddReactor("get", "serviceclasses", func(action core.Action) (bool, runtime.Object, error) {
+		if sc != nil {
+			return true, sc, nil
+		}
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{}, "")
+	})
+	fakeClient.AddReactor("list", "serviceclasses", func(action core.Action) (bool, runtime.Object, error) {
+		list := &servicecatalog.ServiceClassList{}
+		if sc != nil {
+			list.Items = append(list.Items, *sc)
+		}
+		return true, list, nil
+	})
+	fakeClient.AddReactor("get", "servicebrokers", func(action core.Action) (bool, runtime.Object, error) {
+		if sb != nil {
+			return true, sb, nil
+		}
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{}, "")
+	})
+
+	return fakeClient
+}
+
+func newClusterServiceClass() *servicecatalog.ClusterServiceClass {
+	return &servicecatalog.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-id"},
+		Spec: servicecatalog.ClusterServiceClassSpec{
+			CommonServiceClassSpec:   servicecatalog.CommonServiceClassSpec{ExternalName: "foo"},
+			ClusterServiceBrokerName: "broker",
+		},
+	}
+}
+
+func newClusterServiceBroker(quiesced bool) *servicecatalog.ClusterServiceBroker {
+	return &servicecatalog.ClusterServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{Name: "broker"},
+		Spec: servicecatalog.ClusterServiceBrokerSpec{
+			CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{Quiesced: quiesced},
+		},
+	}
+}
+
+func newServiceClass() *servicecatalog.ServiceClass {
+	return &servicecatalog.ServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo-id", Namespace: "dummy"},
+		Spec: servicecatalog.ServiceClassSpec{
+			CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{ExternalName: "foo"},
+			ServiceBrokerName:      "broker",
+		},
+	}
+}
+
+func newServiceBroker(quiesced bool) *servicecatalog.ServiceBroker {
+	return &servicecatalog.ServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{Name: "broker", Namespace: "dummy"},
+		Spec: servicecatalog.ServiceBrokerSpec{
+			CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{Quiesced: quiesced},
+		},
+	}
+}
+
+func TestDenyQuiescedBroker(t *testing.T) {
+	cases := []struct {
+		name     string
+		csc      *servicecatalog.ClusterServiceClass
+		csb      *servicecatalog.ClusterServiceBroker
+		sc       *servicecatalog.ServiceClass
+		sb       *servicecatalog.ServiceBroker
+		ref      servicecatalog.PlanReference
+		expected string
+	}{
+		{
+			name: "cluster broker not quiesced",
+			csc:  newClusterServiceClass(),
+			csb:  newClusterServiceBroker(false),
+			ref:  servicecatalog.PlanReference{ClusterServiceClassExternalName: "foo", ClusterServicePlanExternalName: "bar"},
+		},
+		{
+			name:     "cluster broker quiesced",
+			csc:      newClusterServiceClass(),
+			csb:      newClusterServiceBroker(true),
+			ref:      servicecatalog.PlanReference{ClusterServiceClassExternalName: "foo", ClusterServicePlanExternalName: "bar"},
+			expected: `ClusterServiceBroker "broker" is quiesced`,
+		},
+		{
+			name:     "cluster broker quiesced by kubernetes name",
+			csc:      newClusterServiceClass(),
+			csb:      newClusterServiceBroker(true),
+			ref:      servicecatalog.PlanReference{ClusterServiceClassName: "foo-id", ClusterServicePlanName: "bar-id"},
+			expected: `ClusterServiceBroker "broker" is quiesced`,
+		},
+		{
+			name: "cluster class not found",
+			csb:  newClusterServiceBroker(true),
+			ref:  servicecatalog.PlanReference{ClusterServiceClassExternalName: "foo", ClusterServicePlanExternalName: "bar"},
+		},
+		{
+			name: "cluster broker not found",
+			csc:  newClusterServiceClass(),
+			ref:  servicecatalog.PlanReference{ClusterServiceClassExternalName: "foo", ClusterServicePlanExternalName: "bar"},
+		},
+		{
+			name: "namespaced broker not quiesced",
+			sc:   newServiceClass(),
+			sb:   newServiceBroker(false),
+			ref:  servicecatalog.PlanReference{ServiceClassExternalName: "foo", ServicePlanExternalName: "bar"},
+		},
+		{
+			name:     "namespaced broker quiesced",
+			sc:       newServiceClass(),
+			sb:       newServiceBroker(true),
+			ref:      servicecatalog.PlanReference{ServiceClassExternalName: "foo", ServicePlanExternalName: "bar"},
+			expected: `ServiceBroker "broker" is quiesced`,
+		},
+		{
+			name: "namespaced class not found",
+			sb:   newServiceBroker(true),
+			ref:  servicecatalog.PlanReference{ServiceClassName: "foo-id", ServicePlanName: "bar-id"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, err := newHandlerForTest(newFakeServiceCatalogClientForTest(tc.csc, tc.csb, tc.sc, tc.sb))
+			if err != nil {
+				t.Fatalf("unexpected error initializing handler: %v", err)
+			}
+
+			instance := &servicecatalog.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "dummy"},
+				Spec:       servicecatalog.ServiceInstanceSpec{PlanReference: tc.ref},
+			}
+			err = handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(instance, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", admission.Create, false, nil))
+			if tc.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("expected error containing %q, got %q", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestDenyQuiescedBrokerOnlyHandlesCreate(t *testing.T) {
+	handler, err := NewDenyQuiescedBroker()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !handler.Handles(admission.Create) {
+		t.Error("expected the plugin to handle creates")
+	}
+	if handler.Handles(admission.Update) || handler.Handles(admission.Delete) {
+		t.Error("expected the plugin to let updates and deletes of existing instances through")
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package removedclass
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"k8s.io/klog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/poy/service-catalog/pkg/client/clientset_generated/internalclientset"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/poy/service-catalog/pkg/apiserver/admission"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceInstanceRemovedClass"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewDenyRemovedClass()
+	})
+}
+
+// denyRemovedClass is an implementation of admission.Interface.
+// It rejects the creation of Service Instances of a Service Class that was
+// removed from the catalog of its broker, either by the broker itself or by
+// the catalog restrictions of the broker.
+type denyRemovedClass struct {
+	*admission.Handler
+	internalClientSet internalclientset.Interface
+}
+
+var _ = scadmission.WantsInternalServiceCatalogClientSet(&denyRemovedClass{})
+
+func (d *denyRemovedClass) Admit(a admission.Attributes) error {
+	// We only care about service Instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+
+	var kind, externalName string
+	var removed bool
+	var err error
+	if instance.Spec.ClusterServiceClassSpecified() {
+		kind = "ClusterServiceClass"
+		externalName, removed, err = d.isClusterServiceClassRemoved(a, &instance.Spec.PlanReference)
+	} else if instance.Spec.ServiceClassSpecified() {
+		kind = "ServiceClass"
+		externalName, removed, err = d.isServiceClassRemoved(a, instance.Namespace, &instance.Spec.PlanReference)
+	} else {
+		return nil
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Let the controller report the missing class on the instance
+			return nil
+		}
+		return admission.NewForbidden(a, err)
+	}
+	if !removed {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%s %q has been removed from the catalog of its broker, new instances of it cannot be created", kind, externalName)
+	klog.V(4).Infof(`ServiceInstance "%s/%s": %s`, instance.Namespace, instance.Name, msg)
+	return admission.NewForbidden(a, errors.New(msg))
+}
+
+// isClusterServiceClassRemoved returns the external name of the
+// ClusterServiceClass the plan reference points to and whether it was removed
+// from the catalog of its broker.
+func (d *denyRemovedClass) isClusterServiceClassRemoved(a admission.Attributes, ref *servicecatalog.PlanReference) (string, bool, error) {
+	client := d.internalClientSet.Servicecatalog().ClusterServiceClasses()
+	if ref.ClusterServiceClassName != "" {
+		sc, err := client.Get(ref.ClusterServiceClassName, metav1.GetOptions{})
+		if err != nil {
+			return "", false, err
+		}
+		return sc.Spec.ExternalName, sc.Status.RemovedFromBrokerCatalog, nil
+	}
+
+	listOpts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(ref.GetClusterServiceClassFilterFieldName(), ref.GetSpecifiedClusterServiceClass()).String(),
+	}
+	serviceClasses, err := client.List(listOpts)
+	if err != nil {
+		return "", false, err
+	}
+	if len(serviceClasses.Items) != 1 {
+		return "", false, admission.NewNotFound(a)
+	}
+	sc := serviceClasses.Items[0]
+	return sc.Spec.ExternalName, sc.Status.RemovedFromBrokerCatalog, nil
+}
+
+// isServiceClassRemoved returns the external name of the ServiceClass the plan
+// reference points to and whether it was removed from the catalog of its
+// broker.
+func (d *denyRemovedClass) isServiceClassRemoved(a admission.Attributes, namespace string, ref *servicecatalog.PlanReference) (string, bool, error) {
+	client := d.internalClientSet.Servicecatalog().ServiceClasses(namespace)
+	if ref.ServiceClassName != "" {
+		sc, err := client.Get(ref.ServiceClassName, metav1.GetOptions{})
+		if err != nil {
+			return "", false, err
+		}
+		return sc.Spec.ExternalName, sc.Status.RemovedFromBrokerCatalog, nil
+	}
+
+	listOpts := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(ref.GetServiceClassFilterFieldName(), ref.GetSpecifiedServiceClass()).String(),
+	}
+	serviceClasses, err := client.List(listOpts)
+	if err != nil {
+		return "", false, err
+	}
+	if len(serviceClasses.Items) != 1 {
+		return "", false, admission.NewNotFound(a)
+	}
+	sc := serviceClasses.Items[0]
+	return sc.Spec.ExternalName, sc.Status.RemovedFromBrokerCatalog, nil
+}
+
+// NewDenyRemovedClass creates a new admission control handler that rejects
+// the creation of Service Instances of a Service Class that was removed from
+// the catalog of its broker.
+func NewDenyRemovedClass() (admission.Interface, error) {
+	return &denyRemovedClass{
+		Handler: admission.NewHandler(admission.Create),
+	}, nil
+}
+
+func (d *denyRemovedClass) SetInternalServiceCatalogClientSet(i internalclientset.Interface) {
+	d.internalClientSet = i
+}
+
+func (d *denyRemovedClass) ValidateInitialization() error {
+	if d.internalClientSet == nil {
+		return errors.New("missing service catalog clientset")
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requiredlabels
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceInstanceRequiredLabels"
+)
+
+// Configuration is the configuration of the plugin, read from the file
+// given for it in the admission control configuration, e.g.
+//
+//   requiredLabels:
+//   - team
+//   - cost-center
+type Configuration struct {
+	// RequiredLabels are the keys of the labels every ServiceInstance must
+	// have.
+	RequiredLabels []string `json:"requiredLabels"`
+}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(config io.Reader) (admission.Interface, error) {
+		c, err := loadConfiguration(config)
+		if err != nil {
+			return nil, err
+		}
+		return NewRequiredLabels(c.RequiredLabels)
+	})
+}
+
+// loadConfiguration reads the plugin configuration. A missing configuration
+// is returned as an empty one, which ValidateInitialization rejects.
+func loadConfiguration(config io.Reader) (*Configuration, error) {
+	c := &Configuration{}
+	if config == nil {
+		return c, nil
+	}
+	data, err := ioutil.ReadAll(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s configuration: %v", PluginName, err)
+	}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("unable to parse %s configuration: %v", PluginName, err)
+	}
+	for _, key := range c.RequiredLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid required label %q in %s configuration: %s", key, PluginName, strings.Join(errs, "; "))
+		}
+	}
+	return c, nil
+}
+
+// requireLabels is an implementation of admission.Interface.
+// It rejects the creation of, and updates to, Service Instances that are
+// missing any of the configured label keys.
+type requireLabels struct {
+	*admission.Handler
+	requiredLabels []string
+}
+
+func (r *requireLabels) Admit(a admission.Attributes) error {
+	// We only care about service Instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") {
+		return nil
+	}
+	// Status updates are made by the controller, which does not manage labels
+	if a.GetSubresource() != "" {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind Instance but was unable to be converted")
+	}
+	// Let an instance that is being deleted finish deleting, even if it
+	// was created before the labels were required
+	if instance.DeletionTimestamp != nil {
+		return nil
+	}
+
+	var missing []string
+	for _, key := range r.requiredLabels {
+		if _, ok := instance.Labels[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("ServiceInstance %s/%s is missing required labels: %s", instance.Namespace, instance.Name, strings.Join(missing, ", "))
+	klog.V(4).Info(msg)
+	return admission.NewForbidden(a, errors.New(msg))
+}
+
+// NewRequiredLabels creates a new admission control handler that rejects
+// the creation of, and updates to, Service Instances that are missing any
+// of the given label keys.
+func NewRequiredLabels(requiredLabels []string) (admission.Interface, error) {
+	return &requireLabels{
+		Handler:        admission.NewHandler(admission.Create, admission.Update),
+		requiredLabels: requiredLabels,
+	}, nil
+}
+
+func (r *requireLabels) ValidateInitialization() error {
+	if len(r.requiredLabels) == 0 {
+		return errors.New("no required labels configured")
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requiredlabels
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+)
+
+// newHandlerForTest returns a handler created from the given plugin
+// configuration the same way the API server creates it.
+func newHandlerForTest(config io.Reader) (admission.Interface, error) {
+	plugins := admission.NewPlugins()
+	Register(plugins)
+	return plugins.InitPlugin(PluginName, config, admission.PluginInitializers{})
+}
+
+// newServiceInstance returns a new instance with the given labels.
+func newServiceInstance(labels map[string]string) *servicecatalog.ServiceInstance {
+	return &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "instance",
+			Namespace: "dummy",
+			Labels:    labels,
+		},
+	}
+}
+
+func admit(handler admission.Interface, instance *servicecatalog.ServiceInstance, operation admission.Operation, subresource string) error {
+	return handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(instance, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), subresource, operation, false, nil))
+}
+
+const testConfig = `
+requiredLabels:
+- team
+- cost-center
+`
+
+func TestRequiredLabels(t *testing.T) {
+	cases := []struct {
+		name        string
+		operation   admission.Operation
+		subresource string
+		labels      map[string]string
+		deleting    bool
+		// missing is the missing labels listed in the error, if any
+		missing string
+	}{
+		{
+			name:      "create with all labels",
+			operation: admission.Create,
+			labels:    map[string]string{"team": "a", "cost-center": "1", "other": "x"},
+		},
+		{
+			name:      "create with empty label values",
+			operation: admission.Create,
+			labels:    map[string]string{"team": "", "cost-center": ""},
+		},
+		{
+			name:      "create without labels",
+			operation: admission.Create,
+			missing:   "team, cost-center",
+		},
+		{
+			name:      "create missing one label",
+			operation: admission.Create,
+			labels:    map[string]string{"team": "a"},
+			missing:   "cost-center",
+		},
+		{
+			name:      "update missing one label",
+			operation: admission.Update,
+			labels:    map[string]string{"cost-center": "1"},
+			missing:   "team",
+		},
+		{
+			name:        "status update missing labels",
+			operation:   admission.Update,
+			subresource: "status",
+		},
+		{
+			name:      "update of an instance being deleted",
+			operation: admission.Update,
+			deleting:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, err := newHandlerForTest(strings.NewReader(testConfig))
+			if err != nil {
+				t.Fatalf("unexpected error initializing handler: %v", err)
+			}
+			instance := newServiceInstance(tc.labels)
+			if tc.deleting {
+				now := metav1.Now()
+				instance.DeletionTimestamp = &now
+			}
+
+			err = admit(handler, instance, tc.operation, tc.subresource)
+			if tc.missing == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !apierrors.IsForbidden(err) {
+				t.Fatalf("expected a Forbidden error, got: %v", err)
+			}
+			if e, a := "is missing required labels: "+tc.missing, err.Error(); !strings.Contains(a, e) {
+				t.Fatalf("expected error to contain %q, got %q", e, a)
+			}
+		})
+	}
+}
+
+func TestRequiredLabelsConfiguration(t *testing.T) {
+	cases := []struct {
+		name   string
+		config io.Reader
+	}{
+		{
+			name: "no configuration",
+		},
+		{
+			name:   "no required labels",
+			config: strings.NewReader("requiredLabels: []"),
+		},
+		{
+			name:   "malformed configuration",
+			config: strings.NewReader("requiredLabels: team"),
+		},
+		{
+			name:   "invalid label key",
+			config: strings.NewReader("requiredLabels: [\"cost center\"]"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := newHandlerForTest(tc.config); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
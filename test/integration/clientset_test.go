@@ -419,7 +419,7 @@ func testNamespacedServiceBrokerClient(client servicecatalogclient.Interface, na
 		)
 	}
 
-	authSecret := &v1beta1.LocalObjectReference{
+	authSecret := &v1beta1.ObjectReference{
 		Name: "test-name",
 	}
 
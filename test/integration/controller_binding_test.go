@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	clientgotesting "k8s.io/client-go/testing"
 
@@ -609,6 +610,67 @@ func TestCreateServiceBindingWithSecretTransform(t *testing.T) {
 	}
 }
 
+// TestUpdateServiceBindingSecretTransform tests that changing the secret
+// transforms of a bound binding writes its secret again from the credentials
+// fetched from the broker, without binding again.
+func TestUpdateServiceBindingSecretTransform(t *testing.T) {
+	ct := &controllerTest{
+		t:        t,
+		broker:   getTestBroker(),
+		instance: getTestInstance(),
+		binding:  getTestBinding(),
+		setup: func(ct *controllerTest) {
+			catalog := getTestCatalogResponse()
+			catalog.Services[0].BindingsRetrievable = true
+			ct.osbClient.CatalogReaction = &fakeosb.CatalogReaction{Response: catalog}
+		},
+	}
+	ct.run(func(ct *controllerTest) {
+		binding, err := ct.client.ServiceBindings(testNamespace).Get(testBindingName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("error getting binding: %v", err)
+		}
+		binding.Spec.SecretTransforms = []v1beta1.SecretTransform{
+			{
+				RenameKey: &v1beta1.RenameKeyTransform{
+					From: "foo",
+					To:   "renamedFoo",
+				},
+			},
+		}
+		binding, err = ct.client.ServiceBindings(testNamespace).Update(binding)
+		if err != nil {
+			t.Fatalf("error updating binding: %v", err)
+		}
+		if err := util.WaitForBindingReconciledGeneration(ct.client, testNamespace, testBindingName, binding.Generation); err != nil {
+			t.Fatalf("error waiting for binding to reconcile: %v", err)
+		}
+
+		if e, a := 1, len(findBrokerActions(t, ct.osbClient, fakeosb.Bind)); e != a {
+			t.Fatalf("unexpected number of bind requests: expected %v, got %v", e, a)
+		}
+		if len(findBrokerActions(t, ct.osbClient, fakeosb.GetBinding)) == 0 {
+			t.Fatal("expected the binding to be fetched from the broker")
+		}
+
+		kubeActions := findKubeActions(ct.kubeClient, "create", "secrets")
+		if e, a := 2, len(kubeActions); e != a {
+			t.Fatalf("unexpected number of secret writes: expected %v, got %v", e, a)
+		}
+		secret, ok := kubeActions[1].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+		if !ok {
+			t.Fatal("couldn't convert secret into a corev1.Secret")
+		}
+		expectedSecretData := map[string][]byte{
+			"renamedFoo": []byte("bar"),
+			"baz":        []byte("zap"),
+		}
+		if !reflect.DeepEqual(secret.Data, expectedSecretData) {
+			t.Errorf("unexpected transformed secret data; expected: %v; actual: %v", expectedSecretData, secret.Data)
+		}
+	})
+}
+
 // TestDeleteServiceBindingRetry tests whether deletion of a service binding
 // retries after failing.
 func TestDeleteServiceBindingFailureRetry(t *testing.T) {
@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"errors"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog"
+	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/poy/service-catalog/test/util"
+)
+
+// TestNamespacedBrokerFailureIsolation verifies that a namespaced broker
+// whose catalog cannot be fetched does not hold up the relist of the brokers
+// in other namespaces, nor of the cluster-scoped brokers.
+func TestNamespacedBrokerFailureIsolation(t *testing.T) {
+	const (
+		unreachableBrokerURL = "https://unreachable.example.com"
+		goodNamespace        = "good-namespace"
+		badNamespace         = "bad-namespace"
+	)
+
+	fakeKubeClient := &fake.Clientset{}
+	prependGetSecretNotFoundReaction(fakeKubeClient)
+
+	catalogClient, _, shutdownServer := getFreshApiserverAndClient(t, func() runtime.Object {
+		return &servicecatalog.ServiceBroker{}
+	})
+	defer shutdownServer()
+
+	goodOSBClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
+	unreachableOSBClient := fakeosb.NewFakeClient(fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: errors.New("dial tcp: connection refused"),
+		},
+	})
+	brokerClFunc := func(config *osb.ClientConfiguration) (osb.Client, error) {
+		if config.URL == unreachableBrokerURL {
+			return unreachableOSBClient, nil
+		}
+		return goodOSBClient, nil
+	}
+
+	testController, informerFactory := newControllerForTestWithBrokerClientFunc(t, fakeKubeClient, catalogClient, brokerClFunc)
+	defer startControllerForTest(testController, informerFactory)()
+
+	client := catalogClient.ServicecatalogV1beta1()
+	newBroker := func(namespace, url string) *v1beta1.ServiceBroker {
+		return &v1beta1.ServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: testClusterServiceBrokerName},
+			Spec: v1beta1.ServiceBrokerSpec{
+				CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{URL: url},
+			},
+		}
+	}
+	if _, err := client.ServiceBrokers(badNamespace).Create(newBroker(badNamespace, unreachableBrokerURL)); err != nil {
+		t.Fatalf("error creating the unreachable broker: %v", err)
+	}
+	if _, err := client.ServiceBrokers(goodNamespace).Create(newBroker(goodNamespace, testBrokerURL)); err != nil {
+		t.Fatalf("error creating the broker: %v", err)
+	}
+	if _, err := client.ClusterServiceBrokers().Create(getTestBroker()); err != nil {
+		t.Fatalf("error creating the cluster-scoped broker: %v", err)
+	}
+
+	ready := v1beta1.ServiceBrokerCondition{
+		Type:   v1beta1.ServiceBrokerConditionReady,
+		Status: v1beta1.ConditionTrue,
+	}
+	if err := util.WaitForServiceBrokerCondition(client, goodNamespace, testClusterServiceBrokerName, ready); err != nil {
+		t.Fatalf("error waiting for the broker to become ready: %v", err)
+	}
+	if err := util.WaitForBrokerCondition(client, testClusterServiceBrokerName, ready); err != nil {
+		t.Fatalf("error waiting for the cluster-scoped broker to become ready: %v", err)
+	}
+
+	if err := util.WaitForServiceBrokerCondition(client, badNamespace, testClusterServiceBrokerName, v1beta1.ServiceBrokerCondition{
+		Type:   v1beta1.ServiceBrokerConditionReady,
+		Status: v1beta1.ConditionFalse,
+		Reason: "ErrorFetchingCatalog",
+	}); err != nil {
+		t.Fatalf("error waiting for the unreachable broker to report the failure: %v", err)
+	}
+
+	classes, err := client.ServiceClasses(goodNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("error listing classes: %v", err)
+	}
+	if len(classes.Items) == 0 {
+		t.Fatal("expected the classes of the broker to be created")
+	}
+	classes, err = client.ServiceClasses(badNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("error listing classes: %v", err)
+	}
+	if len(classes.Items) != 0 {
+		t.Fatalf("expected no classes for the unreachable broker, got %d", len(classes.Items))
+	}
+}
@@ -23,8 +23,10 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	scfeatures "github.com/poy/service-catalog/pkg/features"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
@@ -1758,3 +1760,84 @@ func TestRetryAsyncDeprovision(t *testing.T) {
 	}
 	ct.run(func(_ *controllerTest) {})
 }
+
+// TestResumeAsyncDeprovisionAfterControllerRestart tests that a controller
+// that restarts while an asynchronous deprovision is in progress resumes
+// polling the operation started by the broker, rather than sending another
+// deprovision request.
+func TestResumeAsyncDeprovisionAfterControllerRestart(t *testing.T) {
+	operationKey := osb.OperationKey("deprovision-operation")
+	ct := &controllerTest{
+		t:        t,
+		broker:   getTestBroker(),
+		instance: getTestInstance(),
+		setup: func(ct *controllerTest) {
+			ct.osbClient.DeprovisionReaction = &fakeosb.DeprovisionReaction{
+				Response: &osb.DeprovisionResponse{
+					Async:        true,
+					OperationKey: &operationKey,
+				},
+			}
+			ct.osbClient.PollLastOperationReaction = &fakeosb.PollLastOperationReaction{
+				Response: &osb.LastOperationResponse{
+					State: osb.StateInProgress,
+				},
+			}
+		},
+	}
+	ct.run(func(ct *controllerTest) {
+		if err := ct.client.ServiceInstances(ct.instance.Namespace).Delete(ct.instance.Name, &metav1.DeleteOptions{}); err != nil {
+			t.Fatalf("instance delete should have been accepted: %v", err)
+		}
+
+		// Wait for the operation to be recorded in the instance status
+		err := wait.PollImmediate(100*time.Millisecond, wait.ForeverTestTimeout, func() (bool, error) {
+			instance, err := ct.client.ServiceInstances(ct.instance.Namespace).Get(ct.instance.Name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			return instance.Status.AsyncOpInProgress &&
+				instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationDeprovision &&
+				instance.Status.OperationStartTime != nil &&
+				instance.Status.LastOperation != nil && *instance.Status.LastOperation == string(operationKey), nil
+		})
+		if err != nil {
+			t.Fatalf("error waiting for the deprovision operation to be in progress: %v", err)
+		}
+
+		osbClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
+		osbClient.DeprovisionReaction = &fakeosb.DeprovisionReaction{
+			Error: errors.New("deprovision should not be requested again"),
+		}
+		osbClient.PollLastOperationReaction = &fakeosb.PollLastOperationReaction{
+			Response: &osb.LastOperationResponse{
+				State: osb.StateSucceeded,
+			},
+		}
+		ct.restartController(osbClient)
+
+		if err := util.WaitForInstanceToNotExist(ct.client, ct.instance.Namespace, ct.instance.Name); err != nil {
+			t.Fatalf("error waiting for instance to be deleted: %v", err)
+		}
+
+		polled := false
+		for _, action := range osbClient.Actions() {
+			switch action.Type {
+			case fakeosb.DeprovisionInstance:
+				t.Fatal("unexpected deprovision request after the controller restarted")
+			case fakeosb.PollLastOperation:
+				request := action.Request.(*osb.LastOperationRequest)
+				if request.OperationKey == nil || *request.OperationKey != operationKey {
+					t.Fatalf("unexpected operation key polled; expected %q, got %v", operationKey, request.OperationKey)
+				}
+				polled = true
+			}
+		}
+		if !polled {
+			t.Fatal("expected the last operation to be polled after the controller restarted")
+		}
+
+		// We deleted the instance above, clear it so test cleanup doesn't fail
+		ct.instance = nil
+	})
+}
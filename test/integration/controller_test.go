@@ -756,42 +756,10 @@ func newControllerTestTestController(ct *controllerTest) (
 	})
 
 	fakeOSBClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
 
-	// create informers
-	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
+	testController, informerFactory := newControllerForTest(t, fakeKubeClient, catalogClient, fakeOSBClient)
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
 
-	// WARNING: Should you try to record more events than the buffer size
-	// passed here, the recording function will hang indefinitely.
-	fakeRecorder := record.NewFakeRecorder(50)
-
-	// create a test controller
-	testController, err := controller.NewController(
-		fakeKubeClient,
-		catalogClient.ServicecatalogV1beta1(),
-		serviceCatalogSharedInformers.ClusterServiceBrokers(),
-		serviceCatalogSharedInformers.ServiceBrokers(),
-		serviceCatalogSharedInformers.ClusterServiceClasses(),
-		serviceCatalogSharedInformers.ServiceClasses(),
-		serviceCatalogSharedInformers.ServiceInstances(),
-		serviceCatalogSharedInformers.ServiceBindings(),
-		serviceCatalogSharedInformers.ClusterServicePlans(),
-		serviceCatalogSharedInformers.ServicePlans(),
-		brokerClFunc,
-		24*time.Hour,
-		osb.LatestAPIVersion().HeaderValue(),
-		fakeRecorder,
-		7*24*time.Hour,
-		7*24*time.Hour,
-		controller.DefaultClusterIDConfigMapName,
-		controller.DefaultClusterIDConfigMapNamespace,
-	)
-	t.Log("controller start")
-	if err != nil {
-		t.Fatal(err)
-	}
-
 	ct.client = catalogClient.ServicecatalogV1beta1()
 
 	ct.kubeClient = fakeKubeClient
@@ -805,25 +773,7 @@ func newControllerTestTestController(ct *controllerTest) (
 		ct.setup(ct)
 	}
 
-	stopCh := make(chan struct{})
-
-	klog.V(4).Info("Waiting for caches to sync")
-	informerFactory.Start(stopCh)
-
-	klog.V(4).Info("Waiting for caches to sync")
-	informerFactory.WaitForCacheSync(stopCh)
-
-	controllerStopped := make(chan struct{})
-
-	go func() {
-		testController.Run(1, stopCh)
-		controllerStopped <- struct{}{}
-	}()
-
-	shutdownController := func() {
-		close(stopCh)
-		<-controllerStopped
-	}
+	shutdownController := startControllerForTest(testController, informerFactory)
 
 	if ct.broker != nil {
 		if ct.preCreateBroker != nil {
@@ -881,6 +831,89 @@ func newControllerTestTestController(ct *controllerTest) (
 	return fakeKubeClient, catalogClient, catalogClientConfig, fakeOSBClient, testController, serviceCatalogSharedInformers, shutdownServer, shutdownController
 }
 
+// newControllerForTest creates a controller that uses the given clients,
+// along with the informer factory it gets its informers from. If there is an
+// error, newControllerForTest calls 'Fatal' on the injected testing.T.
+func newControllerForTest(t *testing.T, kubeClient *fake.Clientset, catalogClient clientset.Interface, osbClient *fakeosb.FakeClient) (controller.Controller, scinformers.SharedInformerFactory) {
+	return newControllerForTestWithBrokerClientFunc(t, kubeClient, catalogClient, fakeosb.ReturnFakeClientFunc(osbClient))
+}
+
+// newControllerForTestWithBrokerClientFunc creates a controller that uses the
+// given clients and creates its broker clients with brokerClFunc, along with
+// the informer factory it gets its informers from. If there is an error,
+// newControllerForTestWithBrokerClientFunc calls 'Fatal' on the injected
+// testing.T.
+func newControllerForTestWithBrokerClientFunc(t *testing.T, kubeClient *fake.Clientset, catalogClient clientset.Interface, brokerClFunc osb.CreateFunc) (controller.Controller, scinformers.SharedInformerFactory) {
+	// create informers
+	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
+	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
+
+	// WARNING: Should you try to record more events than the buffer size
+	// passed here, the recording function will hang indefinitely.
+	fakeRecorder := record.NewFakeRecorder(50)
+
+	// create a test controller
+	testController, err := controller.NewController(
+		kubeClient,
+		catalogClient.ServicecatalogV1beta1(),
+		serviceCatalogSharedInformers.ClusterServiceBrokers(),
+		serviceCatalogSharedInformers.ServiceBrokers(),
+		serviceCatalogSharedInformers.ClusterServiceClasses(),
+		serviceCatalogSharedInformers.ServiceClasses(),
+		serviceCatalogSharedInformers.ServiceInstances(),
+		serviceCatalogSharedInformers.ServiceBindings(),
+		serviceCatalogSharedInformers.ClusterServicePlans(),
+		serviceCatalogSharedInformers.ServicePlans(),
+		brokerClFunc,
+		0,
+		0,
+		24*time.Hour,
+		osb.LatestAPIVersion().HeaderValue(),
+		"",
+		fakeRecorder,
+		7*24*time.Hour,
+		7*24*time.Hour,
+		controller.DefaultClusterIDConfigMapName,
+		controller.DefaultClusterIDConfigMapNamespace,
+		controller.SecretKeyConvention{},
+		controller.ForceDeletionPolicy{},
+		controller.QuotaExceededPolicy{},
+		nil,
+		controller.RequestContextPolicy{},
+		controller.OrphanMitigationPolicy{},
+		controller.SecretUpdateStrategyInPlace,
+	)
+	t.Log("controller start")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testController, informerFactory
+}
+
+// startControllerForTest starts the informers and then the controller, and
+// returns a function for shutting down the controller.
+func startControllerForTest(testController controller.Controller, informerFactory scinformers.SharedInformerFactory) func() {
+	stopCh := make(chan struct{})
+
+	klog.V(4).Info("Waiting for caches to sync")
+	informerFactory.Start(stopCh)
+
+	klog.V(4).Info("Waiting for caches to sync")
+	informerFactory.WaitForCacheSync(stopCh)
+
+	controllerStopped := make(chan struct{})
+
+	go func() {
+		testController.Run(1, stopCh)
+		controllerStopped <- struct{}{}
+	}()
+
+	return func() {
+		close(stopCh)
+		<-controllerStopped
+	}
+}
+
 // newTestController creates a new test controller injected with fake clients
 // and returns:
 //
@@ -939,13 +972,23 @@ func newTestController(t *testing.T) (
 		serviceCatalogSharedInformers.ClusterServicePlans(),
 		serviceCatalogSharedInformers.ServicePlans(),
 		brokerClFunc,
+		0,
+		0,
 		24*time.Hour,
 		osb.LatestAPIVersion().HeaderValue(),
+		"",
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
+		controller.SecretKeyConvention{},
+		controller.ForceDeletionPolicy{},
+		controller.QuotaExceededPolicy{},
+		nil,
+		controller.RequestContextPolicy{},
+		controller.OrphanMitigationPolicy{},
+		controller.SecretUpdateStrategyInPlace,
 	)
 	t.Log("controller start")
 	if err != nil {
@@ -1284,6 +1327,8 @@ type controllerTest struct {
 	controller controller.Controller
 	// fake informers
 	informers informers.Interface
+	// function for shutting down the controller
+	shutdownController func()
 
 	// the broker to create.
 	// After the broker is created and verified, this is the broker from storage.
@@ -1367,7 +1412,10 @@ type controllerTest struct {
 // - clean up controller and API server
 func (ct *controllerTest) run(test func(*controllerTest)) {
 	kubeClient, catalogClient, catalogClientConfig, osbClient, controller, informers, shutdownServer, shutdownController := newControllerTestTestController(ct)
-	defer shutdownController()
+	ct.shutdownController = shutdownController
+	// The test may have restarted the controller, so shut down whichever
+	// controller is running at the end.
+	defer func() { ct.shutdownController() }()
 	defer shutdownServer()
 
 	ct.kubeClient = kubeClient
@@ -1414,6 +1462,21 @@ func (ct *controllerTest) run(test func(*controllerTest)) {
 	}
 }
 
+// restartController shuts down the running controller and starts a new one
+// against the same API server, simulating a restart of the controller
+// manager. The new controller starts with empty caches and queues, so
+// everything it knows about resources in progress comes from the API server.
+// It uses osbClient to talk to brokers.
+func (ct *controllerTest) restartController(osbClient *fakeosb.FakeClient) {
+	ct.shutdownController()
+
+	testController, informerFactory := newControllerForTest(ct.t, ct.kubeClient, ct.catalogClient, osbClient)
+	ct.osbClient = osbClient
+	ct.controller = testController
+	ct.informers = informerFactory.Servicecatalog().V1beta1()
+	ct.shutdownController = startControllerForTest(testController, informerFactory)
+}
+
 // getLastBrokerActions gets the last action made to the fake broker client.
 // It also verifies that the last action had the specified action type.
 func getLastBrokerAction(t *testing.T, osbClient *fakeosb.FakeClient, actionType fakeosb.ActionType) fakeosb.Action {
@@ -67,6 +67,30 @@ func WaitForBrokerCondition(client v1beta1servicecatalog.ServicecatalogV1beta1In
 	)
 }
 
+// WaitForServiceBrokerCondition waits for the status of the namespaced broker
+// to contain a condition whose type and status matches the supplied one.
+func WaitForServiceBrokerCondition(client v1beta1servicecatalog.ServicecatalogV1beta1Interface, namespace, name string, condition v1beta1.ServiceBrokerCondition) error {
+	return wait.PollImmediate(500*time.Millisecond, 3*time.Minute,
+		func() (bool, error) {
+			klog.V(5).Infof("Waiting for broker %v/%v condition %#v", namespace, name, condition)
+			broker, err := client.ServiceBrokers(namespace).Get(name, metav1.GetOptions{})
+			if nil != err {
+				return false, fmt.Errorf("error getting Broker %v/%v: %v", namespace, name, err)
+			}
+
+			for _, cond := range broker.Status.Conditions {
+				if condition.Type == cond.Type && condition.Status == cond.Status {
+					if condition.Reason == "" || condition.Reason == cond.Reason {
+						return true, nil
+					}
+				}
+			}
+
+			return false, nil
+		},
+	)
+}
+
 // WaitForBrokerToNotExist waits for the Broker with the given name to no
 // longer exist.
 func WaitForBrokerToNotExist(client v1beta1servicecatalog.ServicecatalogV1beta1Interface, name string) error {